@@ -0,0 +1,353 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/jsonschema"
+	"github.com/eduardo/blueprint/internal/regen"
+	"github.com/eduardo/blueprint/internal/verify"
+	"github.com/spf13/cobra"
+)
+
+const defaultBlueprint = "blueprint.md"
+
+// blueprintNames are the blueprint files looked for when none is given, in order
+var blueprintNames = []string{defaultBlueprint, "blueprint.yaml", "blueprint.yml", "blueprint.json"}
+
+// findBlueprint returns the first blueprint present in the current directory,
+// or the default name when there is none, so the error names the usual file
+func findBlueprint() string {
+	for _, name := range blueprintNames {
+		if _, err := os.Stat(name); err == nil {
+			return name
+		}
+	}
+	return defaultBlueprint
+}
+
+// maxArgs is cobra.MaximumNArgs reported as a usage error
+func maxArgs(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) > n {
+			return usageError("%s accepts at most %d argument(s), received %d", cmd.CommandPath(), n, len(args))
+		}
+		return nil
+	}
+}
+
+type generateOptions struct {
+	global     *globalOptions
+	out        string
+	force      bool
+	dryRun     bool
+	onConflict string
+	verify     bool
+	templates  string
+}
+
+func newGenerateCommand(global *globalOptions) *cobra.Command {
+	opts := &generateOptions{global: global}
+
+	cmd := &cobra.Command{
+		Use:   "generate [blueprint.md | blueprint.yaml | blueprint.json]",
+		Short: "Generate the project described by a blueprint",
+		Args:  maxArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filename := findBlueprint()
+			if len(args) > 0 {
+				filename = args[0]
+			}
+			return runGenerate(cmd, opts, filename)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.out, "out", "o", "", "directory the project folder is created in (default: current directory)")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "overwrite files you modified since the last generation")
+	cmd.Flags().StringVar(&opts.onConflict, "on-conflict", string(regen.StrategyMerge), "what to do with files you modified: merge, side (write <file>.new), skip or overwrite")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "print the files that would be written without touching disk")
+	cmd.Flags().BoolVar(&opts.verify, "verify", false, "type-check the generated Go code and report errors with the template that produced them")
+	cmd.Flags().StringVar(&opts.templates, "templates", "", "directory of <name>.tmpl files replacing built-in templates (default: <project>/.blueprint/templates)")
+	return cmd
+}
+
+func runGenerate(cmd *cobra.Command, opts *generateOptions, filename string) error {
+	if _, err := os.Stat(filename); err != nil {
+		return usageError("blueprint %s not found", filename)
+	}
+
+	outputDir := opts.out
+	if outputDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		outputDir = wd
+	}
+
+	strategy, err := regen.ParseStrategy(opts.onConflict)
+	if err != nil {
+		return &exitError{code: exitUsage, err: err}
+	}
+	if opts.force {
+		strategy = regen.StrategyOverwrite
+	}
+	if opts.templates != "" {
+		if info, err := os.Stat(opts.templates); err != nil || !info.IsDir() {
+			return usageError("templates directory %s not found", opts.templates)
+		}
+	}
+
+	var baseFS domain.FileSystemPort = infrastructure.NewOSFileSystem()
+	var memFS *infrastructure.MemoryFileSystem
+	if opts.dryRun {
+		memFS = infrastructure.NewMemoryFileSystem(baseFS)
+		baseFS = memFS
+	}
+	service := newServiceWithFS(baseFS)
+	ctx := cmd.Context()
+
+	log.Printf("Generating project from blueprint: %s", filename)
+	config, err := service.Load(ctx, filename)
+	if err != nil {
+		return err
+	}
+
+	projectPath := filepath.Join(outputDir, config.ProjectName)
+	templateDir := opts.templates
+	if templateDir == "" {
+		templateDir = filepath.Join(projectPath, regen.StateDir, templatesDir)
+	}
+	warnUnknownTemplates(templateDir)
+	templates := infrastructure.NewTemplateSet(infrastructure.NewGoTemplateEngine(), baseFS, templateDir)
+	service = newServiceWithTemplates(baseFS, templates)
+
+	writer, err := regen.NewFileSystem(baseFS, projectPath, strategy)
+	if err != nil {
+		return err
+	}
+
+	if err := service.GenerateTo(ctx, config, outputDir, writer); err != nil {
+		return fmt.Errorf("failed to generate project: %w", err)
+	}
+	if err := writer.Commit(); err != nil {
+		return fmt.Errorf("failed to save regeneration manifest: %w", err)
+	}
+
+	if opts.dryRun {
+		infrastructure.WritePlan(cmd.OutOrStdout(), outputDir, memFS.Plan(), func(path string) bool {
+			return regen.IsState(projectPath, path)
+		})
+	}
+
+	conflicts := reportRegeneration(writer.Results())
+	if conflicts > 0 {
+		return fmt.Errorf("%d file(s) could not be merged, review the %s files", conflicts, regen.SideFileSuffix)
+	}
+	if !opts.dryRun {
+		log.Printf("Successfully generated project in %s", projectPath)
+	}
+	if opts.verify {
+		return verifyProject(cmd, config, projectPath, templates)
+	}
+	return nil
+}
+
+// verifyProject type-checks the project as generated, before any merge with
+// the user's changes, and prints each error at its path under projectPath
+func verifyProject(cmd *cobra.Command, config *domain.Config, projectPath string, templates domain.TemplatePort) error {
+	log.Printf("Type-checking the generated code")
+	recorder := verify.NewRecorder(templates)
+	files, err := generateInMemory(cmd.Context(), newServiceWithTemplates(infrastructure.NewOSFileSystem(), recorder), config)
+	if err != nil {
+		return err
+	}
+	project := make(map[string][]byte, len(files))
+	for path, content := range files {
+		project[path] = []byte(content)
+	}
+
+	problems, err := verify.Check(project, recorder)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		p.Pos.Filename = filepath.Join(projectPath, filepath.FromSlash(p.Pos.Filename))
+		fmt.Fprintln(cmd.OutOrStdout(), p.String())
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("the generated code has %d type error(s)", len(problems))
+	}
+	log.Printf("Generated code type-checks")
+	return nil
+}
+
+// reportRegeneration logs the files that need the user's attention and returns the number of conflicts
+func reportRegeneration(results []regen.Result) int {
+	conflicts := 0
+	for _, r := range results {
+		if !r.NeedsAttention() {
+			continue
+		}
+		switch r.Action {
+		case regen.ActionConflict:
+			conflicts++
+			log.Printf("conflict:    %s (merge result with markers written to %s%s)", r.Path, r.Path, regen.SideFileSuffix)
+		case regen.ActionSideFile:
+			log.Printf("side-file:   %s (your version kept, generated version written to %s%s)", r.Path, r.Path, regen.SideFileSuffix)
+		case regen.ActionStale:
+			log.Printf("stale:       %s (no longer generated, left in place)", r.Path)
+		case regen.ActionOrphaned:
+			log.Printf("orphaned:    %s (custom regions removed from the template saved to %s%s)", r.Path, r.Path, regen.RegionsSuffix)
+		default:
+			log.Printf("%-12s %s", string(r.Action)+":", r.Path)
+		}
+	}
+	return conflicts
+}
+
+type validateOptions struct {
+	global *globalOptions
+	strict bool
+}
+
+func newValidateCommand(global *globalOptions) *cobra.Command {
+	opts := &validateOptions{global: global}
+
+	cmd := &cobra.Command{
+		Use:   "validate [blueprint.md...]",
+		Short: "Check blueprints and report every problem with its line and column",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{findBlueprint()}
+			}
+			return runValidate(cmd, opts, args)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.strict, "strict", false, "treat warnings as errors")
+	return cmd
+}
+
+func runValidate(cmd *cobra.Command, opts *validateOptions, files []string) error {
+	service := newService()
+	out := cmd.OutOrStdout()
+
+	failed := 0
+	for _, file := range files {
+		diags, err := service.Validate(cmd.Context(), file)
+		if err != nil {
+			fmt.Fprintf(out, "%s: error: %v\n", file, err)
+			failed++
+			continue
+		}
+
+		invalid := false
+		for _, d := range diags {
+			fmt.Fprintln(out, d.String())
+			invalid = invalid || d.Severity == domain.SeverityError || opts.strict
+		}
+		if invalid {
+			failed++
+		} else if !opts.global.quiet {
+			fmt.Fprintf(out, "%s: ok\n", file)
+		}
+	}
+
+	if failed > 0 {
+		return &exitError{code: exitInvalid, err: fmt.Errorf("%d of %d blueprint(s) failed validation", failed, len(files))}
+	}
+	return nil
+}
+
+type initOptions struct {
+	global   *globalOptions
+	name     string
+	database string
+	force    bool
+}
+
+func newInitCommand(global *globalOptions) *cobra.Command {
+	opts := &initOptions{global: global}
+
+	cmd := &cobra.Command{
+		Use:   "init [blueprint.md]",
+		Short: "Create a new blueprint, interactively unless --name is given",
+		Args:  maxArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filename := defaultBlueprint
+			if len(args) > 0 {
+				filename = args[0]
+			}
+			return runInit(opts, filename)
+		},
+	}
+	cmd.Flags().StringVar(&opts.name, "name", "", "project name; skips the wizard and writes a starter blueprint")
+	cmd.Flags().StringVar(&opts.database, "database", "firestore", "database type for the starter blueprint (firestore, postgresql, mongodb)")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "overwrite an existing blueprint file")
+	return cmd
+}
+
+func runInit(opts *initOptions, filename string) error {
+	if _, err := os.Stat(filename); err == nil && !opts.force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", filename)
+	}
+
+	if opts.name == "" {
+		_, err := createNewBlueprint(filename)
+		return err
+	}
+
+	switch opts.database {
+	case "firestore", "postgresql", "mongodb":
+	default:
+		return usageError("unsupported database type %q", opts.database)
+	}
+	if err := writeStarterBlueprint(filename, opts.name, opts.database); err != nil {
+		return err
+	}
+	log.Printf("Created %s", filename)
+	return nil
+}
+
+func newVersionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the blueprint version",
+		Args:  maxArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(cmd.OutOrStdout(), "blueprint %s (%s %s/%s)\n", version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+		},
+	}
+}
+
+type schemaOptions struct {
+	out string
+}
+
+func newSchemaCommand() *cobra.Command {
+	opts := &schemaOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the blueprint format, for editor completion and validation",
+		Args:  maxArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema := jsonschema.BlueprintJSON()
+			if opts.out == "" {
+				_, err := cmd.OutOrStdout().Write(schema)
+				return err
+			}
+			if err := os.WriteFile(opts.out, schema, 0644); err != nil {
+				return fmt.Errorf("failed to write schema: %w", err)
+			}
+			log.Printf("Wrote %s", opts.out)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&opts.out, "out", "o", "", "file to write the schema to instead of stdout")
+	return cmd
+}
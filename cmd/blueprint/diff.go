@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/diff"
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/spf13/cobra"
+)
+
+type diffOptions struct {
+	global      *globalOptions
+	exitCode    bool
+	summaryOnly bool
+	context     int
+}
+
+func newDiffCommand(global *globalOptions) *cobra.Command {
+	opts := &diffOptions{global: global}
+
+	cmd := &cobra.Command{
+		Use:   "diff <old-blueprint> <new-blueprint>",
+		Short: "Show how the generated project changes between two blueprints",
+		Long: "Generate both blueprints in memory and print a summary of the added, removed and\n" +
+			"changed models, fields and routes, followed by a unified diff of the generated files.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return usageError("diff requires exactly 2 arguments, received %d", len(args))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd, opts, args[0], args[1])
+		},
+	}
+	cmd.Flags().BoolVar(&opts.exitCode, "exit-code", false, "exit with status 1 when the blueprints differ")
+	cmd.Flags().BoolVar(&opts.summaryOnly, "summary", false, "print only the models, fields and routes summary")
+	cmd.Flags().IntVarP(&opts.context, "unified", "U", 3, "lines of context around each change")
+	return cmd
+}
+
+func runDiff(cmd *cobra.Command, opts *diffOptions, oldFile, newFile string) error {
+	service := newService()
+	ctx := cmd.Context()
+
+	oldConfig, err := service.Load(ctx, oldFile)
+	if err != nil {
+		return err
+	}
+	newConfig, err := service.Load(ctx, newFile)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	changes := application.CompareConfigs(oldConfig, newConfig)
+	changes = append(changes, application.CompareRoutes(oldConfig, newConfig)...)
+	for _, c := range changes {
+		fmt.Fprintln(out, c.String())
+	}
+
+	differs := len(changes) > 0
+	if !opts.summaryOnly {
+		oldFiles, err := generateInMemory(ctx, service, oldConfig)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s: %w", oldFile, err)
+		}
+		newFiles, err := generateInMemory(ctx, service, newConfig)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s: %w", newFile, err)
+		}
+		if len(changes) > 0 {
+			fmt.Fprintln(out)
+		}
+		if printProjectDiff(out, oldFiles, newFiles, opts.context) {
+			differs = true
+		}
+	}
+
+	if differs && opts.exitCode {
+		return &exitError{code: exitFailure}
+	}
+	return nil
+}
+
+// generateInMemory renders config without touching disk and returns its files keyed by project-relative path
+func generateInMemory(ctx context.Context, service *application.BlueprintService, config *domain.Config) (map[string]string, error) {
+	fs := infrastructure.NewMemoryFileSystem(nil)
+	if err := service.GenerateTo(ctx, config, ".", fs); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string)
+	for _, path := range fs.Files() {
+		rel, err := filepath.Rel(config.ProjectName, path)
+		if err != nil {
+			return nil, err
+		}
+		data, err := fs.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.ToSlash(rel)] = string(data)
+	}
+	return files, nil
+}
+
+// printProjectDiff writes a unified diff of every file that differs and reports whether any did
+func printProjectDiff(w io.Writer, oldFiles, newFiles map[string]string, context int) bool {
+	paths := make(map[string]bool)
+	for p := range oldFiles {
+		paths[p] = true
+	}
+	for p := range newFiles {
+		paths[p] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	differs := false
+	for _, p := range sorted {
+		oldContent, inOld := oldFiles[p]
+		newContent, inNew := newFiles[p]
+		oldName, newName := "a/"+p, "b/"+p
+		if !inOld {
+			oldName = "/dev/null"
+		}
+		if !inNew {
+			newName = "/dev/null"
+		}
+		if d := diff.Unified(oldName, newName, oldContent, newContent, context); d != "" {
+			fmt.Fprint(w, d)
+			differs = true
+		}
+	}
+	return differs
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/generator"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/regen"
+	"github.com/spf13/cobra"
+)
+
+// templatesDir holds the template overrides inside the regeneration state of a project
+const templatesDir = "templates"
+
+type ejectOptions struct {
+	dir   string
+	force bool
+	list  bool
+}
+
+func newEjectCommand() *cobra.Command {
+	opts := &ejectOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "eject [templates...]",
+		Short: "Copy built-in templates to a directory where generate picks up your changes",
+		Long: "Copy built-in templates, or all of them, to .blueprint/templates as <name>.tmpl files.\n" +
+			"Run it in a generated project: later generations render these files instead of the built-in templates.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEject(cmd, opts, args)
+		},
+	}
+	cmd.Flags().StringVar(&opts.dir, "templates", filepath.Join(regen.StateDir, templatesDir), "directory to write the templates to")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "overwrite templates ejected before")
+	cmd.Flags().BoolVar(&opts.list, "list", false, "print the template names instead")
+	return cmd
+}
+
+func runEject(cmd *cobra.Command, opts *ejectOptions, names []string) error {
+	if opts.list {
+		for _, name := range generator.TemplateNames() {
+			fmt.Fprintln(cmd.OutOrStdout(), name)
+		}
+		return nil
+	}
+
+	if len(names) == 0 {
+		names = generator.TemplateNames()
+	}
+	for _, name := range names {
+		if _, ok := generator.DefaultTemplate(name); !ok {
+			return usageError("unknown template %q, templates are: %s", name, strings.Join(generator.TemplateNames(), ", "))
+		}
+	}
+
+	if err := os.MkdirAll(opts.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create templates directory: %w", err)
+	}
+	written := 0
+	for _, name := range names {
+		path := filepath.Join(opts.dir, name+infrastructure.TemplateExt)
+		if _, err := os.Stat(path); err == nil && !opts.force {
+			log.Printf("Skipping %s: it exists, use --force to overwrite it", path)
+			continue
+		}
+		text, _ := generator.DefaultTemplate(name)
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			return fmt.Errorf("failed to write template: %w", err)
+		}
+		written++
+	}
+	log.Printf("Wrote %d template(s) to %s", written, opts.dir)
+	return nil
+}
+
+// warnUnknownTemplates flags the files of a template directory that don't
+// replace any template, such as misspelled names, since they are ignored
+func warnUnknownTemplates(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), infrastructure.TemplateExt)
+		if _, known := generator.DefaultTemplate(name); ok && !known {
+			log.Printf("Warning: %s doesn't replace any template, see blueprint eject --list", filepath.Join(dir, entry.Name()))
+		}
+	}
+}
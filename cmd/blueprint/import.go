@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/migration"
+	"github.com/eduardo/blueprint/internal/openapi"
+	"github.com/spf13/cobra"
+)
+
+type importOptions struct {
+	global   *globalOptions
+	out      string
+	name     string
+	database string
+	force    bool
+}
+
+func newImportCommand(global *globalOptions) *cobra.Command {
+	opts := &importOptions{global: global}
+
+	cmd := &cobra.Command{
+		Use:   "import <openapi.yaml | schema.json... | schema.sql>",
+		Short: "Create a blueprint from an OpenAPI 3 document, a set of JSON Schemas or PostgreSQL DDL",
+		Long: "Map the component schemas the API reads or returns to models, $refs between them to\n" +
+			"relations and operations that require security to protected models. Whatever has no\n" +
+			"blueprint equivalent is left out and listed as a warning.\n\n" +
+			"A .sql file is read as the output of pg_dump --schema-only: tables become models,\n" +
+			"foreign keys belongsTo and hasMany relations and tables holding only two foreign keys\n" +
+			"manyToMany relations. No database connection is needed.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return usageError("import requires at least 1 argument")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(cmd, opts, args)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.out, "out", "o", defaultBlueprint, "blueprint file to write")
+	cmd.Flags().StringVar(&opts.name, "name", "", "project name (default: derived from the API title or the .sql file name)")
+	cmd.Flags().StringVar(&opts.database, "database", "firestore", "database type of the blueprint (firestore, postgresql, mongodb; postgresql for .sql files)")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "overwrite an existing blueprint file")
+	return cmd
+}
+
+func runImport(cmd *cobra.Command, opts *importOptions, files []string) error {
+	if _, err := os.Stat(opts.out); err == nil && !opts.force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", opts.out)
+	}
+	switch opts.database {
+	case "firestore", "postgresql", "mongodb":
+	default:
+		return usageError("unsupported database type %q", opts.database)
+	}
+
+	var sqlFiles int
+	for _, file := range files {
+		if strings.EqualFold(filepath.Ext(file), ".sql") {
+			sqlFiles++
+		}
+	}
+	switch {
+	case sqlFiles > 1:
+		return usageError("import reads a single .sql file, got %d", sqlFiles)
+	case sqlFiles == 1 && len(files) > 1:
+		return usageError("a .sql file can't be imported together with API documents")
+	case sqlFiles == 1 && !cmd.Flags().Changed("database"):
+		opts.database = "postgresql"
+	}
+
+	var config *domain.Config
+	var notes []domain.Diagnostic
+	var err error
+	if sqlFiles == 1 {
+		config, notes, err = importDDL(files[0])
+	} else {
+		config, notes, err = importAPI(files)
+	}
+	if err != nil {
+		return err
+	}
+	if opts.name != "" {
+		config.ProjectName = opts.name
+	}
+	config.Database.Type = opts.database
+	if opts.database == "firestore" {
+		config.Database.ProjectID = "your-project-id"
+	}
+
+	for _, note := range notes {
+		log.Print(note.String())
+	}
+	if err := writeBlueprint(opts.out, config.ProjectName, config); err != nil {
+		return err
+	}
+	log.Printf("Created %s with %d model(s), %d warning(s)", opts.out, len(config.Models), len(notes))
+
+	// Names the API allows may still be rejected, such as a model called "auth"
+	diags, err := newService().Validate(cmd.Context(), opts.out)
+	if err != nil {
+		return err
+	}
+	for _, d := range diags {
+		log.Print(d.String())
+	}
+	if domain.HasErrors(diags) {
+		return &exitError{code: exitInvalid, err: fmt.Errorf("%s needs fixing before it can be generated", opts.out)}
+	}
+	return nil
+}
+
+func importAPI(files []string) (*domain.Config, []domain.Diagnostic, error) {
+	var docs []openapi.Document
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, usageError("failed to read %s: %v", file, err)
+		}
+		docs = append(docs, openapi.Document{Name: filepath.ToSlash(file), Data: data})
+	}
+	return openapi.Import(docs)
+}
+
+func importDDL(file string) (*domain.Config, []domain.Diagnostic, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, usageError("failed to read %s: %v", file, err)
+	}
+	dump, notes, err := migration.ParseDDL(file, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	config, more := migration.Reverse(dump)
+	notes = append(notes, more...)
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].Pos.Line < notes[j].Pos.Line
+	})
+	return config, notes, nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,6 +11,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/huh"
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/yaml"
 )
 
 func runInteractiveMode() (string, error) {
@@ -33,7 +36,7 @@ func runInteractiveMode() (string, error) {
 
 	switch action {
 	case "create":
-		return createNewBlueprint()
+		return createNewBlueprint(defaultBlueprint)
 	case "select":
 		return selectExistingBlueprint()
 	default:
@@ -41,7 +44,7 @@ func runInteractiveMode() (string, error) {
 	}
 }
 
-func createNewBlueprint() (string, error) {
+func createNewBlueprint(filename string) (string, error) {
 	var (
 		projectName    string
 		dbType         string
@@ -159,13 +162,7 @@ func createNewBlueprint() (string, error) {
 						Value(&fieldName),
 					huh.NewSelect[string]().
 						Title("Field Type").
-						Options(
-							huh.NewOption("String", "string"),
-							huh.NewOption("Integer", "integer"),
-							huh.NewOption("Float", "float"),
-							huh.NewOption("Boolean", "boolean"),
-							huh.NewOption("DateTime", "datetime"),
-						).
+						Options(fieldTypeOptions()...).
 						Value(&fieldType),
 				),
 			).Run()
@@ -205,6 +202,7 @@ func createNewBlueprint() (string, error) {
 							huh.NewOption("Has Many", "hasMany"),
 							huh.NewOption("Belongs To", "belongsTo"),
 							huh.NewOption("Has One", "hasOne"),
+							huh.NewOption("Many To Many", "manyToMany"),
 						).
 						Value(&relType),
 					huh.NewInput().
@@ -231,11 +229,8 @@ func createNewBlueprint() (string, error) {
 			Type      string `json:"type"`
 			ProjectID string `json:"project_id"`
 		} `json:"database"`
-		Auth *struct {
-			Enabled        bool   `json:"enabled"`
-			UserCollection string `json:"user_collection"`
-		} `json:"auth,omitempty"`
-		Models []map[string]interface{} `json:"models"`
+		Models  []map[string]interface{} `json:"models"`
+		Modules map[string]interface{}   `json:"modules,omitempty"`
 	}
 
 	config := Config{
@@ -245,12 +240,8 @@ func createNewBlueprint() (string, error) {
 	config.Database.ProjectID = "your-project-id"
 
 	if enableAuth {
-		config.Auth = &struct {
-			Enabled        bool   `json:"enabled"`
-			UserCollection string `json:"user_collection"`
-		}{
-			Enabled:        true,
-			UserCollection: userCollection,
+		config.Modules = map[string]interface{}{
+			"auth": map[string]string{"user_collection": userCollection},
 		}
 	}
 
@@ -272,20 +263,67 @@ func createNewBlueprint() (string, error) {
 		config.Models = append(config.Models, modelMap)
 	}
 
-	jsonBytes, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
+	if err := writeBlueprint(filename, projectName, config); err != nil {
 		return "", err
 	}
 
-	content := fmt.Sprintf("# %s Blueprint\n\n```json\n%s\n```\n", projectName, string(jsonBytes))
-	filename := "blueprint.md"
+	fmt.Printf("Created %s\n", filename)
+	return filename, nil
+}
 
-	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
-		return "", fmt.Errorf("failed to create blueprint file: %w", err)
+// writeStarterBlueprint creates a minimal blueprint without going through the wizard
+func writeStarterBlueprint(filename, projectName, dbType string) error {
+	config := domain.Config{
+		ProjectName: projectName,
+		Database:    domain.Database{Type: dbType},
+		Models: []domain.Model{
+			{
+				Name: "items",
+				Fields: map[string]string{
+					"name":        "string",
+					"description": "text",
+					"created_at":  "datetime",
+				},
+				Relations: map[string]string{},
+			},
+		},
 	}
+	if dbType == "firestore" {
+		config.Database.ProjectID = "your-project-id"
+	}
+	return writeBlueprint(filename, projectName, config)
+}
 
-	fmt.Printf("Created %s\n", filename)
-	return filename, nil
+// writeBlueprint writes the config in the format the file extension selects:
+// plain JSON or YAML, or else the markdown layout with a json fence
+func writeBlueprint(filename, projectName string, config interface{}) error {
+	// Without HTML escaping, so types such as array<string> stay readable
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(config); err != nil {
+		return err
+	}
+
+	var content []byte
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		content = buf.Bytes()
+	case ".yaml", ".yml":
+		yamlContent, err := yaml.FromJSON(buf.Bytes())
+		if err != nil {
+			return err
+		}
+		content = yamlContent
+	default:
+		content = []byte(fmt.Sprintf("# %s Blueprint\n\n```json\n%s```\n", projectName, buf.String()))
+	}
+
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		return fmt.Errorf("failed to create blueprint file: %w", err)
+	}
+	return nil
 }
 
 func selectExistingBlueprint() (string, error) {
@@ -293,9 +331,15 @@ func selectExistingBlueprint() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	// Other .json and .yaml files are rarely blueprints, so only the usual names are offered
+	for _, name := range blueprintNames[1:] {
+		if _, err := os.Stat(name); err == nil {
+			files = append(files, name)
+		}
+	}
 
 	if len(files) == 0 {
-		return "", fmt.Errorf("no .md files found in current directory")
+		return "", fmt.Errorf("no blueprint files found in current directory")
 	}
 
 	var options []huh.Option[string]
@@ -325,3 +369,15 @@ func _unused() {
 	_ = bufio.NewReader(os.Stdin)
 	_ = strconv.Itoa(1)
 }
+
+// fieldTypeOptions offers the registered field types that take no parameters;
+// enum(...) and array<...> can be written in the blueprint afterwards
+func fieldTypeOptions() []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, t := range domain.FieldTypes() {
+		if t.Label != "" {
+			options = append(options, huh.NewOption(t.Label, t.Name))
+		}
+	}
+	return options
+}
@@ -1,66 +1,157 @@
 package main
 
 import (
-	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"os"
 
 	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/domain"
 	"github.com/eduardo/blueprint/internal/generator"
 	"github.com/eduardo/blueprint/internal/infrastructure"
 	"github.com/eduardo/blueprint/internal/parser"
+	"github.com/eduardo/blueprint/internal/regen"
+	"github.com/eduardo/blueprint/internal/validator"
+	"github.com/spf13/cobra"
 )
 
+// version is overridden at build time with -ldflags "-X main.version=..."
+var version = "dev"
+
+// Exit codes returned to the shell so CI can tell failures apart
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+	exitInvalid = 3
+)
+
+// exitError carries the exit code a command wants to terminate with
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit status %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+func usageError(format string, args ...interface{}) error {
+	return &exitError{code: exitUsage, err: fmt.Errorf(format, args...)}
+}
+
 func main() {
-	f, _ := os.Create("debug_log.txt")
-	defer f.Close()
-	log.SetOutput(f)
+	log.SetOutput(os.Stderr)
+	log.SetFlags(0)
 
-	// 1. Initialize Adapters
-	fs := infrastructure.NewOSFileSystem()
-	templateEngine := infrastructure.NewGoTemplateEngine()
-	markdownParser := parser.NewMarkdownParser(fs)
+	root := newRootCommand()
+	err := root.Execute()
+	os.Exit(exitCode(err))
+}
 
-	// 2. Initialize Application Service
-	// We pass the Generate function from the generator package as a dependency
-	blueprintService := application.NewBlueprintService(fs, templateEngine, markdownParser, generator.Generate)
-
-	// 3. Parse and Generate
-	var filename string
-	var outputDir string
-	var err error
-
-	if len(os.Args) > 1 {
-		filename = os.Args[1]
-		if len(os.Args) > 2 {
-			outputDir = os.Args[2]
-		} else {
-			outputDir, err = os.Getwd()
-			if err != nil {
-				log.Fatalf("Failed to get current directory: %v", err)
-			}
-		}
-	} else {
-		filename, err = runInteractiveMode()
-		if err != nil {
-			log.Fatalf("Interactive mode failed: %v", err)
-		}
-		outputDir, err = os.Getwd()
-		if err != nil {
-			log.Fatalf("Failed to get current directory: %v", err)
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		if exitErr.err != nil {
+			log.Printf("Error: %v", exitErr.err)
 		}
+		return exitErr.code
 	}
 
-	log.Printf("Generating project from blueprint: %s", filename)
-	if err := blueprintService.Generate(context.Background(), filename, outputDir); err != nil {
-		log.Fatalf("Failed to generate project: %v", err)
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		log.Print(validationErr.Error())
+		return exitInvalid
 	}
 
-	// 4. Run setup.sh (Optional/Manual step usually, but keeping it for compatibility)
-	// We need to know the project name, which is in the config.
-	// Since we don't have the config here easily (it's inside the service),
-	// we might need to change the service to return it or just let the user run setup.sh.
-	// For now, I'll assume the user wants to run it manually or I'll just skip it if I can't easily get the project name.
-	// Actually, I'll just skip it to keep the main clean and follow the new architecture.
-	log.Printf("Successfully generated project in %s", outputDir)
+	log.Printf("Error: %v", err)
+	return exitFailure
+}
+
+// globalOptions holds the flags shared by every command
+type globalOptions struct {
+	quiet bool
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &globalOptions{}
+
+	root := &cobra.Command{
+		Use:   "blueprint [blueprint.md] [output-dir]",
+		Short: "Generate Go API projects from a markdown blueprint",
+		Long: "blueprint turns a blueprint file into a ready-to-run Go API project.\n" +
+			"Run it without arguments to launch the interactive wizard.",
+		Args:          maxArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if opts.quiet {
+				log.SetOutput(io.Discard)
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Kept for compatibility with "blueprint <file> [output-dir]"
+			if len(args) > 0 {
+				genOpts := &generateOptions{global: opts, onConflict: string(regen.StrategyMerge)}
+				if len(args) > 1 {
+					genOpts.out = args[1]
+				}
+				return runGenerate(cmd, genOpts, args[0])
+			}
+
+			filename, err := runInteractiveMode()
+			if err != nil {
+				return fmt.Errorf("interactive mode failed: %w", err)
+			}
+			return runGenerate(cmd, &generateOptions{global: opts, onConflict: string(regen.StrategyMerge)}, filename)
+		},
+	}
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &exitError{code: exitUsage, err: err}
+	})
+	root.PersistentFlags().BoolVarP(&opts.quiet, "quiet", "q", false, "only print errors")
+
+	root.AddCommand(
+		newGenerateCommand(opts),
+		newValidateCommand(opts),
+		newInitCommand(opts),
+		newDiffCommand(opts),
+		newImportCommand(opts),
+		newSchemaCommand(),
+		newEjectCommand(),
+		newVersionCommand(),
+	)
+	return root
+}
+
+// newService wires the adapters into the application service
+func newService() *application.BlueprintService {
+	return newServiceWithFS(infrastructure.NewOSFileSystem())
+}
+
+// newServiceWithFS is newService writing through the given filesystem
+func newServiceWithFS(fs domain.FileSystemPort) *application.BlueprintService {
+	return newServiceWithTemplates(fs, infrastructure.NewGoTemplateEngine())
+}
+
+// newServiceWithTemplates builds the service around another template engine
+func newServiceWithTemplates(fs domain.FileSystemPort, templateEngine domain.TemplatePort) *application.BlueprintService {
+	blueprintParser := parser.NewFileParser(fs)
+	blueprintValidator := validator.NewValidator()
+
+	// We pass the Generate function from the generator package as a dependency
+	return application.NewBlueprintService(fs, templateEngine, blueprintParser, blueprintValidator, generator.Generate)
 }
@@ -0,0 +1,9 @@
+package main
+
+// Generator modules are compiled into the CLI: importing a module package for
+// its init function registers the module. See internal/module for the
+// contract a module implements.
+import (
+	_ "github.com/eduardo/blueprint/internal/modules/auth"
+	_ "github.com/eduardo/blueprint/internal/modules/payments"
+)
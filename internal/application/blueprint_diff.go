@@ -0,0 +1,226 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/module"
+)
+
+// ChangeKind tells whether an element was added, removed or modified
+type ChangeKind string
+
+const (
+	Added    ChangeKind = "+"
+	Removed  ChangeKind = "-"
+	Modified ChangeKind = "~"
+)
+
+// Change describes a single difference between two blueprints
+type Change struct {
+	Kind   ChangeKind
+	Model  string // empty for project-level changes such as routes
+	Member string // field or relation name, empty for model-level changes
+	Detail string
+}
+
+func (c Change) String() string {
+	if c.Model == "" {
+		return fmt.Sprintf("%s %s%s", c.Kind, c.Member, c.Detail)
+	}
+	if c.Member == "" {
+		return fmt.Sprintf("%s model %s%s", c.Kind, c.Model, c.Detail)
+	}
+	return fmt.Sprintf("    %s %s%s", c.Kind, c.Member, c.Detail)
+}
+
+// CompareConfigs lists the model, field and relation changes going from old to new
+func CompareConfigs(old, new *domain.Config) []Change {
+	oldModels := indexModels(old)
+	newModels := indexModels(new)
+
+	var changes []Change
+	for _, name := range unionKeys(oldModels, newModels) {
+		before, inOld := oldModels[name]
+		after, inNew := newModels[name]
+
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: Added, Model: name})
+		case !inNew:
+			changes = append(changes, Change{Kind: Removed, Model: name})
+		default:
+			members := compareModel(before, after)
+			if len(members) == 0 {
+				continue
+			}
+			changes = append(changes, Change{Kind: Modified, Model: name})
+			changes = append(changes, members...)
+		}
+	}
+	return changes
+}
+
+func compareModel(before, after domain.Model) []Change {
+	var changes []Change
+	if before.Protected != after.Protected {
+		changes = append(changes, Change{
+			Kind: Modified, Model: after.Name, Member: "protected",
+			Detail: fmt.Sprintf(": %t -> %t", before.Protected, after.Protected),
+		})
+	}
+	changes = append(changes, compareMembers(after.Name, "field", fieldSpecs(before), fieldSpecs(after))...)
+	changes = append(changes, compareMembers(after.Name, "relation", before.Relations, after.Relations)...)
+	return changes
+}
+
+func compareMembers(model, label string, before, after map[string]string) []Change {
+	var changes []Change
+	for _, name := range unionKeys(before, after) {
+		oldValue, inOld := before[name]
+		newValue, inNew := after[name]
+		member := label + " " + name
+
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: Added, Model: model, Member: member, Detail: fmt.Sprintf(" (%s)", newValue)})
+		case !inNew:
+			changes = append(changes, Change{Kind: Removed, Model: model, Member: member, Detail: fmt.Sprintf(" (%s)", oldValue)})
+		case oldValue != newValue:
+			changes = append(changes, Change{Kind: Modified, Model: model, Member: member, Detail: fmt.Sprintf(": %s -> %s", oldValue, newValue)})
+		}
+	}
+	return changes
+}
+
+// fieldSpecs describes each field by its type and rules, so rule changes show up
+// as modified fields: "price" is "float" or "float required, min=0"
+func fieldSpecs(model domain.Model) map[string]string {
+	specs := make(map[string]string, len(model.Fields))
+	for name, fieldType := range model.Fields {
+		rules, ok := model.Rules[name]
+		if !ok {
+			specs[name] = fieldType
+			continue
+		}
+		values := map[string]interface{}{
+			"pattern": rules.Pattern,
+			"enum":    strings.Join(rules.Enum, "|"),
+			"default": rules.Default,
+		}
+		if rules.Min != nil {
+			values["min"] = *rules.Min
+		}
+		if rules.Max != nil {
+			values["max"] = *rules.Max
+		}
+		if rules.MinLength != nil {
+			values["min_length"] = *rules.MinLength
+		}
+		if rules.MaxLength != nil {
+			values["max_length"] = *rules.MaxLength
+		}
+
+		var parts []string
+		for _, rule := range rules.RuleNames() {
+			if value, ok := values[rule]; ok {
+				parts = append(parts, fmt.Sprintf("%s=%v", rule, value))
+			} else {
+				parts = append(parts, rule)
+			}
+		}
+		specs[name] = strings.TrimSpace(fieldType + " " + strings.Join(parts, ", "))
+	}
+	return specs
+}
+
+// Route is an HTTP endpoint exposed by the generated API
+type Route struct {
+	Method string
+	Path   string
+}
+
+func (r Route) String() string {
+	return r.Method + " " + r.Path
+}
+
+// Routes lists the endpoints registered by the generated cmd/api/main.go
+func Routes(config *domain.Config) []Route {
+	routes := []Route{{"GET", "/openapi.yaml"}, {"GET", "/swagger/*any"}}
+
+	// A blueprint whose modules can't contribute fails validation before any diff
+	contributions, _ := module.Contributions(config)
+	for _, c := range contributions {
+		for _, e := range c.Endpoints {
+			routes = append(routes, Route{e.Method, e.Path})
+		}
+	}
+
+	for _, m := range config.Models {
+		base := "/api/" + m.Name
+		routes = append(routes,
+			Route{"GET", base},
+			Route{"GET", base + "/:id"},
+			Route{"POST", base},
+			Route{"PUT", base + "/:id"},
+			Route{"DELETE", base + "/:id"},
+		)
+		for _, name := range sortedKeys(m.Relations) {
+			if domain.ParseRelation(m.Relations[name]).Kind == domain.ManyToMany {
+				link := base + "/:id/" + name + "/:targetId"
+				routes = append(routes, Route{"POST", link}, Route{"DELETE", link})
+			}
+		}
+	}
+	return routes
+}
+
+// CompareRoutes lists the endpoints added or removed going from old to new
+func CompareRoutes(old, new *domain.Config) []Change {
+	index := func(config *domain.Config) map[string]Route {
+		routes := make(map[string]Route)
+		for _, r := range Routes(config) {
+			routes[r.String()] = r
+		}
+		return routes
+	}
+	before, after := index(old), index(new)
+
+	var changes []Change
+	for _, key := range unionKeys(before, after) {
+		_, inOld := before[key]
+		_, inNew := after[key]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: Added, Member: "route " + key})
+		case !inNew:
+			changes = append(changes, Change{Kind: Removed, Member: "route " + key})
+		}
+	}
+	return changes
+}
+
+func indexModels(config *domain.Config) map[string]domain.Model {
+	models := make(map[string]domain.Model)
+	for _, m := range config.Models {
+		models[m.Name] = m
+	}
+	return models
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]V{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
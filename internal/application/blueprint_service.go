@@ -2,135 +2,137 @@ package application
 
 import (
 	"context"
+	"log"
+	"sort"
 
 	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/module"
 )
 
 // BlueprintService implements domain.BlueprintServicePort
 type BlueprintService struct {
-	fs       domain.FileSystemPort
-	template domain.TemplatePort
-	parser   domain.ParserPort
+	fs        domain.FileSystemPort
+	template  domain.TemplatePort
+	parser    domain.ParserPort
+	validator domain.ValidatorPort
 	// We will add the generator logic here or call a generator adapter
 	generateFunc func(config *domain.Config, outputDir string, fs domain.FileSystemPort, template domain.TemplatePort) error
 }
 
-func NewBlueprintService(fs domain.FileSystemPort, template domain.TemplatePort, parser domain.ParserPort, generateFunc func(*domain.Config, string, domain.FileSystemPort, domain.TemplatePort) error) *BlueprintService {
+func NewBlueprintService(fs domain.FileSystemPort, template domain.TemplatePort, parser domain.ParserPort, validator domain.ValidatorPort, generateFunc func(*domain.Config, string, domain.FileSystemPort, domain.TemplatePort) error) *BlueprintService {
 	return &BlueprintService{
 		fs:           fs,
 		template:     template,
 		parser:       parser,
+		validator:    validator,
 		generateFunc: generateFunc,
 	}
 }
 
 func (s *BlueprintService) Generate(ctx context.Context, blueprintPath, outputDir string) error {
-	config, err := s.parser.Parse(blueprintPath)
+	config, err := s.Load(ctx, blueprintPath)
 	if err != nil {
 		return err
 	}
 
-	s.enrichConfig(config)
+	return s.GenerateFromConfig(ctx, config, outputDir)
+}
 
-	return s.generateFunc(config, outputDir, s.fs, s.template)
+// GenerateFromConfig runs the generator for a config previously returned by Load
+func (s *BlueprintService) GenerateFromConfig(ctx context.Context, config *domain.Config, outputDir string) error {
+	return s.GenerateTo(ctx, config, outputDir, s.fs)
 }
 
-func (s *BlueprintService) enrichConfig(config *domain.Config) {
-	s.enrichAuth(config)
-	s.enrichPayments(config)
+// GenerateTo runs the generator writing through fs instead of the service filesystem
+func (s *BlueprintService) GenerateTo(ctx context.Context, config *domain.Config, outputDir string, fs domain.FileSystemPort) error {
+	return s.generateFunc(config, outputDir, fs, s.template)
 }
 
-func (s *BlueprintService) enrichAuth(config *domain.Config) {
-	if config.Auth == nil || !config.Auth.Enabled {
-		return
+// Validate parses the blueprint and returns every diagnostic without generating anything
+func (s *BlueprintService) Validate(ctx context.Context, blueprintPath string) ([]domain.Diagnostic, error) {
+	config, err := s.parser.Parse(blueprintPath)
+	if err != nil {
+		return nil, err
 	}
+	return s.validate(config), nil
+}
 
-	if config.Auth.Provider == "" {
-		config.Auth.Provider = "firebase"
+// validate lets the modules add their models first, so the validator checks
+// them like declared ones. Settings a module can't read are reported by the
+// validator at their key.
+func (s *BlueprintService) validate(config *domain.Config) []domain.Diagnostic {
+	if err := module.Enrich(config); err != nil {
+		diags := s.validator.Validate(config)
+		if !domain.HasErrors(diags) {
+			diags = append(diags, domain.Diagnostic{Pos: config.Source.Lookup("modules"), Path: "modules", Severity: domain.SeverityError, Message: err.Error()})
+		}
+		return diags
 	}
+	return s.validator.Validate(config)
+}
 
-	if config.Auth.UserCollection == "" {
-		config.Auth.UserCollection = "users"
+// Load parses, validates and enriches the blueprint, ready to be handed to the generator
+func (s *BlueprintService) Load(ctx context.Context, blueprintPath string) (*domain.Config, error) {
+	config, err := s.parser.Parse(blueprintPath)
+	if err != nil {
+		return nil, err
 	}
 
-	if !s.hasModel(config, config.Auth.UserCollection) {
-		config.Models = append(config.Models, domain.Model{
-			Name:      config.Auth.UserCollection,
-			Protected: true,
-			Fields:    map[string]string{},
-			Relations: map[string]string{},
-		})
+	diags := s.validate(config)
+	if domain.HasErrors(diags) {
+		return nil, &domain.ValidationError{Diagnostics: diags}
 	}
-
-	// Ensure required auth fields are present
-	for i, m := range config.Models {
-		if m.Name == config.Auth.UserCollection {
-			if m.Fields == nil {
-				config.Models[i].Fields = make(map[string]string)
-			}
-			if _, ok := m.Fields["email"]; !ok {
-				config.Models[i].Fields["email"] = "string"
-			}
-			if _, ok := m.Fields["name"]; !ok {
-				config.Models[i].Fields["name"] = "string"
-			}
-			if _, ok := m.Fields["picture"]; !ok {
-				config.Models[i].Fields["picture"] = "string"
-			}
-			if _, ok := m.Fields["role_id"]; !ok {
-				config.Models[i].Fields["role_id"] = "string"
-			}
-			if _, ok := m.Fields["created_at"]; !ok {
-				config.Models[i].Fields["created_at"] = "datetime"
-			}
-			if _, ok := m.Fields["updated_at"]; !ok {
-				config.Models[i].Fields["updated_at"] = "datetime"
-			}
-
-			if config.Auth.Provider == "firebase" {
-				if _, ok := m.Fields["uid"]; !ok {
-					config.Models[i].Fields["uid"] = "string"
-				}
-			} else {
-				if _, ok := m.Fields["password"]; !ok {
-					config.Models[i].Fields["password"] = "string"
-				}
-			}
-			break
-		}
+	for _, d := range diags {
+		log.Print(d.String())
 	}
+
+	// The modules enriched the config before validation
+	s.enrichRelations(config)
+	return config, nil
 }
 
-func (s *BlueprintService) enrichPayments(config *domain.Config) {
-	if config.Payments == nil || !config.Payments.Enabled {
+// enrichRelations gives every Postgres hasMany relation a foreign key on the target
+// table, adding the belongsTo side when the blueprint doesn't declare it
+func (s *BlueprintService) enrichRelations(config *domain.Config) {
+	if config.Database.Type != "postgresql" {
 		return
 	}
 
-	if config.Payments.TransactionsColl == "" {
-		config.Payments.TransactionsColl = "transactions"
-	}
-
-	if !s.hasModel(config, config.Payments.TransactionsColl) {
-		config.Models = append(config.Models, domain.Model{
-			Name:      config.Payments.TransactionsColl,
-			Protected: true,
-			Fields: map[string]string{
-				"amount":     "float",
-				"status":     "string",
-				"provider":   "string",
-				"payload":    "text",
-				"created_at": "datetime",
-			},
-			Relations: map[string]string{},
-		})
+	for _, m := range config.Models {
+		for _, name := range sortedKeys(m.Relations) {
+			rel := domain.ParseRelation(m.Relations[name])
+			if rel.Kind != domain.HasMany {
+				continue
+			}
+			for i := range config.Models {
+				target := &config.Models[i]
+				if target.Name != rel.Target {
+					continue
+				}
+				if _, ok := domain.InverseKey(*target, m.Name); ok {
+					break
+				}
+				if target.Relations == nil {
+					target.Relations = make(map[string]string)
+				}
+				// A name clash leaves the relation without foreign key, stored as an array column
+				key := domain.Singular(m.Name) + "_id"
+				_, isField := target.Fields[key]
+				_, isRelation := target.Relations[key]
+				if !isField && !isRelation {
+					target.Relations[key] = domain.BelongsTo + ":" + m.Name
+				}
+				break
+			}
+		}
 	}
 }
 
-func (s *BlueprintService) hasModel(config *domain.Config, name string) bool {
-	for _, model := range config.Models {
-		if model.Name == name {
-			return true
-		}
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
-	return false
+	sort.Strings(keys)
+	return keys
 }
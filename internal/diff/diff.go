@@ -0,0 +1,95 @@
+package diff
+
+import "strings"
+
+// Hunk replaces the lines Base[Start:End] with Lines
+type Hunk struct {
+	Start int
+	End   int
+	Lines []string
+}
+
+// SplitLines splits s into lines, keeping the trailing newline of each one
+func SplitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Hunks computes the changes that turn base into other, using the longest common subsequence
+func Hunks(base, other []string) []Hunk {
+	lcs := longestCommonSubsequence(base, other)
+
+	var hunks []Hunk
+	i, j := 0, 0
+	for _, m := range append(lcs, match{len(base), len(other)}) {
+		if i < m.a || j < m.b {
+			hunks = append(hunks, Hunk{Start: i, End: m.a, Lines: other[j:m.b]})
+		}
+		i, j = m.a+1, m.b+1
+	}
+	return hunks
+}
+
+// match pairs equal lines base[a] == other[b]
+type match struct {
+	a, b int
+}
+
+func longestCommonSubsequence(a, b []string) []match {
+	// Trim the common prefix and suffix first: generated files usually differ in a few places
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var matches []match
+	for k := 0; k < prefix; k++ {
+		matches = append(matches, match{k, k})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+	n, m := len(midA), len(midB)
+
+	// table[i][j] holds the LCS length of midA[i:] and midB[j:]
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case midA[i] == midB[j]:
+			matches = append(matches, match{prefix + i, prefix + j})
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	for k := suffix; k > 0; k-- {
+		matches = append(matches, match{len(a) - k, len(b) - k})
+	}
+	return matches
+}
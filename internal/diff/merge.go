@@ -0,0 +1,137 @@
+package diff
+
+import "strings"
+
+// Conflict markers written when both sides changed the same lines
+const (
+	MarkerOurs   = "<<<<<<< yours\n"
+	MarkerBase   = "||||||| previously generated\n"
+	MarkerSplit  = "=======\n"
+	MarkerTheirs = ">>>>>>> generated\n"
+)
+
+// Merge3 applies the changes between base and theirs on top of ours.
+// It returns the merged text and the number of conflicting regions, which are
+// delimited with git-style markers.
+func Merge3(base, ours, theirs string) (string, int) {
+	baseLines := SplitLines(base)
+	oursHunks := Hunks(baseLines, SplitLines(ours))
+	theirsHunks := Hunks(baseLines, SplitLines(theirs))
+
+	var out strings.Builder
+	conflicts := 0
+	pos := 0
+
+	for len(oursHunks) > 0 || len(theirsHunks) > 0 {
+		// Seed a region with the earliest pending hunk and grow it while hunks of either side overlap it
+		var regionOurs, regionTheirs []Hunk
+		var seed Hunk
+		if takeOursFirst(oursHunks, theirsHunks) {
+			seed, oursHunks = oursHunks[0], oursHunks[1:]
+			regionOurs = []Hunk{seed}
+		} else {
+			seed, theirsHunks = theirsHunks[0], theirsHunks[1:]
+			regionTheirs = []Hunk{seed}
+		}
+		start, end := seed.Start, seed.End
+		for {
+			grown := false
+			if len(oursHunks) > 0 && overlaps(start, end, oursHunks[0]) {
+				end = max(end, oursHunks[0].End)
+				regionOurs = append(regionOurs, oursHunks[0])
+				oursHunks = oursHunks[1:]
+				grown = true
+			}
+			if len(theirsHunks) > 0 && overlaps(start, end, theirsHunks[0]) {
+				end = max(end, theirsHunks[0].End)
+				regionTheirs = append(regionTheirs, theirsHunks[0])
+				theirsHunks = theirsHunks[1:]
+				grown = true
+			}
+			if !grown {
+				break
+			}
+		}
+
+		writeLines(&out, baseLines[pos:start])
+		pos = end
+
+		oursText := apply(baseLines, start, end, regionOurs)
+		theirsText := apply(baseLines, start, end, regionTheirs)
+		switch {
+		case len(regionOurs) == 0:
+			out.WriteString(theirsText)
+		case len(regionTheirs) == 0 || oursText == theirsText:
+			out.WriteString(oursText)
+		default:
+			conflicts++
+			out.WriteString(MarkerOurs)
+			writeTerminated(&out, oursText)
+			out.WriteString(MarkerBase)
+			writeTerminated(&out, strings.Join(baseLines[start:end], ""))
+			out.WriteString(MarkerSplit)
+			writeTerminated(&out, theirsText)
+			out.WriteString(MarkerTheirs)
+		}
+	}
+	writeLines(&out, baseLines[pos:])
+
+	return out.String(), conflicts
+}
+
+// takeOursFirst tells which side holds the next hunk; pure insertions go before
+// changes starting on the same line so regions are emitted in order
+func takeOursFirst(ours, theirs []Hunk) bool {
+	switch {
+	case len(theirs) == 0:
+		return true
+	case len(ours) == 0:
+		return false
+	case ours[0].Start != theirs[0].Start:
+		return ours[0].Start < theirs[0].Start
+	default:
+		return ours[0].Start == ours[0].End
+	}
+}
+
+// overlaps reports whether h touches the base lines [start, end) of a region.
+// Insertions at the edge of a change don't conflict with it: both can be applied.
+func overlaps(start, end int, h Hunk) bool {
+	switch {
+	case h.Start == h.End && start == end:
+		return h.Start == start
+	case h.Start == h.End:
+		return start < h.Start && h.Start < end
+	case start == end:
+		return h.Start < start && start < h.End
+	default:
+		return h.Start < end && start < h.End
+	}
+}
+
+// apply renders base[start:end] with the given hunks applied
+func apply(base []string, start, end int, hunks []Hunk) string {
+	var out strings.Builder
+	pos := start
+	for _, h := range hunks {
+		writeLines(&out, base[pos:h.Start])
+		writeLines(&out, h.Lines)
+		pos = h.End
+	}
+	writeLines(&out, base[pos:end])
+	return out.String()
+}
+
+func writeLines(out *strings.Builder, lines []string) {
+	for _, l := range lines {
+		out.WriteString(l)
+	}
+}
+
+// writeTerminated makes sure conflict markers always start on their own line
+func writeTerminated(out *strings.Builder, s string) {
+	out.WriteString(s)
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		out.WriteString("\n")
+	}
+}
@@ -0,0 +1,86 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Unified renders the changes from a to b in unified diff format, with context
+// unchanged lines around each change. It returns "" when both texts are equal.
+func Unified(nameA, nameB, a, b string, context int) string {
+	linesA, linesB := SplitLines(a), SplitLines(b)
+	hunks := Hunks(linesA, linesB)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", nameA, nameB)
+
+	// delta is how many lines b has gained over a before the current group
+	delta := 0
+	for len(hunks) > 0 {
+		// Group the hunks whose context would touch or overlap
+		n := 1
+		for n < len(hunks) && hunks[n].Start-hunks[n-1].End <= 2*context {
+			n++
+		}
+		group := hunks[:n]
+		hunks = hunks[n:]
+
+		startA := max(group[0].Start-context, 0)
+		endA := min(group[len(group)-1].End+context, len(linesA))
+		startB := startA + delta
+
+		var body strings.Builder
+		countA, countB := 0, 0
+		unchanged := func(lines []string) {
+			for _, l := range lines {
+				writeUnifiedLine(&body, ' ', l)
+			}
+			countA += len(lines)
+			countB += len(lines)
+		}
+
+		pos := startA
+		for _, h := range group {
+			unchanged(linesA[pos:h.Start])
+			for _, l := range linesA[h.Start:h.End] {
+				writeUnifiedLine(&body, '-', l)
+			}
+			for _, l := range h.Lines {
+				writeUnifiedLine(&body, '+', l)
+			}
+			countA += h.End - h.Start
+			countB += len(h.Lines)
+			delta += len(h.Lines) - (h.End - h.Start)
+			pos = h.End
+		}
+		unchanged(linesA[pos:endA])
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(startA, countA), hunkRange(startB, countB))
+		out.WriteString(body.String())
+	}
+	return out.String()
+}
+
+// hunkRange formats the 1-based "start,count" of a hunk header; empty ranges
+// point at the line before them, as diff(1) does
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
+
+func writeUnifiedLine(out *strings.Builder, prefix byte, line string) {
+	out.WriteByte(prefix)
+	out.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		out.WriteString("\n\\ No newline at end of file\n")
+	}
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity classifies a diagnostic reported while validating a blueprint
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Position identifies a location inside a blueprint source file
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	if p.Line == 0 {
+		return p.File
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Diagnostic describes a single problem found in a blueprint.
+// Path uses the JSON notation of the offending key, e.g. "models[0].relations.author".
+type Diagnostic struct {
+	Pos      Position
+	Path     string
+	Severity Severity
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	var b strings.Builder
+	if pos := d.Pos.String(); pos != "" {
+		b.WriteString(pos)
+		b.WriteString(": ")
+	}
+	b.WriteString(string(d.Severity))
+	b.WriteString(": ")
+	if d.Path != "" {
+		b.WriteString(d.Path)
+		b.WriteString(": ")
+	}
+	b.WriteString(d.Message)
+	return b.String()
+}
+
+// HasErrors reports whether any of the diagnostics is an error
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidationError is returned when a blueprint fails validation
+type ValidationError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *ValidationError) Error() string {
+	var lines []string
+	errors := 0
+	for _, d := range e.Diagnostics {
+		if d.Severity == SeverityError {
+			errors++
+		}
+		lines = append(lines, d.String())
+	}
+	return fmt.Sprintf("blueprint is invalid (%d error(s)):\n%s", errors, strings.Join(lines, "\n"))
+}
+
+// SourceMap records where every JSON key of a blueprint was declared
+type SourceMap struct {
+	File      string
+	positions map[string]Position
+	paths     []string
+	renamed   map[string]string // paths shown otherwise in diagnostics, by prefix
+}
+
+func NewSourceMap(file string) *SourceMap {
+	return &SourceMap{File: file, positions: make(map[string]Position), renamed: make(map[string]string)}
+}
+
+// Rename shows the paths under prefix as under shown, such as models[7] merged
+// from an included file as the models[2] it is declared as there
+func (m *SourceMap) Rename(prefix, shown string) {
+	m.renamed[prefix] = shown
+}
+
+// Name returns path as it is declared in its file
+func (m *SourceMap) Name(path string) string {
+	if m == nil {
+		return path
+	}
+	for prefix := path; prefix != ""; prefix = ParentPath(prefix) {
+		if shown, ok := m.renamed[prefix]; ok {
+			return shown + path[len(prefix):]
+		}
+	}
+	return path
+}
+
+// Add records the position of a JSON path
+func (m *SourceMap) Add(path string, pos Position) {
+	if _, ok := m.positions[path]; !ok {
+		m.paths = append(m.paths, path)
+	}
+	m.positions[path] = pos
+}
+
+// Paths returns every recorded path in declaration order
+func (m *SourceMap) Paths() []string {
+	return m.paths
+}
+
+// Lookup returns the position of path, falling back to its closest declared parent
+func (m *SourceMap) Lookup(path string) Position {
+	if m == nil {
+		return Position{}
+	}
+	for {
+		if pos, ok := m.positions[path]; ok {
+			return pos
+		}
+		if path == "" {
+			return Position{File: m.File}
+		}
+		path = ParentPath(path)
+	}
+}
+
+// ParentPath strips the last segment of a JSON path ("models[0].fields" -> "models[0]")
+func ParentPath(path string) string {
+	i := strings.LastIndexAny(path, ".[")
+	if i < 0 {
+		return ""
+	}
+	return path[:i]
+}
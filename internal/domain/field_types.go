@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FieldTypeInfo describes a field type and how every generated layer represents it
+type FieldTypeInfo struct {
+	Name    string
+	Aliases []string
+	Label   string // shown by the interactive mode; empty for types that take parameters
+	Go      string // type in the generated domain package
+	SQL     string // PostgreSQL column type
+	Sample  string // JSON value used in generated test payloads
+	Binding string // binding tag implied by the type, if any
+	Schema  string // OpenAPI "type" or "type:format"; empty accepts any JSON value
+	Null    bool   // the generated API writes null when the value is missing
+	Numeric bool   // accepts the min and max rules
+	Text    bool   // accepts the min_length, max_length, pattern, enum, email and url rules
+	Scalar  bool   // can be filtered and sorted on, and held by an array
+}
+
+// fieldTypes is the registry every generator consults. enum and array take
+// parameters, so their Go and SQL types and samples are derived by FieldType.
+// MongoDB and Firestore store the Go value under the field name.
+var fieldTypes = []FieldTypeInfo{
+	{Name: "string", Label: "String", Go: "string", SQL: "TEXT", Sample: `"test"`, Text: true, Scalar: true, Schema: "string"},
+	{Name: "text", Label: "Text", Go: "string", SQL: "TEXT", Sample: `"test"`, Text: true, Scalar: true, Schema: "string"},
+	{Name: "integer", Aliases: []string{"int"}, Label: "Integer", Go: "int", SQL: "INTEGER", Sample: "10", Numeric: true, Scalar: true, Schema: "integer"},
+	{Name: "float", Label: "Float", Go: "float64", SQL: "DOUBLE PRECISION", Sample: "99.99", Numeric: true, Scalar: true, Schema: "number:double"},
+	{Name: "decimal", Label: "Decimal", Go: "Decimal", SQL: "NUMERIC", Sample: `"19.90"`, Scalar: true, Schema: "string:decimal", Null: true},
+	{Name: "boolean", Aliases: []string{"bool"}, Label: "Boolean", Go: "bool", SQL: "BOOLEAN", Sample: "true", Scalar: true, Schema: "boolean"},
+	{Name: "datetime", Aliases: []string{"timestamp"}, Label: "DateTime", Go: "time.Time", SQL: "TIMESTAMP", Sample: `"2023-01-01T00:00:00Z"`, Scalar: true, Schema: "string:date-time"},
+	{Name: "date", Label: "Date", Go: "Date", SQL: "DATE", Sample: `"2023-01-01"`, Scalar: true, Schema: "string:date", Null: true},
+	{Name: "uuid", Label: "UUID", Go: "string", SQL: "UUID", Sample: `"6ba7b810-9dad-41d1-80b4-00c04fd430c8"`, Binding: "uuid", Scalar: true, Schema: "string:uuid"},
+	{Name: "enum", Go: "string", SQL: "TEXT", Scalar: true, Schema: "string"},
+	{Name: "json", Label: "JSON", Go: "interface{}", SQL: "JSONB", Sample: `{"key": "value"}`, Null: true},
+	{Name: "array", Schema: "array", Null: true},
+	// A pointer, so a missing location is null rather than 0,0
+	{Name: "geopoint", Label: "GeoPoint", Go: "*GeoPoint", SQL: "POINT", Sample: `{"lat": 40.4168, "lng": -3.7038}`, Schema: "object", Null: true},
+	{Name: "file", Label: "File", Go: "string", SQL: "TEXT", Sample: `"https://example.com/files/test.pdf"`, Scalar: true, Schema: "string:uri"},
+}
+
+// FieldTypes lists the registered field types
+func FieldTypes() []FieldTypeInfo {
+	return fieldTypes
+}
+
+// FieldTypeNames lists the canonical names of the registered field types
+func FieldTypeNames() []string {
+	names := make([]string, len(fieldTypes))
+	for i, t := range fieldTypes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// ArrayElemTypes lists the types an array can hold: the scalar types without parameters
+func ArrayElemTypes() []string {
+	var names []string
+	for _, t := range fieldTypes {
+		if t.Scalar && t.Name != "enum" {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// LookupFieldType finds a registered type by name or alias
+func LookupFieldType(name string) (FieldTypeInfo, bool) {
+	for _, t := range fieldTypes {
+		if t.Name == name {
+			return t, true
+		}
+		for _, alias := range t.Aliases {
+			if alias == name {
+				return t, true
+			}
+		}
+	}
+	return FieldTypeInfo{}, false
+}
+
+// NormalizeFieldType rewrites the aliases in a field type to canonical names,
+// e.g. "array<int>" to "array<integer>"
+func NormalizeFieldType(spec string) (string, error) {
+	t := ParseFieldType(spec)
+	info, ok := LookupFieldType(t.Name)
+	if !ok {
+		return spec, fmt.Errorf("unknown field type %q", spec)
+	}
+	t.Name = info.Name
+	if t.Name == "array" {
+		elem, ok := LookupFieldType(t.Elem)
+		if !ok {
+			return spec, fmt.Errorf("unknown array element type %q", t.Elem)
+		}
+		t.Elem = elem.Name
+	}
+	return t.String(), nil
+}
+
+// NormalizeFieldTypes rewrites the aliases in every field of config, returning
+// an error listing the fields whose type is unknown
+func NormalizeFieldTypes(config *Config) error {
+	var unknown []string
+	for _, model := range config.Models {
+		for name, spec := range model.Fields {
+			normalized, err := NormalizeFieldType(spec)
+			if err != nil {
+				unknown = append(unknown, fmt.Sprintf("%s.%s: %v", model.Name, name, err))
+				continue
+			}
+			model.Fields[name] = normalized
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%d field(s) have an unknown type: %v", len(unknown), unknown)
+	}
+	return nil
+}
+
+// Info returns the registry entry of the type; ok is false for unknown types
+func (t FieldType) Info() (info FieldTypeInfo, ok bool) {
+	return LookupFieldType(t.Name)
+}
+
+// GoType is the type of the field in the generated domain package
+func (t FieldType) GoType() string {
+	if t.Name == "array" {
+		return "[]" + ParseFieldType(t.Elem).GoType()
+	}
+	if info, ok := t.Info(); ok {
+		return info.Go
+	}
+	// Unknown types hold whatever the client sent
+	return "interface{}"
+}
+
+// SQLType is the PostgreSQL column type of the field
+func (t FieldType) SQLType() string {
+	if t.Name == "array" {
+		return ParseFieldType(t.Elem).SQLType() + "[]"
+	}
+	if info, ok := t.Info(); ok {
+		return info.SQL
+	}
+	return "TEXT"
+}
+
+// Sample is a JSON value of the type, for generated test payloads
+func (t FieldType) Sample() string {
+	switch t.Name {
+	case "array":
+		return "[" + ParseFieldType(t.Elem).Sample() + "]"
+	case "enum":
+		if len(t.Values) > 0 {
+			return fmt.Sprintf("%q", t.Values[0])
+		}
+	}
+	if info, ok := t.Info(); ok && info.Sample != "" {
+		return info.Sample
+	}
+	return `"unknown"`
+}
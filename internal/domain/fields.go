@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldRules is the object form of a field, declaring validation next to the type:
+//
+//	"price": {"type": "float", "required": true, "min": 0}
+type FieldRules struct {
+	Type      string      `json:"type"`
+	Required  bool        `json:"required,omitempty"`
+	Min       *float64    `json:"min,omitempty"` // numbers only
+	Max       *float64    `json:"max,omitempty"`
+	MinLength *int        `json:"min_length,omitempty"` // strings only
+	MaxLength *int        `json:"max_length,omitempty"`
+	Pattern   string      `json:"pattern,omitempty"` // Go regular expression the whole value must match
+	Enum      []string    `json:"enum,omitempty"`
+	Email     bool        `json:"email,omitempty"`
+	URL       bool        `json:"url,omitempty"`
+	Unique    bool        `json:"unique,omitempty"`
+	Default   interface{} `json:"default,omitempty"` // applied on create when the value is empty
+}
+
+// ModelError is a mistake inside a model object, located by its path within
+// the model, e.g. "fields.price.min": the offsets of encoding/json errors
+// raised while decoding a model are relative to the model, not the file
+type ModelError struct {
+	Path string
+	Err  error
+}
+
+func (e *ModelError) Error() string {
+	return e.Err.Error()
+}
+
+// UnmarshalJSON accepts each field either as a type name or as FieldRules; the
+// type always ends up in Fields and the rules, if any, in Rules
+func (m *Model) UnmarshalJSON(data []byte) error {
+	type plain Model
+	var raw struct {
+		plain
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return modelError("", err)
+	}
+	*m = Model(raw.plain)
+	if raw.Fields == nil {
+		return nil
+	}
+
+	// In name order, so the first mistake reported is always the same
+	names := make([]string, 0, len(raw.Fields))
+	for name := range raw.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	m.Fields = make(map[string]string, len(raw.Fields))
+	for _, name := range names {
+		value := raw.Fields[name]
+		var fieldType string
+		if err := json.Unmarshal(value, &fieldType); err == nil {
+			m.Fields[name] = fieldType
+			continue
+		}
+		var rules FieldRules
+		if err := json.Unmarshal(value, &rules); err != nil {
+			return modelError("fields."+name, err)
+		}
+		m.Fields[name] = rules.Type
+		if m.Rules == nil {
+			m.Rules = make(map[string]FieldRules)
+		}
+		m.Rules[name] = rules
+	}
+	return nil
+}
+
+// modelError drops the offset of an error decoding the model value at path,
+// keeping the key it was found under
+func modelError(path string, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return &ModelError{Path: path, Err: errors.New(err.Error())}
+	}
+	if typeErr.Field == "" {
+		// The value itself has the wrong type: only fields hold objects or names
+		if path != "" {
+			return &ModelError{Path: path, Err: errors.New(`expected a type name or an object with "type"`)}
+		}
+		return &ModelError{Err: fmt.Errorf("expected an object, got %s", typeErr.Value)}
+	}
+	field := strings.TrimPrefix(typeErr.Field, "plain.")
+	if path != "" {
+		field = path + "." + field
+	}
+	return &ModelError{Path: field, Err: fmt.Errorf("expected %s, got %s", typeErr.Type, typeErr.Value)}
+}
+
+// MarshalJSON writes fields with rules in object form and the others as type names
+func (m Model) MarshalJSON() ([]byte, error) {
+	type plain Model
+	out := struct {
+		plain
+		Fields map[string]interface{} `json:"fields"`
+	}{plain: plain(m)}
+
+	if m.Fields != nil {
+		out.Fields = make(map[string]interface{}, len(m.Fields))
+		for name, fieldType := range m.Fields {
+			if rules, ok := m.Rules[name]; ok {
+				rules.Type = fieldType
+				out.Fields[name] = rules
+				continue
+			}
+			out.Fields[name] = fieldType
+		}
+	}
+	// Types such as array<string> stay readable in the written blueprint
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(out); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// RuleNames lists the rules set on a field, in declaration order
+func (r FieldRules) RuleNames() []string {
+	var names []string
+	add := func(set bool, name string) {
+		if set {
+			names = append(names, name)
+		}
+	}
+	add(r.Required, "required")
+	add(r.Min != nil, "min")
+	add(r.Max != nil, "max")
+	add(r.MinLength != nil, "min_length")
+	add(r.MaxLength != nil, "max_length")
+	add(r.Pattern != "", "pattern")
+	add(len(r.Enum) > 0, "enum")
+	add(r.Email, "email")
+	add(r.URL, "url")
+	add(r.Unique, "unique")
+	add(r.Default != nil, "default")
+	return names
+}
+
+// UniqueFields lists the fields of model declared unique, sorted by name
+func (m Model) UniqueFields() []string {
+	var names []string
+	for name, rules := range m.Rules {
+		if rules.Unique {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FieldType is a parsed field type: a plain name such as "decimal", an
+// "enum(draft,active)" with its values, or an "array<string>" with its element type
+type FieldType struct {
+	Name   string
+	Elem   string   // array only
+	Values []string // enum only
+}
+
+// ParseFieldType splits a field type; malformed parameters are left for the
+// validator to report
+func ParseFieldType(spec string) FieldType {
+	spec = strings.TrimSpace(spec)
+	switch {
+	case strings.HasPrefix(spec, "enum(") && strings.HasSuffix(spec, ")"):
+		t := FieldType{Name: "enum"}
+		for _, value := range strings.Split(spec[len("enum("):len(spec)-1], ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				t.Values = append(t.Values, value)
+			}
+		}
+		return t
+	case strings.HasPrefix(spec, "array<") && strings.HasSuffix(spec, ">"):
+		return FieldType{Name: "array", Elem: strings.TrimSpace(spec[len("array<") : len(spec)-1])}
+	}
+	return FieldType{Name: spec}
+}
+
+func (t FieldType) String() string {
+	switch t.Name {
+	case "enum":
+		return "enum(" + strings.Join(t.Values, ",") + ")"
+	case "array":
+		return "array<" + t.Elem + ">"
+	}
+	return t.Name
+}
@@ -1,21 +1,60 @@
 package domain
 
+import "encoding/json"
+
 // Config represents the top-level structure of the blueprint JSON
 type Config struct {
+	// Schema points editors at the JSON Schema of the format; the generator ignores it
+	Schema string `json:"$schema,omitempty"`
+
 	ProjectName        string      `json:"project_name"`
 	Database           Database    `json:"database"`
 	FirestoreProjectID string      `json:"firestore_project_id,omitempty"` // Deprecated: use Database.ProjectID
-	Auth               *Auth       `json:"auth,omitempty"`
-	Payments           *Payments   `json:"payments,omitempty"`
+	LegacyAuth         *Auth       `json:"auth,omitempty"`                 // Deprecated: use Modules["auth"]
+	Payments           *Payments   `json:"payments,omitempty"`             // Deprecated: use Modules["payments"]
 	Pagination         *Pagination `json:"pagination,omitempty"`
 	Models             []Model     `json:"models"`
+
+	// Modules turns on generator modules by name, with their settings
+	Modules map[string]json.RawMessage `json:"modules,omitempty"`
+
+	// Include lists blueprint files, relative to this one, whose models are merged in
+	Include []string `json:"include,omitempty"`
+
+	// Auth holds the settings of the auth module, defaults applied, once it
+	// enriched the config; nil when the blueprint doesn't enable it
+	Auth *Auth `json:"-"`
+
+	// Source maps JSON paths back to the blueprint file; set by the parser
+	Source *SourceMap `json:"-"`
 }
 
 // Pagination configures the default pagination settings
 type Pagination struct {
-	DefaultLimit int `json:"default_limit"`
+	DefaultLimit int    `json:"default_limit"`
+	Mode         string `json:"mode,omitempty"` // "offset" (default) or "cursor"
 }
 
+// Pagination modes of the generated list endpoints
+const (
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
+)
+
+// CursorPagination reports whether list endpoints page with opaque cursors
+// instead of limit/page offsets
+func (c *Config) CursorPagination() bool {
+	return c.Pagination != nil && c.Pagination.Mode == PaginationCursor
+}
+
+// Accepted values of the blueprint settings
+var (
+	DatabaseTypes    = []string{"firestore", "postgresql", "mongodb"}
+	AuthProviders    = []string{"firebase", "jwt"}
+	PaymentProviders = []string{"mercadopago", "stripe"}
+	PaginationModes  = []string{PaginationOffset, PaginationCursor}
+)
+
 // Database configures the database driver
 type Database struct {
 	Type      string `json:"type"`                 // "firestore", "postgresql", "mongodb"
@@ -23,14 +62,16 @@ type Database struct {
 	URL       string `json:"url,omitempty"`        // For Postgres/Mongo
 }
 
-// Auth configures the authentication module
+// Auth configures the authentication module. Blueprints set it under the
+// auth key before modules; the parser moves it to Modules["auth"].
 type Auth struct {
 	Enabled        bool   `json:"enabled"`
 	Provider       string `json:"provider"` // "firebase" (default) or "jwt"
 	UserCollection string `json:"user_collection"`
 }
 
-// Payments configures the payment module
+// Payments configures the payments module the way blueprints did before
+// modules; the parser moves it to Modules["payments"]
 type Payments struct {
 	Enabled          bool   `json:"enabled"`
 	Provider         string `json:"provider"` // e.g., "mercadopago"
@@ -41,6 +82,9 @@ type Payments struct {
 type Model struct {
 	Name      string            `json:"name"`
 	Protected bool              `json:"protected"`
-	Fields    map[string]string `json:"fields"`
+	Fields    map[string]string `json:"fields"` // field name to type name
 	Relations map[string]string `json:"relations"`
+
+	// Rules holds the validation of fields declared in object form, see FieldRules
+	Rules map[string]FieldRules `json:"-"`
 }
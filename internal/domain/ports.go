@@ -10,6 +10,7 @@ type FileSystemPort interface {
 	CopyFile(src, dst string) error
 	Chmod(path string, mode uint32) error
 	RemoveAll(path string) error
+	Exists(path string) bool
 }
 
 // TemplatePort defines the interface for rendering templates
@@ -22,6 +23,11 @@ type ParserPort interface {
 	Parse(filename string) (*Config, error)
 }
 
+// ValidatorPort defines the interface for checking a parsed blueprint
+type ValidatorPort interface {
+	Validate(config *Config) []Diagnostic
+}
+
 // BlueprintServicePort defines the interface for the core generation logic
 type BlueprintServicePort interface {
 	Generate(ctx context.Context, config *Config, outputDir string) error
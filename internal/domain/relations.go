@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// Relation kinds accepted in a model's "relations" map
+const (
+	BelongsTo  = "belongsTo"
+	HasMany    = "hasMany"
+	HasOne     = "hasOne"
+	ManyToMany = "manyToMany"
+)
+
+// RelationKinds lists the accepted relation kinds
+var RelationKinds = []string{BelongsTo, HasMany, HasOne, ManyToMany}
+
+// OnDeleteActions maps the ON DELETE options of a belongsTo relation to SQL
+var OnDeleteActions = map[string]string{
+	"cascade":   "CASCADE",
+	"set_null":  "SET NULL",
+	"restrict":  "RESTRICT",
+	"no_action": "NO ACTION",
+}
+
+// DefaultOnDelete applies when a belongsTo relation doesn't choose an action
+const DefaultOnDelete = "set_null"
+
+// Relation is a parsed "<kind>:<target>[:<on_delete>]" relation spec
+type Relation struct {
+	Kind     string
+	Target   string
+	OnDelete string // belongsTo only, one of the OnDeleteActions keys
+}
+
+// ParseRelation splits a relation spec; missing parts are left empty
+func ParseRelation(spec string) Relation {
+	parts := strings.SplitN(spec, ":", 3)
+	r := Relation{Kind: parts[0]}
+	if len(parts) > 1 {
+		r.Target = parts[1]
+	}
+	if len(parts) > 2 {
+		r.OnDelete = parts[2]
+	}
+	return r
+}
+
+func (r Relation) String() string {
+	if r.OnDelete != "" {
+		return r.Kind + ":" + r.Target + ":" + r.OnDelete
+	}
+	return r.Kind + ":" + r.Target
+}
+
+// OnDeleteSQL returns the SQL action of a belongsTo relation, applying the default
+func (r Relation) OnDeleteSQL() string {
+	if action, ok := OnDeleteActions[r.OnDelete]; ok {
+		return action
+	}
+	return OnDeleteActions[DefaultOnDelete]
+}
+
+// JoinTable names the table backing a manyToMany relation of model
+func JoinTable(model, relation string) string {
+	return model + "_" + relation
+}
+
+// Join describes the table backing a manyToMany relation, seen from the model
+// declaring the relation
+type Join struct {
+	Table        string
+	OwnerColumn  string // holds the id of the model declaring the relation
+	TargetColumn string // holds the id of the related record
+}
+
+// ManyToManyJoin returns the join table of a manyToMany relation of model.
+// When the target declares the inverse relation, both sides share one table,
+// named and laid out from the side whose model name sorts first, so the links
+// made from either side show up on the other.
+func ManyToManyJoin(models []Model, model Model, relation string) Join {
+	owner, ownerRelation, target := model.Name, relation, ParseRelation(model.Relations[relation]).Target
+	if inverse, ok := InverseManyToMany(models, model, relation); ok && target < owner {
+		owner, ownerRelation, target = target, inverse, owner
+	}
+	ownerColumn, targetColumn := JoinColumns(owner, target)
+	if owner != model.Name {
+		ownerColumn, targetColumn = targetColumn, ownerColumn
+	}
+	return Join{Table: JoinTable(owner, ownerRelation), OwnerColumn: ownerColumn, TargetColumn: targetColumn}
+}
+
+// InverseManyToMany finds the manyToMany relation pointing back to model from
+// the target of its relation. Only a single manyToMany each way between two
+// different models pairs up; relations of a model to itself never do, since
+// either side could be the owner.
+func InverseManyToMany(models []Model, model Model, relation string) (string, bool) {
+	rel := ParseRelation(model.Relations[relation])
+	if rel.Kind != ManyToMany || rel.Target == model.Name || len(manyToManyTo(model, rel.Target)) != 1 {
+		return "", false
+	}
+	for _, m := range models {
+		if m.Name != rel.Target {
+			continue
+		}
+		if back := manyToManyTo(m, model.Name); len(back) == 1 {
+			return back[0], true
+		}
+	}
+	return "", false
+}
+
+// SharesJoinTable reports whether a manyToMany relation of model uses the
+// join table of its inverse, which the other side declares in the schema
+func SharesJoinTable(models []Model, model Model, relation string) bool {
+	return ManyToManyJoin(models, model, relation).Table != JoinTable(model.Name, relation)
+}
+
+// manyToManyTo lists the manyToMany relations of model to target, sorted
+func manyToManyTo(model Model, target string) []string {
+	var names []string
+	for name, spec := range model.Relations {
+		if rel := ParseRelation(spec); rel.Kind == ManyToMany && rel.Target == target {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// JoinColumns names the columns of a join table holding the owner and target ids
+func JoinColumns(model, target string) (owner, other string) {
+	owner = Singular(model) + "_id"
+	other = Singular(target) + "_id"
+	if owner == other {
+		other = "related_" + other
+	}
+	return owner, other
+}
+
+// InverseKey finds the belongsTo relation of target pointing back to model,
+// which holds the foreign key of a hasMany relation from model to target
+func InverseKey(target Model, model string) (string, bool) {
+	keys := make([]string, 0, len(target.Relations))
+	for k := range target.Relations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		r := ParseRelation(target.Relations[k])
+		if r.Kind == BelongsTo && r.Target == model {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// Singular is a best-effort English singular for collection names ("categories" -> "category")
+func Singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"), strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "ss"), strings.HasSuffix(name, "us"):
+		return name
+	case strings.HasSuffix(name, "s") && len(name) > 1:
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
@@ -28,6 +28,10 @@ func NewPostgresRepository(url string) (Repository, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %v", err)
 	}
+	if err := Migrate(ctx, pool); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("unable to migrate database: %v", err)
+	}
 
 	return &PostgresRepository{Pool: pool}, nil
 }
@@ -61,29 +65,110 @@ func (r *PostgresRepository) Delete(ctx context.Context, table, id string) error
 }
 `
 
-const PostgresRepoTemplate = `package db
+// PostgresMigrateTemplate applies the embedded migrations on startup. It keeps its
+// state in golang-migrate's schema_migrations layout, so the migrate CLI can roll back.
+const PostgresMigrateTemplate = `package db
 
 import (
 	"context"
+	"embed"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrate runs every up migration newer than the database version, each in its own transaction
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	if _, err := pool.Exec(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)"); err != nil {
+		return err
+	}
+
+	var current int64
+	var dirty bool
+	err := pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&current, &dirty)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("database is dirty at version %d, fix it and force the version with the migrate CLI", current)
+	}
+
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid migration file name %s", name)
+		}
+		if version <= current {
+			continue
+		}
+
+		script, err := migrationFiles.ReadFile("migrations/" + name)
+		if err != nil {
+			return err
+		}
+		if err := applyMigration(ctx, pool, version, string(script)); err != nil {
+			return fmt.Errorf("migration %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, pool *pgxpool.Pool, version int64, script string) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", version); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+`
+
+const PostgresRepoTemplate = `package db
+
+import (
+	"context"
 	"{{.ProjectName}}/internal/domain"
 	{{if .IsJWT}}
 	"time"
 	{{end}}
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
 )
 
 type {{.Model.Name | title}}Repository struct {
 	db *pgxpool.Pool
 }
 
+// New{{.Model.Name | title}}Repository expects the {{.Model.Name}} table to exist; see migrations.go
 func New{{.Model.Name | title}}Repository(repo *PostgresRepository) *{{.Model.Name | title}}Repository {
-	// Ensure the table exists
-	_, err := repo.Pool.Exec(context.Background(), "{{.CreateTableSQL}}")
-	if err != nil {
-		fmt.Printf("Error creating table {{.Model.Name}}: %v\n", err)
-	}
 	return &{{.Model.Name | title}}Repository{db: repo.Pool}
 }
 
@@ -109,8 +194,24 @@ func (r *{{.Model.Name | title}}Repository) RegisterUser(ctx context.Context, us
 }
 {{end}}
 
-func (r *{{.Model.Name | title}}Repository) List(ctx context.Context, limit, offset int) ([]*domain.{{.Model.Name | title}}, error) {
-	rows, err := r.db.Query(ctx, "SELECT {{.SelectColumns}} FROM {{.Model.Name}} LIMIT $1 OFFSET $2", limit, offset)
+{{if .Cursor}}
+// Count returns how many records match the filters of q, ignoring the page
+func (r *{{.Model.Name | title}}Repository) Count(ctx context.Context, q domain.ListQuery) (int64, error) {
+	query, args, err := countSQL("{{.Model.Name}}", q)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&total)
+	return total, err
+}
+{{end}}
+func (r *{{.Model.Name | title}}Repository) List(ctx context.Context, q domain.ListQuery) ([]*domain.{{.Model.Name | title}}, error) {
+	query, args, err := listSQL("SELECT {{.SelectColumns}} FROM {{.Model.Name}}", "{{.Model.Name}}", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -147,11 +248,35 @@ func (r *{{.Model.Name | title}}Repository) Get(ctx context.Context, id string)
 	return &m, nil
 }
 
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *{{.Model.Name | title}}Repository) GetMany(ctx context.Context, ids []string) ([]*domain.{{.Model.Name | title}}, error) {
+	rows, err := r.db.Query(ctx, "SELECT {{.SelectColumns}} FROM {{.Model.Name}} WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.{{.Model.Name | title}}
+	for rows.Next() {
+		var m domain.{{.Model.Name | title}}
+		fields := []interface{}{&m.ID}
+		{{range $f := .Fields}}
+		fields = append(fields, &m.{{$f | pascal}})
+		{{end}}
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
 func (r *{{.Model.Name | title}}Repository) Create(ctx context.Context, m *domain.{{.Model.Name | title}}) (string, error) {
-	query := "INSERT INTO {{.Model.Name}} ({{.InsertColumns}}) VALUES ({{.InsertPlaceholders}}) RETURNING id"
+	query := "INSERT INTO {{.Model.Name}} {{if .Columns}}({{.InsertColumns}}) VALUES ({{.InsertPlaceholders}}){{else}}DEFAULT VALUES{{end}} RETURNING id"
 	
 	values := []interface{}{
-		{{range $f := .Fields}}m.{{$f | pascal}},
+		{{range $f := .Columns}}m.{{$f | pascal}},
 		{{end}}
 	}
 
@@ -164,22 +289,40 @@ func (r *{{.Model.Name | title}}Repository) Create(ctx context.Context, m *domai
 }
 
 func (r *{{.Model.Name | title}}Repository) Update(ctx context.Context, id string, m *domain.{{.Model.Name | title}}) error {
+	{{if .Columns}}
 	query := "UPDATE {{.Model.Name}} SET {{.UpdateSet}} WHERE id = ${{add .TotalFields 1}}"
 	
 	values := []interface{}{
-		{{range $f := .Fields}}m.{{$f | pascal}},
+		{{range $f := .Columns}}m.{{$f | pascal}},
 		{{end}}
 		id,
 	}
 
 	_, err := r.db.Exec(ctx, query, values...)
 	return err
+	{{else}}
+	// Every relation of {{.Model.Name}} lives in other tables: nothing to update here
+	return nil
+	{{end}}
+}
+{{range .Links}}
+func (r *{{$.Model.Name | title}}Repository) Link{{.Relation | pascal}}(ctx context.Context, id, targetID string) error {
+	_, err := r.db.Exec(ctx, "INSERT INTO {{.JoinTable}} ({{.OwnerColumn}}, {{.TargetColumn}}) VALUES ($1, $2) ON CONFLICT DO NOTHING", id, targetID)
+	return err
 }
 
+func (r *{{$.Model.Name | title}}Repository) Unlink{{.Relation | pascal}}(ctx context.Context, id, targetID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM {{.JoinTable}} WHERE {{.OwnerColumn}} = $1 AND {{.TargetColumn}} = $2", id, targetID)
+	return err
+}
+{{end}}
 func (r *{{.Model.Name | title}}Repository) Delete(ctx context.Context, id string) error {
 	_, err := r.db.Exec(ctx, "DELETE FROM {{.Model.Name}} WHERE id = $1", id)
 	return err
 }
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
 `
 
 const MongoBaseTemplate = `package db
@@ -278,6 +421,8 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
 )
 
 type {{.Model.Name | title}}Repository struct {
@@ -318,9 +463,16 @@ func (r *{{.Model.Name | title}}Repository) RegisterUser(ctx context.Context, us
 }
 {{end}}
 
-func (r *{{.Model.Name | title}}Repository) List(ctx context.Context, limit, offset int) ([]*domain.{{.Model.Name | title}}, error) {
-	opts := options.Find().SetLimit(int64(limit)).SetSkip(int64(offset))
-	cursor, err := r.repo.DB.Collection("{{.Model.Name}}").Find(ctx, bson.M{}, opts)
+{{if .Cursor}}
+// Count returns how many documents match the filters of q, ignoring the page
+func (r *{{.Model.Name | title}}Repository) Count(ctx context.Context, q domain.ListQuery) (int64, error) {
+	q.After = nil
+	return r.repo.DB.Collection("{{.Model.Name}}").CountDocuments(ctx, mongoFilter(q))
+}
+{{end}}
+func (r *{{.Model.Name | title}}Repository) List(ctx context.Context, q domain.ListQuery) ([]*domain.{{.Model.Name | title}}, error) {
+	opts := options.Find().SetLimit(int64(q.Limit)).SetSkip(int64(q.Offset)).SetSort(mongoSort(q))
+	cursor, err := r.repo.DB.Collection("{{.Model.Name}}").Find(ctx, mongoFilter(q), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -341,6 +493,25 @@ func (r *{{.Model.Name | title}}Repository) Get(ctx context.Context, id string)
 	return &m, nil
 }
 
+// GetMany loads the documents with the given ids in a single query, in no particular order
+func (r *{{.Model.Name | title}}Repository) GetMany(ctx context.Context, ids []string) ([]*domain.{{.Model.Name | title}}, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	cursor, err := r.repo.DB.Collection("{{.Model.Name}}").Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.{{.Model.Name | title}}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 func (r *{{.Model.Name | title}}Repository) Create(ctx context.Context, m *domain.{{.Model.Name | title}}) (string, error) {
 	res, err := r.repo.DB.Collection("{{.Model.Name}}").InsertOne(ctx, m)
 	if err != nil {
@@ -360,4 +531,342 @@ func (r *{{.Model.Name | title}}Repository) Delete(ctx context.Context, id strin
 	_, err := r.repo.DB.Collection("{{.Model.Name}}").DeleteOne(ctx, bson.M{"_id": objID})
 	return err
 }
+{{range .Links}}
+func (r *{{$.Model.Name | title}}Repository) Link{{.Relation | pascal}}(ctx context.Context, id, targetID string) error {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.repo.DB.Collection("{{$.Model.Name}}").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$addToSet": bson.M{"{{.Relation}}": targetID}})
+	return err
+}
+
+func (r *{{$.Model.Name | title}}Repository) Unlink{{.Relation | pascal}}(ctx context.Context, id, targetID string) error {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.repo.DB.Collection("{{$.Model.Name}}").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$pull": bson.M{"{{.Relation}}": targetID}})
+	return err
+}
+{{end}}
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
+`
+
+// PostgresQueryTemplate translates a domain.ListQuery into SQL. Field names
+// are safe to interpolate: the query parser only accepts the model's fields.
+const PostgresQueryTemplate = `package db
+
+import (
+	"fmt"
+	"strings"
+
+	"{{.ProjectName}}/internal/domain"
+)
+
+var sqlOperators = map[string]string{
+	domain.OpEq:  "=",
+	domain.OpNe:  "IS DISTINCT FROM",
+	domain.OpGt:  ">",
+	domain.OpGte: ">=",
+	domain.OpLt:  "<",
+	domain.OpLte: "<=",
+}
+
+// listSQL appends the filters, sort and paging of q to a SELECT on table
+func listSQL(selectSQL, table string, q domain.ListQuery) (string, []interface{}, error) {
+	var args []interface{}
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where, err := filterSQL(table, q.Filters, placeholder)
+	if err != nil {
+		return "", nil, err
+	}
+	{{- if .CursorPagination}}
+	if len(q.After) > 0 {
+		where = append(where, afterSQL(table, q, placeholder))
+	}
+	{{- end}}
+
+	query := selectSQL
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	if len(q.Sort) > 0 {
+		var order []string
+		for _, s := range q.Sort {
+			direction := "ASC"
+			if s.Desc {
+				direction = "DESC"
+			}
+			order = append(order, table+"."+s.Field+" "+direction)
+		}
+		query += " ORDER BY " + strings.Join(order, ", ")
+	}
+	query += " LIMIT " + placeholder(q.Limit) + " OFFSET " + placeholder(q.Offset)
+	return query, args, nil
+}
+
+func filterSQL(table string, filters []domain.Filter, placeholder func(interface{}) string) ([]string, error) {
+	var where []string
+	for _, f := range filters {
+		column := table + "." + f.Field
+		if f.Op == domain.OpIn {
+			values, ok := f.Value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%w: %s[in] needs a list of values, got %T", domain.ErrInvalidQuery, f.Field, f.Value)
+			}
+			var placeholders []string
+			for _, value := range values {
+				placeholders = append(placeholders, placeholder(value))
+			}
+			if len(placeholders) == 0 {
+				where = append(where, "FALSE")
+				continue
+			}
+			where = append(where, column+" IN ("+strings.Join(placeholders, ", ")+")")
+			continue
+		}
+		where = append(where, column+" "+sqlOperators[f.Op]+" "+placeholder(f.Value))
+	}
+	return where, nil
+}
+{{- if .CursorPagination}}
+
+// countSQL counts the rows of table matching the filters of q, whatever the page
+func countSQL(table string, q domain.ListQuery) (string, []interface{}, error) {
+	var args []interface{}
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where, err := filterSQL(table, q.Filters, placeholder)
+	if err != nil {
+		return "", nil, err
+	}
+	query := "SELECT COUNT(*) FROM " + table
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	return query, args, nil
+}
+
+// afterSQL matches the rows sorted after q.After: (a > $1) OR (a = $1 AND b > $2) ...
+func afterSQL(table string, q domain.ListQuery, placeholder func(interface{}) string) string {
+	var alternatives []string
+	for i, s := range q.Sort {
+		var conditions []string
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, table+"."+q.Sort[j].Field+" = "+placeholder(q.After[j]))
+		}
+		operator := ">"
+		if s.Desc {
+			operator = "<"
+		}
+		conditions = append(conditions, table+"."+s.Field+" "+operator+" "+placeholder(q.After[i]))
+		alternatives = append(alternatives, "("+strings.Join(conditions, " AND ")+")")
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
+{{- end}}
+`
+
+// MongoQueryTemplate translates a domain.ListQuery into a MongoDB filter and sort
+const MongoQueryTemplate = `package db
+
+import (
+	"{{.ProjectName}}/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var mongoOperators = map[string]string{
+	domain.OpEq:  "$eq",
+	domain.OpNe:  "$ne",
+	domain.OpGt:  "$gt",
+	domain.OpGte: "$gte",
+	domain.OpLt:  "$lt",
+	domain.OpLte: "$lte",
+	domain.OpIn:  "$in",
+}
+
+// mongoFilter combines the filters of q; several on one field must all match
+func mongoFilter(q domain.ListQuery) bson.M {
+	filter := bson.M{}
+	for _, f := range q.Filters {
+		field, value := f.Field, f.Value
+		if field == "id" {
+			field, value = "_id", objectIDs(value)
+		}
+		conditions, ok := filter[field].(bson.M)
+		if !ok {
+			conditions = bson.M{}
+			filter[field] = conditions
+		}
+		conditions[mongoOperators[f.Op]] = value
+	}
+	{{- if .CursorPagination}}
+	if len(q.After) > 0 {
+		filter = bson.M{"$and": []bson.M{filter, mongoAfter(q)}}
+	}
+	{{- end}}
+	return filter
+}
+
+{{- if .CursorPagination}}
+
+// mongoAfter matches the documents sorted after q.After, like a keyset WHERE clause
+func mongoAfter(q domain.ListQuery) bson.M {
+	var alternatives []bson.M
+	for i, s := range q.Sort {
+		condition := bson.M{}
+		for j := 0; j < i; j++ {
+			field, value := mongoField(q.Sort[j].Field, q.After[j])
+			condition[field] = value
+		}
+		operator := "$gt"
+		if s.Desc {
+			operator = "$lt"
+		}
+		field, value := mongoField(s.Field, q.After[i])
+		condition[field] = bson.M{operator: value}
+		alternatives = append(alternatives, condition)
+	}
+	return bson.M{"$or": alternatives}
+}
+
+func mongoField(field string, value interface{}) (string, interface{}) {
+	if field == "id" {
+		return "_id", objectIDs(value)
+	}
+	return field, value
+}
+{{- end}}
+
+func mongoSort(q domain.ListQuery) bson.D {
+	sort := bson.D{}
+	for _, s := range q.Sort {
+		field := s.Field
+		if field == "id" {
+			field = "_id"
+		}
+		direction := 1
+		if s.Desc {
+			direction = -1
+		}
+		sort = append(sort, bson.E{Key: field, Value: direction})
+	}
+	return sort
+}
+
+// objectIDs converts an id, or a list of ids, to the ObjectIDs stored in _id
+func objectIDs(value interface{}) interface{} {
+	if list, ok := value.([]interface{}); ok {
+		ids := make([]interface{}, len(list))
+		for i, item := range list {
+			ids[i] = objectIDs(item)
+		}
+		return ids
+	}
+	if id, ok := value.(string); ok {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			return objID
+		}
+	}
+	return value
+}
+`
+
+// FirestoreQueryTemplate translates a domain.ListQuery into a Firestore query.
+// Combining range filters or sorts on several fields needs a composite index.
+const FirestoreQueryTemplate = `package db
+
+import (
+	{{- if .CursorPagination}}
+	"context"
+	"fmt"
+	{{- end}}
+
+	"{{.ProjectName}}/internal/domain"
+
+	"cloud.google.com/go/firestore"
+	{{- if .CursorPagination}}
+	"cloud.google.com/go/firestore/apiv1/firestorepb"
+	{{- end}}
+)
+
+var firestoreOperators = map[string]string{
+	domain.OpEq:  "==",
+	domain.OpNe:  "!=",
+	domain.OpGt:  ">",
+	domain.OpGte: ">=",
+	domain.OpLt:  "<",
+	domain.OpLte: "<=",
+	domain.OpIn:  "in",
+}
+
+// listQuery applies the filters, sort and paging of q to a collection
+func listQuery(collection *firestore.CollectionRef, q domain.ListQuery) firestore.Query {
+	query := filterQuery(collection, q)
+	for _, s := range q.Sort {
+		path := s.Field
+		if path == "id" {
+			path = firestore.DocumentID
+		}
+		direction := firestore.Asc
+		if s.Desc {
+			direction = firestore.Desc
+		}
+		query = query.OrderBy(path, direction)
+	}
+	{{- if .CursorPagination}}
+	if len(q.After) > 0 {
+		query = query.StartAfter(q.After...)
+	}
+	{{- end}}
+	return query.Offset(q.Offset).Limit(q.Limit)
+}
+
+func filterQuery(collection *firestore.CollectionRef, q domain.ListQuery) firestore.Query {
+	query := collection.Query
+	for _, f := range q.Filters {
+		path, value := f.Field, f.Value
+		if path == "id" {
+			path, value = firestore.DocumentID, documentRefs(collection, value)
+		}
+		query = query.Where(path, firestoreOperators[f.Op], value)
+	}
+	return query
+}
+{{- if .CursorPagination}}
+
+// countQuery counts the documents matching the filters of q with an aggregation query
+func countQuery(ctx context.Context, collection *firestore.CollectionRef, q domain.ListQuery) (int64, error) {
+	query := filterQuery(collection, q)
+	result, err := query.NewAggregationQuery().WithCount("total").Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	total, ok := result["total"].(*firestorepb.Value)
+	if !ok {
+		return 0, fmt.Errorf("unexpected count result %v", result["total"])
+	}
+	return total.GetIntegerValue(), nil
+}
+{{- end}}
+
+// documentRefs converts an id, or a list of ids, to the references DocumentID compares against
+func documentRefs(collection *firestore.CollectionRef, value interface{}) interface{} {
+	if list, ok := value.([]interface{}); ok {
+		refs := make([]interface{}, len(list))
+		for i, item := range list {
+			refs[i] = documentRefs(collection, item)
+		}
+		return refs
+	}
+	if id, ok := value.(string); ok {
+		return collection.Doc(id)
+	}
+	return value
+}
 `
@@ -0,0 +1,37 @@
+package generator
+
+import (
+	"path/filepath"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/openapi"
+)
+
+// DocsTemplate generates docs/docs.go, which embeds the OpenAPI document so
+// the binary serves it without reading files at runtime
+const DocsTemplate = `package docs
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 document of the API, generated from the blueprint
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
+`
+
+// generateDocs writes docs/openapi.yaml, built from the blueprint alone, and
+// the package embedding it
+func generateDocs(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	if err := fs.MkdirAll(filepath.Join(projectPath, "docs")); err != nil {
+		return err
+	}
+	if err := fs.WriteFile(filepath.Join(projectPath, "docs", "openapi.yaml"), openapi.Generate(config)); err != nil {
+		return err
+	}
+
+	content, err := render(template, "docs", config)
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(filepath.Join(projectPath, "docs", "docs.go"), content)
+}
@@ -0,0 +1,259 @@
+package generator
+
+// ExpandTemplate generates internal/expand, which embeds related documents in
+// responses for ?include=. Each level of the include tree costs one batched
+// GetMany call per relation, however many documents are being expanded.
+const ExpandTemplate = `package expand
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// MaxDepth bounds nested includes: ?include=author.posts has depth 2
+const MaxDepth = {{.MaxDepth}}
+
+// Fetcher loads the documents of one model with the given ids in a single call
+type Fetcher func(ctx context.Context, ids []string) (interface{}, error)
+
+// Fetch adapts a repository GetMany method to a Fetcher
+func Fetch[T any](getMany func(ctx context.Context, ids []string) ([]*T, error)) Fetcher {
+	return func(ctx context.Context, ids []string) (interface{}, error) {
+		return getMany(ctx, ids)
+	}
+}
+
+type relation struct {
+	field  string // JSON field holding the related id(s)
+	target string
+	many   bool
+}
+
+// relations lists what each model can include, by include name
+var relations = map[string]map[string]relation{
+{{- range .Models}}
+	"{{.Name}}": {
+	{{- range .Relations}}
+		"{{.Include}}": {field: "{{.Field}}", target: "{{.Target}}", many: {{.Many}}},
+	{{- end}}
+	},
+{{- end}}
+}
+
+// protected models can only be included from endpoints that require authentication
+var protected = map[string]bool{
+{{- range .Models}}{{if .Protected}}
+	"{{.Name}}": true,
+{{- end}}{{end}}
+}
+
+// Error reports an invalid include parameter
+type Error struct {
+	msg string
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Status maps an expansion error to the HTTP status to answer with
+func Status(err error) int {
+	if _, ok := err.(*Error); ok {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// Expander embeds related documents into API responses
+type Expander struct {
+	fetchers map[string]Fetcher
+}
+
+func New() *Expander {
+	return &Expander{fetchers: make(map[string]Fetcher)}
+}
+
+// Register sets how the documents of model are loaded
+func (e *Expander) Register(model string, fetch Fetcher) {
+	e.fetchers[model] = fetch
+}
+
+// tree is a parsed include parameter: "author,comments.author" is {author: {}, comments: {author: {}}}
+type tree map[string]tree
+
+// parse validates include against the relations reachable from model
+func parse(model, include string, authenticated bool) (tree, error) {
+	root := tree{}
+	for _, path := range strings.Split(include, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		names := strings.Split(path, ".")
+		if len(names) > MaxDepth {
+			return nil, &Error{fmt.Sprintf("include %q is nested deeper than %d levels", path, MaxDepth)}
+		}
+
+		node, current := root, model
+		for _, name := range names {
+			rel, ok := relations[current][name]
+			if !ok {
+				return nil, &Error{fmt.Sprintf("%s has no relation %q (available: %s)", current, name, available(current))}
+			}
+			if protected[rel.target] && !authenticated {
+				return nil, &Error{fmt.Sprintf("%s cannot be included from a public endpoint", rel.target)}
+			}
+			if node[name] == nil {
+				node[name] = tree{}
+			}
+			node, current = node[name], rel.target
+		}
+	}
+	return root, nil
+}
+
+// Roots returns the relations included at the top level of include
+func Roots(include string) []string {
+	var roots []string
+	for _, path := range strings.Split(include, ",") {
+		if root := strings.TrimSpace(strings.Split(path, ".")[0]); root != "" {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
+func available(model string) string {
+	var names []string
+	for name := range relations[model] {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// One expands a single document of model
+func (e *Expander) One(ctx context.Context, model string, doc interface{}, include string, authenticated bool) (map[string]interface{}, error) {
+	docs, err := e.Many(ctx, model, []interface{}{doc}, include, authenticated)
+	if err != nil {
+		return nil, err
+	}
+	return docs[0], nil
+}
+
+// Many expands a list of documents of model; docs may be any slice
+func (e *Expander) Many(ctx context.Context, model string, docs interface{}, include string, authenticated bool) ([]map[string]interface{}, error) {
+	includes, err := parse(model, include, authenticated)
+	if err != nil {
+		return nil, err
+	}
+	maps, err := toMaps(docs)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.expand(ctx, model, maps, includes); err != nil {
+		return nil, err
+	}
+	return maps, nil
+}
+
+// expand loads each relation of the tree once for all docs, then recurses into the loaded documents
+func (e *Expander) expand(ctx context.Context, model string, docs []map[string]interface{}, includes tree) error {
+	for name, nested := range includes {
+		rel := relations[model][name]
+		fetch, ok := e.fetchers[rel.target]
+		if !ok {
+			return fmt.Errorf("no fetcher registered for %s", rel.target)
+		}
+
+		var ids []string
+		seen := make(map[string]bool)
+		for _, doc := range docs {
+			for _, id := range idsOf(doc[rel.field]) {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+		}
+
+		byID := make(map[string]map[string]interface{})
+		if len(ids) > 0 {
+			loaded, err := fetch(ctx, ids)
+			if err != nil {
+				return err
+			}
+			related, err := toMaps(loaded)
+			if err != nil {
+				return err
+			}
+			if err := e.expand(ctx, rel.target, related, nested); err != nil {
+				return err
+			}
+			for _, r := range related {
+				if id, ok := r["id"].(string); ok {
+					byID[id] = r
+				}
+			}
+		}
+
+		for _, doc := range docs {
+			if !rel.many {
+				var value interface{}
+				if ids := idsOf(doc[rel.field]); len(ids) > 0 && byID[ids[0]] != nil {
+					value = byID[ids[0]]
+				}
+				doc[name] = value
+				continue
+			}
+			items := []map[string]interface{}{}
+			for _, id := range idsOf(doc[rel.field]) {
+				if item, ok := byID[id]; ok {
+					items = append(items, item)
+				}
+			}
+			doc[name] = items
+		}
+	}
+	return nil
+}
+
+// idsOf reads a relation field decoded from JSON: an id or a list of ids
+func idsOf(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []interface{}:
+		var ids []string
+		for _, item := range v {
+			if id, ok := item.(string); ok && id != "" {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	return nil
+}
+
+// toMaps converts documents to their JSON representation so related ones can be embedded
+func toMaps(docs interface{}) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(docs)
+	if err != nil {
+		return nil, err
+	}
+	var maps []map[string]interface{}
+	if err := json.Unmarshal(data, &maps); err != nil {
+		return nil, err
+	}
+	return maps, nil
+}
+`
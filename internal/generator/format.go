@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/gosrc"
+)
+
+// sourceFormatter sits between the generator and its filesystem and template
+// engine: every Go file is written without its unused imports, with the
+// imports grouped and formatted like gofmt, and a file that isn't valid Go
+// fails the generation naming the template that rendered it
+type sourceFormatter struct {
+	domain.FileSystemPort
+	template domain.TemplatePort
+	module   string
+	rendered map[string]string // template name by output
+}
+
+func newSourceFormatter(fs domain.FileSystemPort, template domain.TemplatePort, module string) *sourceFormatter {
+	return &sourceFormatter{FileSystemPort: fs, template: template, module: module, rendered: make(map[string]string)}
+}
+
+func (f *sourceFormatter) Render(name, tmpl string, data interface{}) ([]byte, error) {
+	content, err := f.template.Render(name, tmpl, data)
+	if err == nil {
+		f.rendered[string(content)] = name
+	}
+	return content, err
+}
+
+func (f *sourceFormatter) WriteFile(path string, data []byte) error {
+	if filepath.Ext(path) != ".go" {
+		return f.FileSystemPort.WriteFile(path, data)
+	}
+	formatted, err := gosrc.Format(path, data, f.module)
+	if err != nil {
+		if name, ok := f.rendered[string(data)]; ok {
+			return fmt.Errorf("template %s rendered invalid Go: %w", name, err)
+		}
+		return fmt.Errorf("generated invalid Go: %w", err)
+	}
+	return f.FileSystemPort.WriteFile(path, formatted)
+}
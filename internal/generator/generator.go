@@ -3,17 +3,34 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"log"
 	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/migration"
+	"github.com/eduardo/blueprint/internal/module"
+	"github.com/eduardo/blueprint/internal/regen"
 )
 
 // Generate creates the API project based on the config
 func Generate(config *domain.Config, outputDir string, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	// Every template below looks types up in the registry by canonical name
+	if err := domain.NormalizeFieldTypes(config); err != nil {
+		return err
+	}
+
+	formatter := newSourceFormatter(fs, template, config.ProjectName)
+	fs, template = formatter, formatter
+
+	contributions, err := module.Contributions(config)
+	if err != nil {
+		return err
+	}
+
 	projectPath := filepath.Join(outputDir, config.ProjectName)
-	fmt.Printf("Creating project at %s\n", projectPath)
+	log.Printf("Creating project at %s", projectPath)
 
 	if err := fs.MkdirAll(projectPath); err != nil {
 		return fmt.Errorf("failed to create project directory: %w", err)
@@ -25,7 +42,7 @@ func Generate(config *domain.Config, outputDir string, fs domain.FileSystemPort,
 		return err
 	}
 
-	if err := generateGoMod(projectPath, config, fs); err != nil {
+	if err := generateGoMod(projectPath, config, contributions, fs); err != nil {
 		return err
 	}
 
@@ -33,11 +50,15 @@ func Generate(config *domain.Config, outputDir string, fs domain.FileSystemPort,
 		return err
 	}
 
-	if err := generateAuth(projectPath, config, fs, template); err != nil {
+	if err := generateListQuery(projectPath, config, fs, template); err != nil {
+		return err
+	}
+
+	if err := generateValidation(projectPath, config, fs, template); err != nil {
 		return err
 	}
 
-	if err := generatePayments(projectPath, config, fs, template); err != nil {
+	if err := generateModules(projectPath, config, contributions, fs, template); err != nil {
 		return err
 	}
 
@@ -56,11 +77,15 @@ func Generate(config *domain.Config, outputDir string, fs domain.FileSystemPort,
 		}
 	}
 
+	if err := generateExpand(projectPath, config, fs, template); err != nil {
+		return err
+	}
+
 	if err := copyFirebaseCredentials(projectPath, fs); err != nil {
-		fmt.Printf("Warning: firebaseCredentials.json not found or could not be copied: %v\n", err)
+		log.Printf("Warning: firebaseCredentials.json not found or could not be copied: %v", err)
 	}
 
-	if err := generateMain(projectPath, config, fs, template); err != nil {
+	if err := generateMain(projectPath, config, contributions, fs, template); err != nil {
 		return err
 	}
 
@@ -72,7 +97,7 @@ func Generate(config *domain.Config, outputDir string, fs domain.FileSystemPort,
 		return err
 	}
 
-	if err := generateDocsPlaceholder(projectPath, config, fs); err != nil {
+	if err := generateDocs(projectPath, config, fs, template); err != nil {
 		return err
 	}
 
@@ -80,7 +105,7 @@ func Generate(config *domain.Config, outputDir string, fs domain.FileSystemPort,
 		return err
 	}
 
-	if err := generateEnvFile(projectPath, config, fs); err != nil {
+	if err := generateEnvFile(projectPath, config, contributions, fs); err != nil {
 		return err
 	}
 
@@ -88,15 +113,14 @@ func Generate(config *domain.Config, outputDir string, fs domain.FileSystemPort,
 		return err
 	}
 
-	if err := generateDockerCompose(projectPath, config, fs, template); err != nil {
+	if err := generateDockerCompose(projectPath, config, contributions, fs, template); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func generateDockerCompose(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	const dockerComposeTemplate = `version: '3.8'
+const dockerComposeTemplate = `version: '3.8'
 
 services:
   api:
@@ -113,17 +137,9 @@ services:
       - FIRESTORE_PROJECT_ID={{.Database.ProjectID}}
       - GOOGLE_APPLICATION_CREDENTIALS=/app/firebaseCredentials.json
       {{end}}
-      {{if and .Auth .Auth.Enabled}}
-      - MOCK_AUTH=false
-      {{end}}
-      {{if and .Payments .Payments.Enabled}}
-      {{if eq .Payments.Provider "mercadopago"}}
-      - MP_ACCESS_TOKEN=your_token_here
-      {{else if eq .Payments.Provider "stripe"}}
-      - STRIPE_SECRET_KEY=your_stripe_secret_key
-      - STRIPE_WEBHOOK_SECRET=your_stripe_webhook_secret
-      {{end}}
-      {{end}}
+      {{range .Contributions}}{{range .Env}}
+      - {{.Name}}={{.Value}}
+      {{end}}{{end}}
     depends_on:
       {{if eq .Database.Type "postgresql"}}
       - postgres
@@ -147,15 +163,20 @@ services:
       - "27017:27017"
   {{end}}
 `
-	content, err := template.Render("docker-compose", dockerComposeTemplate, config)
+
+func generateDockerCompose(projectPath string, config *domain.Config, contributions []*module.Contribution, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	data := struct {
+		*domain.Config
+		Contributions []*module.Contribution
+	}{config, contributions}
+	content, err := render(template, "docker-compose", data)
 	if err != nil {
 		return err
 	}
 	return fs.WriteFile(filepath.Join(projectPath, "docker-compose.yml"), content)
 }
 
-func generateDockerfile(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	const dockerfileTemplate = `# Build stage
+const dockerfileTemplate = `# Build stage
 FROM golang:1.23-alpine AS builder
 WORKDIR /app
 COPY go.mod go.sum ./
@@ -177,20 +198,21 @@ COPY --from=builder /app/firebaseCredentials.json .
 EXPOSE 8080
 CMD ["./main"]
 `
-	content, err := template.Render("dockerfile", dockerfileTemplate, config)
+
+func generateDockerfile(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	content, err := render(template, "dockerfile", config)
 	if err != nil {
 		return err
 	}
 	return fs.WriteFile(filepath.Join(projectPath, "Dockerfile"), content)
 }
 
-func generateMakefile(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	const makefileTemplate = `PROJECT_ID ?= {{.Database.ProjectID}}
+const makefileTemplate = `PROJECT_ID ?= {{.Database.ProjectID}}
 REGION ?= us-central1
 SERVICE_NAME ?= {{.ProjectName}}
 IMAGE_NAME ?= gcr.io/$(PROJECT_ID)/$(SERVICE_NAME)
 
-.PHONY: run build test docker-build docker-push deploy
+.PHONY: run build test docker-build docker-push deploy{{if eq .Database.Type "postgresql"}} migrate-up migrate-down{{end}}
 
 run:
 	go run cmd/api/main.go
@@ -200,6 +222,16 @@ build:
 
 test:
 	go test ./...
+{{if eq .Database.Type "postgresql"}}
+# Migrations also run on startup; these targets need the golang-migrate CLI
+MIGRATIONS ?= internal/infrastructure/db/migrations
+
+migrate-up:
+	migrate -path $(MIGRATIONS) -database "$(DATABASE_URL)" up
+
+migrate-down:
+	migrate -path $(MIGRATIONS) -database "$(DATABASE_URL)" down 1
+{{end}}
 
 docker-build:
 	docker build -t $(IMAGE_NAME) .
@@ -215,12 +247,14 @@ deploy:
 		--allow-unauthenticated \
 		--project $(PROJECT_ID)
 `
+
+func generateMakefile(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
 	// Fallback if ProjectID is empty (e.g. for Postgres/Mongo if not specified)
 	if config.Database.ProjectID == "" {
 		config.Database.ProjectID = "your-project-id"
 	}
 
-	content, err := template.Render("makefile", makefileTemplate, config)
+	content, err := render(template, "makefile", config)
 	if err != nil {
 		return err
 	}
@@ -232,10 +266,9 @@ func createDirectories(projectPath string, config *domain.Config, fs domain.File
 		"cmd/api",
 		"internal/domain",
 		"internal/infrastructure/db",
-		"internal/auth",
-		"internal/handlers/auth",
-		"internal/payments",
-		"internal/config",
+		"internal/expand",
+		"internal/query",
+		"internal/validation",
 	}
 	for _, model := range config.Models {
 		dirs = append(dirs, filepath.Join("internal/handlers", strings.ToLower(model.Name)))
@@ -248,27 +281,6 @@ func createDirectories(projectPath string, config *domain.Config, fs domain.File
 	return nil
 }
 
-func generateAuth(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	if config.Auth == nil || !config.Auth.Enabled {
-		return nil
-	}
-	return generateAuthFiles(projectPath, config, fs, template)
-}
-
-func generatePayments(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	// Generate config if Auth or Payments enabled
-	if (config.Auth != nil && config.Auth.Enabled) || (config.Payments != nil && config.Payments.Enabled) {
-		if err := generateConfigFiles(projectPath, config, fs, template); err != nil {
-			return err
-		}
-	}
-
-	if config.Payments == nil || !config.Payments.Enabled {
-		return nil
-	}
-	return generatePaymentFiles(projectPath, config, fs, template)
-}
-
 func copyFirebaseCredentials(projectPath string, fs domain.FileSystemPort) error {
 	return fs.CopyFile("firebaseCredentials.json", filepath.Join(projectPath, "firebaseCredentials.json"))
 }
@@ -280,10 +292,7 @@ func generateScripts(projectPath string, config *domain.Config, fs domain.FileSy
 	if err := generateSetupScript(projectPath, config, fs); err != nil {
 		return err
 	}
-	if err := generateRunScript(projectPath, config, fs); err != nil {
-		return err
-	}
-	return generateDocsScript(projectPath, fs)
+	return generateRunScript(projectPath, config, fs)
 }
 
 func generateRunScript(projectPath string, config *domain.Config, fs domain.FileSystemPort) error {
@@ -298,27 +307,15 @@ func generateRunScript(projectPath string, config *domain.Config, fs domain.File
 	return fs.Chmod(filepath.Join(projectPath, "run.sh"), 0755)
 }
 
-func generateGoMod(projectPath string, config *domain.Config, fs domain.FileSystemPort) error {
+func generateGoMod(projectPath string, config *domain.Config, contributions []*module.Contribution, fs domain.FileSystemPort) error {
 	var deps []string
 	deps = append(deps, "github.com/gin-gonic/gin v1.9.1")
+	deps = append(deps, "github.com/go-playground/validator/v10 v10.14.0")
 	deps = append(deps, "github.com/swaggo/files v1.0.1")
 	deps = append(deps, "github.com/swaggo/gin-swagger v1.6.0")
-	deps = append(deps, "github.com/swaggo/swag v1.16.2")
 	deps = append(deps, "github.com/stretchr/testify v1.8.4")
 	deps = append(deps, "github.com/joho/godotenv v1.5.1")
 
-	if config.Auth != nil && config.Auth.Enabled {
-		deps = append(deps, "firebase.google.com/go/v4 v4.13.0")
-		if config.Auth.Provider == "jwt" {
-			deps = append(deps, "github.com/golang-jwt/jwt/v5 v5.2.0")
-			deps = append(deps, "golang.org/x/crypto v0.19.0")
-		}
-	}
-
-	if config.Payments != nil && config.Payments.Enabled && config.Payments.Provider == "stripe" {
-		deps = append(deps, "github.com/stripe/stripe-go/v76 v76.0.0")
-	}
-
 	switch config.Database.Type {
 	case "firestore":
 		deps = append(deps, "cloud.google.com/go/firestore v1.14.0")
@@ -329,6 +326,20 @@ func generateGoMod(projectPath string, config *domain.Config, fs domain.FileSyst
 		deps = append(deps, "go.mongodb.org/mongo-driver v1.13.0")
 	}
 
+	// A module requiring a path the generator already does gets the generator's version
+	required := make(map[string]bool)
+	for _, dep := range deps {
+		required[requirePath(dep)] = true
+	}
+	for _, c := range contributions {
+		for _, dep := range c.Requires {
+			if !required[requirePath(dep)] {
+				required[requirePath(dep)] = true
+				deps = append(deps, dep)
+			}
+		}
+	}
+
 	content := fmt.Sprintf(`module %s
 
 go 1.23
@@ -354,23 +365,139 @@ func generateDatabase(projectPath string, config *domain.Config, fs domain.FileS
 }
 
 func generatePostgres(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	content, err := template.Render("postgres_base", PostgresBaseTemplate, config)
+	content, err := render(template, "postgres_base", config)
+	if err != nil {
+		return err
+	}
+	if err := fs.WriteFile(filepath.Join(projectPath, "internal/infrastructure/db/postgres.go"), content); err != nil {
+		return err
+	}
+	if err := generateDatabaseQuery(projectPath, config, fs, template, "postgres_query"); err != nil {
+		return err
+	}
+	return generateMigrations(projectPath, config, fs, template)
+}
+
+// generateMigrations writes every migration recorded in the project's history, adding
+// one for the changes between the schema generated last time and the current blueprint
+func generateMigrations(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	historyPath := filepath.Join(projectPath, regen.StateDir, migration.HistoryFile)
+	data, _ := fs.ReadFile(historyPath)
+	history, err := migration.LoadHistory(data)
+	if err != nil {
+		return err
+	}
+	if m, ok := history.Advance(migration.FromConfig(config)); ok {
+		log.Printf("Adding migration %s", m.UpFile())
+	}
+
+	dir := filepath.Join(projectPath, "internal/infrastructure/db/migrations")
+	if err := fs.MkdirAll(dir); err != nil {
+		return err
+	}
+	for _, m := range history.Migrations {
+		if err := fs.WriteFile(filepath.Join(dir, m.UpFile()), []byte(m.Up)); err != nil {
+			return err
+		}
+		if err := fs.WriteFile(filepath.Join(dir, m.DownFile()), []byte(m.Down)); err != nil {
+			return err
+		}
+	}
+
+	content, err := render(template, "postgres_migrate", config)
+	if err != nil {
+		return err
+	}
+	if err := fs.WriteFile(filepath.Join(projectPath, "internal/infrastructure/db/migrations.go"), content); err != nil {
+		return err
+	}
+
+	data, err = history.Marshal()
 	if err != nil {
 		return err
 	}
-	return fs.WriteFile(filepath.Join(projectPath, "internal/infrastructure/db/postgres.go"), content)
+	if err := fs.MkdirAll(filepath.Dir(historyPath)); err != nil {
+		return err
+	}
+	return fs.WriteFile(historyPath, data)
 }
 
 func generateMongo(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	content, err := template.Render("mongo_base", MongoBaseTemplate, config)
+	content, err := render(template, "mongo_base", config)
 	if err != nil {
 		return err
 	}
-	return fs.WriteFile(filepath.Join(projectPath, "internal/infrastructure/db/mongo.go"), content)
+	if err := fs.WriteFile(filepath.Join(projectPath, "internal/infrastructure/db/mongo.go"), content); err != nil {
+		return err
+	}
+	return generateDatabaseQuery(projectPath, config, fs, template, "mongo_query")
 }
 
-func generateFirestore(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	const firestoreTemplate = `package db
+// generateDatabaseQuery writes the translation of list criteria into the database's own queries
+func generateDatabaseQuery(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort, queryTemplate string) error {
+	content, err := render(template, queryTemplate, config)
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(filepath.Join(projectPath, "internal/infrastructure/db/query.go"), content)
+}
+
+// generateValidation writes the field error type, the field types bound from
+// request bodies, and the package answering 422 with field errors
+func generateValidation(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	content, err := render(template, "field_error", config)
+	if err != nil {
+		return err
+	}
+	if err := fs.WriteFile(filepath.Join(projectPath, "internal/domain/validation.go"), content); err != nil {
+		return err
+	}
+
+	content, err = render(template, "types", config)
+	if err != nil {
+		return err
+	}
+	if err := fs.WriteFile(filepath.Join(projectPath, "internal/domain/types.go"), content); err != nil {
+		return err
+	}
+
+	content, err = render(template, "validation", config)
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(filepath.Join(projectPath, "internal/validation/validation.go"), content)
+}
+
+// generateListQuery writes the list criteria types and the query string parser producing them
+func generateListQuery(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	content, err := render(template, "list_query", config)
+	if err != nil {
+		return err
+	}
+	if err := fs.WriteFile(filepath.Join(projectPath, "internal/domain/query.go"), content); err != nil {
+		return err
+	}
+
+	data := struct {
+		*domain.Config
+		Structured   []string
+		TextDecimals string // database storing decimals as text, where order is lexical
+	}{Config: config}
+	data.TextDecimals = map[string]string{"mongodb": "MongoDB", "firestore": "Firestore"}[config.Database.Type]
+	for _, t := range domain.FieldTypes() {
+		if !t.Scalar {
+			data.Structured = append(data.Structured, t.Name)
+		}
+	}
+
+	content, err = render(template, "query_parser", data)
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(filepath.Join(projectPath, "internal/query/query.go"), content)
+}
+
+const firestoreTemplate = `package db
 
 import (
 	"context"
@@ -475,74 +602,171 @@ func (r *FirestoreRepository) Delete(ctx context.Context, collection, id string)
 	return err
 }
 `
+
+func generateFirestore(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
 	if config.FirestoreProjectID == "" {
 		config.FirestoreProjectID = "tiendaonline-mvp"
 	}
 
-	content, err := template.Render("firestore", firestoreTemplate, config)
+	content, err := render(template, "firestore_base", config)
 	if err != nil {
 		return err
 	}
-	return fs.WriteFile(filepath.Join(projectPath, "internal/infrastructure/db/firestore.go"), content)
+	if err := fs.WriteFile(filepath.Join(projectPath, "internal/infrastructure/db/firestore.go"), content); err != nil {
+		return err
+	}
+	return generateDatabaseQuery(projectPath, config, fs, template, "firestore_query")
 }
 
-func generateModelDomain(projectPath string, config *domain.Config, model domain.Model, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	const domainTemplate = `package domain
+const domainTemplate = `package domain
 
 import (
 	"context"
-	{{$hasTime := false}}{{range $k, $v := .Model.Fields}}{{if eq $v "datetime"}}{{$hasTime = true}}{{end}}{{end}}{{if $hasTime}}"time"{{end}}
+	{{if .Patterns}}"regexp"{{end}}
+	{{if .UsesTime}}"time"{{end}}
 )
 
 type {{.Model.Name | title}} struct {
-	ID string ` + "`" + `json:"id" bson:"_id,omitempty"` + "`" + `
+	ID string ` + "`" + `json:"id" bson:"_id,omitempty" firestore:"-"` + "`" + `
 	{{range $k, $v := .Model.Fields}}
-	{{$k | pascal}} {{if eq $v "string"}}string{{else if eq $v "integer"}}int{{else if eq $v "float"}}float64{{else if eq $v "boolean"}}bool{{else if eq $v "datetime"}}time.Time{{else}}interface{}{{end}} ` + "`" + `json:"{{$k}}" bson:"{{$k}}"` + "`" + `
+	{{$k | pascal}} {{index $.GoTypes $k}} ` + "`" + `json:"{{$k}}" bson:"{{$k}}" firestore:"{{$k}}"{{index $.Bindings $k}}` + "`" + `
 	{{end}}
 	{{range $k, $v := .Model.Relations}}
-	{{$k | pascal}} {{if hasPrefix $v "hasMany"}}[]string{{else}}string{{end}} ` + "`" + `json:"{{$k}}" bson:"{{$k}}"` + "`" + `
+	{{$k | pascal}} {{if or (hasPrefix $v "hasMany") (hasPrefix $v "manyToMany")}}[]string{{else}}string{{end}} ` + "`" + `json:"{{$k}}" bson:"{{$k}}" firestore:"{{$k}}"` + "`" + `
 	{{end}}
 }
 
+{{- range .Patterns}}
+
+var {{.Var}} = regexp.MustCompile({{.Regexp}})
+{{- end}}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *{{.Model.Name | title}}) ApplyDefaults() {
+	{{- range .Defaults}}
+	if {{.Empty}} {
+		{{- if .Pointer}}
+		{{.Field}} = new({{.Pointer}})
+		*{{.Field}} = {{.Value}}
+		{{- else}}
+		{{.Field}} = {{.Value}}
+		{{- end}}
+	}
+	{{- end}}
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *{{.Model.Name | title}}) Validate() []FieldError {
+	var errs []FieldError
+	{{- range .Patterns}}
+	if value{{if not .String}}, _{{end}} := {{.Field}}{{if not .String}}.(string){{end}}; value != "" && !{{.Var}}.MatchString(value) {
+		errs = append(errs, FieldError{Field: "{{.Name}}", Rule: "pattern", Message: {{.Message}}})
+	}
+	{{- end}}
+	return errs
+}
+
+// {{.Model.Name | title}}Fields maps the fields List can filter, sort and select on to their blueprint types
+var {{.Model.Name | title}}Fields = map[string]string{
+	"id": "string",
+	{{- range .QueryFields}}
+	"{{.Name}}": "{{.Type}}",
+	{{- end}}
+}
+
 type {{.Model.Name | title}}Repository interface {
-	List(ctx context.Context, limit, offset int) ([]*{{.Model.Name | title}}, error)
+	List(ctx context.Context, q ListQuery) ([]*{{.Model.Name | title}}, error)
+	{{- if .Cursor}}
+	Count(ctx context.Context, q ListQuery) (int64, error)
+	{{- end}}
 	Get(ctx context.Context, id string) (*{{.Model.Name | title}}, error)
+	GetMany(ctx context.Context, ids []string) ([]*{{.Model.Name | title}}, error)
 	Create(ctx context.Context, model *{{.Model.Name | title}}) (string, error)
 	Update(ctx context.Context, id string, model *{{.Model.Name | title}}) error
 	Delete(ctx context.Context, id string) error
+	{{range .Links}}
+	Link{{.Relation | pascal}}(ctx context.Context, id, targetID string) error
+	Unlink{{.Relation | pascal}}(ctx context.Context, id, targetID string) error
+	{{end}}
 }
 `
+
+func generateModelDomain(projectPath string, config *domain.Config, model domain.Model, fs domain.FileSystemPort, template domain.TemplatePort) error {
 	data := struct {
 		ProjectName string
 		Model       domain.Model
+		Links       []linkView
+		QueryFields []queryField
+		Cursor      bool
+		GoTypes     map[string]string
+		UsesTime    bool
+		Bindings    map[string]string
+		Defaults    []defaultView
+		Patterns    []patternView
 	}{
 		ProjectName: config.ProjectName,
 		Model:       model,
+		Links:       manyToManyLinks(config, model),
+		QueryFields: queryFields(model),
+		Cursor:      config.CursorPagination(),
+		GoTypes:     goTypes(model),
+		UsesTime:    usesTime(model),
+		Bindings:    bindingTags(model),
+		Defaults:    fieldDefaults(model),
+		Patterns:    fieldPatterns(model),
 	}
 
-	content, err := template.Render(model.Name+"_domain", domainTemplate, data)
+	content, err := render(template, "domain", data)
 	if err != nil {
 		return err
 	}
 	return fs.WriteFile(filepath.Join(projectPath, "internal/domain", strings.ToLower(model.Name)+".go"), content)
 }
 
-func generateModelHandlers(projectPath string, config *domain.Config, model domain.Model, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	const handlerTemplate = `package {{.Model.Name | lower}}
+type queryField struct {
+	Name string
+	Type string
+}
+
+// queryFields lists the fields and relations of model with the type the query
+// parser converts their values to; relations holding several ids are arrays
+func queryFields(model domain.Model) []queryField {
+	var fields []queryField
+	for _, name := range sortedKeys(model.Fields) {
+		fields = append(fields, queryField{Name: name, Type: model.Fields[name]})
+	}
+	for _, name := range sortedKeys(model.Relations) {
+		fieldType := "string"
+		switch domain.ParseRelation(model.Relations[name]).Kind {
+		case domain.HasMany, domain.ManyToMany:
+			fieldType = "array"
+		}
+		fields = append(fields, queryField{Name: name, Type: fieldType})
+	}
+	return fields
+}
+
+const handlerTemplate = `package {{.Model.Name | lower}}
 
 import (
 	"net/http"
 	"strconv"
 	"{{.ProjectName}}/internal/domain"
+	"{{.ProjectName}}/internal/expand"
+	"{{.ProjectName}}/internal/query"
+	"{{.ProjectName}}/internal/validation"
 	"github.com/gin-gonic/gin"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
 )
 
 type {{.Model.Name | title}}Handler struct {
-	repo domain.{{.Model.Name | title}}Repository
+	repo     domain.{{.Model.Name | title}}Repository
+	expander *expand.Expander
 }
 
-func New{{.Model.Name | title}}Handler(repo domain.{{.Model.Name | title}}Repository) *{{.Model.Name | title}}Handler {
-	return &{{.Model.Name | title}}Handler{repo: repo}
+func New{{.Model.Name | title}}Handler(repo domain.{{.Model.Name | title}}Repository, expander *expand.Expander) *{{.Model.Name | title}}Handler {
+	return &{{.Model.Name | title}}Handler{repo: repo, expander: expander}
 }
 
 func (h *{{.Model.Name | title}}Handler) List(c *gin.Context) {
@@ -552,6 +776,43 @@ func (h *{{.Model.Name | title}}Handler) List(c *gin.Context) {
 			limit = val
 		}
 	}
+{{- if .Cursor}}
+
+	q, err := query.Parse(c.Request.URL.Query(), domain.{{.Model.Name | title}}Fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := query.ApplyCursor(&q, c.Query("cursor"), domain.{{.Model.Name | title}}Fields); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// One extra item tells whether there is a next page
+	q.Limit = limit + 1
+
+	results, err := h.repo.List(c.Request.Context(), q)
+	if err != nil {
+		c.JSON(query.Status(err), gin.H{"error": err.Error()})
+		return
+	}
+	total, err := h.repo.Count(c.Request.Context(), q)
+	if err != nil {
+		c.JSON(query.Status(err), gin.H{"error": err.Error()})
+		return
+	}
+	next := ""
+	if len(results) > limit {
+		results = results[:limit]
+		if next, err = query.NextCursor(q, results[limit-1]); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if results == nil {
+		results = []*domain.{{.Model.Name | title}}{}
+	}
+	c.Header("Link", query.Links(c.Request.URL, next))
+{{- else}}
 	page := 1
 	if p := c.Query("page"); p != "" {
 		if val, err := strconv.Atoi(p); err == nil && val > 0 {
@@ -560,12 +821,49 @@ func (h *{{.Model.Name | title}}Handler) List(c *gin.Context) {
 	}
 	offset := (page - 1) * limit
 
-	results, err := h.repo.List(c.Request.Context(), limit, offset)
+	q, err := query.Parse(c.Request.URL.Query(), domain.{{.Model.Name | title}}Fields)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	q.Limit, q.Offset = limit, offset
+
+	results, err := h.repo.List(c.Request.Context(), q)
+	if err != nil {
+		c.JSON(query.Status(err), gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, results)
+{{- end}}
+
+	var body interface{} = results
+	include := c.Query("include")
+	if include != "" {
+		expanded, err := h.expander.Many(c.Request.Context(), "{{.Model.Name}}", results, include, {{.Model.Protected}})
+		if err != nil {
+			c.JSON(expand.Status(err), gin.H{"error": err.Error()})
+			return
+		}
+		body = expanded
+	}
+	if len(q.Fields) > 0 {
+		// Included relations are returned along with the selected fields
+		selected, err := query.Select(body, append(q.Fields, expand.Roots(include)...))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		body = selected
+	}
+{{- if .Cursor}}
+
+	envelope := gin.H{"data": body, "next_cursor": nil, "total": total}
+	if next != "" {
+		envelope["next_cursor"] = next
+	}
+	c.JSON(http.StatusOK, envelope)
+{{- else}}
+	c.JSON(http.StatusOK, body)
+{{- end}}
 }
 
 func (h *{{.Model.Name | title}}Handler) Get(c *gin.Context) {
@@ -575,15 +873,29 @@ func (h *{{.Model.Name | title}}Handler) Get(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
 		return
 	}
+	if include := c.Query("include"); include != "" {
+		expanded, err := h.expander.One(c.Request.Context(), "{{.Model.Name}}", result, include, {{.Model.Protected}})
+		if err != nil {
+			c.JSON(expand.Status(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, expanded)
+		return
+	}
 	c.JSON(http.StatusOK, result)
 }
 
 func (h *{{.Model.Name | title}}Handler) Create(c *gin.Context) {
 	var m domain.{{.Model.Name | title}}
-	if err := c.ShouldBindJSON(&m); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !h.bind(c, &m) {
+		return
+	}
+	m.ApplyDefaults()
+	if !h.validate(c, &m, "") {
 		return
 	}
+	// blueprint:custom-begin create
+	// blueprint:custom-end create
 	id, err := h.repo.Create(c.Request.Context(), &m)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -596,10 +908,11 @@ func (h *{{.Model.Name | title}}Handler) Create(c *gin.Context) {
 func (h *{{.Model.Name | title}}Handler) Update(c *gin.Context) {
 	id := c.Param("id")
 	var m domain.{{.Model.Name | title}}
-	if err := c.ShouldBindJSON(&m); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !h.bind(c, &m) || !h.validate(c, &m, id) {
 		return
 	}
+	// blueprint:custom-begin update
+	// blueprint:custom-end update
 	if err := h.repo.Update(c.Request.Context(), id, &m); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -615,114 +928,112 @@ func (h *{{.Model.Name | title}}Handler) Delete(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
 }
-`
-	data := struct {
-		ProjectName  string
-		Model        domain.Model
-		DefaultLimit int
-	}{
-		ProjectName:  config.ProjectName,
-		Model:        model,
-		DefaultLimit: 10,
-	}
-	if config.Pagination != nil && config.Pagination.DefaultLimit > 0 {
-		data.DefaultLimit = config.Pagination.DefaultLimit
-	}
 
-	content, err := template.Render(model.Name+"_handler", handlerTemplate, data)
-	if err != nil {
-		return err
+// bind decodes the request body into m, answering 422 when a binding rule fails
+func (h *{{.Model.Name | title}}Handler) bind(c *gin.Context, m *domain.{{.Model.Name | title}}) bool {
+	if err := c.ShouldBindJSON(m); err != nil {
+		if errs, ok := validation.FromBinding(err); ok {
+			validation.Respond(c, errs)
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return false
 	}
-	return fs.WriteFile(filepath.Join(projectPath, "internal/handlers", strings.ToLower(model.Name), "handler.go"), content)
+	return true
 }
 
-func generatePaymentFiles(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	if config.Payments.Provider == "mercadopago" {
-		content, err := template.Render("mercadopago", MercadoPagoTemplate, config)
-		if err != nil {
-			return err
-		}
-		return fs.WriteFile(filepath.Join(projectPath, "internal/payments/mercadopago.go"), content)
-	} else if config.Payments.Provider == "stripe" {
-		content, err := template.Render("stripe", StripeTemplate, config)
-		if err != nil {
-			return err
-		}
-		return fs.WriteFile(filepath.Join(projectPath, "internal/payments/stripe.go"), content)
+// validate runs the checks binding cannot do; id is the record being updated, if any
+func (h *{{.Model.Name | title}}Handler) validate(c *gin.Context, m *domain.{{.Model.Name | title}}, id string) bool {
+	errs := m.Validate()
+	{{- range .Unique}}
+	if taken, err := h.taken(c, "{{.}}", m.{{. | pascal}}, id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	} else if taken {
+		errs = append(errs, domain.FieldError{Field: "{{.}}", Rule: "unique", Message: "is already taken"})
 	}
-	return nil
+	{{- end}}
+	if len(errs) > 0 {
+		validation.Respond(c, errs)
+		return false
+	}
+	return true
 }
+{{- if .Unique}}
 
-func generateConfigFiles(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	content, err := template.Render("config", ConfigTemplate, config)
+// taken reports whether a record other than id already has value in field
+func (h *{{.Model.Name | title}}Handler) taken(c *gin.Context, field string, value interface{}, id string) (bool, error) {
+	q := domain.ListQuery{Filters: []domain.Filter{{"{{"}}Field: field, Op: domain.OpEq, Value: value{{"}}"}}, Limit: 2}
+	existing, err := h.repo.List(c.Request.Context(), q)
 	if err != nil {
-		return err
+		return false, err
 	}
-	return fs.WriteFile(filepath.Join(projectPath, "internal/config/config.go"), content)
+	for _, e := range existing {
+		if e.ID != id {
+			return true, nil
+		}
+	}
+	return false, nil
 }
-
-func generateAuthFiles(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	var middlewareTemplate string
-	var handlerTemplateStr string
-
-	if config.Auth.Provider == "jwt" {
-		middlewareTemplate = JWTMiddlewareTemplate
-		handlerTemplateStr = JWTAuthHandlerTemplate
-
-		// Generate domain/auth.go for UserAuthData
-		const authDomainTemplate = `package domain
-
-import "context"
-
-// UserAuthData represents minimal data needed for auth
-type UserAuthData struct {
-	ID       string
-	Email    string
-	Password string
-	Role     string
+{{- end}}
+{{range .Links}}
+func (h *{{$.Model.Name | title}}Handler) Link{{.Relation | pascal}}(c *gin.Context) {
+	if err := h.repo.Link{{.Relation | pascal}}(c.Request.Context(), c.Param("id"), c.Param("targetId")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "linked"})
 }
 
-// UserRepository interface to fetch password hashes
-type UserRepository interface {
-	GetByEmail(ctx context.Context, email string) (*UserAuthData, error)
-	RegisterUser(ctx context.Context, user *UserAuthData) (string, error)
+func (h *{{$.Model.Name | title}}Handler) Unlink{{.Relation | pascal}}(c *gin.Context) {
+	if err := h.repo.Unlink{{.Relation | pascal}}(c.Request.Context(), c.Param("id"), c.Param("targetId")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "unlinked"})
 }
+{{end}}
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
 `
-		if err := fs.WriteFile(filepath.Join(projectPath, "internal/domain/auth.go"), []byte(authDomainTemplate)); err != nil {
-			return err
-		}
-
-	} else {
-		middlewareTemplate = AuthMiddlewareTemplate
-		handlerTemplateStr = AuthHandlerTemplate
-	}
 
-	middlewareContent, err := template.Render("auth_middleware", middlewareTemplate, config)
-	if err != nil {
-		return err
+func generateModelHandlers(projectPath string, config *domain.Config, model domain.Model, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	data := struct {
+		ProjectName  string
+		Model        domain.Model
+		DefaultLimit int
+		Links        []linkView
+		Cursor       bool
+		Unique       []string
+	}{
+		ProjectName:  config.ProjectName,
+		Model:        model,
+		DefaultLimit: 10,
+		Links:        manyToManyLinks(config, model),
+		Cursor:       config.CursorPagination(),
+		Unique:       model.UniqueFields(),
 	}
-	if err := fs.WriteFile(filepath.Join(projectPath, "internal/auth/middleware.go"), middlewareContent); err != nil {
-		return err
+	if config.Pagination != nil && config.Pagination.DefaultLimit > 0 {
+		data.DefaultLimit = config.Pagination.DefaultLimit
 	}
 
-	content, err := template.Render("auth_handler", handlerTemplateStr, config)
+	content, err := render(template, "handler", data)
 	if err != nil {
 		return err
 	}
-	return fs.WriteFile(filepath.Join(projectPath, "internal/handlers/auth/handler.go"), content)
+	return fs.WriteFile(filepath.Join(projectPath, "internal/handlers", strings.ToLower(model.Name), "handler.go"), content)
 }
 
-func generateModelRepository(projectPath string, config *domain.Config, model domain.Model, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	var repoTemplate string
-	switch config.Database.Type {
-	case "firestore":
-		repoTemplate = `package db
+const firestoreRepoTemplate = `package db
 
 import (
 	"context"
 	{{if .IsJWT}}"time"{{end}}
 	"{{.ProjectName}}/internal/domain"
+	"cloud.google.com/go/firestore"
 	"google.golang.org/api/iterator"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
 )
 
 type {{.Model.Name | title}}Repository struct {
@@ -755,8 +1066,14 @@ func (r *{{.Model.Name | title}}Repository) GetByEmail(ctx context.Context, emai
 }
 {{end}}
 
-func (r *{{.Model.Name | title}}Repository) List(ctx context.Context, limit, offset int) ([]*domain.{{.Model.Name | title}}, error) {
-	iter := r.client.client.Collection("{{.Model.Name}}").Offset(offset).Limit(limit).Documents(ctx)
+{{if .Cursor}}
+// Count returns how many documents match the filters of q, ignoring the page
+func (r *{{.Model.Name | title}}Repository) Count(ctx context.Context, q domain.ListQuery) (int64, error) {
+	return countQuery(ctx, r.client.client.Collection("{{.Model.Name}}"), q)
+}
+{{end}}
+func (r *{{.Model.Name | title}}Repository) List(ctx context.Context, q domain.ListQuery) ([]*domain.{{.Model.Name | title}}, error) {
+	iter := listQuery(r.client.client.Collection("{{.Model.Name}}"), q).Documents(ctx)
 	var results []*domain.{{.Model.Name | title}}
 	for {
 		doc, err := iter.Next()
@@ -789,6 +1106,31 @@ func (r *{{.Model.Name | title}}Repository) Get(ctx context.Context, id string)
 	return &m, nil
 }
 
+// GetMany loads the documents with the given ids in a single batched call
+func (r *{{.Model.Name | title}}Repository) GetMany(ctx context.Context, ids []string) ([]*domain.{{.Model.Name | title}}, error) {
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = r.client.client.Collection("{{.Model.Name}}").Doc(id)
+	}
+	docs, err := r.client.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.{{.Model.Name | title}}
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var m domain.{{.Model.Name | title}}
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
 func (r *{{.Model.Name | title}}Repository) Create(ctx context.Context, model *domain.{{.Model.Name | title}}) (string, error) {
 	ref, _, err := r.client.client.Collection("{{.Model.Name}}").Add(ctx, model)
 	if err != nil {
@@ -824,16 +1166,39 @@ func (r *{{.Model.Name | title}}Repository) Delete(ctx context.Context, id strin
 	_, err := r.client.client.Collection("{{.Model.Name}}").Doc(id).Delete(ctx)
 	return err
 }
+{{range .Links}}
+func (r *{{$.Model.Name | title}}Repository) Link{{.Relation | pascal}}(ctx context.Context, id, targetID string) error {
+	_, err := r.client.client.Collection("{{$.Model.Name}}").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "{{.Relation}}", Value: firestore.ArrayUnion(targetID)},
+	})
+	return err
+}
+
+func (r *{{$.Model.Name | title}}Repository) Unlink{{.Relation | pascal}}(ctx context.Context, id, targetID string) error {
+	_, err := r.client.client.Collection("{{$.Model.Name}}").Doc(id).Update(ctx, []firestore.Update{
+		{Path: "{{.Relation}}", Value: firestore.ArrayRemove(targetID)},
+	})
+	return err
+}
+{{end}}
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
 `
+
+func generateModelRepository(projectPath string, config *domain.Config, model domain.Model, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	var repoTemplate string
+	switch config.Database.Type {
+	case "firestore":
+		repoTemplate = "firestore_repo"
 	case "postgresql":
 		// I'll need to adapt the Postgres template dynamically too, but let's stick to the base pattern first.
 		// For now, I'll update the PostgresRepoTemplate constant if possible, or inject the GetByEmail logic.
 		// Since PostgresRepoTemplate is likely a large string, I should check if I can modify it or append methods.
 		// The current architecture uses a fixed template. I might need to make it more flexible.
 		// For simplicity in this iteration, I will inject the GetByEmail method into the template data if it's the User collection.
-		repoTemplate = PostgresRepoTemplate
+		repoTemplate = "postgres_repo"
 	case "mongodb":
-		repoTemplate = MongoRepoTemplate
+		repoTemplate = "mongo_repo"
 	}
 	var allFields []string
 	for k := range model.Fields {
@@ -844,44 +1209,37 @@ func (r *{{.Model.Name | title}}Repository) Delete(ctx context.Context, id strin
 	}
 	sort.Strings(allFields)
 
-	// Pre-calculate SQL parts for Postgres
-	var insertCols []string
+	// Pre-calculate SQL parts for Postgres; the table itself comes from the migrations.
+	// Relations without a column of their own are read from the foreign key or join table.
+	var columns []string
 	var insertPlaceholders []string
 	var updateSet []string
 	var selectCols []string
-	var schemaCols []string
 
 	selectCols = append(selectCols, "id")
-	// ID column definition
-	schemaCols = append(schemaCols, "id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text")
-
-	for i, f := range allFields {
-		insertCols = append(insertCols, f)
-		insertPlaceholders = append(insertPlaceholders, fmt.Sprintf("$%d", i+1))
-		updateSet = append(updateSet, fmt.Sprintf("%s = $%d", f, i+1))
-		selectCols = append(selectCols, f)
-
-		// Map Go types to SQL types
-		sqlType := "TEXT"
-		if fieldType, ok := model.Fields[f]; ok {
-			switch fieldType {
-			case "int":
-				sqlType = "INTEGER"
-			case "float":
-				sqlType = "DOUBLE PRECISION"
-			case "bool":
-				sqlType = "BOOLEAN"
-			case "datetime":
-				sqlType = "TIMESTAMP"
-			}
+
+	for _, f := range allFields {
+		if _, isField := model.Fields[f]; !isField && !migration.HasColumn(config, model, f) {
+			selectCols = append(selectCols, derivedRelationSQL(config, model, f))
+			continue
 		}
-		// Check for relations
-		if relationType, ok := model.Relations[f]; ok {
-			if strings.HasPrefix(relationType, "hasMany") {
-				sqlType = "TEXT[]"
-			}
+
+		placeholder := fmt.Sprintf("$%d", len(columns)+1)
+		selectCol := f
+		switch {
+		case domain.ParseRelation(model.Relations[f]).Kind == domain.BelongsTo:
+			// Foreign keys are NULL when unset, while the Go struct uses ""
+			placeholder = fmt.Sprintf("NULLIF(%s, '')", placeholder)
+			selectCol = fmt.Sprintf("COALESCE(%s, '') AS %s", f, f)
+		case model.Fields[f] == "uuid":
+			// Likewise for uuid columns, which reject ''
+			placeholder = fmt.Sprintf("NULLIF(%s, '')::uuid", placeholder)
+			selectCol = fmt.Sprintf("COALESCE(%s::text, '') AS %s", f, f)
 		}
-		schemaCols = append(schemaCols, fmt.Sprintf("%s %s", f, sqlType))
+		columns = append(columns, f)
+		insertPlaceholders = append(insertPlaceholders, placeholder)
+		updateSet = append(updateSet, fmt.Sprintf("%s = %s", f, placeholder))
+		selectCols = append(selectCols, selectCol)
 	}
 
 	isJWT := false
@@ -895,34 +1253,142 @@ func (r *{{.Model.Name | title}}Repository) Delete(ctx context.Context, id strin
 		ProjectName        string
 		Model              domain.Model
 		Fields             []string // For struct generation/scanning if needed
+		Columns            []string // Fields stored in the model's own table
 		InsertColumns      string
 		InsertPlaceholders string
 		UpdateSet          string
 		SelectColumns      string
-		CreateTableSQL     string
 		TotalFields        int
 		IsJWT              bool
+		Links              []linkView
+		Cursor             bool
 	}{
 		ProjectName:        config.ProjectName,
 		Model:              model,
 		Fields:             allFields,
-		InsertColumns:      strings.Join(insertCols, ", "),
+		Columns:            columns,
+		InsertColumns:      strings.Join(columns, ", "),
 		InsertPlaceholders: strings.Join(insertPlaceholders, ", "),
 		UpdateSet:          strings.Join(updateSet, ", "),
 		SelectColumns:      strings.Join(selectCols, ", "),
-		CreateTableSQL:     fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", model.Name, strings.Join(schemaCols, ", ")),
-		TotalFields:        len(allFields),
+		TotalFields:        len(columns),
 		IsJWT:              isJWT,
+		Links:              manyToManyLinks(config, model),
+		Cursor:             config.CursorPagination(),
 	}
 
-	content, err := template.Render(model.Name+"_repo", repoTemplate, data)
+	content, err := render(template, repoTemplate, data)
 	if err != nil {
 		return err
 	}
 	return fs.WriteFile(filepath.Join(projectPath, "internal/infrastructure/db", strings.ToLower(model.Name)+"_repository.go"), content)
 }
 
-func generateEnvFile(projectPath string, config *domain.Config, fs domain.FileSystemPort) error {
+// expandView describes a model to the template of the generated expand package
+type expandView struct {
+	Name      string
+	Protected bool
+	Relations []includeView
+}
+
+type includeView struct {
+	Include string // name used in ?include= and as the embedded field
+	Field   string // field holding the related id(s)
+	Target  string
+	Many    bool
+}
+
+func generateExpand(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	data := struct {
+		MaxDepth int
+		Models   []expandView
+	}{MaxDepth: 3}
+	for _, model := range config.Models {
+		data.Models = append(data.Models, expandView{
+			Name:      model.Name,
+			Protected: model.Protected,
+			Relations: includes(model),
+		})
+	}
+
+	content, err := render(template, "expand", data)
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(filepath.Join(projectPath, "internal/expand/expand.go"), content)
+}
+
+// includes lists the relations of model by include name. A belongsTo
+// "author_id" is included as "author", unless that name is already taken.
+func includes(model domain.Model) []includeView {
+	var views []includeView
+	for _, name := range sortedKeys(model.Relations) {
+		rel := domain.ParseRelation(model.Relations[name])
+		include := strings.TrimSuffix(name, "_id")
+		if _, taken := model.Fields[include]; taken || include == "" {
+			include = name
+		}
+		if _, taken := model.Relations[include]; taken {
+			include = name
+		}
+		views = append(views, includeView{
+			Include: include,
+			Field:   name,
+			Target:  rel.Target,
+			Many:    rel.Kind == domain.HasMany || rel.Kind == domain.ManyToMany,
+		})
+	}
+	return views
+}
+
+// linkView describes a manyToMany relation to the templates generating link/unlink support
+type linkView struct {
+	Relation     string
+	Target       string
+	JoinTable    string
+	OwnerColumn  string
+	TargetColumn string
+}
+
+func manyToManyLinks(config *domain.Config, model domain.Model) []linkView {
+	var links []linkView
+	for _, name := range sortedKeys(model.Relations) {
+		rel := domain.ParseRelation(model.Relations[name])
+		if rel.Kind != domain.ManyToMany {
+			continue
+		}
+		join := domain.ManyToManyJoin(config.Models, model, name)
+		links = append(links, linkView{
+			Relation:     name,
+			Target:       rel.Target,
+			JoinTable:    join.Table,
+			OwnerColumn:  join.OwnerColumn,
+			TargetColumn: join.TargetColumn,
+		})
+	}
+	return links
+}
+
+// derivedRelationSQL selects the ids of a relation that has no column in the model's table
+func derivedRelationSQL(config *domain.Config, model domain.Model, relation string) string {
+	rel := domain.ParseRelation(model.Relations[relation])
+	if rel.Kind == domain.ManyToMany {
+		join := domain.ManyToManyJoin(config.Models, model, relation)
+		return fmt.Sprintf("ARRAY(SELECT %s FROM %s WHERE %s = %s.id) AS %s",
+			join.TargetColumn, join.Table, join.OwnerColumn, model.Name, relation)
+	}
+
+	// hasMany: the target table holds the foreign key
+	var fk string
+	for _, m := range config.Models {
+		if m.Name == rel.Target {
+			fk, _ = domain.InverseKey(m, model.Name)
+		}
+	}
+	return fmt.Sprintf("ARRAY(SELECT id FROM %s WHERE %s = %s.id) AS %s", rel.Target, fk, model.Name, relation)
+}
+
+func generateEnvFile(projectPath string, config *domain.Config, contributions []*module.Contribution, fs domain.FileSystemPort) error {
 	var buffer bytes.Buffer
 
 	buffer.WriteString("PORT=8080\n")
@@ -935,51 +1401,38 @@ func generateEnvFile(projectPath string, config *domain.Config, fs domain.FileSy
 		buffer.WriteString(fmt.Sprintf("DATABASE_URL=%s\n", url))
 	}
 
-	if config.Auth != nil && config.Auth.Enabled && config.Auth.Provider == "jwt" {
-		buffer.WriteString("JWT_SECRET=your_secret_key_here\n")
-	}
-
-	if config.Payments != nil && config.Payments.Enabled {
-		if config.Payments.Provider == "mercadopago" {
-			buffer.WriteString("MP_ACCESS_TOKEN=your_mercadopago_access_token_here\n")
-		} else if config.Payments.Provider == "stripe" {
-			buffer.WriteString("STRIPE_SECRET_KEY=your_stripe_secret_key_here\n")
-			buffer.WriteString("STRIPE_WEBHOOK_SECRET=your_stripe_webhook_secret_here\n")
+	for _, c := range contributions {
+		for _, env := range c.Env {
+			buffer.WriteString(fmt.Sprintf("%s=%s\n", env.Name, env.Value))
 		}
 	}
 
 	return fs.WriteFile(filepath.Join(projectPath, ".env"), buffer.Bytes())
 }
 
-func generateMain(projectPath string, config *domain.Config, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	const mainTemplate = `package main
+const mainTemplate = `package main
 
 import (
-	{{if and .Auth .Auth.Enabled}}{{if eq .Auth.Provider "firebase"}}"context"{{end}}{{end}}
 	"log"
-	"os"
-	{{if not (and .Auth .Auth.Enabled)}}
 	"net/http"
+	"os"
 	"strings"
-	{{end}}
 
 	"github.com/joho/godotenv"
 	"{{.ProjectName}}/internal/infrastructure/db"
+	"{{.ProjectName}}/internal/expand"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	_ "{{.ProjectName}}/docs"
-	{{if and .Auth .Auth.Enabled}}
-	authService "{{.ProjectName}}/internal/auth"
-	authHandler "{{.ProjectName}}/internal/handlers/auth"
-	{{if eq .Auth.Provider "firebase"}}firebase "firebase.google.com/go/v4"{{end}}
-	{{end}}
-	{{if and .Payments .Payments.Enabled}}
-	"{{.ProjectName}}/internal/payments"
-	{{end}}
+	"{{.ProjectName}}/docs"
+	{{range .Contributions}}{{range .Imports}}
+	{{.}}
+	{{end}}{{end}}
 	{{range .Models}}
 	"{{$.ProjectName}}/internal/handlers/{{.Name | lower}}"
 	{{end}}
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
 )
 
 func main() {
@@ -1001,95 +1454,27 @@ func main() {
 	}
 	defer baseRepo.Close()
 
-	{{if and .Auth .Auth.Enabled}}
-	// Initialize Auth Service
-	{{if eq .Auth.Provider "jwt"}}
-	// Initialize User Repo for JWT
-	{{if eq .Database.Type "firestore"}}
-	userRepo := db.New{{.Auth.UserCollection | title}}Repository(baseRepo.(*db.FirestoreRepository))
-	{{else if eq .Database.Type "postgresql"}}
-	userRepo := db.New{{.Auth.UserCollection | title}}Repository(baseRepo.(*db.PostgresRepository))
-	{{else if eq .Database.Type "mongodb"}}
-	userRepo := db.New{{.Auth.UserCollection | title}}Repository(baseRepo.(*db.MongoRepository))
-	{{end}}
-	authSvc := authService.NewJWTAuthService(userRepo)
-	userHdl := authHandler.NewUserHandler(authSvc, userRepo, "{{.Auth.UserCollection}}")
-	{{else}}
-	var authSvc authService.AuthService
-	if os.Getenv("MOCK_AUTH") == "true" {
-		log.Println("Using Mock Auth Service")
-		authSvc = &authService.MockAuthService{}
-	} else {
-		// Initialize Firebase Auth
-		app, err := firebase.NewApp(context.Background(), &firebase.Config{ProjectID: "{{.Database.ProjectID}}"})
-		if err != nil {
-			log.Fatalf("error initializing app: %v\n", err)
-		}
-		authClient, err := app.Auth(context.Background())
-		if err != nil {
-			log.Fatalf("error getting Auth client: %v\n", err)
-		}
-		authSvc = &authService.FirebaseAuthService{Client: authClient}
-	}
-	// Initialize User Handler
-	{{if eq .Database.Type "firestore"}}
-	userRepo := db.New{{.Auth.UserCollection | title}}Repository(baseRepo.(*db.FirestoreRepository))
-	{{else if eq .Database.Type "postgresql"}}
-	userRepo := db.New{{.Auth.UserCollection | title}}Repository(baseRepo.(*db.PostgresRepository))
-	{{else if eq .Database.Type "mongodb"}}
-	userRepo := db.New{{.Auth.UserCollection | title}}Repository(baseRepo.(*db.MongoRepository))
-	{{end}}
-	userHdl := authHandler.NewUserHandler(authSvc, userRepo, "{{.Auth.UserCollection}}")
-	{{end}}
-	{{end}}
-
-	{{if and .Payments .Payments.Enabled}}
-	// Initialize Payment Service
-	{{if eq .Database.Type "firestore"}}
-	mpRepo := db.New{{.Payments.TransactionsColl | title}}Repository(baseRepo.(*db.FirestoreRepository))
-	{{else if eq .Database.Type "postgresql"}}
-	mpRepo := db.New{{.Payments.TransactionsColl | title}}Repository(baseRepo.(*db.PostgresRepository))
-	{{else if eq .Database.Type "mongodb"}}
-	mpRepo := db.New{{.Payments.TransactionsColl | title}}Repository(baseRepo.(*db.MongoRepository))
-	{{end}}
-
-	{{if eq .Payments.Provider "mercadopago"}}
-	mpService := payments.NewMercadoPagoService(mpRepo)
-	{{else if eq .Payments.Provider "stripe"}}
-	stripeService := payments.NewStripeService(mpRepo)
-	{{end}}
-	{{end}}
+	{{range .Contributions}}{{if .Setup}}
+	// Module {{.Module}}
+	{{.Setup}}
+	{{end}}{{end}}
 
 	// Setup Router
 	r := gin.Default()
 
-	// Swagger Route
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/swagger/doc.json")))
-
-	{{if and .Auth .Auth.Enabled}}
-	// Auth Routes
-	authGroup := r.Group("/auth")
-	{{if eq .Auth.Provider "jwt"}}
-	authGroup.POST("/login", userHdl.Login)
-	authGroup.POST("/register", userHdl.Register)
-	{{else}}
-	authGroup.POST("/login", authService.AuthMiddleware(authSvc), userHdl.Login)
-	{{end}}
-	authGroup.GET("/me", authService.AuthMiddleware(authSvc), userHdl.GetMe)
-	authGroup.GET("/roles", authService.AuthMiddleware(authSvc), userHdl.GetRoles)
-	{{end}}
+	{{range .Contributions}}{{if .Router}}
+	// Module {{.Module}}
+	{{.Router}}
+	{{end}}{{end}}
 
-	{{if and .Payments .Payments.Enabled}}
-	// Payment Routes
-	paymentGroup := r.Group("/payments")
-	{{if eq .Payments.Provider "mercadopago"}}
-	paymentGroup.POST("/mercadopago/preference", mpService.CreatePreferenceHandler)
-	paymentGroup.POST("/mercadopago/webhook", mpService.HandleWebhook)
-	{{else if eq .Payments.Provider "stripe"}}
-	paymentGroup.POST("/stripe/payment-intent", stripeService.CreatePaymentIntentHandler)
-	paymentGroup.POST("/stripe/webhook", stripeService.HandleWebhook)
-	{{end}}
-	{{end}}
+	// API docs: the OpenAPI document generated from the blueprint, browsable with Swagger UI
+	r.GET("/openapi.yaml", func(c *gin.Context) {
+		c.Data(200, "application/yaml", docs.OpenAPI)
+	})
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/openapi.yaml")))
+
+	// Related records for ?include=, registered by each model below
+	expander := expand.New()
 
 	{{range .Models}}
 	// Routes for {{.Name}}
@@ -1101,12 +1486,13 @@ func main() {
 		{{else if eq $.Database.Type "mongodb"}}
 		repo := db.New{{.Name | title}}Repository(baseRepo.(*db.MongoRepository))
 		{{end}}
-		handler := {{.Name | lower}}.New{{.Name | title}}Handler(repo)
+		expander.Register("{{.Name}}", expand.Fetch(repo.GetMany))
+		handler := {{.Name | lower}}.New{{.Name | title}}Handler(repo, expander)
 
 		group := r.Group("/api/{{.Name}}")
 		{{if .Protected}}
-		{{if and $.Auth $.Auth.Enabled}}
-		group.Use(authService.AuthMiddleware(authSvc))
+		{{if $.Protect}}
+		group.Use({{$.Protect}})
 		{{else}}
 		group.Use(AuthMiddleware())
 		{{end}}
@@ -1116,14 +1502,21 @@ func main() {
 		group.POST("", handler.Create)
 		group.PUT("/:id", handler.Update)
 		group.DELETE("/:id", handler.Delete)
+		{{range $name, $spec := .Relations}}{{if hasPrefix $spec "manyToMany"}}
+		group.POST("/:id/{{$name}}/:targetId", handler.Link{{$name | pascal}})
+		group.DELETE("/:id/{{$name}}/:targetId", handler.Unlink{{$name | pascal}})
+		{{end}}{{end}}
 	}
 	{{end}}
 
+	// blueprint:custom-begin routes
+	// blueprint:custom-end routes
+
 	log.Printf("Starting server for project: {{.ProjectName}}")
 	r.Run(":8080")
 }
 
-{{if not (and .Auth .Auth.Enabled)}}
+{{if not .Protect}}
 // AuthMiddleware verifies the Bearer token
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -1137,7 +1530,25 @@ func AuthMiddleware() gin.HandlerFunc {
 }
 {{end}}
 `
-	content, err := template.Render("main", mainTemplate, config)
+
+func generateMain(projectPath string, config *domain.Config, contributions []*module.Contribution, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	// The config fields stay at the top level, as in the other project-wide templates
+	data := struct {
+		*domain.Config
+		Contributions []*module.Contribution
+		Protect       string
+	}{Config: config, Contributions: contributions}
+	for _, c := range contributions {
+		if c.Protect == "" {
+			continue
+		}
+		if data.Protect != "" {
+			return fmt.Errorf("module %s: protected models are already guarded by another module", c.Module)
+		}
+		data.Protect = c.Protect
+	}
+
+	content, err := render(template, "main", data)
 	if err != nil {
 		return err
 	}
@@ -1149,31 +1560,25 @@ func generateTestScript(projectPath string, config *domain.Config, fs domain.Fil
 	// Helper to generate JSON payload
 	generateJSON := func(model domain.Model) string {
 		var parts []string
-		for k, v := range model.Fields {
-			var val string
-			switch v {
-			case "string", "text":
-				val = fmt.Sprintf("\"test_%s\"", k)
-			case "integer", "int":
-				val = "10"
-			case "float":
-				val = "99.99"
-			case "boolean", "bool":
-				val = "true"
-			case "datetime":
-				val = "\"2023-01-01T00:00:00Z\""
-			default:
-				val = "\"unknown\""
+		for _, k := range sortedKeys(model.Fields) {
+			val, ok := sampleValue(k, model.Fields[k], model.Rules[k])
+			if !ok {
+				continue
 			}
 			parts = append(parts, fmt.Sprintf("\"%s\": %s", k, val))
 		}
 		// Add relations
-		for k, v := range model.Relations {
+		for _, k := range sortedKeys(model.Relations) {
+			v := model.Relations[k]
 			if strings.HasPrefix(v, "belongsTo") {
 				// If protected and relation is user_id, skip (injected by backend)
 				if model.Protected && k == "user_id" {
 					continue
 				}
+				// Postgres enforces the foreign key: a made-up id would be rejected
+				if config.Database.Type == "postgresql" {
+					continue
+				}
 				parts = append(parts, fmt.Sprintf("\"%s\": \"test_%s\"", k, k))
 			}
 		}
@@ -1183,18 +1588,8 @@ func generateTestScript(projectPath string, config *domain.Config, fs domain.Fil
 	buf.WriteString("#!/bin/bash\n\n")
 	buf.WriteString("echo \"Installing dependencies...\"\n")
 	buf.WriteString("go mod tidy\n\n")
-	buf.WriteString("echo \"Generating docs...\"\n")
-	buf.WriteString("./update_docs.sh\n\n")
 	buf.WriteString("echo \"Starting server in background...\"\n")
 	buf.WriteString("export MOCK_AUTH=true\n")
-	if config.Payments != nil && config.Payments.Enabled {
-		if config.Payments.Provider == "mercadopago" {
-			buf.WriteString("export MP_ACCESS_TOKEN=\"TEST_MP_TOKEN_12345\"\n")
-		} else if config.Payments.Provider == "stripe" {
-			buf.WriteString("export STRIPE_SECRET_KEY=\"sk_test_12345\"\n")
-			buf.WriteString("export STRIPE_WEBHOOK_SECRET=\"whsec_12345\"\n")
-		}
-	}
 	buf.WriteString("go run cmd/api/main.go &\n")
 	buf.WriteString("PID=$!\n")
 	buf.WriteString("sleep 5\n\n")
@@ -1235,26 +1630,11 @@ func generateTestScript(projectPath string, config *domain.Config, fs domain.Fil
 func generateSetupScript(projectPath string, config *domain.Config, fs domain.FileSystemPort) error {
 	var buf bytes.Buffer
 	buf.WriteString("#!/bin/bash\n\n")
-	buf.WriteString("echo \"[1/3] Installing dependencies...\"\n")
+	buf.WriteString("echo \"[1/2] Installing dependencies...\"\n")
 	buf.WriteString("go mod tidy\n\n")
-	buf.WriteString("export PATH=$PATH:$(go env GOPATH)/bin\n")
-	buf.WriteString("if ! command -v swag &> /dev/null; then\n")
-	buf.WriteString("    echo \"swag could not be found, installing...\"\n")
-	buf.WriteString("    go install github.com/swaggo/swag/cmd/swag@latest\n")
-	buf.WriteString("fi\n\n")
-	buf.WriteString("echo \"[2/3] Generating docs...\"\n")
-	buf.WriteString("./update_docs.sh\n\n")
-	buf.WriteString("echo \"[3/3] Starting server...\"\n")
+	buf.WriteString("echo \"[2/2] Starting server...\"\n")
 	buf.WriteString("echo \"Server will be available at http://localhost:8080\"\n")
 	buf.WriteString("echo \"Swagger docs available at http://localhost:8080/swagger/index.html\"\n")
-	if config.Payments != nil && config.Payments.Enabled {
-		if config.Payments.Provider == "mercadopago" {
-			buf.WriteString("export MP_ACCESS_TOKEN=\"YOUR_MERCADO_PAGO_ACCESS_TOKEN_HERE\"\n")
-		} else if config.Payments.Provider == "stripe" {
-			buf.WriteString("export STRIPE_SECRET_KEY=\"YOUR_STRIPE_SECRET_KEY_HERE\"\n")
-			buf.WriteString("export STRIPE_WEBHOOK_SECRET=\"YOUR_STRIPE_WEBHOOK_SECRET_HERE\"\n")
-		}
-	}
 	buf.WriteString("go run cmd/api/main.go\n")
 
 	if err := fs.WriteFile(filepath.Join(projectPath, "setup.sh"), buf.Bytes()); err != nil {
@@ -1263,27 +1643,7 @@ func generateSetupScript(projectPath string, config *domain.Config, fs domain.Fi
 	return fs.Chmod(filepath.Join(projectPath, "setup.sh"), 0755)
 }
 
-func generateDocsScript(projectPath string, fs domain.FileSystemPort) error {
-	var buf bytes.Buffer
-	buf.WriteString("#!/bin/bash\n\n")
-	buf.WriteString("export PATH=$PATH:$(go env GOPATH)/bin\n\n")
-	buf.WriteString("if ! command -v swag &> /dev/null; then\n")
-	buf.WriteString("    echo \"swag could not be found, installing...\"\n")
-	buf.WriteString("    go install github.com/swaggo/swag/cmd/swag@latest\n")
-	buf.WriteString("fi\n\n")
-	buf.WriteString("echo \"Tidying dependencies...\"\n")
-	buf.WriteString("go mod tidy\n\n")
-	buf.WriteString("echo \"Generating Swagger documentation...\"\n")
-	buf.WriteString("swag init -g cmd/api/main.go --parseDependency --parseInternal\n")
-
-	if err := fs.WriteFile(filepath.Join(projectPath, "update_docs.sh"), buf.Bytes()); err != nil {
-		return err
-	}
-	return fs.Chmod(filepath.Join(projectPath, "update_docs.sh"), 0755)
-}
-
-func generateModelHandlerTests(projectPath string, config *domain.Config, model domain.Model, fs domain.FileSystemPort, template domain.TemplatePort) error {
-	const testTemplate = `package {{.Model.Name | lower}}
+const testTemplate = `package {{.Model.Name | lower}}
 
 import (
 	"bytes"
@@ -1294,6 +1654,7 @@ import (
 	"testing"
 
 	"{{.ProjectName}}/internal/domain"
+	"{{.ProjectName}}/internal/expand"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
@@ -1302,13 +1663,14 @@ type Mock{{.Model.Name | title}}Repository struct {
 	Data map[string]*domain.{{.Model.Name | title}}
 }
 
-func (m *Mock{{.Model.Name | title}}Repository) List(ctx context.Context, limit, offset int) ([]*domain.{{.Model.Name | title}}, error) {
+func (m *Mock{{.Model.Name | title}}Repository) List(ctx context.Context, q domain.ListQuery) ([]*domain.{{.Model.Name | title}}, error) {
 	var results []*domain.{{.Model.Name | title}}
 	for _, v := range m.Data {
 		results = append(results, v)
 	}
 	
-	// Simple slicing for mock pagination
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
 	if offset >= len(results) {
 		return []*domain.{{.Model.Name | title}}{}, nil
 	}
@@ -1319,6 +1681,11 @@ func (m *Mock{{.Model.Name | title}}Repository) List(ctx context.Context, limit,
 	return results[offset:end], nil
 }
 
+{{if .Cursor}}
+func (m *Mock{{.Model.Name | title}}Repository) Count(ctx context.Context, q domain.ListQuery) (int64, error) {
+	return int64(len(m.Data)), nil
+}
+{{end}}
 func (m *Mock{{.Model.Name | title}}Repository) Get(ctx context.Context, id string) (*domain.{{.Model.Name | title}}, error) {
 	if val, ok := m.Data[id]; ok {
 		return val, nil
@@ -1326,6 +1693,16 @@ func (m *Mock{{.Model.Name | title}}Repository) Get(ctx context.Context, id stri
 	return nil, nil
 }
 
+func (m *Mock{{.Model.Name | title}}Repository) GetMany(ctx context.Context, ids []string) ([]*domain.{{.Model.Name | title}}, error) {
+	var results []*domain.{{.Model.Name | title}}
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
 func (m *Mock{{.Model.Name | title}}Repository) Create(ctx context.Context, model *domain.{{.Model.Name | title}}) (string, error) {
 	id := "test-id"
 	model.ID = id
@@ -1345,11 +1722,19 @@ func (m *Mock{{.Model.Name | title}}Repository) Delete(ctx context.Context, id s
 	delete(m.Data, id)
 	return nil
 }
+{{range .Links}}
+func (m *Mock{{$.Model.Name | title}}Repository) Link{{.Relation | pascal}}(ctx context.Context, id, targetID string) error {
+	return nil
+}
 
+func (m *Mock{{$.Model.Name | title}}Repository) Unlink{{.Relation | pascal}}(ctx context.Context, id, targetID string) error {
+	return nil
+}
+{{end}}
 func Test{{.Model.Name | title}}Handler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	repo := &Mock{{.Model.Name | title}}Repository{Data: make(map[string]*domain.{{.Model.Name | title}})}
-	handler := New{{.Model.Name | title}}Handler(repo)
+	handler := New{{.Model.Name | title}}Handler(repo, expand.New())
 	r := gin.Default()
 
 	r.GET("/{{.Model.Name | lower}}", handler.List)
@@ -1361,7 +1746,13 @@ func Test{{.Model.Name | title}}Handler(t *testing.T) {
 		jsonBody, _ := json.Marshal(body)
 		req, _ := http.NewRequest("POST", "/{{.Model.Name | lower}}", bytes.NewBuffer(jsonBody))
 		r.ServeHTTP(w, req)
+		{{- if .Required}}
+		// An empty body misses the required fields
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), ` + "`" + `"rule":"required"` + "`" + `)
+		{{- else}}
 		assert.Equal(t, http.StatusCreated, w.Code)
+		{{- end}}
 	})
 
 	t.Run("List", func(t *testing.T) {
@@ -1370,17 +1761,48 @@ func Test{{.Model.Name | title}}Handler(t *testing.T) {
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/{{.Model.Name | lower}}?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+{{- if .Cursor}}
+	t.Run("InvalidCursor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/{{.Model.Name | lower}}?cursor=invalid", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+{{- end}}
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/{{.Model.Name | lower}}?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 `
+
+func generateModelHandlerTests(projectPath string, config *domain.Config, model domain.Model, fs domain.FileSystemPort, template domain.TemplatePort) error {
 	data := struct {
 		ProjectName string
 		Model       domain.Model
+		Links       []linkView
+		Cursor      bool
+		Required    bool
 	}{
 		ProjectName: config.ProjectName,
 		Model:       model,
+		Links:       manyToManyLinks(config, model),
+		Cursor:      config.CursorPagination(),
+		Required:    hasRequired(model),
 	}
 
-	content, err := template.Render(model.Name+"_test", testTemplate, data)
+	content, err := render(template, "handler_test", data)
 	if err != nil {
 		return err
 	}
@@ -1447,3 +1869,13 @@ The code uses **guard clauses** to keep the logic flat and readable, avoiding de
 `
 	return fs.WriteFile(filepath.Join(projectPath, "ARCHITECTURE.md"), []byte(content))
 }
+
+// sortedKeys keeps map-driven output stable between runs
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
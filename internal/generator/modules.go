@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/module"
+)
+
+// generateModules writes the files the modules of the blueprint contribute
+func generateModules(projectPath string, config *domain.Config, contributions []*module.Contribution, fs domain.FileSystemPort, template domain.TemplatePort) error {
+	for _, c := range contributions {
+		m, _ := module.Lookup(c.Module)
+		for _, file := range c.Files {
+			text, ok := m.Templates()[file.Template]
+			if !ok {
+				return fmt.Errorf("module %s: no template %s for %s", c.Module, file.Template, file.Path)
+			}
+			if _, builtIn := templates[file.Template]; builtIn {
+				return fmt.Errorf("module %s: template %s clashes with a generator template", c.Module, file.Template)
+			}
+			rel := path.Clean(file.Path)
+			if path.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, "../") {
+				return fmt.Errorf("module %s: %s is outside the project", c.Module, file.Path)
+			}
+
+			var data interface{} = config
+			if file.Data != nil {
+				data = file.Data
+			}
+			content, err := template.Render(file.Template, text, data)
+			if err != nil {
+				return err
+			}
+			target := filepath.Join(projectPath, filepath.FromSlash(rel))
+			if err := fs.MkdirAll(filepath.Dir(target)); err != nil {
+				return err
+			}
+			if err := fs.WriteFile(target, content); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// requirePath is the module path of a go.mod requirement, "path version"
+func requirePath(require string) string {
+	modulePath, _, _ := strings.Cut(require, " ")
+	return modulePath
+}
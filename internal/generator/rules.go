@@ -0,0 +1,222 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// bindingTags maps the fields of model with rules, or with a type that implies
+// some, to the binding struct tag gin validates on bind. Rules binding tags cannot express are left to Validate.
+func bindingTags(model domain.Model) map[string]string {
+	tags := make(map[string]string)
+	for name, fieldType := range model.Fields {
+		rules := model.Rules[name]
+		parts := typeBindings(fieldType)
+		if rules.Min != nil {
+			parts = append(parts, "min="+formatNumber(*rules.Min))
+		}
+		if rules.Max != nil {
+			parts = append(parts, "max="+formatNumber(*rules.Max))
+		}
+		if rules.MinLength != nil {
+			parts = append(parts, "min="+strconv.Itoa(*rules.MinLength))
+		}
+		if rules.MaxLength != nil {
+			parts = append(parts, "max="+strconv.Itoa(*rules.MaxLength))
+		}
+		if len(rules.Enum) > 0 {
+			parts = append(parts, "oneof="+strings.Join(rules.Enum, " "))
+		}
+		if rules.Email {
+			parts = append(parts, "email")
+		}
+		if rules.URL {
+			parts = append(parts, "url")
+		}
+
+		switch {
+		case rules.Required:
+			parts = append([]string{"required"}, parts...)
+		case len(parts) > 0:
+			// Optional fields are only checked when present
+			parts = append([]string{"omitempty"}, parts...)
+		default:
+			continue
+		}
+		tags[name] = fmt.Sprintf(` binding:"%s"`, strings.Join(parts, ","))
+	}
+	return tags
+}
+
+func hasRequired(model domain.Model) bool {
+	for _, rules := range model.Rules {
+		if rules.Required {
+			return true
+		}
+	}
+	return false
+}
+
+func isZero(value interface{}) bool {
+	return value == "" || value == 0.0 || value == false
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// defaultView assigns a field's default when the bound value is empty
+type defaultView struct {
+	Field   string
+	Empty   string // Go condition on m that is true when the field is empty
+	Value   string // Go expression of the default
+	Pointer string // Go type the field points to, if it is a pointer
+}
+
+func fieldDefaults(model domain.Model) []defaultView {
+	var defaults []defaultView
+	for _, name := range sortedRuleNames(model) {
+		rules := model.Rules[name]
+		if rules.Default == nil || isZero(rules.Default) {
+			// The field already holds a zero default
+			continue
+		}
+		field := "m." + pascal(name)
+		fieldType := model.Fields[name]
+
+		view := defaultView{Field: field, Empty: field + " == nil"}
+		switch goType(fieldType) {
+		case "string", "Decimal", "Date":
+			view.Empty = field + ` == ""`
+		case "int", "float64", "bool":
+			// A default other than the zero value makes the field a pointer
+			view.Pointer = goType(fieldType)
+		case "time.Time":
+			view.Empty = field + ".IsZero()"
+		}
+
+		switch value := rules.Default.(type) {
+		case string:
+			if fieldType == "datetime" {
+				view.Value = "time.Now()"
+			} else {
+				view.Value = strconv.Quote(value)
+			}
+		case float64:
+			switch goType(fieldType) {
+			case "int":
+				view.Value = strconv.Itoa(int(value))
+			case "Decimal":
+				view.Value = strconv.Quote(formatNumber(value))
+			default:
+				view.Value = formatNumber(value)
+			}
+		default:
+			view.Value = fmt.Sprintf("%v", value)
+		}
+		defaults = append(defaults, view)
+	}
+	return defaults
+}
+
+// patternView checks a field against its pattern, anchored to the whole value
+type patternView struct {
+	Name    string // JSON field name
+	Field   string // Go field of m
+	Var     string // package-level compiled regexp
+	Regexp  string // Go string literal of the anchored pattern
+	Message string // Go string literal of the error message
+	String  bool   // the Go field is a string rather than an interface{}
+}
+
+func fieldPatterns(model domain.Model) []patternView {
+	var patterns []patternView
+	for _, name := range sortedRuleNames(model) {
+		rules := model.Rules[name]
+		if rules.Pattern == "" {
+			continue
+		}
+		patterns = append(patterns, patternView{
+			Name:    name,
+			Field:   "m." + pascal(name),
+			Var:     lowerFirst(pascal(model.Name)) + pascal(name) + "Pattern",
+			Regexp:  strconv.Quote("^(?:" + rules.Pattern + ")$"),
+			Message: strconv.Quote("must match " + rules.Pattern),
+			String:  goType(model.Fields[name]) == "string",
+		})
+	}
+	return patterns
+}
+
+func sortedRuleNames(model domain.Model) []string {
+	names := make([]string, 0, len(model.Rules))
+	for name := range model.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sampleValue is a JSON value of a field that passes its rules, for the test
+// script; ok is false when no value can be made up (a pattern on an optional field)
+func sampleValue(name, fieldType string, rules domain.FieldRules) (value string, ok bool) {
+	switch {
+	case len(rules.Enum) > 0:
+		return strconv.Quote(rules.Enum[0]), true
+	case rules.Email:
+		return `"test@example.com"`, true
+	case rules.URL:
+		return `"https://example.com"`, true
+	case rules.Pattern != "" && !rules.Required:
+		return "", false
+	}
+
+	t := domain.ParseFieldType(fieldType)
+	info, _ := t.Info()
+	switch {
+	case info.Text:
+		s := "test_" + name
+		if rules.MinLength != nil {
+			for len(s) < *rules.MinLength {
+				s += "x"
+			}
+		}
+		if rules.MaxLength != nil && len(s) > *rules.MaxLength {
+			s = s[:*rules.MaxLength]
+		}
+		return strconv.Quote(s), true
+	case info.Numeric && (rules.Min != nil || rules.Max != nil):
+		n, _ := strconv.ParseFloat(info.Sample, 64)
+		if rules.Max != nil && n > *rules.Max {
+			n = *rules.Max
+		}
+		if rules.Min != nil && n < *rules.Min {
+			n = *rules.Min
+		}
+		return formatNumber(n), true
+	}
+	return t.Sample(), true
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// pascal matches the template function of the same name, which names the struct fields
+func pascal(s string) string {
+	parts := strings.Split(s, "_")
+	for i := range parts {
+		if parts[i] == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return strings.Join(parts, "")
+}
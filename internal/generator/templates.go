@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"sort"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/module"
+)
+
+// templates are the built-in templates of the generator by name. The name is
+// what the template engine receives, so a template set can replace any of
+// them with a <name>.tmpl file.
+var templates = map[string]string{
+	"docker-compose":   dockerComposeTemplate,
+	"dockerfile":       dockerfileTemplate,
+	"docs":             DocsTemplate,
+	"domain":           domainTemplate,
+	"expand":           ExpandTemplate,
+	"field_error":      FieldErrorTemplate,
+	"firestore_base":   firestoreTemplate,
+	"firestore_query":  FirestoreQueryTemplate,
+	"firestore_repo":   firestoreRepoTemplate,
+	"handler":          handlerTemplate,
+	"handler_test":     testTemplate,
+	"list_query":       ListQueryTemplate,
+	"main":             mainTemplate,
+	"makefile":         makefileTemplate,
+	"mongo_base":       MongoBaseTemplate,
+	"mongo_query":      MongoQueryTemplate,
+	"mongo_repo":       MongoRepoTemplate,
+	"postgres_base":    PostgresBaseTemplate,
+	"postgres_migrate": PostgresMigrateTemplate,
+	"postgres_query":   PostgresQueryTemplate,
+	"postgres_repo":    PostgresRepoTemplate,
+	"query_parser":     QueryParserTemplate,
+	"types":            TypesTemplate,
+	"validation":       ValidationTemplate,
+}
+
+// TemplateNames lists the templates the generator renders, those of the
+// registered modules included, sorted
+func TemplateNames() []string {
+	names := module.TemplateNames()
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultTemplate returns the built-in text of the named template, looking
+// up the templates of the registered modules too
+func DefaultTemplate(name string) (string, bool) {
+	if text, ok := templates[name]; ok {
+		return text, true
+	}
+	return module.Template(name)
+}
+
+// render executes the named template, whose built-in text the engine may replace
+func render(template domain.TemplatePort, name string, data interface{}) ([]byte, error) {
+	return template.Render(name, templates[name], data)
+}
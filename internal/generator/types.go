@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// goType is the Go type of a field in the generated domain package
+func goType(fieldType string) string {
+	return domain.ParseFieldType(fieldType).GoType()
+}
+
+// goTypes maps each field of model to its Go type, a pointer for the fields
+// whose zero value must be told apart from a missing one
+func goTypes(model domain.Model) map[string]string {
+	types := make(map[string]string, len(model.Fields))
+	for name, fieldType := range model.Fields {
+		types[name] = goType(fieldType)
+		if optionalZero(model, name) {
+			types[name] = "*" + types[name]
+		}
+	}
+	return types
+}
+
+// optionalZero reports whether field is a number or boolean that is required
+// or has a default other than its zero value: a 0 or false the request sends
+// is then a value, which only a nil pointer distinguishes from no value
+func optionalZero(model domain.Model, field string) bool {
+	switch goType(model.Fields[field]) {
+	case "int", "float64", "bool":
+		rules := model.Rules[field]
+		return rules.Required || rules.Default != nil && !isZero(rules.Default)
+	}
+	return false
+}
+
+// usesTime reports whether the domain struct of model needs the time package
+func usesTime(model domain.Model) bool {
+	for _, fieldType := range model.Fields {
+		if strings.HasSuffix(goType(fieldType), "time.Time") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeBindings are the binding tags implied by a field type rather than by its rules
+func typeBindings(fieldType string) []string {
+	t := domain.ParseFieldType(fieldType)
+	if t.Name == "enum" {
+		return []string{"oneof=" + strings.Join(t.Values, " ")}
+	}
+	if info, ok := t.Info(); ok && info.Binding != "" {
+		return []string{info.Binding}
+	}
+	return nil
+}
+
+// TypesTemplate generates internal/domain/types.go, the Go types of the field
+// types that have no exact builtin counterpart. Each one reads and writes
+// PostgreSQL columns through sql.Scanner and driver.Valuer.
+const TypesTemplate = `package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var decimalPattern = regexp.MustCompile(` + "`" + `^-?[0-9]+(\.[0-9]+)?$` + "`" + `)
+
+// Decimal is an exact decimal number such as "19.90", kept as text so amounts
+// never go through a float. JSON accepts it as a number or a string and
+// writes it as a string.
+type Decimal string
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = ""
+		return nil
+	}
+	s := strings.Trim(string(data), ` + "`" + `"` + "`" + `)
+	if !decimalPattern.MatchString(s) {
+		return fmt.Errorf("invalid decimal %s", data)
+	}
+	*d = Decimal(s)
+	return nil
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if d == "" {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Quote(string(d))), nil
+}
+
+func (d *Decimal) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = ""
+	case string:
+		*d = Decimal(v)
+	case []byte:
+		*d = Decimal(v)
+	case float64:
+		*d = Decimal(strconv.FormatFloat(v, 'f', -1, 64))
+	case int64:
+		*d = Decimal(strconv.FormatInt(v, 10))
+	default:
+		return fmt.Errorf("cannot scan %T into Decimal", src)
+	}
+	return nil
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	if d == "" {
+		return nil, nil
+	}
+	return string(d), nil
+}
+
+// DateLayout is the format of Date values
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date such as "2024-03-31", without time or time zone
+type Date string
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid date %s", data)
+	}
+	if s == nil {
+		*d = ""
+		return nil
+	}
+	if _, err := time.Parse(DateLayout, *s); err != nil {
+		return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", *s)
+	}
+	*d = Date(*s)
+	return nil
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(d))
+}
+
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = ""
+	case time.Time:
+		*d = Date(v.Format(DateLayout))
+	case string:
+		*d = Date(v)
+	case []byte:
+		*d = Date(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Date", src)
+	}
+	return nil
+}
+
+func (d Date) Value() (driver.Value, error) {
+	if d == "" {
+		return nil, nil
+	}
+	return string(d), nil
+}
+
+// GeoPoint is a location in degrees
+type GeoPoint struct {
+	Lat float64 ` + "`" + `json:"lat" bson:"lat" firestore:"lat"` + "`" + `
+	Lng float64 ` + "`" + `json:"lng" bson:"lng" firestore:"lng"` + "`" + `
+}
+
+// Scan reads a PostgreSQL POINT, written "(lng,lat)"
+func (p *GeoPoint) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into GeoPoint", src)
+	}
+	if _, err := fmt.Sscanf(s, "(%g,%g)", &p.Lng, &p.Lat); err != nil {
+		return fmt.Errorf("invalid point %q: %w", s, err)
+	}
+	return nil
+}
+
+func (p GeoPoint) Value() (driver.Value, error) {
+	return fmt.Sprintf("(%s,%s)", strconv.FormatFloat(p.Lng, 'f', -1, 64), strconv.FormatFloat(p.Lat, 'f', -1, 64)), nil
+}
+`
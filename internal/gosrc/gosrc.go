@@ -0,0 +1,211 @@
+// Package gosrc tidies generated Go source: it drops the imports a file doesn't
+// use and formats it like gofmt.
+package gosrc
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Format removes the unused imports of src, groups the imports like goimports
+// -local module (standard library, third-party, then the packages of module)
+// and formats it. filename only prefixes the positions of syntax errors.
+func Format(filename string, src []byte, module string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	pruneImports(file)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return format.Source(groupImports(buf.Bytes(), module))
+}
+
+// groupImports rewrites the first parenthesized import block of formatted
+// source into its three groups, dropping the blank lines in between. Comments
+// after the last import stay at the end; a block with comments among the
+// imports is left alone, since they may document the import below them.
+func groupImports(src []byte, module string) []byte {
+	lines := strings.SplitAfter(string(src), "\n")
+	start := -1
+	for i, line := range lines {
+		if line == "import (\n" {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return src
+	}
+
+	var groups [3][]string
+	var trailing []string
+	end := -1
+	for i := start + 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == ")":
+			end = i
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "//"):
+			trailing = append(trailing, lines[i])
+			continue
+		case len(trailing) > 0:
+			return src
+		default:
+			groups[importGroup(line, module)] = append(groups[importGroup(line, module)], lines[i])
+			continue
+		}
+		break
+	}
+	if end < 0 {
+		return src
+	}
+
+	var block []string
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		if len(block) > 0 {
+			block = append(block, "\n")
+		}
+		sort.SliceStable(group, func(i, j int) bool { return importPath(group[i]) < importPath(group[j]) })
+		block = append(block, group...)
+	}
+	block = append(block, trailing...)
+
+	out := append(append(append([]string{}, lines[:start+1]...), block...), lines[end:]...)
+	return []byte(strings.Join(out, ""))
+}
+
+// importGroup sorts an import line into the standard library (0), third-party
+// packages (1) or the packages of module (2)
+func importGroup(line, module string) int {
+	path := importPath(line)
+	switch {
+	case module != "" && (path == module || strings.HasPrefix(path, module+"/")):
+		return 2
+	case !strings.Contains(strings.SplitN(path, "/", 2)[0], "."):
+		return 0
+	}
+	return 1
+}
+
+// importPath is the path of an import line, with or without a name
+func importPath(line string) string {
+	quoted := line[strings.Index(line, `"`):]
+	if path, err := strconv.Unquote(strings.Fields(quoted)[0]); err == nil {
+		return path
+	}
+	return quoted
+}
+
+// pruneImports deletes the imports whose name no selector in file refers to.
+// Blank and dot imports are kept.
+func pruneImports(file *ast.File) {
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			// Package names are the identifiers the parser leaves unresolved
+			if x, ok := sel.X.(*ast.Ident); ok && x.Obj == nil {
+				used[x.Name] = true
+			}
+		}
+		return true
+	})
+
+	unused := make(map[*ast.ImportSpec]bool)
+	for _, spec := range file.Imports {
+		if name := importName(spec); name != "" && !used[name] {
+			unused[spec] = true
+		}
+	}
+	if len(unused) == 0 {
+		return
+	}
+
+	imports := file.Imports[:0]
+	for _, spec := range file.Imports {
+		if !unused[spec] {
+			imports = append(imports, spec)
+		}
+	}
+	file.Imports = imports
+
+	decls := file.Decls[:0]
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			decls = append(decls, decl)
+			continue
+		}
+		specs := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			if !unused[spec.(*ast.ImportSpec)] {
+				specs = append(specs, spec)
+			}
+		}
+		gen.Specs = specs
+		if len(specs) > 0 {
+			decls = append(decls, gen)
+		}
+	}
+	file.Decls = decls
+}
+
+// importName is the name a file refers to an import by, empty for blank and dot imports
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		if spec.Name.Name == "_" || spec.Name.Name == "." {
+			return ""
+		}
+		return spec.Name.Name
+	}
+	importPath, _ := strconv.Unquote(spec.Path.Value)
+	return PackageName(importPath)
+}
+
+// packageNames lists the packages imported by the templates whose name can't
+// be told from their path
+var packageNames = map[string]string{
+	"firebase.google.com/go/v4": "firebase",
+}
+
+// PackageName guesses the name of a package from its import path the way
+// goimports does: "github.com/stripe/stripe-go/v76" declares stripe
+func PackageName(importPath string) string {
+	if name, ok := packageNames[importPath]; ok {
+		return name
+	}
+	elems := strings.Split(importPath, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && isMajorVersion(name) {
+		name = elems[len(elems)-2]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func isMajorVersion(elem string) bool {
+	digits := strings.TrimPrefix(elem, "v")
+	_, err := strconv.Atoi(digits)
+	return digits != elem && err == nil
+}
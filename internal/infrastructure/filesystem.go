@@ -38,3 +38,8 @@ func (fs *OSFileSystem) Chmod(path string, mode uint32) error {
 func (fs *OSFileSystem) RemoveAll(path string) error {
 	return os.RemoveAll(path)
 }
+
+func (fs *OSFileSystem) Exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
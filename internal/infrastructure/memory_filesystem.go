@@ -0,0 +1,210 @@
+package infrastructure
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// OperationKind identifies what a planned filesystem operation does
+type OperationKind string
+
+const (
+	OpMkdir  OperationKind = "dir"
+	OpWrite  OperationKind = "file"
+	OpChmod  OperationKind = "chmod"
+	OpRemove OperationKind = "remove"
+)
+
+// OperationStatus compares a planned operation against what is already on disk
+type OperationStatus string
+
+const (
+	StatusNew       OperationStatus = "new"
+	StatusOverwrite OperationStatus = "overwrite"
+	StatusUnchanged OperationStatus = "unchanged"
+	StatusExists    OperationStatus = "exists"
+)
+
+// Operation is a single entry of the plan recorded by MemoryFileSystem
+type Operation struct {
+	Kind   OperationKind
+	Path   string
+	Size   int
+	Mode   uint32
+	Status OperationStatus
+}
+
+// MemoryFileSystem implements domain.FileSystemPort without touching disk.
+// Reads fall through to base (if any) so generation can still load the blueprint
+// and optional files, and so the plan can tell new files from overwrites.
+type MemoryFileSystem struct {
+	base    domain.FileSystemPort
+	files   map[string][]byte
+	dirs    map[string]bool
+	modes   map[string]uint32
+	removed map[string]bool
+}
+
+func NewMemoryFileSystem(base domain.FileSystemPort) *MemoryFileSystem {
+	return &MemoryFileSystem{
+		base:    base,
+		files:   make(map[string][]byte),
+		dirs:    make(map[string]bool),
+		modes:   make(map[string]uint32),
+		removed: make(map[string]bool),
+	}
+}
+
+func (fs *MemoryFileSystem) MkdirAll(path string) error {
+	fs.dirs[filepath.Clean(path)] = true
+	return nil
+}
+
+func (fs *MemoryFileSystem) WriteFile(path string, data []byte) error {
+	path = filepath.Clean(path)
+	fs.files[path] = append([]byte(nil), data...)
+	delete(fs.removed, path)
+	return nil
+}
+
+func (fs *MemoryFileSystem) ReadFile(path string) ([]byte, error) {
+	path = filepath.Clean(path)
+	if data, ok := fs.files[path]; ok {
+		return append([]byte(nil), data...), nil
+	}
+	if fs.base == nil || fs.isRemoved(path) {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+	return fs.base.ReadFile(path)
+}
+
+func (fs *MemoryFileSystem) CopyFile(src, dst string) error {
+	data, err := fs.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(dst, data)
+}
+
+func (fs *MemoryFileSystem) Chmod(path string, mode uint32) error {
+	path = filepath.Clean(path)
+	if !fs.Exists(path) {
+		return &os.PathError{Op: "chmod", Path: path, Err: os.ErrNotExist}
+	}
+	fs.modes[path] = mode
+	return nil
+}
+
+func (fs *MemoryFileSystem) RemoveAll(path string) error {
+	path = filepath.Clean(path)
+	fs.removed[path] = true
+	for p := range fs.files {
+		if isWithin(p, path) {
+			delete(fs.files, p)
+		}
+	}
+	for p := range fs.dirs {
+		if isWithin(p, path) {
+			delete(fs.dirs, p)
+		}
+	}
+	return nil
+}
+
+func (fs *MemoryFileSystem) Exists(path string) bool {
+	path = filepath.Clean(path)
+	if _, ok := fs.files[path]; ok {
+		return true
+	}
+	if fs.dirs[path] {
+		return true
+	}
+	return fs.base != nil && !fs.isRemoved(path) && fs.base.Exists(path)
+}
+
+// Files returns the paths of every file written so far, sorted
+func (fs *MemoryFileSystem) Files() []string {
+	paths := make([]string, 0, len(fs.files))
+	for p := range fs.files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Mode returns the permissions requested through Chmod for path, if any
+func (fs *MemoryFileSystem) Mode(path string) (uint32, bool) {
+	mode, ok := fs.modes[filepath.Clean(path)]
+	return mode, ok
+}
+
+// Plan lists every recorded operation sorted by path, comparing each one with
+// the base filesystem as it was before the run: a file written again after
+// RemoveAll replaces the one on disk, so it is an overwrite, not a new file
+func (fs *MemoryFileSystem) Plan() []Operation {
+	var ops []Operation
+
+	for p := range fs.removed {
+		if fs.existedBefore(p) {
+			ops = append(ops, Operation{Kind: OpRemove, Path: p, Status: StatusExists})
+		}
+	}
+	for p := range fs.dirs {
+		status := StatusNew
+		if fs.existedBefore(p) {
+			status = StatusExists
+		}
+		ops = append(ops, Operation{Kind: OpMkdir, Path: p, Status: status})
+	}
+	for p, data := range fs.files {
+		ops = append(ops, Operation{Kind: OpWrite, Path: p, Size: len(data), Status: fs.fileStatus(p, data)})
+	}
+	for p, mode := range fs.modes {
+		ops = append(ops, Operation{Kind: OpChmod, Path: p, Mode: mode})
+	}
+
+	order := map[OperationKind]int{OpRemove: 0, OpMkdir: 1, OpWrite: 2, OpChmod: 3}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return order[ops[i].Kind] < order[ops[j].Kind]
+	})
+	return ops
+}
+
+func (fs *MemoryFileSystem) fileStatus(path string, data []byte) OperationStatus {
+	if !fs.existedBefore(path) {
+		return StatusNew
+	}
+	existing, err := fs.base.ReadFile(path)
+	if err == nil && bytes.Equal(existing, data) {
+		return StatusUnchanged
+	}
+	return StatusOverwrite
+}
+
+// existedBefore reports whether path was on the base filesystem before the
+// run, removed since or not; nothing is ever written to base
+func (fs *MemoryFileSystem) existedBefore(path string) bool {
+	return fs.base != nil && fs.base.Exists(path)
+}
+
+// isRemoved reports whether path lies under a directory removed through RemoveAll
+func (fs *MemoryFileSystem) isRemoved(path string) bool {
+	for p := range fs.removed {
+		if isWithin(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isWithin(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
@@ -0,0 +1,55 @@
+package infrastructure
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/tabwriter"
+)
+
+// WritePlan renders a dry-run plan as aligned tables, with paths relative to
+// outputDir, followed by a count of the operations. The operations on paths
+// isState accepts, bookkeeping rather than project files, are listed apart and
+// left out of the count; isState may be nil.
+func WritePlan(w io.Writer, outputDir string, ops []Operation, isState func(path string) bool) {
+	var project, state []Operation
+	for _, op := range ops {
+		if isState != nil && isState(op.Path) {
+			state = append(state, op)
+		} else {
+			project = append(project, op)
+		}
+	}
+
+	writeOperations(w, outputDir, project)
+	if len(state) > 0 {
+		fmt.Fprintf(w, "\nRegeneration state:\n")
+		writeOperations(w, outputDir, state)
+	}
+
+	counts := make(map[OperationKind]int)
+	for _, op := range project {
+		counts[op.Kind]++
+	}
+	fmt.Fprintf(w, "\nDry run: %d file(s), %d director(ies), %d chmod(s), %d removal(s); nothing was written.\n",
+		counts[OpWrite], counts[OpMkdir], counts[OpChmod], counts[OpRemove])
+}
+
+func writeOperations(w io.Writer, outputDir string, ops []Operation) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, op := range ops {
+		path := op.Path
+		if rel, err := filepath.Rel(outputDir, op.Path); err == nil {
+			path = rel
+		}
+		switch op.Kind {
+		case OpWrite:
+			fmt.Fprintf(tw, "%s\t%s\t%d B\t%s\n", op.Kind, path, op.Size, op.Status)
+		case OpChmod:
+			fmt.Fprintf(tw, "%s\t%s\t%04o\t\n", op.Kind, path, op.Mode)
+		default:
+			fmt.Fprintf(tw, "%s\t%s\t\t%s\n", op.Kind, path, op.Status)
+		}
+	}
+	tw.Flush()
+}
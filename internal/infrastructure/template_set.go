@@ -0,0 +1,47 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// TemplateExt is the extension of the files of a template set
+const TemplateExt = ".tmpl"
+
+// TemplateSet implements domain.TemplatePort on top of another engine,
+// rendering <dir>/<name>.tmpl instead of the built-in text of a template when
+// the file exists
+type TemplateSet struct {
+	engine domain.TemplatePort
+	fs     domain.FileSystemPort
+	dir    string
+	used   map[string]bool
+}
+
+func NewTemplateSet(engine domain.TemplatePort, fs domain.FileSystemPort, dir string) *TemplateSet {
+	return &TemplateSet{engine: engine, fs: fs, dir: dir, used: make(map[string]bool)}
+}
+
+func (t *TemplateSet) Render(name, tmpl string, data interface{}) ([]byte, error) {
+	path := filepath.Join(t.dir, name+TemplateExt)
+	if !t.fs.Exists(path) {
+		return t.engine.Render(name, tmpl, data)
+	}
+
+	override, err := t.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	if !t.used[name] {
+		t.used[name] = true
+		log.Printf("Using template %s", path)
+	}
+	content, err := t.engine.Render(name, string(override), data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,260 @@
+// Package jsonschema derives the JSON Schema of the blueprint format from the
+// domain types, so editors can complete and check blueprint.json and
+// blueprint.yaml files.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/module"
+)
+
+// Draft is the JSON Schema dialect of the generated schema
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// descriptions documents the properties, by Go type and json name
+var descriptions = map[string]string{
+	"Config.$schema":                   "JSON Schema the file is checked against by editors; ignored by the generator.",
+	"Config.project_name":              "Name of the project, used for the Go module and the root folder.",
+	"Config.database":                  "Database the generated repositories use. Defaults to Firestore.",
+	"Config.firestore_project_id":      "Deprecated: use database.project_id.",
+	"Config.auth":                      "Deprecated: use modules.auth.",
+	"Config.payments":                  "Deprecated: use modules.payments.",
+	"Config.pagination":                "Defaults of the generated list endpoints.",
+	"Config.models":                    "Resources of the API; each gets a table or collection, a repository and CRUD handlers.",
+	"Config.include":                   "Blueprint files, relative to this one, whose models are merged in.",
+	"Config.modules":                   "Generator modules to add, by name, with their settings.",
+	"Database.type":                    "Database driver.",
+	"Database.project_id":              "Firebase project ID, for Firestore.",
+	"Database.url":                     "Connection URL, for PostgreSQL and MongoDB.",
+	"Auth.enabled":                     "Adds the auth module.",
+	"Auth.provider":                    "Firebase ID tokens (default) or JWTs signed by the API.",
+	"Auth.user_collection":             "Model holding the users, \"users\" by default.",
+	"Payments.enabled":                 "Adds the payments module.",
+	"Payments.provider":                "Payment provider.",
+	"Payments.transactions_collection": "Model recording the payments, \"transactions\" by default.",
+	"Pagination.default_limit":         "Page size when the client doesn't send limit.",
+	"Pagination.mode":                  "offset (default) or cursor pagination.",
+	"Model.name":                       "Model name: the table or collection and the route segment.",
+	"Model.protected":                  "Require authentication on every route of the model.",
+	"Model.fields":                     "Field name to type, e.g. \"string\", \"enum(draft,active)\", \"array<string>\", or to an object with the type and its validation rules.",
+	"Model.relations":                  "Relation name to \"<kind>:<model>\"; belongsTo accepts an on-delete action as a third part.",
+	"FieldRules.type":                  "Field type.",
+	"FieldRules.required":              "Reject requests without the field.",
+	"FieldRules.min":                   "Smallest accepted number.",
+	"FieldRules.max":                   "Largest accepted number.",
+	"FieldRules.min_length":            "Shortest accepted string.",
+	"FieldRules.max_length":            "Longest accepted string.",
+	"FieldRules.pattern":               "Go regular expression the whole value must match.",
+	"FieldRules.enum":                  "Accepted values of a string field.",
+	"FieldRules.email":                 "The value must be an email address.",
+	"FieldRules.url":                   "The value must be an absolute URL.",
+	"FieldRules.unique":                "No two records may share the value.",
+	"FieldRules.default":               "Value applied on create when the field is empty; \"now\" for datetime fields.",
+}
+
+// enums lists the accepted values of string properties, by Go type and json name
+var enums = map[string][]string{
+	"Database.type":     domain.DatabaseTypes,
+	"Auth.provider":     domain.AuthProviders,
+	"Payments.provider": domain.PaymentProviders,
+	"Pagination.mode":   domain.PaginationModes,
+}
+
+// deprecated marks the properties kept only for older blueprints
+var deprecated = map[string]bool{"Config.firestore_project_id": true}
+
+// required lists the properties that must be present, by Go type
+var required = map[string][]string{
+	"Config":     {"project_name"},
+	"Model":      {"name"},
+	"FieldRules": {"type"},
+}
+
+// Blueprint returns the JSON Schema of a blueprint configuration
+func Blueprint() map[string]interface{} {
+	g := &generator{defs: make(map[string]interface{})}
+	root := g.object(reflect.TypeOf(domain.Config{}))
+	root["$schema"] = Draft
+	root["title"] = "Blueprint"
+	root["description"] = "Configuration of an API generated by blueprint: its database, auth, payments and models."
+	root["$defs"] = g.defs
+	return root
+}
+
+// BlueprintJSON is Blueprint encoded as indented JSON
+func BlueprintJSON() []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(Blueprint()); err != nil {
+		// Only maps, slices and strings are encoded
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+type generator struct {
+	defs map[string]interface{}
+}
+
+// object describes a struct through its json tags
+func (g *generator) object(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		key := t.Name() + "." + name
+
+		var property map[string]interface{}
+		switch key {
+		case "Config.modules":
+			property = modules()
+		case "Model.fields":
+			property = g.fields()
+		case "Model.relations":
+			property = map[string]interface{}{"type": "object", "additionalProperties": relationSchema()}
+		case "FieldRules.type":
+			property = fieldTypeSchema()
+		case "FieldRules.default":
+			property = map[string]interface{}{"type": []string{"string", "number", "boolean"}}
+		default:
+			property = g.schema(f.Type)
+		}
+		if values, ok := enums[key]; ok {
+			property["enum"] = values
+		}
+		if description, ok := descriptions[key]; ok {
+			property["description"] = description
+		}
+		if deprecated[key] {
+			property["deprecated"] = true
+		}
+		properties[name] = property
+	}
+
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if names := required[t.Name()]; len(names) > 0 {
+		schema["required"] = names
+	}
+	return schema
+}
+
+// schema describes a Go type, referencing structs from $defs
+func (g *generator) schema(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schema(t.Elem())
+	case reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // Reserved, for types that refer to themselves
+			g.defs[t.Name()] = g.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Int32:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float64, reflect.Float32:
+		return map[string]interface{}{"type": "number"}
+	}
+	return map[string]interface{}{}
+}
+
+// modules describes Config.modules: the registered modules, each with the
+// schema of its settings
+func modules() map[string]interface{} {
+	properties := make(map[string]interface{})
+	for _, name := range module.Names() {
+		m, _ := module.Lookup(name)
+		properties[name] = m.Schema()
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// fields describes Model.fields, where each field is a type name or FieldRules
+func (g *generator) fields() map[string]interface{} {
+	rules := g.schema(reflect.TypeOf(domain.FieldRules{}))
+	return map[string]interface{}{
+		"type": "object",
+		"propertyNames": map[string]interface{}{
+			"pattern": "^[A-Za-z][A-Za-z0-9_]*$",
+		},
+		"additionalProperties": map[string]interface{}{
+			"anyOf": []interface{}{fieldTypeSchema(), rules},
+		},
+	}
+}
+
+// fieldTypeSchema accepts the registered field types and their aliases, plus
+// the parameterized enum(...) and array<...> forms
+func fieldTypeSchema() map[string]interface{} {
+	var names, elems []string
+	for _, t := range domain.FieldTypes() {
+		if t.Name == "enum" || t.Name == "array" {
+			continue
+		}
+		names = append(names, t.Name)
+		names = append(names, t.Aliases...)
+	}
+	for _, name := range domain.ArrayElemTypes() {
+		info, _ := domain.LookupFieldType(name)
+		elems = append(elems, name)
+		elems = append(elems, info.Aliases...)
+	}
+	sort.Strings(elems)
+	return map[string]interface{}{
+		"type": "string",
+		"anyOf": []interface{}{
+			map[string]interface{}{"enum": names},
+			map[string]interface{}{"pattern": `^enum\([^\s()]+\)$`},
+			map[string]interface{}{"pattern": "^array<(" + alternatives(elems) + ")>$"},
+		},
+	}
+}
+
+// relationSchema accepts "<kind>:<model>" and "belongsTo:<model>:<on_delete>"
+func relationSchema() map[string]interface{} {
+	actions := make([]string, 0, len(domain.OnDeleteActions))
+	for action := range domain.OnDeleteActions {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return map[string]interface{}{
+		"type": "string",
+		"pattern": "^((" + alternatives(domain.RelationKinds) + "):[A-Za-z][A-Za-z0-9_]*|" +
+			domain.BelongsTo + ":[A-Za-z][A-Za-z0-9_]*:(" + alternatives(actions) + "))$",
+	}
+}
+
+func alternatives(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = regexp.QuoteMeta(v)
+	}
+	return strings.Join(quoted, "|")
+}
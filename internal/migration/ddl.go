@@ -0,0 +1,681 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// Dump is a schema read back from DDL, with what the generated Schema has no
+// place for
+type Dump struct {
+	Schema
+	File   string
+	Enums  map[string][]string // values of each CREATE TYPE ... AS ENUM
+	Checks map[string]int      // CHECK constraints per table, which are not imported
+	Lines  map[string]int      // line of each "table" and "table.column" definition
+}
+
+// ParseDDL reads the tables, columns, keys, indexes and enum types of a
+// PostgreSQL schema, as written by pg_dump --schema-only. Statements that
+// declare something a blueprint cannot hold, such as views or functions, are
+// reported as warnings; settings, grants, comments and sequences are ignored.
+func ParseDDL(file string, data []byte) (*Dump, []domain.Diagnostic, error) {
+	statements, err := splitStatements(string(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	p := &ddlParser{dump: &Dump{
+		File:   file,
+		Enums:  make(map[string][]string),
+		Checks: make(map[string]int),
+		Lines:  make(map[string]int),
+	}}
+	for _, stmt := range statements {
+		p.stmt, p.pos = stmt, 0
+		if err := p.statement(); err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: %w", file, stmt[0].line, err)
+		}
+	}
+	return p.dump, p.notes, nil
+}
+
+type tokenKind int
+
+const (
+	tokWord   tokenKind = iota // keyword or unquoted identifier, lowercased
+	tokIdent                   // "quoted identifier"
+	tokString                  // 'literal', E'literal' or $$literal$$
+	tokNumber
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+// splitStatements tokenizes SQL and splits it at the semicolons ending each statement
+func splitStatements(sql string) ([][]token, error) {
+	var statements [][]token
+	var current []token
+	line := 1
+	i := 0
+	emit := func(kind tokenKind, text string, at int) {
+		current = append(current, token{kind: kind, text: text, line: at})
+	}
+
+	for i < len(sql) {
+		c := sql[i]
+		start := line
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(sql[i:], "--"), c == '\\' && (i == 0 || sql[i-1] == '\n'):
+			// Comments and psql meta-commands such as \connect run to the end of the line
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(sql[i:i+2+end], "\n")
+			i += end + 4
+		case c == '\'' || (c == 'E' || c == 'e') && i+1 < len(sql) && sql[i+1] == '\'':
+			escapes := c != '\''
+			if escapes {
+				i++
+			}
+			text, n, err := quoted(sql[i:], '\'', escapes)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			line += strings.Count(sql[i:i+n], "\n")
+			i += n
+			emit(tokString, text, start)
+		case c == '"':
+			text, n, err := quoted(sql[i:], '"', false)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			i += n
+			emit(tokIdent, text, start)
+		case c == '$' && dollarTag(sql[i:]) != "":
+			tag := dollarTag(sql[i:])
+			end := strings.Index(sql[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated %s quote", line, tag)
+			}
+			body := sql[i+len(tag) : i+len(tag)+end]
+			line += strings.Count(body, "\n")
+			i += 2*len(tag) + end
+			emit(tokString, body, start)
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(sql) && sql[i+1] >= '0' && sql[i+1] <= '9':
+			j := i
+			for j < len(sql) && (sql[j] >= '0' && sql[j] <= '9' || sql[j] == '.' || sql[j] == 'e' || sql[j] == 'E') {
+				j++
+			}
+			emit(tokNumber, sql[i:j], start)
+			i = j
+		case c == '_' || unicode.IsLetter(rune(c)) || c >= 0x80:
+			j := i
+			for j < len(sql) && (sql[j] == '_' || sql[j] == '$' || sql[j] >= 0x80 || unicode.IsLetter(rune(sql[j])) || unicode.IsDigit(rune(sql[j]))) {
+				j++
+			}
+			emit(tokWord, strings.ToLower(sql[i:j]), start)
+			i = j
+		case c == ';':
+			if len(current) > 0 {
+				statements = append(statements, current)
+				current = nil
+			}
+			i++
+		case strings.HasPrefix(sql[i:], "::"):
+			emit(tokPunct, "::", start)
+			i += 2
+		default:
+			emit(tokPunct, string(c), start)
+			i++
+		}
+	}
+	if len(current) > 0 {
+		statements = append(statements, current)
+	}
+	return statements, nil
+}
+
+// quoted reads a literal delimited by quote, where a doubled quote stands for
+// itself, and returns its text and length
+func quoted(s string, quote byte, escapes bool) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch {
+		case escapes && s[i] == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(s[i])
+			}
+		case s[i] == quote && i+1 < len(s) && s[i+1] == quote:
+			b.WriteByte(quote)
+			i++
+		case s[i] == quote:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated %c quote", quote)
+}
+
+// dollarTag returns the $tag$ s starts with, or ""
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '$':
+			return s[:i+1]
+		case c != '_' && !unicode.IsLetter(rune(c)) && !unicode.IsDigit(rune(c)):
+			return ""
+		}
+	}
+	return ""
+}
+
+type ddlParser struct {
+	dump  *Dump
+	notes []domain.Diagnostic
+	stmt  []token
+	pos   int
+}
+
+func (p *ddlParser) warnf(line int, format string, args ...interface{}) {
+	p.notes = append(p.notes, domain.Diagnostic{
+		Pos:      domain.Position{File: p.dump.File, Line: line, Column: 1},
+		Severity: domain.SeverityWarning,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (p *ddlParser) peek() token {
+	if p.pos < len(p.stmt) {
+		return p.stmt[p.pos]
+	}
+	return token{kind: tokPunct}
+}
+
+// is reports whether the next tokens are the given keywords or punctuation
+func (p *ddlParser) is(words ...string) bool {
+	for k, w := range words {
+		if p.pos+k >= len(p.stmt) {
+			return false
+		}
+		t := p.stmt[p.pos+k]
+		if t.kind != tokWord && t.kind != tokPunct || t.text != w {
+			return false
+		}
+	}
+	return true
+}
+
+// accept consumes the given tokens if they come next
+func (p *ddlParser) accept(words ...string) bool {
+	if !p.is(words...) {
+		return false
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *ddlParser) expect(words ...string) error {
+	if !p.accept(words...) {
+		return fmt.Errorf("expected %q, found %q", strings.Join(words, " "), p.peek().text)
+	}
+	return nil
+}
+
+// name reads an identifier, keeping the last part of a qualified name such as public.users
+func (p *ddlParser) name() (string, error) {
+	var name string
+	for {
+		t := p.peek()
+		if t.kind != tokWord && t.kind != tokIdent {
+			return "", fmt.Errorf("expected a name, found %q", t.text)
+		}
+		p.pos++
+		name = t.text
+		if !p.accept(".") {
+			return name, nil
+		}
+	}
+}
+
+// names reads a parenthesized list of column names
+func (p *ddlParser) names() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if p.accept(")") {
+			return names, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// until reads tokens up to a comma or closing parenthesis at depth 0, or one of
+// the stop keywords, and writes them back as SQL
+func (p *ddlParser) until(stop ...string) string {
+	var b strings.Builder
+	depth := 0
+	prevWord := false
+	for p.pos < len(p.stmt) {
+		t := p.peek()
+		if depth == 0 && (t.kind == tokPunct && (t.text == "," || t.text == ")") || t.kind == tokWord && containsString(stop, t.text)) {
+			break
+		}
+		switch {
+		case t.text == "(" && t.kind == tokPunct:
+			depth++
+		case t.text == ")" && t.kind == tokPunct:
+			depth--
+		}
+
+		word := t.kind != tokPunct
+		if word && prevWord {
+			b.WriteByte(' ')
+		}
+		switch t.kind {
+		case tokString:
+			b.WriteString("'" + strings.ReplaceAll(t.text, "'", "''") + "'")
+		case tokIdent:
+			b.WriteString(`"` + t.text + `"`)
+		default:
+			b.WriteString(t.text)
+		}
+		prevWord = word
+		p.pos++
+	}
+	return b.String()
+}
+
+func (p *ddlParser) statement() error {
+	line := p.peek().line
+	switch {
+	case p.accept("create", "table"), p.accept("create", "unlogged", "table"):
+		return p.createTable(line)
+	case p.accept("alter", "table"):
+		return p.alterTable()
+	case p.accept("create", "index"):
+		return p.createIndex(false)
+	case p.accept("create", "unique", "index"):
+		return p.createIndex(true)
+	case p.accept("create", "type"):
+		return p.createType()
+	case p.is("create", "view"), p.is("create", "or", "replace", "view"), p.is("create", "materialized", "view"):
+		p.warnf(line, "views are not imported")
+	case p.is("create", "function"), p.is("create", "or", "replace", "function"), p.is("create", "procedure"), p.is("create", "or", "replace", "procedure"):
+		p.warnf(line, "functions and procedures are not imported")
+	case p.is("create", "trigger"), p.is("create", "constraint", "trigger"), p.is("create", "rule"), p.is("create", "policy"):
+		p.warnf(line, "%s %s statements are not imported", strings.ToUpper(p.stmt[0].text), strings.ToUpper(p.stmt[1].text))
+	case p.is("create", "domain"):
+		p.warnf(line, "domains are not imported, columns using them become strings")
+	}
+	return nil
+}
+
+func (p *ddlParser) createTable(line int) error {
+	p.accept("if", "not", "exists")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if p.is("partition", "of") || p.is("of") {
+		p.warnf(line, "table %s is a partition or typed table and is not imported", name)
+		return nil
+	}
+	table := Table{Name: name}
+	p.dump.Lines[name] = line
+
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for !p.accept(")") {
+		if err := p.tableElement(&table); err != nil {
+			return err
+		}
+		if !p.accept(",") && !p.is(")") {
+			return fmt.Errorf("expected ',' or ')' in table %s, found %q", name, p.peek().text)
+		}
+	}
+	if p.is("inherits") {
+		p.warnf(line, "inheritance of table %s is not imported", name)
+	}
+	p.dump.Tables = append(p.dump.Tables, table)
+	return nil
+}
+
+func (p *ddlParser) tableElement(table *Table) error {
+	if p.is("constraint") || p.is("primary") || p.is("unique") || p.is("foreign") || p.is("check") || p.is("exclude") {
+		return p.tableConstraint(table)
+	}
+	if p.is("like") {
+		p.warnf(p.peek().line, "LIKE in table %s is not imported", table.Name)
+		p.until()
+		return nil
+	}
+	return p.columnDefinition(table)
+}
+
+var columnConstraints = []string{"constraint", "not", "null", "default", "primary", "unique", "references", "check", "collate", "generated"}
+
+func (p *ddlParser) columnDefinition(table *Table) error {
+	line := p.peek().line
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	column := Column{Name: name, Type: p.until(columnConstraints...)}
+	p.dump.Lines[table.Name+"."+name] = line
+
+	for !p.is(",") && !p.is(")") && p.pos < len(p.stmt) {
+		switch {
+		case p.accept("constraint"):
+			if _, err := p.name(); err != nil {
+				return err
+			}
+		case p.accept("not", "null"):
+			column.NotNull = true
+		case p.accept("null"):
+		case p.accept("default"):
+			column.Default = p.until(columnConstraints...)
+		case p.accept("primary", "key"):
+			column.PrimaryKey = true
+			column.NotNull = true
+		case p.accept("unique"):
+			table.Indexes = append(table.Indexes, Index{Name: table.Name + "_" + name + "_key", Columns: []string{name}, Unique: true})
+		case p.accept("references"):
+			fk, err := p.references(name)
+			if err != nil {
+				return err
+			}
+			table.ForeignKeys = append(table.ForeignKeys, fk)
+		case p.accept("check"):
+			p.dump.Checks[table.Name]++
+			p.until(columnConstraints...)
+		default:
+			// COLLATE, GENERATED ... AS IDENTITY and the like
+			p.pos++
+			p.until(columnConstraints...)
+		}
+	}
+	table.Columns = append(table.Columns, column)
+	return nil
+}
+
+// references reads "REFERENCES table [(column)] [ON DELETE action] ..." for column
+func (p *ddlParser) references(column string) (ForeignKey, error) {
+	fk := ForeignKey{Column: column, RefColumn: "id"}
+	var err error
+	if fk.RefTable, err = p.name(); err != nil {
+		return fk, err
+	}
+	if p.is("(") {
+		cols, err := p.names()
+		if err != nil {
+			return fk, err
+		}
+		fk.RefColumn = cols[0]
+	}
+	for {
+		switch {
+		case p.accept("on", "delete"):
+			fk.OnDelete = p.action()
+		case p.accept("on", "update"):
+			p.action()
+		case p.accept("match"), p.accept("deferrable"), p.accept("not", "deferrable"), p.accept("initially"):
+			p.accept("full")
+			p.accept("simple")
+			p.accept("deferred")
+			p.accept("immediate")
+		default:
+			return fk, nil
+		}
+	}
+}
+
+func (p *ddlParser) action() string {
+	for _, action := range []string{"cascade", "restrict", "set null", "set default", "no action"} {
+		if p.accept(strings.Fields(action)...) {
+			return strings.ToUpper(action)
+		}
+	}
+	return ""
+}
+
+func (p *ddlParser) tableConstraint(table *Table) error {
+	line := p.peek().line
+	name := ""
+	if p.accept("constraint") {
+		var err error
+		if name, err = p.name(); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case p.accept("primary", "key"):
+		cols, err := p.names()
+		if err != nil {
+			return err
+		}
+		for i := range table.Columns {
+			if containsString(cols, table.Columns[i].Name) {
+				table.Columns[i].PrimaryKey = true
+				table.Columns[i].NotNull = true
+			}
+		}
+	case p.accept("unique"):
+		p.accept("nulls", "not", "distinct")
+		cols, err := p.names()
+		if err != nil {
+			return err
+		}
+		table.Indexes = append(table.Indexes, Index{Name: name, Columns: cols, Unique: true})
+	case p.accept("foreign", "key"):
+		cols, err := p.names()
+		if err != nil {
+			return err
+		}
+		if err := p.expect("references"); err != nil {
+			return err
+		}
+		fk, err := p.references(cols[0])
+		if err != nil {
+			return err
+		}
+		fk.Name = name
+		if len(cols) > 1 {
+			p.warnf(line, "foreign key %s of table %s spans several columns and is not imported", name, table.Name)
+			break
+		}
+		table.ForeignKeys = append(table.ForeignKeys, fk)
+	case p.accept("check"):
+		p.dump.Checks[table.Name]++
+	default:
+		p.warnf(line, "constraint %s of table %s is not imported", name, table.Name)
+	}
+	p.until()
+	return nil
+}
+
+func (p *ddlParser) alterTable() error {
+	p.accept("if", "exists")
+	p.accept("only")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	var table *Table
+	for i := range p.dump.Tables {
+		if p.dump.Tables[i].Name == name {
+			table = &p.dump.Tables[i]
+		}
+	}
+	if table == nil {
+		// Tables this dump doesn't create, such as partitions
+		return nil
+	}
+
+	for p.pos < len(p.stmt) {
+		switch {
+		case p.is("add", "constraint"), p.is("add", "primary"), p.is("add", "unique"), p.is("add", "foreign"), p.is("add", "check"):
+			p.pos++
+			if err := p.tableConstraint(table); err != nil {
+				return err
+			}
+		case p.accept("add", "column"), p.accept("add"):
+			p.accept("if", "not", "exists")
+			if err := p.columnDefinition(table); err != nil {
+				return err
+			}
+		case p.accept("alter", "column"), p.accept("alter"):
+			if err := p.alterColumn(table); err != nil {
+				return err
+			}
+		default:
+			// OWNER TO, ENABLE TRIGGER and other settings
+			p.until()
+		}
+		if !p.accept(",") {
+			break
+		}
+	}
+	return nil
+}
+
+func (p *ddlParser) alterColumn(table *Table) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	var column *Column
+	for i := range table.Columns {
+		if table.Columns[i].Name == name {
+			column = &table.Columns[i]
+		}
+	}
+	switch {
+	case column == nil:
+	case p.accept("set", "default"):
+		column.Default = p.until()
+	case p.accept("set", "not", "null"):
+		column.NotNull = true
+	case p.accept("drop", "not", "null"):
+		column.NotNull = false
+	case p.accept("drop", "default"):
+		column.Default = ""
+	}
+	p.until()
+	return nil
+}
+
+func (p *ddlParser) createIndex(unique bool) error {
+	p.accept("concurrently")
+	p.accept("if", "not", "exists")
+	name := ""
+	if !p.is("on") {
+		var err error
+		if name, err = p.name(); err != nil {
+			return err
+		}
+	}
+	if err := p.expect("on"); err != nil {
+		return err
+	}
+	p.accept("only")
+	tableName, err := p.name()
+	if err != nil {
+		return err
+	}
+	if p.accept("using") {
+		p.pos++
+	}
+
+	// Expression indexes such as lower(email) are kept as written
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	var cols []string
+	for {
+		cols = append(cols, p.until("asc", "desc", "nulls"))
+		p.until()
+		if p.accept(")") {
+			break
+		}
+		if err := p.expect(","); err != nil {
+			return err
+		}
+	}
+	for i := range p.dump.Tables {
+		if p.dump.Tables[i].Name == tableName {
+			p.dump.Tables[i].Indexes = append(p.dump.Tables[i].Indexes, Index{Name: name, Columns: cols, Unique: unique})
+		}
+	}
+	return nil
+}
+
+func (p *ddlParser) createType() error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if !p.accept("as", "enum") {
+		p.warnf(p.stmt[0].line, "type %s is not an enum and is not imported", name)
+		return nil
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	values := []string{}
+	for !p.accept(")") {
+		t := p.peek()
+		if t.kind != tokString {
+			return fmt.Errorf("expected an enum value, found %q", t.text)
+		}
+		values = append(values, t.text)
+		p.pos++
+		p.accept(",")
+	}
+	p.dump.Enums[name] = values
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
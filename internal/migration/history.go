@@ -0,0 +1,91 @@
+package migration
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// HistoryFile stores the schema of the last generation and every migration
+// emitted so far, so the next generation only has to add the difference
+const HistoryFile = "schema.json"
+
+// Migration is a numbered pair of up/down scripts
+type Migration struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	Up      string `json:"up"`
+	Down    string `json:"down"`
+}
+
+// UpFile and DownFile follow the golang-migrate naming convention
+func (m Migration) UpFile() string {
+	return fmt.Sprintf("%06d_%s.up.sql", m.Version, m.Name)
+}
+
+func (m Migration) DownFile() string {
+	return fmt.Sprintf("%06d_%s.down.sql", m.Version, m.Name)
+}
+
+// History is the content of HistoryFile
+type History struct {
+	Schema     Schema      `json:"schema"`
+	Migrations []Migration `json:"migrations"`
+}
+
+// LoadHistory parses a history file; nil or empty data yields an empty history
+func LoadHistory(data []byte) (*History, error) {
+	h := &History{}
+	if len(data) == 0 {
+		return h, nil
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("invalid migration history: %w", err)
+	}
+	return h, nil
+}
+
+func (h *History) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Advance records the migration from the last known schema to schema, if
+// anything changed, and returns it. The first migration is always created so a
+// fresh project has a schema to start from.
+func (h *History) Advance(schema Schema) (*Migration, bool) {
+	steps := Plan(h.Schema, schema)
+	if len(steps) == 0 && len(h.Migrations) > 0 {
+		return nil, false
+	}
+
+	m := Migration{Version: len(h.Migrations) + 1}
+	switch {
+	case m.Version == 1:
+		m.Name = "init"
+	case len(steps) == 1:
+		m.Name = steps[0].Name
+	default:
+		m.Name = "update_schema"
+	}
+
+	var up, down strings.Builder
+	for _, s := range steps {
+		up.WriteString(s.Up + ";\n\n")
+	}
+	for i := len(steps) - 1; i >= 0; i-- {
+		down.WriteString(steps[i].Down + ";\n\n")
+	}
+	m.Up = strings.TrimSuffix(up.String(), "\n")
+	m.Down = strings.TrimSuffix(down.String(), "\n")
+	if m.Up == "" {
+		m.Up, m.Down = "-- no tables\n", "-- no tables\n"
+	}
+
+	h.Schema = schema
+	h.Migrations = append(h.Migrations, m)
+	return &m, true
+}
@@ -0,0 +1,215 @@
+package migration
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Step is a single schema change together with the statement that reverts it
+type Step struct {
+	Name string // short description used to name single-step migrations
+	Up   string
+	Down string
+}
+
+// Plan computes the steps turning the old schema into the new one. Destructive
+// changes come first and constraints last, so each statement only depends on
+// the ones before it; the down statements are meant to run in reverse order.
+func Plan(old, new Schema) []Step {
+	var drops, creates []Step
+
+	// Constraints and indexes that disappear or change are dropped up front
+	for _, before := range old.Tables {
+		after, _ := new.table(before.Name)
+		for _, fk := range before.ForeignKeys {
+			if !containsForeignKey(after.ForeignKeys, fk) {
+				drops = append(drops, dropForeignKey(before.Name, fk))
+			}
+		}
+	}
+	for _, before := range old.Tables {
+		after, _ := new.table(before.Name)
+		for _, idx := range before.Indexes {
+			if !containsIndex(after.Indexes, idx) {
+				drops = append(drops, dropIndex(before.Name, idx))
+			}
+		}
+	}
+	for _, before := range old.Tables {
+		after, ok := new.table(before.Name)
+		if !ok {
+			continue
+		}
+		for _, col := range before.Columns {
+			if _, ok := after.column(col.Name); !ok {
+				drops = append(drops, dropColumn(before.Name, col))
+			}
+		}
+	}
+	for _, before := range old.Tables {
+		if _, ok := new.table(before.Name); !ok {
+			drops = append(drops, dropTable(before))
+		}
+	}
+
+	for _, after := range new.Tables {
+		if _, ok := old.table(after.Name); !ok {
+			creates = append(creates, createTable(after))
+		}
+	}
+	for _, after := range new.Tables {
+		before, ok := old.table(after.Name)
+		if !ok {
+			continue
+		}
+		for _, col := range after.Columns {
+			previous, ok := before.column(col.Name)
+			switch {
+			case !ok:
+				creates = append(creates, addColumn(after.Name, col))
+			case previous.Type != col.Type:
+				creates = append(creates, alterColumnType(after.Name, previous, col))
+			}
+		}
+	}
+	for _, after := range new.Tables {
+		before, _ := old.table(after.Name)
+		for _, idx := range after.Indexes {
+			if !containsIndex(before.Indexes, idx) {
+				creates = append(creates, createIndex(after.Name, idx))
+			}
+		}
+	}
+	for _, after := range new.Tables {
+		before, _ := old.table(after.Name)
+		for _, fk := range after.ForeignKeys {
+			if !containsForeignKey(before.ForeignKeys, fk) {
+				_, existing := before.column(fk.Column)
+				creates = append(creates, addForeignKey(after.Name, fk, existing))
+			}
+		}
+	}
+
+	return append(drops, creates...)
+}
+
+func createTable(t Table) Step {
+	var keys []string
+	for _, c := range t.Columns {
+		if c.PrimaryKey {
+			keys = append(keys, c.Name)
+		}
+	}
+
+	var defs []string
+	for _, c := range t.Columns {
+		if len(keys) > 1 {
+			c.PrimaryKey = false
+		}
+		defs = append(defs, columnDefinition(c))
+	}
+	if len(keys) > 1 {
+		defs = append(defs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(keys, ", ")))
+	}
+	return Step{
+		Name: "create_" + t.Name,
+		Up:   fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n    %s\n)", t.Name, strings.Join(defs, ",\n    ")),
+		Down: fmt.Sprintf("DROP TABLE IF EXISTS %s", t.Name),
+	}
+}
+
+func dropTable(t Table) Step {
+	step := createTable(t)
+	return Step{Name: "drop_" + t.Name, Up: step.Down, Down: step.Up}
+}
+
+func addColumn(table string, c Column) Step {
+	return Step{
+		Name: "add_" + table + "_" + c.Name,
+		Up:   fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s", table, columnDefinition(c)),
+		Down: fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s", table, c.Name),
+	}
+}
+
+func dropColumn(table string, c Column) Step {
+	step := addColumn(table, c)
+	return Step{Name: "drop_" + table + "_" + c.Name, Up: step.Down, Down: step.Up}
+}
+
+func alterColumnType(table string, before, after Column) Step {
+	alter := func(c Column) string {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s", table, c.Name, c.Type, c.Name, c.Type)
+	}
+	return Step{Name: "alter_" + table + "_" + after.Name, Up: alter(after), Down: alter(before)}
+}
+
+func createIndex(table string, idx Index) Step {
+	unique := ""
+	if idx.Unique {
+		unique = "UNIQUE "
+	}
+	return Step{
+		Name: "index_" + table + "_" + strings.Join(idx.Columns, "_"),
+		Up:   fmt.Sprintf("CREATE %sINDEX IF NOT EXISTS %s ON %s (%s)", unique, idx.Name, table, strings.Join(idx.Columns, ", ")),
+		Down: fmt.Sprintf("DROP INDEX IF EXISTS %s", idx.Name),
+	}
+}
+
+func dropIndex(table string, idx Index) Step {
+	step := createIndex(table, idx)
+	return Step{Name: "drop_" + idx.Name, Up: step.Down, Down: step.Up}
+}
+
+// addForeignKey constrains a column; existing columns may hold empty strings
+// written before the constraint, which would not satisfy it
+func addForeignKey(table string, fk ForeignKey, existing bool) Step {
+	onDelete := ""
+	if fk.OnDelete != "" {
+		onDelete = " ON DELETE " + fk.OnDelete
+	}
+	up := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)%s",
+		table, fk.Name, fk.Column, fk.RefTable, fk.RefColumn, onDelete)
+	if existing {
+		up = fmt.Sprintf("UPDATE %s SET %s = NULL WHERE %s = '';\n\n%s", table, fk.Column, fk.Column, up)
+	}
+	return Step{
+		Name: "link_" + table + "_" + fk.Column,
+		Up:   up,
+		Down: fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s", table, fk.Name),
+	}
+}
+
+func dropForeignKey(table string, fk ForeignKey) Step {
+	step := addForeignKey(table, fk, false)
+	return Step{Name: "unlink_" + table + "_" + fk.Column, Up: step.Down, Down: step.Up}
+}
+
+func columnDefinition(c Column) string {
+	def := c.Name + " " + c.Type
+	if c.PrimaryKey {
+		def += " PRIMARY KEY"
+	}
+	if c.Default != "" {
+		def += " DEFAULT " + c.Default
+	}
+	return def
+}
+
+func containsIndex(indexes []Index, idx Index) bool {
+	for _, i := range indexes {
+		if reflect.DeepEqual(i, idx) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsForeignKey(fks []ForeignKey, fk ForeignKey) bool {
+	for _, f := range fks {
+		if f == fk {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,428 @@
+package migration
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+var (
+	identifierRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	castRe       = regexp.MustCompile(`::[a-z_ ."]+(\([0-9, ]+\))?(\[\])*$`)
+	sizeRe       = regexp.MustCompile(`\s*\(([0-9, ]+)\)`)
+)
+
+// migrationsTable is the bookkeeping table of the generated migrate command
+const migrationsTable = "schema_migrations"
+
+// sqlFieldTypes maps PostgreSQL type names, without size or array brackets, to field types
+var sqlFieldTypes = map[string]string{
+	"text": "string", "varchar": "string", "character varying": "string", "character": "string",
+	"char": "string", "bpchar": "string", "citext": "string", "name": "string",
+	"smallint": "integer", "integer": "integer", "int": "integer", "bigint": "integer",
+	"int2": "integer", "int4": "integer", "int8": "integer",
+	"smallserial": "integer", "serial": "integer", "bigserial": "integer", "serial2": "integer", "serial4": "integer", "serial8": "integer",
+	"real": "float", "double precision": "float", "float4": "float", "float8": "float", "float": "float",
+	"numeric": "decimal", "decimal": "decimal", "money": "decimal",
+	"boolean": "boolean", "bool": "boolean",
+	"timestamp": "datetime", "timestamptz": "datetime",
+	"date": "date",
+	"uuid": "uuid",
+	"json": "json", "jsonb": "json",
+	"point": "geopoint",
+}
+
+// nowDefaults are the column defaults a datetime "now" default stands for
+var nowDefaults = []string{"now()", "current_timestamp", "localtimestamp", "transaction_timestamp()", "statement_timestamp()", "clock_timestamp()"}
+
+// Reverse builds the blueprint a dump describes: each table becomes a model,
+// each foreign key a belongsTo relation with a hasMany on the table it
+// references, and each table holding only two foreign keys a manyToMany
+// relation. Whatever has no blueprint equivalent is reported as a warning.
+func Reverse(dump *Dump) (*domain.Config, []domain.Diagnostic) {
+	r := &reverser{dump: dump, config: &domain.Config{
+		ProjectName: projectName(dump.File),
+		Database:    domain.Database{Type: "postgresql"},
+	}}
+
+	var tables []Table
+	for _, table := range dump.Tables {
+		switch {
+		case table.Name == migrationsTable:
+		case !identifierRe.MatchString(table.Name):
+			r.warnf(table.Name, "", "table name is not a valid model name, skipped")
+		case r.joinTable(table):
+		default:
+			tables = append(tables, table)
+		}
+	}
+	for _, table := range tables {
+		r.config.Models = append(r.config.Models, r.model(table))
+	}
+	r.inverseRelations(tables)
+	r.linkJoinTables()
+
+	// Relations are linked once every table is read, so their warnings come last
+	sort.SliceStable(r.notes, func(i, j int) bool {
+		return r.notes[i].Pos.Line < r.notes[j].Pos.Line
+	})
+	return r.config, r.notes
+}
+
+type reverser struct {
+	dump   *Dump
+	config *domain.Config
+	notes  []domain.Diagnostic
+	joins  []Table // tables backing a manyToMany relation, linked once every model exists
+}
+
+// warnf reports a problem with a table, or one of its columns
+func (r *reverser) warnf(table, column, format string, args ...interface{}) {
+	path, key := table, table
+	if column != "" {
+		path = table + "." + column
+		key = path
+	}
+	r.notes = append(r.notes, domain.Diagnostic{
+		Pos:      domain.Position{File: r.dump.File, Line: r.dump.Lines[key], Column: 1},
+		Path:     path,
+		Severity: domain.SeverityWarning,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// joinTable reports whether table only links two others, the way the join
+// table of a manyToMany relation does
+func (r *reverser) joinTable(table Table) bool {
+	if len(table.Columns) != 2 || len(table.ForeignKeys) != 2 || table.ForeignKeys[0].Column == table.ForeignKeys[1].Column {
+		return false
+	}
+	r.joins = append(r.joins, table)
+	return true
+}
+
+func (r *reverser) linkJoinTables() {
+	for _, table := range r.joins {
+		owner, other := table.ForeignKeys[0], table.ForeignKeys[1]
+		if strings.HasPrefix(table.Name, other.RefTable+"_") && !strings.HasPrefix(table.Name, owner.RefTable+"_") {
+			owner, other = other, owner
+		}
+		model := r.findModel(owner.RefTable)
+		if model == nil || r.findModel(other.RefTable) == nil {
+			r.warnf(table.Name, "", "join table references a table that is not imported, skipped")
+			continue
+		}
+
+		// The generated join table is named after the owner and the relation
+		name := strings.TrimPrefix(table.Name, owner.RefTable+"_")
+		if name == table.Name || !identifierRe.MatchString(name) {
+			name = table.Name
+			r.warnf(table.Name, "", "join table becomes the relation %s.%s, stored in %s", owner.RefTable, name, domain.JoinTable(owner.RefTable, name))
+		}
+		if _, taken := model.Relations[name]; taken {
+			r.warnf(table.Name, "", "%s already has a relation %s, join table skipped", owner.RefTable, name)
+			continue
+		}
+		if _, taken := model.Fields[name]; taken {
+			r.warnf(table.Name, "", "%s already has a field %s, join table skipped", owner.RefTable, name)
+			continue
+		}
+		model.Relations[name] = domain.ManyToMany + ":" + other.RefTable
+	}
+}
+
+func (r *reverser) findModel(name string) *domain.Model {
+	for i := range r.config.Models {
+		if r.config.Models[i].Name == name {
+			return &r.config.Models[i]
+		}
+	}
+	return nil
+}
+
+func (r *reverser) model(table Table) domain.Model {
+	model := domain.Model{
+		Name:      table.Name,
+		Fields:    make(map[string]string),
+		Relations: make(map[string]string),
+	}
+
+	var primary []string
+	for _, c := range table.Columns {
+		if c.PrimaryKey {
+			primary = append(primary, c.Name)
+		}
+	}
+	switch {
+	case len(primary) == 1 && primary[0] == "id":
+		id, _ := table.column("id")
+		if t := strings.ToLower(id.Type); !strings.HasPrefix(t, "text") && !strings.HasPrefix(t, "uuid") && !strings.HasPrefix(t, "character varying") && !strings.HasPrefix(t, "varchar") {
+			r.warnf(table.Name, "id", "primary key is %s, the generated table uses text ids", id.Type)
+		}
+	case len(primary) == 0:
+		r.warnf(table.Name, "", "table has no primary key, the generated table adds a text id")
+	default:
+		r.warnf(table.Name, "", "primary key (%s) becomes regular fields, the generated table uses a text id", strings.Join(primary, ", "))
+	}
+
+	foreign := make(map[string]ForeignKey)
+	for _, fk := range table.ForeignKeys {
+		foreign[fk.Column] = fk
+	}
+	for _, c := range table.Columns {
+		switch {
+		case c.Name == "id" && len(primary) == 1 && primary[0] == "id":
+		case strings.EqualFold(c.Name, "id"):
+			r.warnf(table.Name, c.Name, "column is reserved for the generated primary key, skipped")
+		case !identifierRe.MatchString(c.Name):
+			r.warnf(table.Name, c.Name, "column name is not a valid field name, skipped")
+		default:
+			if fk, ok := foreign[c.Name]; ok && r.imported(fk.RefTable) {
+				model.Relations[c.Name] = r.belongsTo(table, fk)
+				continue
+			}
+			r.field(&model, table, c)
+		}
+	}
+
+	for _, index := range table.Indexes {
+		if !index.Unique {
+			continue
+		}
+		if len(index.Columns) > 1 {
+			r.warnf(table.Name, "", "unique constraint on (%s) spans several columns and is not imported", strings.Join(index.Columns, ", "))
+			continue
+		}
+		column := index.Columns[0]
+		if _, ok := model.Fields[column]; !ok {
+			if _, isColumn := table.column(column); !isColumn {
+				r.warnf(table.Name, "", "unique index on %s is not imported", column)
+			}
+			continue
+		}
+		rules := model.Rules[column]
+		rules.Unique = true
+		r.setRules(&model, column, rules)
+	}
+
+	if n := r.dump.Checks[table.Name]; n > 0 {
+		r.warnf(table.Name, "", "%d CHECK constraint(s) are not imported", n)
+	}
+	return model
+}
+
+// imported reports whether a table becomes a model, so relations can target it
+func (r *reverser) imported(name string) bool {
+	for _, table := range r.dump.Tables {
+		if table.Name == name {
+			return identifierRe.MatchString(name) && name != migrationsTable && !r.isJoin(name)
+		}
+	}
+	return false
+}
+
+func (r *reverser) isJoin(name string) bool {
+	for _, table := range r.joins {
+		if table.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *reverser) belongsTo(table Table, fk ForeignKey) string {
+	if fk.RefColumn != "id" {
+		r.warnf(table.Name, fk.Column, "foreign key references %s.%s, the generated key references %s.id", fk.RefTable, fk.RefColumn, fk.RefTable)
+	}
+	rel := domain.Relation{Kind: domain.BelongsTo, Target: fk.RefTable}
+	switch onDelete := fk.OnDelete; onDelete {
+	case "":
+		// PostgreSQL's default
+		rel.OnDelete = "no_action"
+	case "SET DEFAULT":
+		r.warnf(table.Name, fk.Column, "ON DELETE SET DEFAULT is not supported, imported as set_null")
+	default:
+		for key, action := range domain.OnDeleteActions {
+			if action == onDelete && key != domain.DefaultOnDelete {
+				rel.OnDelete = key
+			}
+		}
+	}
+	return rel.String()
+}
+
+// inverseRelations adds the hasMany relation of each table referenced by a
+// foreign key, once per referencing table
+func (r *reverser) inverseRelations(tables []Table) {
+	for _, table := range tables {
+		for _, fk := range table.ForeignKeys {
+			target := r.findModel(fk.RefTable)
+			if target == nil || fk.RefTable == table.Name {
+				continue
+			}
+			source := r.findModel(table.Name)
+			if domain.ParseRelation(source.Relations[fk.Column]).Kind != domain.BelongsTo {
+				continue
+			}
+			if _, taken := target.Relations[table.Name]; taken {
+				continue
+			}
+			if _, taken := target.Fields[table.Name]; taken {
+				r.warnf(fk.RefTable, table.Name, "field has the name of the hasMany relation to %s, relation skipped", table.Name)
+				continue
+			}
+			target.Relations[table.Name] = domain.HasMany + ":" + table.Name
+		}
+	}
+}
+
+func (r *reverser) field(model *domain.Model, table Table, c Column) {
+	spec, maxLength := r.fieldType(table, c)
+	model.Fields[c.Name] = spec
+
+	rules := domain.FieldRules{MaxLength: maxLength, Required: c.NotNull && c.Default == ""}
+	if c.Default != "" {
+		rules.Default = r.fieldDefault(table, c, spec)
+	}
+	r.setRules(model, c.Name, rules)
+}
+
+func (r *reverser) setRules(model *domain.Model, name string, rules domain.FieldRules) {
+	if len(rules.RuleNames()) == 0 {
+		return
+	}
+	if model.Rules == nil {
+		model.Rules = make(map[string]domain.FieldRules)
+	}
+	model.Rules[name] = rules
+}
+
+// fieldType maps a column type to a field type, with the length limit of types such as varchar(80)
+func (r *reverser) fieldType(table Table, c Column) (string, *int) {
+	sqlType := strings.ToLower(c.Type)
+	array := strings.HasSuffix(sqlType, "[]")
+	sqlType = strings.TrimRight(sqlType, "[]")
+
+	// "character varying(80)", "timestamp(3) with time zone", public.status
+	var size string
+	if m := sizeRe.FindStringSubmatch(sqlType); m != nil {
+		size = strings.TrimSpace(m[1])
+	}
+	name := strings.Join(strings.Fields(sizeRe.ReplaceAllString(sqlType, "")), " ")
+	name = strings.TrimSuffix(strings.TrimSuffix(name, " with time zone"), " without time zone")
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+	name = strings.Trim(name, `"`)
+
+	spec, ok := sqlFieldTypes[name]
+	var maxLength *int
+	switch {
+	case ok && spec == "string" && size != "" && !array:
+		if n, err := strconv.Atoi(size); err == nil {
+			maxLength = &n
+		}
+	case !ok && r.dump.Enums[name] != nil:
+		spec = r.enumType(table, c, name)
+	case !ok:
+		r.warnf(table.Name, c.Name, "type %s has no field type, imported as string", c.Type)
+		spec = "string"
+	}
+
+	if array {
+		if !containsString(domain.ArrayElemTypes(), spec) {
+			r.warnf(table.Name, c.Name, "arrays of %s have no field type, imported as json", c.Type)
+			return "json", nil
+		}
+		return "array<" + spec + ">", nil
+	}
+	return spec, maxLength
+}
+
+func (r *reverser) enumType(table Table, c Column, name string) string {
+	values := r.dump.Enums[name]
+	for _, v := range values {
+		if v == "" || strings.ContainsAny(v, " (),") {
+			r.warnf(table.Name, c.Name, "value %q of enum %s cannot be written in a blueprint, imported as string", v, name)
+			return "string"
+		}
+	}
+	if len(values) == 0 {
+		r.warnf(table.Name, c.Name, "enum %s has no values, imported as string", name)
+		return "string"
+	}
+	return "enum(" + strings.Join(values, ",") + ")"
+}
+
+// fieldDefault turns a column default into the default rule of the field, or
+// nil when the field type can't hold it
+func (r *reverser) fieldDefault(table Table, c Column, spec string) interface{} {
+	expr := strings.TrimSpace(c.Default)
+	for strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+	expr = castRe.ReplaceAllString(expr, "")
+	drop := func(reason string) interface{} {
+		r.warnf(table.Name, c.Name, "DEFAULT %s %s, dropped", c.Default, reason)
+		return nil
+	}
+
+	t := domain.ParseFieldType(spec)
+	info, _ := t.Info()
+	lower := strings.ToLower(expr)
+	switch {
+	case lower == "null":
+		return nil
+	case !info.Scalar:
+		return drop("does not apply to a " + t.Name + " field")
+	case containsString(nowDefaults, lower) || strings.HasPrefix(lower, "current_timestamp("):
+		if t.Name != "datetime" {
+			return drop("only applies to datetime fields")
+		}
+		return "now"
+	case t.Name == "boolean" && (lower == "true" || lower == "false"):
+		return lower == "true"
+	}
+
+	literal := expr
+	if strings.HasPrefix(expr, "'") && strings.HasSuffix(expr, "'") && len(expr) > 1 {
+		literal = strings.ReplaceAll(expr[1:len(expr)-1], "''", "'")
+	} else if _, err := strconv.ParseFloat(expr, 64); err != nil {
+		return drop("is an expression")
+	}
+
+	if info.Numeric {
+		n, err := strconv.ParseFloat(literal, 64)
+		if err != nil {
+			return drop("is not a number")
+		}
+		return n
+	}
+	if t.Name == "enum" && !containsString(t.Values, literal) {
+		return drop("is not one of the enum values")
+	}
+	if t.Name == "datetime" {
+		return drop("is a fixed time, only now is supported")
+	}
+	return literal
+}
+
+// projectName derives a project name from the dump's file name, "shop_db.sql" -> "ShopDb"
+func projectName(file string) string {
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(base, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	if name := b.String(); identifierRe.MatchString(name) {
+		return name
+	}
+	return "ImportedAPI"
+}
@@ -0,0 +1,183 @@
+package migration
+
+import (
+	"sort"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// Schema is the relational layout generated for a blueprint
+type Schema struct {
+	Tables []Table `json:"tables"`
+}
+
+// Table is a model's table; columns keep the order they are created in
+type Table struct {
+	Name        string       `json:"name"`
+	Columns     []Column     `json:"columns"`
+	Indexes     []Index      `json:"indexes,omitempty"`
+	ForeignKeys []ForeignKey `json:"foreign_keys,omitempty"`
+}
+
+type Column struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primary_key,omitempty"`
+	Default    string `json:"default,omitempty"`
+	NotNull    bool   `json:"not_null,omitempty"` // read from DDL; generated columns are nullable
+}
+
+type Index struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique,omitempty"`
+}
+
+type ForeignKey struct {
+	Name      string `json:"name"`
+	Column    string `json:"column"`
+	RefTable  string `json:"ref_table"`
+	RefColumn string `json:"ref_column"`
+	OnDelete  string `json:"on_delete,omitempty"`
+}
+
+// SQLType maps a blueprint field type to its PostgreSQL column type
+func SQLType(fieldType string) string {
+	return domain.ParseFieldType(fieldType).SQLType()
+}
+
+// FromConfig derives the schema of every model in config. Columns after the
+// primary key are sorted by name, as the generated repositories expect.
+func FromConfig(config *domain.Config) Schema {
+	var schema Schema
+	for _, model := range config.Models {
+		schema.Tables = append(schema.Tables, tableFor(config, model))
+		schema.Tables = append(schema.Tables, joinTablesFor(config, model)...)
+	}
+	sort.Slice(schema.Tables, func(i, j int) bool {
+		return schema.Tables[i].Name < schema.Tables[j].Name
+	})
+	return schema
+}
+
+// HasColumn reports whether a relation is stored in a column of the model's own
+// table, rather than derived from another table's foreign key or a join table
+func HasColumn(config *domain.Config, model domain.Model, relation string) bool {
+	rel := domain.ParseRelation(model.Relations[relation])
+	switch rel.Kind {
+	case domain.ManyToMany:
+		return false
+	case domain.HasMany:
+		target, ok := findModel(config, rel.Target)
+		if !ok {
+			return true
+		}
+		_, derived := domain.InverseKey(target, model.Name)
+		return !derived
+	}
+	return true
+}
+
+func tableFor(config *domain.Config, model domain.Model) Table {
+	table := Table{
+		Name:    model.Name,
+		Columns: []Column{{Name: "id", Type: "TEXT", PrimaryKey: true, Default: "gen_random_uuid()::text"}},
+	}
+
+	var names []string
+	for name := range model.Fields {
+		names = append(names, name)
+	}
+	for name := range model.Relations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if fieldType, ok := model.Fields[name]; ok {
+			table.Columns = append(table.Columns, Column{Name: name, Type: SQLType(fieldType)})
+			if model.Rules[name].Unique {
+				table.Indexes = append(table.Indexes, Index{Name: "uniq_" + model.Name + "_" + name, Columns: []string{name}, Unique: true})
+			}
+			continue
+		}
+		if !HasColumn(config, model, name) {
+			continue
+		}
+
+		rel := domain.ParseRelation(model.Relations[name])
+		switch rel.Kind {
+		case domain.HasMany:
+			// No inverse foreign key could be added: keep the ids in an array
+			table.Columns = append(table.Columns, Column{Name: name, Type: "TEXT[]"})
+		case domain.BelongsTo:
+			// Lookups by the owning record are the common query on belongsTo columns
+			table.Columns = append(table.Columns, Column{Name: name, Type: "TEXT"})
+			table.Indexes = append(table.Indexes, Index{Name: "idx_" + model.Name + "_" + name, Columns: []string{name}})
+			table.ForeignKeys = append(table.ForeignKeys, ForeignKey{
+				Name:      "fk_" + model.Name + "_" + name,
+				Column:    name,
+				RefTable:  rel.Target,
+				RefColumn: "id",
+				OnDelete:  rel.OnDeleteSQL(),
+			})
+		default:
+			table.Columns = append(table.Columns, Column{Name: name, Type: "TEXT"})
+		}
+	}
+	return table
+}
+
+// joinTablesFor builds the table linking model to the target of each
+// manyToMany relation, but for those sharing the table of their inverse
+func joinTablesFor(config *domain.Config, model domain.Model) []Table {
+	var tables []Table
+	for name, spec := range model.Relations {
+		rel := domain.ParseRelation(spec)
+		if rel.Kind != domain.ManyToMany || domain.SharesJoinTable(config.Models, model, name) {
+			continue
+		}
+		join := domain.ManyToManyJoin(config.Models, model, name)
+		table, owner, other := join.Table, join.OwnerColumn, join.TargetColumn
+		tables = append(tables, Table{
+			Name: table,
+			Columns: []Column{
+				{Name: owner, Type: "TEXT", PrimaryKey: true},
+				{Name: other, Type: "TEXT", PrimaryKey: true},
+			},
+			Indexes: []Index{{Name: "idx_" + table + "_" + other, Columns: []string{other}}},
+			ForeignKeys: []ForeignKey{
+				{Name: "fk_" + table + "_" + owner, Column: owner, RefTable: model.Name, RefColumn: "id", OnDelete: "CASCADE"},
+				{Name: "fk_" + table + "_" + other, Column: other, RefTable: rel.Target, RefColumn: "id", OnDelete: "CASCADE"},
+			},
+		})
+	}
+	return tables
+}
+
+func findModel(config *domain.Config, name string) (domain.Model, bool) {
+	for _, m := range config.Models {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return domain.Model{}, false
+}
+
+func (s Schema) table(name string) (Table, bool) {
+	for _, t := range s.Tables {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Table{}, false
+}
+
+func (t Table) column(name string) (Column, bool) {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
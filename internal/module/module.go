@@ -0,0 +1,281 @@
+// Package module is the extension point of the generator. A module adds a
+// feature to the projects whose blueprint lists it under "modules": it checks
+// its settings, may add models to the config, and contributes files, main.go
+// code, routes and dependencies, all without changes to the generator.
+//
+// Modules are compiled in: a module package calls Register from an init
+// function, and a blank import of it in cmd/blueprint/modules.go enables it.
+package module
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// Module is implemented by the features the generator can be extended with.
+// settings is the value of the module key under "modules" in the blueprint,
+// as JSON whatever the blueprint format; Decode reads it strictly.
+type Module interface {
+	// Name is the key of the module under "modules"
+	Name() string
+
+	// Schema is the JSON Schema of the settings, published with the schema of
+	// the blueprint format for editors
+	Schema() map[string]interface{}
+
+	// Templates are the module templates by name. The names share the
+	// namespace of the generator templates, which they must not reuse, so a
+	// project can override them and eject them the same way.
+	Templates() map[string]string
+
+	// Validate reports the mistakes in the settings. Paths are relative to
+	// the settings, empty for the settings themselves.
+	Validate(config *domain.Config, settings json.RawMessage) []domain.Diagnostic
+
+	// Enrich completes config before generation, e.g. with the models the
+	// module stores its data in; these are generated like declared models
+	Enrich(config *domain.Config, settings json.RawMessage) error
+
+	// Contribute returns what the module adds to the project generated from
+	// the enriched config
+	Contribute(config *domain.Config, settings json.RawMessage) (*Contribution, error)
+}
+
+// Contribution is what a module adds to a generated project
+type Contribution struct {
+	// Module is the name of the contributing module; set by Contributions
+	Module string
+
+	// Files are rendered from the module templates into the project
+	Files []File
+
+	// Requires lists go.mod requirements, "path version"
+	Requires []string
+
+	// Imports are import specs main.go needs for Setup and Router, e.g.
+	// `audit "shop/internal/audit"`; unused ones are dropped
+	Imports []string
+
+	// Setup is Go code run in main once the database is open, as baseRepo.
+	// Modules are set up in name order, so the variables of a module's Setup
+	// are in scope in the Setup of the modules after it.
+	Setup string
+
+	// Router is Go code run once the gin engine r exists, before any route is
+	// registered, so middleware added with r.Use applies to every route
+	Router string
+
+	// Protect is a Go expression, valid after Setup, of the gin.HandlerFunc
+	// guarding the routes of protected models, e.g.
+	// `authService.AuthMiddleware(authSvc)`. Without one, main.go only checks
+	// that a bearer token is sent. At most one module may set it.
+	Protect string
+
+	// Endpoints lists the routes Router registers, for the OpenAPI document
+	// and blueprint diff
+	Endpoints []Endpoint
+
+	// Env lists the variables written to the .env file of the project
+	Env []EnvVar
+}
+
+// File is a file rendered from a module template
+type File struct {
+	Path     string      // relative to the project root, slash separated
+	Template string      // name of one of the module templates
+	Data     interface{} // the config when nil
+}
+
+// Endpoint is a route a module registers
+type Endpoint struct {
+	Method  string // GET, POST...
+	Path    string // gin syntax, e.g. /audit/:id
+	ID      string // OpenAPI operationId
+	Summary string
+
+	// Headers lists the request headers the endpoint requires
+	Headers []string
+
+	// Bearer is set when the endpoint requires the bearer token Protect checks
+	Bearer bool
+
+	// Request is the JSON Schema of the request body, if the endpoint reads
+	// one; the body may be left out when RequestOptional is set
+	Request         map[string]interface{}
+	RequestOptional bool
+
+	// Status is the code of a successful answer, 200 when zero, and
+	// Description describes it, the status text when empty
+	Status      int
+	Description string
+
+	// Response is the JSON Schema of the successful answer, any object when nil
+	Response map[string]interface{}
+
+	// Errors lists the error codes the endpoint answers besides 400 for a bad
+	// required body, 401 for a bearer endpoint and 500
+	Errors []int
+}
+
+// EnvVar is an environment variable of the generated project, with the
+// placeholder written to .env
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+var (
+	mu        sync.RWMutex
+	registry  = make(map[string]Module)
+	templates = make(map[string]string) // module owning each template name
+)
+
+// Register makes a module available to blueprints under its name. It panics
+// if the name is empty or taken, or if another module registered one of its
+// template names, like database/sql.Register.
+func Register(m Module) {
+	mu.Lock()
+	defer mu.Unlock()
+	name := m.Name()
+	if name == "" {
+		panic("module: Register of a module without name")
+	}
+	if _, dup := registry[name]; dup {
+		panic("module: Register called twice for module " + name)
+	}
+	for template := range m.Templates() {
+		if owner, dup := templates[template]; dup {
+			panic(fmt.Sprintf("module: template %q of module %s is already registered by module %s", template, name, owner))
+		}
+	}
+	registry[name] = m
+	for template := range m.Templates() {
+		templates[template] = name
+	}
+}
+
+// Unregister removes a module, for tests and tools registering modules at run time
+func Unregister(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(registry, name)
+	for template, owner := range templates {
+		if owner == name {
+			delete(templates, template)
+		}
+	}
+}
+
+// Lookup returns the registered module called name
+func Lookup(name string) (Module, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	m, ok := registry[name]
+	return m, ok
+}
+
+// Names lists the registered modules, sorted
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Decode reads settings into v, rejecting the keys v doesn't declare. Absent
+// settings leave v untouched.
+func Decode(settings json.RawMessage, v interface{}) error {
+	if len(settings) == 0 || string(settings) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(settings))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// Enabled returns the modules config lists, in name order
+func Enabled(config *domain.Config) ([]Module, error) {
+	names := make([]string, 0, len(config.Modules))
+	for name := range config.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	modules := make([]Module, 0, len(names))
+	for _, name := range names {
+		m, ok := Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown module %q", name)
+		}
+		modules = append(modules, m)
+	}
+	return modules, nil
+}
+
+// Enrich lets every module config lists complete it
+func Enrich(config *domain.Config) error {
+	modules, err := Enabled(config)
+	if err != nil {
+		return err
+	}
+	for _, m := range modules {
+		if err := m.Enrich(config, config.Modules[m.Name()]); err != nil {
+			return fmt.Errorf("module %s: %w", m.Name(), err)
+		}
+	}
+	return nil
+}
+
+// Contributions collects what the modules config lists add to the project
+func Contributions(config *domain.Config) ([]*Contribution, error) {
+	modules, err := Enabled(config)
+	if err != nil {
+		return nil, err
+	}
+	contributions := make([]*Contribution, 0, len(modules))
+	for _, m := range modules {
+		c, err := m.Contribute(config, config.Modules[m.Name()])
+		if err != nil {
+			return nil, fmt.Errorf("module %s: %w", m.Name(), err)
+		}
+		if c == nil {
+			c = &Contribution{}
+		}
+		c.Module = m.Name()
+		contributions = append(contributions, c)
+	}
+	return contributions, nil
+}
+
+// Template returns the text of a module template by name
+func Template(name string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	owner, ok := templates[name]
+	if !ok {
+		return "", false
+	}
+	text, ok := registry[owner].Templates()[name]
+	return text, ok
+}
+
+// TemplateNames lists the templates of the registered modules
+func TemplateNames() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,261 @@
+// Package auth is the generator module authenticating the users of the API,
+// with Firebase ID tokens or JWTs the API signs itself: it completes the user
+// model, adds the /auth routes and guards the routes of protected models.
+// Blueprints enable it under modules.auth, or with the auth key that predates
+// modules.
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module implements module.Module
+type Module struct{}
+
+type settings struct {
+	Provider       string `json:"provider"`
+	UserCollection string `json:"user_collection"`
+}
+
+var identifierRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+func (Module) Name() string { return "auth" }
+
+func (Module) Schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"provider":        map[string]interface{}{"enum": domain.AuthProviders, "description": "Firebase ID tokens (default) or JWTs signed by the API."},
+			"user_collection": map[string]interface{}{"type": "string", "description": "Model holding the users, \"users\" by default."},
+		},
+		"additionalProperties": false,
+	}
+}
+
+func (Module) Templates() map[string]string {
+	return map[string]string{
+		"config":                   configTemplate,
+		"firebase_auth_handler":    firebaseHandlerTemplate,
+		"firebase_auth_middleware": firebaseMiddlewareTemplate,
+		"jwt_auth_domain":          jwtDomainTemplate,
+		"jwt_auth_handler":         jwtHandlerTemplate,
+		"jwt_auth_middleware":      jwtMiddlewareTemplate,
+	}
+}
+
+func (Module) settings(raw json.RawMessage) (settings, error) {
+	var s settings
+	err := module.Decode(raw, &s)
+	if s.Provider == "" {
+		s.Provider = "firebase"
+	}
+	if s.UserCollection == "" {
+		s.UserCollection = "users"
+	}
+	return s, err
+}
+
+func (m Module) Validate(config *domain.Config, raw json.RawMessage) []domain.Diagnostic {
+	s, err := m.settings(raw)
+	if err != nil {
+		return []domain.Diagnostic{{Message: err.Error()}}
+	}
+	var diags []domain.Diagnostic
+	if !contains(domain.AuthProviders, s.Provider) {
+		diags = append(diags, domain.Diagnostic{
+			Path:    "provider",
+			Message: fmt.Sprintf("unsupported auth provider %q (available: %s)", s.Provider, strings.Join(domain.AuthProviders, ", ")),
+		})
+	}
+	if !identifierRe.MatchString(s.UserCollection) {
+		diags = append(diags, domain.Diagnostic{
+			Path:    "user_collection",
+			Message: fmt.Sprintf("invalid collection name %q", s.UserCollection),
+		})
+	}
+	return diags
+}
+
+// Enrich records the settings in config.Auth, for the repository of the
+// users and the OpenAPI document, and adds the user model or the fields the
+// handlers read and write to the declared one
+func (m Module) Enrich(config *domain.Config, raw json.RawMessage) error {
+	s, err := m.settings(raw)
+	if err != nil {
+		return err
+	}
+	config.Auth = &domain.Auth{Enabled: true, Provider: s.Provider, UserCollection: s.UserCollection}
+
+	fields := map[string]string{
+		"email":      "string",
+		"name":       "string",
+		"picture":    "string",
+		"role_id":    "string",
+		"created_at": "datetime",
+		"updated_at": "datetime",
+	}
+	if s.Provider == "jwt" {
+		fields["password"] = "string"
+	} else {
+		fields["uid"] = "string"
+	}
+	for i := range config.Models {
+		model := &config.Models[i]
+		if model.Name != s.UserCollection {
+			continue
+		}
+		if model.Fields == nil {
+			model.Fields = make(map[string]string)
+		}
+		for name, spec := range fields {
+			if _, ok := model.Fields[name]; !ok {
+				model.Fields[name] = spec
+			}
+		}
+		return nil
+	}
+	config.Models = append(config.Models, domain.Model{
+		Name:      s.UserCollection,
+		Protected: true,
+		Fields:    fields,
+		Relations: map[string]string{},
+	})
+	return nil
+}
+
+func (m Module) Contribute(config *domain.Config, raw json.RawMessage) (*module.Contribution, error) {
+	s, err := m.settings(raw)
+	if err != nil {
+		return nil, err
+	}
+	typeName := strings.ToUpper(s.UserCollection[:1]) + s.UserCollection[1:]
+	repository := map[string]string{"firestore": "FirestoreRepository", "postgresql": "PostgresRepository", "mongodb": "MongoRepository"}[config.Database.Type]
+	c := &module.Contribution{
+		Files: []module.File{
+			{Path: "internal/config/config.go", Template: "config"},
+			{Path: "internal/auth/middleware.go", Template: s.Provider + "_auth_middleware"},
+			{Path: "internal/handlers/auth/handler.go", Template: s.Provider + "_auth_handler"},
+		},
+		Requires: []string{"firebase.google.com/go/v4 v4.13.0"},
+		// Aliased, since both packages are called auth
+		Imports: []string{
+			fmt.Sprintf("authService %q", config.ProjectName+"/internal/auth"),
+			fmt.Sprintf("authHandler %q", config.ProjectName+"/internal/handlers/auth"),
+		},
+		Protect: "authService.AuthMiddleware(authSvc)",
+	}
+	userRepo := fmt.Sprintf("userRepo := db.New%sRepository(baseRepo.(*db.%s))", typeName, repository)
+	userHdl := fmt.Sprintf("userHdl := authHandler.NewUserHandler(authSvc, userRepo, %q)", s.UserCollection)
+
+	anyObject := map[string]interface{}{"type": "object", "additionalProperties": true}
+	switch s.Provider {
+	case "jwt":
+		c.Files = append(c.Files, module.File{Path: "internal/domain/auth.go", Template: "jwt_auth_domain"})
+		c.Requires = append(c.Requires, "github.com/golang-jwt/jwt/v5 v5.2.0", "golang.org/x/crypto v0.19.0")
+		c.Setup = userRepo + "\nauthSvc := authService.NewJWTAuthService(userRepo)\n" + userHdl
+		c.Router = `authGroup := r.Group("/auth")
+authGroup.POST("/login", userHdl.Login)
+authGroup.POST("/register", userHdl.Register)`
+		credentials := map[string]interface{}{
+			"type":     "object",
+			"required": []string{"email", "password"},
+			"properties": map[string]interface{}{
+				"email":    map[string]interface{}{"type": "string", "format": "email"},
+				"password": map[string]interface{}{"type": "string", "format": "password"},
+			},
+		}
+		c.Endpoints = []module.Endpoint{
+			{
+				Method: "POST", Path: "/auth/login", ID: "login", Summary: "Exchange credentials for a JWT",
+				Request:     credentials,
+				Description: "Signed token",
+				Response:    object(map[string]interface{}{"token": map[string]interface{}{"type": "string"}}),
+				Errors:      []int{401},
+			},
+			{
+				Method: "POST", Path: "/auth/register", ID: "register", Summary: "Create a user",
+				Request:     credentials,
+				Status:      201,
+				Description: "Created user",
+				Response: object(map[string]interface{}{
+					"id":      map[string]interface{}{"type": "string"},
+					"message": map[string]interface{}{"type": "string"},
+				}),
+			},
+		}
+		c.Env = []module.EnvVar{{Name: "JWT_SECRET", Value: "your_secret_key_here"}}
+	default:
+		c.Imports = append(c.Imports, `"context"`, `firebase "firebase.google.com/go/v4"`)
+		c.Setup = fmt.Sprintf(`var authSvc authService.AuthService
+if os.Getenv("MOCK_AUTH") == "true" {
+	log.Println("Using Mock Auth Service")
+	authSvc = &authService.MockAuthService{}
+} else {
+	app, err := firebase.NewApp(context.Background(), &firebase.Config{ProjectID: %q})
+	if err != nil {
+		log.Fatalf("error initializing app: %%v\n", err)
+	}
+	authClient, err := app.Auth(context.Background())
+	if err != nil {
+		log.Fatalf("error getting Auth client: %%v\n", err)
+	}
+	authSvc = &authService.FirebaseAuthService{Client: authClient}
+}
+%s
+%s`, config.Database.ProjectID, userRepo, userHdl)
+		c.Router = `authGroup := r.Group("/auth")
+authGroup.POST("/login", authService.AuthMiddleware(authSvc), userHdl.Login)`
+		c.Endpoints = []module.Endpoint{{
+			Method: "POST", Path: "/auth/login", ID: "login", Summary: "Record the user of a Firebase ID token",
+			Bearer: true,
+			Request: object(map[string]interface{}{
+				"role":     map[string]interface{}{"type": "string"},
+				"settings": anyObject,
+			}),
+			RequestOptional: true,
+			Description:     "Stored user",
+			Response:        anyObject,
+		}}
+	}
+	c.Router += `
+authGroup.GET("/me", authService.AuthMiddleware(authSvc), userHdl.GetMe)
+authGroup.GET("/roles", authService.AuthMiddleware(authSvc), userHdl.GetRoles)`
+	c.Endpoints = append(c.Endpoints,
+		module.Endpoint{
+			Method: "GET", Path: "/auth/me", ID: "me", Summary: "The authenticated user", Bearer: true,
+			Description: "User data",
+			Response:    anyObject,
+			Errors:      []int{404},
+		},
+		module.Endpoint{
+			Method: "GET", Path: "/auth/roles", ID: "roles", Summary: "Available roles", Bearer: true,
+			Description: "Role names",
+			Response:    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+		},
+	)
+	c.Env = append(c.Env, module.EnvVar{Name: "MOCK_AUTH", Value: "false"})
+	return c, nil
+}
+
+func object(properties map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
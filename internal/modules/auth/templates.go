@@ -1,6 +1,7 @@
-package generator
+package auth
 
-const AuthMiddlewareTemplate = `package auth
+// firebaseMiddlewareTemplate renders internal/auth/middleware.go with Firebase ID tokens
+const firebaseMiddlewareTemplate = `package auth
 
 import (
 	"context"
@@ -87,7 +88,8 @@ func AuthMiddleware(service AuthService) gin.HandlerFunc {
 }
 `
 
-const JWTMiddlewareTemplate = `package auth
+// jwtMiddlewareTemplate renders internal/auth/middleware.go with JWTs signed by the API
+const jwtMiddlewareTemplate = `package auth
 
 import (
 	"context"
@@ -250,7 +252,8 @@ func AuthMiddleware(service AuthService) gin.HandlerFunc {
 }
 `
 
-const AuthHandlerTemplate = `package auth
+// firebaseHandlerTemplate renders internal/handlers/auth/handler.go for Firebase users
+const firebaseHandlerTemplate = `package auth
 
 import (
 	"context"
@@ -399,7 +402,8 @@ func (h *UserHandler) GetRoles(c *gin.Context) {
 }
 `
 
-const JWTAuthHandlerTemplate = `package auth
+// jwtHandlerTemplate renders internal/handlers/auth/handler.go for JWT users
+const jwtHandlerTemplate = `package auth
 
 import (
 	"context"
@@ -496,3 +500,37 @@ func (h *UserHandler) GetRoles(c *gin.Context) {
 	c.JSON(http.StatusOK, []string{"admin", "user"})
 }
 `
+
+// jwtDomainTemplate renders internal/domain/auth.go, the user data JWT logins read
+const jwtDomainTemplate = `package domain
+
+import "context"
+
+// UserAuthData represents minimal data needed for auth
+type UserAuthData struct {
+	ID       string
+	Email    string
+	Password string
+	Role     string
+}
+
+// UserRepository interface to fetch password hashes
+type UserRepository interface {
+	GetByEmail(ctx context.Context, email string) (*UserAuthData, error)
+	RegisterUser(ctx context.Context, user *UserAuthData) (string, error)
+}
+`
+
+// configTemplate renders internal/config/config.go
+const configTemplate = `package config
+
+import "os"
+
+func GetJWTSecret() string {
+	token := os.Getenv("JWT_SECRET")
+	if token == "" {
+		return "your_jwt_secret_key"
+	}
+	return token
+}
+`
@@ -0,0 +1,207 @@
+// Package payments is the generator module taking payments through Mercado
+// Pago or Stripe: it records every notification in a model of the project and
+// adds the checkout and webhook routes. Blueprints enable it under
+// modules.payments, or with the payments key that predates modules.
+package payments
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module implements module.Module
+type Module struct{}
+
+type settings struct {
+	Provider               string `json:"provider"`
+	TransactionsCollection string `json:"transactions_collection"`
+}
+
+var identifierRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+func (Module) Name() string { return "payments" }
+
+func (Module) Schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"provider":                map[string]interface{}{"enum": domain.PaymentProviders, "description": "Payment provider."},
+			"transactions_collection": map[string]interface{}{"type": "string", "description": "Model recording the payments, \"transactions\" by default."},
+		},
+		"required":             []string{"provider"},
+		"additionalProperties": false,
+	}
+}
+
+func (Module) Templates() map[string]string {
+	return map[string]string{"mercadopago": mercadoPagoTemplate, "stripe": stripeTemplate}
+}
+
+func (Module) settings(raw json.RawMessage) (settings, error) {
+	s := settings{TransactionsCollection: "transactions"}
+	err := module.Decode(raw, &s)
+	if s.TransactionsCollection == "" {
+		s.TransactionsCollection = "transactions"
+	}
+	return s, err
+}
+
+func (m Module) Validate(config *domain.Config, raw json.RawMessage) []domain.Diagnostic {
+	s, err := m.settings(raw)
+	if err != nil {
+		return []domain.Diagnostic{{Message: err.Error()}}
+	}
+	var diags []domain.Diagnostic
+	switch {
+	case s.Provider == "":
+		diags = append(diags, domain.Diagnostic{Message: "provider is required"})
+	case !contains(domain.PaymentProviders, s.Provider):
+		diags = append(diags, domain.Diagnostic{
+			Path:    "provider",
+			Message: fmt.Sprintf("unsupported payment provider %q (available: %s)", s.Provider, strings.Join(domain.PaymentProviders, ", ")),
+		})
+	}
+	if !identifierRe.MatchString(s.TransactionsCollection) {
+		diags = append(diags, domain.Diagnostic{
+			Path:    "transactions_collection",
+			Message: fmt.Sprintf("invalid collection name %q", s.TransactionsCollection),
+		})
+	}
+	return diags
+}
+
+// Enrich adds the model recording the payments, or the fields the webhooks
+// write to the declared one
+func (m Module) Enrich(config *domain.Config, raw json.RawMessage) error {
+	s, err := m.settings(raw)
+	if err != nil {
+		return err
+	}
+	for i := range config.Models {
+		model := &config.Models[i]
+		if model.Name != s.TransactionsCollection {
+			continue
+		}
+		if model.Fields == nil {
+			model.Fields = make(map[string]string)
+		}
+		for name, spec := range map[string]string{"status": "string", "provider": "string", "payload": "string", "created_at": "datetime"} {
+			if _, ok := model.Fields[name]; !ok {
+				model.Fields[name] = spec
+			}
+		}
+		return nil
+	}
+	config.Models = append(config.Models, domain.Model{
+		Name:      s.TransactionsCollection,
+		Protected: true,
+		Fields: map[string]string{
+			"amount":     "float",
+			"status":     "string",
+			"provider":   "string",
+			"payload":    "text",
+			"created_at": "datetime",
+		},
+		Relations: map[string]string{},
+	})
+	return nil
+}
+
+func (m Module) Contribute(config *domain.Config, raw json.RawMessage) (*module.Contribution, error) {
+	s, err := m.settings(raw)
+	if err != nil {
+		return nil, err
+	}
+	typeName := strings.ToUpper(s.TransactionsCollection[:1]) + s.TransactionsCollection[1:]
+	repository := map[string]string{"firestore": "FirestoreRepository", "postgresql": "PostgresRepository", "mongodb": "MongoRepository"}[config.Database.Type]
+	c := &module.Contribution{
+		Files: []module.File{{
+			Path:     "internal/payments/" + s.Provider + ".go",
+			Template: s.Provider,
+			Data:     struct{ ProjectName, Type string }{config.ProjectName, typeName},
+		}},
+		// Aliased, since a model may be called payments too
+		Imports: []string{fmt.Sprintf("paymentService %q", config.ProjectName+"/internal/payments")},
+		Setup:   fmt.Sprintf("paymentRepo := db.New%sRepository(baseRepo.(*db.%s))", typeName, repository),
+	}
+
+	received := map[string]interface{}{"type": "object", "additionalProperties": true}
+	status := object(map[string]interface{}{"status": map[string]interface{}{"type": "string", "example": "received"}})
+	switch s.Provider {
+	case "mercadopago":
+		c.Setup += "\nmpService := paymentService.NewMercadoPagoService(paymentRepo)"
+		c.Router = `paymentGroup := r.Group("/payments")
+paymentGroup.POST("/mercadopago/preference", mpService.CreatePreferenceHandler)
+paymentGroup.POST("/mercadopago/webhook", mpService.HandleWebhook)`
+		item := object(map[string]interface{}{
+			"title":      map[string]interface{}{"type": "string"},
+			"quantity":   map[string]interface{}{"type": "integer"},
+			"unit_price": map[string]interface{}{"type": "number", "format": "double"},
+		})
+		c.Endpoints = []module.Endpoint{
+			{
+				Method: "POST", Path: "/payments/mercadopago/preference", ID: "createPreference", Summary: "Create a Mercado Pago checkout preference",
+				Request:  object(map[string]interface{}{"items": map[string]interface{}{"type": "array", "items": item}}),
+				Response: received,
+			},
+			{
+				Method: "POST", Path: "/payments/mercadopago/webhook", ID: "mercadoPagoWebhook", Summary: "Mercado Pago notification",
+				Request:  received,
+				Response: status,
+			},
+		}
+		c.Env = []module.EnvVar{{Name: "MP_ACCESS_TOKEN", Value: "your_mercadopago_access_token_here"}}
+	case "stripe":
+		c.Requires = []string{"github.com/stripe/stripe-go/v76 v76.0.0"}
+		c.Setup += "\nstripeService := paymentService.NewStripeService(paymentRepo)"
+		c.Router = `paymentGroup := r.Group("/payments")
+paymentGroup.POST("/stripe/payment-intent", stripeService.CreatePaymentIntentHandler)
+paymentGroup.POST("/stripe/webhook", stripeService.HandleWebhook)`
+		c.Endpoints = []module.Endpoint{
+			{
+				Method: "POST", Path: "/payments/stripe/payment-intent", ID: "createPaymentIntent", Summary: "Create a Stripe payment intent",
+				Request: object(map[string]interface{}{
+					"amount":   map[string]interface{}{"type": "integer", "format": "int64", "description": "Amount in cents"},
+					"currency": map[string]interface{}{"type": "string", "example": "usd"},
+				}),
+				Response: object(map[string]interface{}{
+					"client_secret": map[string]interface{}{"type": "string"},
+					"id":            map[string]interface{}{"type": "string"},
+				}),
+			},
+			{
+				Method: "POST", Path: "/payments/stripe/webhook", ID: "stripeWebhook", Summary: "Stripe event, signed in the Stripe-Signature header",
+				Headers:  []string{"Stripe-Signature"},
+				Request:  received,
+				Response: status,
+			},
+		}
+		c.Env = []module.EnvVar{
+			{Name: "STRIPE_SECRET_KEY", Value: "your_stripe_secret_key_here"},
+			{Name: "STRIPE_WEBHOOK_SECRET", Value: "your_stripe_webhook_secret_here"},
+		}
+	}
+	return c, nil
+}
+
+func object(properties map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
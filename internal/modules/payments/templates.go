@@ -1,27 +1,28 @@
-package generator
+package payments
 
-const MercadoPagoTemplate = `package payments
+// mercadoPagoTemplate renders internal/payments/mercadopago.go
+const mercadoPagoTemplate = `package payments
 
 import (
 	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
+	"os"
 	"time"
 
 	"{{.ProjectName}}/internal/domain"
-	"{{.ProjectName}}/internal/config"
 	"github.com/gin-gonic/gin"
 )
 
 type MercadoPagoService struct {
 	AccessToken string
-	Repo        domain.{{.Payments.TransactionsColl | title}}Repository
+	Repo        domain.{{.Type}}Repository
 }
 
-func NewMercadoPagoService(repo domain.{{.Payments.TransactionsColl | title}}Repository) *MercadoPagoService {
+func NewMercadoPagoService(repo domain.{{.Type}}Repository) *MercadoPagoService {
 	return &MercadoPagoService{
-		AccessToken: config.GetMPAccessToken(),
+		AccessToken: getenv("MP_ACCESS_TOKEN", "YOUR_MERCADO_PAGO_ACCESS_TOKEN_HERE"),
 		Repo:        repo,
 	}
 }
@@ -75,7 +76,7 @@ func (s *MercadoPagoService) HandleWebhook(c *gin.Context) {
 	}
 
 	// Save transaction to Firestore
-	transaction := &domain.{{.Payments.TransactionsColl | title}}{
+	transaction := &domain.{{.Type}}{
 		Provider:  "mercadopago",
 		Payload:   "notification received", // Simplified for this template
 		CreatedAt: time.Now(),
@@ -105,16 +106,25 @@ func (s *MercadoPagoService) CreatePreferenceHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// getenv reads a setting from the environment, or returns a placeholder
+func getenv(name, placeholder string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return placeholder
+}
 `
 
-const StripeTemplate = `package payments
+// stripeTemplate renders internal/payments/stripe.go
+const stripeTemplate = `package payments
 
 import (
 	"net/http"
+	"os"
 	"time"
 
 	"{{.ProjectName}}/internal/domain"
-	"{{.ProjectName}}/internal/config"
 	"github.com/gin-gonic/gin"
 	"github.com/stripe/stripe-go/v76"
 	"github.com/stripe/stripe-go/v76/paymentintent"
@@ -124,15 +134,15 @@ import (
 type StripeService struct {
 	SecretKey      string
 	WebhookSecret  string
-	Repo           domain.{{.Payments.TransactionsColl | title}}Repository
+	Repo           domain.{{.Type}}Repository
 }
 
-func NewStripeService(repo domain.{{.Payments.TransactionsColl | title}}Repository) *StripeService {
-	key := config.GetStripeSecretKey()
+func NewStripeService(repo domain.{{.Type}}Repository) *StripeService {
+	key := getenv("STRIPE_SECRET_KEY", "your_stripe_secret_key")
 	stripe.Key = key
 	return &StripeService{
 		SecretKey:     key,
-		WebhookSecret: config.GetStripeWebhookSecret(),
+		WebhookSecret: getenv("STRIPE_WEBHOOK_SECRET", "your_stripe_webhook_secret"),
 		Repo:          repo,
 	}
 }
@@ -187,7 +197,7 @@ func (s *StripeService) HandleWebhook(c *gin.Context) {
 	}
 
 	// Save transaction/event to Firestore (simplified)
-	transaction := &domain.{{.Payments.TransactionsColl | title}}{
+	transaction := &domain.{{.Type}}{
 		Provider:  "stripe",
 		Payload:   string(event.Type),
 		Status:    string(event.Type),
@@ -207,41 +217,12 @@ func (s *StripeService) HandleWebhook(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "received"})
 }
-`
-
-const ConfigTemplate = `package config
-
-import "os"
-
-func GetMPAccessToken() string {
-	token := os.Getenv("MP_ACCESS_TOKEN")
-	if token == "" {
-		return "YOUR_MERCADO_PAGO_ACCESS_TOKEN_HERE"
-	}
-	return token
-}
-
-func GetStripeSecretKey() string {
-	token := os.Getenv("STRIPE_SECRET_KEY")
-	if token == "" {
-		return "your_stripe_secret_key"
-	}
-	return token
-}
-
-func GetStripeWebhookSecret() string {
-	token := os.Getenv("STRIPE_WEBHOOK_SECRET")
-	if token == "" {
-		return "your_stripe_webhook_secret"
-	}
-	return token
-}
 
-func GetJWTSecret() string {
-	token := os.Getenv("JWT_SECRET")
-	if token == "" {
-		return "your_jwt_secret_key"
+// getenv reads a setting from the environment, or returns a placeholder
+func getenv(name, placeholder string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
 	}
-	return token
+	return placeholder
 }
 `
@@ -0,0 +1,973 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/yaml"
+)
+
+// Extensions the exporter writes where the schema alone is ambiguous, so its
+// documents import back to the same blueprint
+const (
+	typeExtension     = "x-blueprint-type"
+	relationExtension = "x-blueprint-relation"
+)
+
+var identifierRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// droppedKeywords are JSON Schema constraints no field rule can express
+var droppedKeywords = []string{
+	"exclusiveMinimum", "exclusiveMaximum", "multipleOf", "minItems", "maxItems",
+	"uniqueItems", "minProperties", "maxProperties", "const", "not",
+}
+
+// Document is an OpenAPI 3 document or a JSON Schema file to import, in JSON or YAML
+type Document struct {
+	Name string // slash-separated path, reported in warnings and matched by $refs between files
+	Data []byte
+}
+
+// Import builds a blueprint from an OpenAPI 3 document or from a set of JSON
+// Schemas. The component schemas operations read or return become models,
+// $refs between them relations, and operations on them that require security
+// make the model protected; without paths every object schema becomes a model.
+// Anything without a blueprint equivalent is left out and reported as a
+// warning. The database type is left for the caller to choose.
+func Import(docs []Document) (*domain.Config, []domain.Diagnostic, error) {
+	i := &importer{
+		schemas: make(map[string]*schemaEntry),
+		schemes: make(map[string]bool),
+		config:  &domain.Config{},
+	}
+	for _, doc := range docs {
+		if err := i.load(doc); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, doc := range i.apis {
+		i.collectOperations(doc)
+	}
+	i.nameModels()
+	i.buildModels()
+	for _, doc := range i.apis {
+		i.importAuth(doc)
+		i.importPayments(doc)
+		i.checkOperations(doc)
+	}
+
+	i.config.ProjectName = i.projectName()
+	sort.SliceStable(i.notes, func(a, b int) bool {
+		return i.notes[a].Pos.File < i.notes[b].Pos.File
+	})
+	return i.config, i.notes, nil
+}
+
+// schemaEntry is a schema that can be the target of a $ref
+type schemaEntry struct {
+	name      string // schema name, e.g. OrderItem
+	file      string
+	pointer   string // JSON pointer of the schema inside file
+	schema    map[string]interface{}
+	resource  string // path segment of the operations that read or write it
+	protected bool   // some of those operations require security
+	errors    bool   // only used by error responses
+	model     string // name of the model built from it
+}
+
+// api is a loaded OpenAPI document
+type api struct {
+	file string
+	root map[string]interface{}
+}
+
+type importer struct {
+	schemas map[string]*schemaEntry // by file + "#" + pointer
+	apis    []api
+	schemes map[string]bool // security schemes the operations require
+	config  *domain.Config
+	many    []manyRelation
+	notes   []domain.Diagnostic
+}
+
+// manyRelation is an array of $refs, a hasMany or a manyToMany relation
+// depending on whether the target points back
+type manyRelation struct {
+	model, name, target string
+}
+
+func (i *importer) warnf(file, pointer, format string, args ...interface{}) {
+	i.notes = append(i.notes, domain.Diagnostic{
+		Pos:      domain.Position{File: file},
+		Path:     "#" + pointer,
+		Severity: domain.SeverityWarning,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (i *importer) load(doc Document) error {
+	var value interface{}
+	var err error
+	if data := bytes.TrimSpace(doc.Data); bytes.HasPrefix(data, []byte("{")) {
+		err = json.Unmarshal(data, &value)
+	} else {
+		value, err = yaml.Decode(data)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", doc.Name, err)
+	}
+	root, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s: expected an OpenAPI document or a JSON Schema object", doc.Name)
+	}
+
+	file := path.Clean(doc.Name)
+	switch {
+	case root["swagger"] != nil:
+		return fmt.Errorf("%s: Swagger 2 documents are not supported, convert it to OpenAPI 3 first", doc.Name)
+	case root["openapi"] != nil:
+		i.apis = append(i.apis, api{file: file, root: root})
+		schemas := getMap(root, "components", "schemas")
+		for _, name := range keys(schemas) {
+			i.register(file, "/components/schemas/"+escapePointer(name), name, schemas[name])
+		}
+	default:
+		for _, defs := range []string{"$defs", "definitions"} {
+			schemas := getMap(root, defs)
+			for _, name := range keys(schemas) {
+				i.register(file, "/"+defs+"/"+escapePointer(name), name, schemas[name])
+			}
+		}
+		if root["properties"] != nil {
+			name, _ := root["title"].(string)
+			if name == "" {
+				name, _, _ = strings.Cut(path.Base(file), ".")
+			}
+			i.register(file, "", name, root)
+		}
+	}
+	return nil
+}
+
+func (i *importer) register(file, pointer, name string, value interface{}) {
+	schema, ok := value.(map[string]interface{})
+	if !ok {
+		i.warnf(file, pointer, "schema is not an object, skipped")
+		return
+	}
+	i.schemas[file+"#"+pointer] = &schemaEntry{name: name, file: file, pointer: pointer, schema: schema}
+}
+
+// resolve finds the schema a $ref found in file points to. A relative target
+// is read from the directory of file, so same-named files in different
+// directories stay apart.
+func (i *importer) resolve(ref, file string) (*schemaEntry, bool) {
+	target, fragment, _ := strings.Cut(ref, "#")
+	if target != "" {
+		if !path.IsAbs(target) {
+			target = path.Join(path.Dir(file), target)
+		}
+		file = path.Clean(target)
+	}
+	if unescaped, err := url.PathUnescape(fragment); err == nil {
+		fragment = unescaped
+	}
+	e, ok := i.schemas[file+"#"+strings.TrimSuffix(fragment, "/")]
+	return e, ok
+}
+
+var methods = []string{"get", "put", "post", "delete", "patch", "options", "head", "trace"}
+
+// collectOperations finds the schemas each path reads or returns, and the paths that require security
+func (i *importer) collectOperations(doc api) {
+	paths := getMap(doc.root, "paths")
+	for _, p := range keys(paths) {
+		item := getMap(paths, p)
+		segment := resourceSegment(p)
+		for _, method := range methods {
+			op := getMap(item, method)
+			if op == nil {
+				continue
+			}
+
+			security, ok := op["security"]
+			if !ok {
+				security = doc.root["security"]
+			}
+			names := requirements(security)
+			for _, name := range names {
+				i.schemes[name] = true
+			}
+			secured := len(names) > 0
+
+			body := deref(doc.root, op["requestBody"])
+			for _, media := range getMap(body, "content") {
+				i.markResource(doc.file, mediaSchema(media), segment, secured)
+			}
+			responses := getMap(op, "responses")
+			for _, code := range keys(responses) {
+				for _, media := range getMap(deref(doc.root, responses[code]), "content") {
+					if strings.HasPrefix(code, "2") {
+						i.markResource(doc.file, mediaSchema(media), segment, secured)
+					} else {
+						i.markErrors(doc.file, mediaSchema(media), map[*schemaEntry]bool{})
+					}
+				}
+			}
+
+			if method == "get" && !strings.HasSuffix(p, "}") {
+				i.importPagination(doc, item, op)
+			}
+		}
+	}
+}
+
+// markResource records segment as the path of the schemas a body refers to,
+// looking through arrays and envelopes such as {"data": [...]}
+func (i *importer) markResource(file string, schema map[string]interface{}, segment string, secured bool) {
+	if schema == nil || segment == "" {
+		return
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		if e, ok := i.resolve(ref, file); ok {
+			if e.resource == "" {
+				e.resource = segment
+			}
+			e.protected = e.protected || secured
+		}
+		return
+	}
+	i.markResource(file, getMap(schema, "items"), segment, secured)
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		for _, sub := range getList(schema, key) {
+			if sub, ok := sub.(map[string]interface{}); ok {
+				i.markResource(file, sub, segment, secured)
+			}
+		}
+	}
+	for _, prop := range getMap(schema, "properties") {
+		if prop, ok := prop.(map[string]interface{}); ok {
+			i.markResource(file, prop, segment, secured)
+		}
+	}
+}
+
+// markErrors flags the schemas error responses use, which are not resources
+func (i *importer) markErrors(file string, schema map[string]interface{}, seen map[*schemaEntry]bool) {
+	if schema == nil {
+		return
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		if e, ok := i.resolve(ref, file); ok && !seen[e] {
+			seen[e] = true
+			e.errors = true
+			i.markErrors(e.file, e.schema, seen)
+		}
+		return
+	}
+	i.markErrors(file, getMap(schema, "items"), seen)
+	for _, prop := range getMap(schema, "properties") {
+		if prop, ok := prop.(map[string]interface{}); ok {
+			i.markErrors(file, prop, seen)
+		}
+	}
+}
+
+func (i *importer) importPagination(doc api, item, op map[string]interface{}) {
+	params := append(getList(item, "parameters"), getList(op, "parameters")...)
+	for _, param := range params {
+		param := deref(doc.root, param)
+		if param["in"] != "query" {
+			continue
+		}
+		switch param["name"] {
+		case "cursor":
+			if i.config.Pagination == nil {
+				i.config.Pagination = &domain.Pagination{}
+			}
+			i.config.Pagination.Mode = domain.PaginationCursor
+		case "limit":
+			if limit, ok := getMap(param, "schema")["default"].(float64); ok && limit > 0 {
+				if i.config.Pagination == nil {
+					i.config.Pagination = &domain.Pagination{}
+				}
+				i.config.Pagination.DefaultLimit = int(limit)
+			}
+		}
+	}
+}
+
+// nameModels decides which schemas become models: the ones operations use,
+// or every object schema when no operation uses any
+func (i *importer) nameModels() {
+	entries := i.sortedEntries()
+	hasResources := false
+	for _, e := range entries {
+		hasResources = hasResources || e.resource != ""
+	}
+
+	taken := make(map[string]*schemaEntry)
+	for _, e := range entries {
+		switch {
+		case hasResources && e.resource == "":
+			continue
+		case e.resource == "" && isGeoPoint(e.schema):
+			// Read as a geopoint field by the properties referring to it
+			continue
+		case !isObject(e.schema):
+			if e.resource != "" {
+				i.warnf(e.file, e.pointer, "%s is not an object schema, no model was created", e.name)
+			}
+			continue
+		}
+
+		name := strings.ReplaceAll(e.resource, "-", "_")
+		if !identifierRe.MatchString(name) {
+			name = collectionName(e.name)
+		}
+		if other, ok := taken[name]; ok {
+			i.warnf(e.file, e.pointer, "model %q already comes from %s, skipped", name, other.name)
+			continue
+		}
+		taken[name] = e
+		e.model = name
+	}
+}
+
+func (i *importer) buildModels() {
+	models := make(map[string]*domain.Model)
+	var names []string
+	for _, e := range i.sortedEntries() {
+		if e.model == "" {
+			continue
+		}
+		model := &domain.Model{
+			Name:      e.model,
+			Protected: e.protected,
+			Fields:    make(map[string]string),
+			Relations: make(map[string]string),
+		}
+		i.importProperties(e, model)
+		if len(model.Rules) == 0 {
+			model.Rules = nil
+		}
+		models[model.Name] = model
+		names = append(names, model.Name)
+	}
+
+	for _, rel := range i.many {
+		kind := domain.ManyToMany
+		if target, ok := models[rel.target]; ok {
+			if _, back := domain.InverseKey(*target, rel.model); back {
+				kind = domain.HasMany
+			}
+		}
+		models[rel.model].Relations[rel.name] = kind + ":" + rel.target
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		i.config.Models = append(i.config.Models, *models[name])
+	}
+
+	for _, e := range i.sortedEntries() {
+		if e.model == "" && e.resource == "" && !e.errors && !i.referenced(e) && len(i.apis) > 0 {
+			i.warnf(e.file, e.pointer, "%s is not read or written by any operation, skipped", e.name)
+		}
+	}
+}
+
+// referenced reports whether a property of a model refers to e, as a GeoPoint does
+func (i *importer) referenced(e *schemaEntry) bool {
+	for _, other := range i.schemas {
+		if other.model == "" {
+			continue
+		}
+		props, _ := i.properties(other, map[*schemaEntry]bool{})
+		for _, prop := range props {
+			prop, _ := prop.(map[string]interface{})
+			for _, s := range []map[string]interface{}{unwrap(prop), getMap(unwrap(prop), "items")} {
+				if ref, ok := s["$ref"].(string); ok {
+					if target, ok := i.resolve(ref, other.file); ok && target == e {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// properties merges the properties of a schema with those of its allOf members
+func (i *importer) properties(e *schemaEntry, seen map[*schemaEntry]bool) (map[string]interface{}, []string) {
+	seen[e] = true
+	props := make(map[string]interface{})
+	var required []string
+	for _, sub := range getList(e.schema, "allOf") {
+		sub, _ := sub.(map[string]interface{})
+		member := &schemaEntry{file: e.file, schema: sub}
+		if ref, ok := sub["$ref"].(string); ok {
+			if target, ok := i.resolve(ref, e.file); ok && !seen[target] {
+				member = target
+			}
+		}
+		subProps, subRequired := i.properties(member, seen)
+		for name, prop := range subProps {
+			props[name] = prop
+		}
+		required = append(required, subRequired...)
+	}
+	for name, prop := range getMap(e.schema, "properties") {
+		props[name] = prop
+	}
+	for _, name := range getList(e.schema, "required") {
+		if name, ok := name.(string); ok {
+			required = append(required, name)
+		}
+	}
+	return props, required
+}
+
+func (i *importer) importProperties(e *schemaEntry, model *domain.Model) {
+	props, required := i.properties(e, map[*schemaEntry]bool{})
+	for _, name := range keys(props) {
+		pointer := e.pointer + "/properties/" + escapePointer(name)
+		if strings.EqualFold(name, "id") {
+			continue
+		}
+		field := snake(name)
+		if !identifierRe.MatchString(field) {
+			i.warnf(e.file, pointer, "%q is not a valid field name, skipped", name)
+			continue
+		}
+		if field != name {
+			i.warnf(e.file, pointer, "renamed to %q", field)
+		}
+
+		schema, ok := props[name].(map[string]interface{})
+		if !ok {
+			i.warnf(e.file, pointer, "property is not a schema, skipped")
+			continue
+		}
+		if rel, ok := schema[relationExtension].(string); ok {
+			model.Relations[field] = rel
+			continue
+		}
+		i.importField(e.file, pointer, model, field, schema, contains(required, name))
+	}
+}
+
+func (i *importer) importField(file, pointer string, model *domain.Model, field string, schema map[string]interface{}, required bool) {
+	s := unwrap(schema)
+	spec := ""
+
+	if ref, ok := s["$ref"].(string); ok {
+		target, ok := i.resolve(ref, file)
+		switch {
+		case !ok:
+			i.warnf(file, pointer, "unresolved $ref %q, kept as json", ref)
+			spec = "json"
+		case target.model != "":
+			model.Relations[field] = domain.BelongsTo + ":" + target.model
+			return
+		case isGeoPoint(target.schema):
+			spec = "geopoint"
+		default:
+			i.warnf(file, pointer, "%s is not a model, kept as json", target.name)
+			spec = "json"
+		}
+	} else if items := getMap(s, "items"); items != nil && items["$ref"] != nil {
+		ref, _ := items["$ref"].(string)
+		target, ok := i.resolve(ref, file)
+		if ok && target.model != "" {
+			i.many = append(i.many, manyRelation{model: model.Name, name: field, target: target.model})
+			return
+		}
+		i.warnf(file, pointer, "array of %s has no field type, kept as json", path.Base(ref))
+		spec = "json"
+	}
+
+	if spec == "" {
+		if declared, ok := s[typeExtension].(string); ok {
+			if normalized, err := domain.NormalizeFieldType(declared); err == nil {
+				spec = normalized
+			} else {
+				i.warnf(file, pointer, "%v", err)
+			}
+		}
+	}
+	if spec == "" {
+		var problem string
+		spec, problem = inferType(s)
+		if problem != "" {
+			i.warnf(file, pointer, "%s, kept as json", problem)
+		}
+	}
+	model.Fields[field] = spec
+
+	if rules := i.importRules(file, pointer, spec, s, required); len(rules.RuleNames()) > 0 {
+		if model.Rules == nil {
+			model.Rules = make(map[string]domain.FieldRules)
+		}
+		model.Rules[field] = rules
+	}
+}
+
+// importRules maps the constraints of a property to the rules its field type accepts
+func (i *importer) importRules(file, pointer, spec string, s map[string]interface{}, required bool) domain.FieldRules {
+	t := domain.ParseFieldType(spec)
+	info, _ := t.Info()
+	rules := domain.FieldRules{Required: required}
+	drop := func(keyword string) {
+		i.warnf(file, pointer, "%s does not apply to a %s field, dropped", keyword, t.Name)
+	}
+
+	for _, keyword := range []string{"minimum", "maximum"} {
+		n, ok := s[keyword].(float64)
+		switch {
+		case !ok:
+		case !info.Numeric:
+			drop(keyword)
+		case keyword == "minimum":
+			rules.Min = &n
+		default:
+			rules.Max = &n
+		}
+	}
+	for _, keyword := range []string{"minLength", "maxLength"} {
+		n, ok := s[keyword].(float64)
+		length := int(n)
+		switch {
+		case !ok:
+		case !info.Text:
+			drop(keyword)
+		case keyword == "minLength":
+			rules.MinLength = &length
+		default:
+			rules.MaxLength = &length
+		}
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		if info.Text {
+			rules.Pattern = i.importPattern(file, pointer, pattern)
+		} else {
+			drop("pattern")
+		}
+	}
+	if values := getList(s, "enum"); len(values) > 0 && t.Name != "enum" {
+		if info.Text {
+			for _, v := range values {
+				rules.Enum = append(rules.Enum, fmt.Sprint(v))
+			}
+		} else {
+			drop("enum")
+		}
+	}
+	if info.Text {
+		switch s["format"] {
+		case "email":
+			rules.Email = true
+		case "uri", "url":
+			rules.URL = true
+		}
+	}
+	switch value := s["default"].(type) {
+	case nil:
+	case string, float64, bool:
+		if info.Scalar {
+			rules.Default = value
+		} else {
+			drop("default")
+		}
+	default:
+		drop("default")
+	}
+
+	for _, keyword := range droppedKeywords {
+		if _, ok := s[keyword]; ok {
+			i.warnf(file, pointer, "%s has no blueprint rule, dropped", keyword)
+		}
+	}
+	return rules
+}
+
+// importPattern turns a search pattern into one the whole value must match
+func (i *importer) importPattern(file, pointer, pattern string) string {
+	switch {
+	case strings.HasPrefix(pattern, "^(?:") && strings.HasSuffix(pattern, ")$"):
+		pattern = pattern[len("^(?:") : len(pattern)-len(")$")]
+	case strings.HasPrefix(pattern, "^") && strings.HasSuffix(pattern, "$") && !strings.HasSuffix(pattern, `\$`):
+		pattern = pattern[1 : len(pattern)-1]
+	default:
+		pattern = ".*(?:" + pattern + ").*"
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		i.warnf(file, pointer, "pattern is not a Go regular expression, dropped: %v", err)
+		return ""
+	}
+	return pattern
+}
+
+// importAuth enables auth when operations require security, mapping the scheme to a provider
+func (i *importer) importAuth(doc api) {
+	if len(i.schemes) == 0 {
+		return
+	}
+	provider := "jwt"
+	schemes := getMap(doc.root, "components", "securitySchemes")
+	for _, name := range keys(schemes) {
+		if !i.schemes[name] {
+			continue
+		}
+		scheme := deref(doc.root, schemes[name])
+		description, _ := scheme["description"].(string)
+		switch {
+		case scheme["type"] == "http" && strings.EqualFold(fmt.Sprint(scheme["scheme"]), "bearer"):
+			if strings.Contains(strings.ToLower(description), "firebase") {
+				provider = "firebase"
+			}
+		default:
+			i.warnf(doc.file, "/components/securitySchemes/"+escapePointer(name),
+				"%v security has no blueprint equivalent, protected models use bearer JWT auth instead", scheme["type"])
+		}
+	}
+	i.enableModule("auth", fmt.Sprintf(`{"provider": %q}`, provider))
+}
+
+// importPayments enables the payment module whose routes the document lists
+func (i *importer) importPayments(doc api) {
+	for _, p := range keys(getMap(doc.root, "paths")) {
+		segments := strings.Split(strings.Trim(p, "/"), "/")
+		if len(segments) > 2 && segments[0] == "payments" && contains(domain.PaymentProviders, segments[1]) {
+			i.enableModule("payments", fmt.Sprintf(`{"provider": %q}`, segments[1]))
+			return
+		}
+	}
+}
+
+// enableModule turns on a generator module, settings being its JSON
+func (i *importer) enableModule(name, settings string) {
+	if i.config.Modules == nil {
+		i.config.Modules = make(map[string]json.RawMessage)
+	}
+	i.config.Modules[name] = json.RawMessage(settings)
+}
+
+// checkOperations reports the operations the generated API will not have
+func (i *importer) checkOperations(doc api) {
+	models := make(map[string]domain.Model)
+	for _, e := range i.schemas {
+		if e.model != "" {
+			models[e.resource] = i.model(e.model)
+		}
+	}
+
+	paths := getMap(doc.root, "paths")
+	for _, p := range keys(paths) {
+		segments := strings.Split(strings.Trim(p, "/"), "/")
+		if segments[0] == "auth" && i.config.Modules["auth"] != nil || segments[0] == "payments" && i.config.Modules["payments"] != nil {
+			continue
+		}
+		n := len(segments)
+		param := func(k int) bool {
+			return k >= 0 && k < n && strings.HasPrefix(segments[k], "{")
+		}
+
+		var allowed []string
+		switch {
+		case n >= 1 && !param(n-1):
+			if _, ok := models[segments[n-1]]; ok {
+				allowed = []string{"get", "post"}
+			}
+		case n >= 2 && !param(n-2):
+			if _, ok := models[segments[n-2]]; ok {
+				allowed = []string{"get", "put", "patch", "delete"}
+			}
+		}
+		if n >= 4 && param(n-1) && !param(n-2) && param(n-3) && !param(n-4) {
+			model, ok := models[segments[n-4]]
+			if ok && domain.ParseRelation(model.Relations[segments[n-2]]).Kind == domain.ManyToMany {
+				allowed = []string{"post", "delete"}
+			}
+		}
+
+		item := getMap(paths, p)
+		for _, method := range methods {
+			if item[method] != nil && !contains(allowed, method) {
+				i.warnf(doc.file, "/paths/"+escapePointer(p)+"/"+method, "%s %s has no blueprint equivalent, skipped", strings.ToUpper(method), p)
+			}
+		}
+	}
+}
+
+func (i *importer) model(name string) domain.Model {
+	for _, m := range i.config.Models {
+		if m.Name == name {
+			return m
+		}
+	}
+	return domain.Model{}
+}
+
+// projectName derives the project from the API title, "Pet Store API" -> "PetStore"
+func (i *importer) projectName() string {
+	for _, doc := range i.apis {
+		title, _ := getMap(doc.root, "info")["title"].(string)
+		title = strings.TrimSuffix(strings.TrimSpace(title), " API")
+		var b strings.Builder
+		for _, word := range strings.FieldsFunc(title, func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+		}) {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+		if name := b.String(); identifierRe.MatchString(name) {
+			return name
+		}
+	}
+	return "ImportedAPI"
+}
+
+func (i *importer) sortedEntries() []*schemaEntry {
+	entries := make([]*schemaEntry, 0, len(i.schemas))
+	for _, key := range keys(i.schemas) {
+		entries = append(entries, i.schemas[key])
+	}
+	return entries
+}
+
+// inferType maps a schema to the field type that represents it; problem
+// explains why a structured schema had to fall back to json
+func inferType(s map[string]interface{}) (spec, problem string) {
+	kind, _ := s["type"].(string)
+	if kinds := getList(s, "type"); len(kinds) > 0 {
+		// OpenAPI 3.1 writes nullable types as ["string", "null"]
+		for _, k := range kinds {
+			if k != "null" {
+				kind, _ = k.(string)
+			}
+		}
+	}
+	format, _ := s["format"].(string)
+
+	switch kind {
+	case "integer":
+		return "integer", ""
+	case "number":
+		if format == "decimal" {
+			return "decimal", ""
+		}
+		return "float", ""
+	case "boolean":
+		return "boolean", ""
+	case "string":
+		switch format {
+		case "date-time":
+			return "datetime", ""
+		case "date":
+			return "date", ""
+		case "uuid":
+			return "uuid", ""
+		case "decimal":
+			return "decimal", ""
+		case "binary":
+			return "file", ""
+		}
+		if values := getList(s, "enum"); len(values) > 0 {
+			var names []string
+			for _, v := range values {
+				name, ok := v.(string)
+				if !ok || name == "" || strings.ContainsAny(name, ",()") {
+					return "string", ""
+				}
+				names = append(names, name)
+			}
+			return domain.FieldType{Name: "enum", Values: names}.String(), ""
+		}
+		return "string", ""
+	case "array":
+		items := getMap(s, "items")
+		if items == nil {
+			return "json", "array without items has no field type"
+		}
+		elem, _ := inferType(unwrap(items))
+		if contains(domain.ArrayElemTypes(), elem) {
+			return "array<" + elem + ">", ""
+		}
+		return "json", "array of " + describe(items) + " has no field type"
+	}
+
+	switch {
+	case isGeoPoint(s):
+		return "geopoint", ""
+	case s["oneOf"] != nil || s["anyOf"] != nil:
+		return "json", "oneOf and anyOf have no field type"
+	case s["properties"] != nil:
+		return "json", "nested object has no field type"
+	}
+	return "json", ""
+}
+
+func describe(s map[string]interface{}) string {
+	if ref, ok := s["$ref"].(string); ok {
+		return path.Base(ref)
+	}
+	if kind, ok := s["type"].(string); ok {
+		return kind + "s"
+	}
+	return "values"
+}
+
+// unwrap reads through the allOf a single $ref is wrapped in to be nullable
+func unwrap(s map[string]interface{}) map[string]interface{} {
+	if all := getList(s, "allOf"); len(all) == 1 && s["type"] == nil && s["properties"] == nil {
+		if inner, ok := all[0].(map[string]interface{}); ok {
+			return inner
+		}
+	}
+	return s
+}
+
+func isObject(s map[string]interface{}) bool {
+	return s["type"] == "object" || s["properties"] != nil || s["allOf"] != nil
+}
+
+// isGeoPoint matches the {lat, lng} schema the exporter writes for geopoint
+func isGeoPoint(s map[string]interface{}) bool {
+	props := getMap(s, "properties")
+	if len(props) != 2 {
+		return false
+	}
+	for _, name := range []string{"lat", "lng"} {
+		if getMap(props, name)["type"] != "number" {
+			return false
+		}
+	}
+	return true
+}
+
+// requirements lists the schemes a security requirement needs; an empty
+// requirement makes security optional
+func requirements(security interface{}) []string {
+	var names []string
+	reqs, _ := security.([]interface{})
+	for _, req := range reqs {
+		req, _ := req.(map[string]interface{})
+		if len(req) == 0 {
+			return nil
+		}
+		names = append(names, keys(req)...)
+	}
+	return names
+}
+
+// resourceSegment is the collection a path operates on, "/api/products/{id}" -> "products"
+func resourceSegment(p string) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	last := segments[len(segments)-1]
+	if strings.HasPrefix(last, "{") && len(segments) > 1 {
+		last = segments[len(segments)-2]
+	}
+	if strings.HasPrefix(last, "{") {
+		return ""
+	}
+	return last
+}
+
+// deref follows a local $ref such as #/components/requestBodies/Product
+func deref(root map[string]interface{}, value interface{}) map[string]interface{} {
+	node, _ := value.(map[string]interface{})
+	ref, ok := node["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/") {
+		return node
+	}
+	var current interface{} = root
+	for _, part := range strings.Split(ref[2:], "/") {
+		part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+		m, _ := current.(map[string]interface{})
+		current = m[part]
+	}
+	resolved, _ := current.(map[string]interface{})
+	return resolved
+}
+
+// object walks nested objects by key, returning nil when one is missing
+func getMap(m map[string]interface{}, path ...string) map[string]interface{} {
+	for _, key := range path {
+		m, _ = m[key].(map[string]interface{})
+	}
+	return m
+}
+
+// mediaSchema is the schema of a media type object, such as content["application/json"]
+func mediaSchema(media interface{}) map[string]interface{} {
+	m, _ := media.(map[string]interface{})
+	return getMap(m, "schema")
+}
+
+func getList(m map[string]interface{}, key string) []interface{} {
+	l, _ := m[key].([]interface{})
+	return l
+}
+
+func keys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// snake converts a property or schema name to the blueprint naming, "firstName" -> "first_name"
+func snake(s string) string {
+	var b strings.Builder
+	for k, r := range s {
+		switch {
+		case r == '-' || r == ' ' || r == '.':
+			b.WriteByte('_')
+		case r >= 'A' && r <= 'Z':
+			if k > 0 && s[k-1] != '_' && !(s[k-1] >= 'A' && s[k-1] <= 'Z') {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r + 'a' - 'A')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// collectionName is the model name of a schema, "OrderItem" -> "order_items"
+func collectionName(schema string) string {
+	name := snake(schema)
+	switch {
+	case domain.Singular(name) != name:
+		return name
+	case strings.HasSuffix(name, "y") && !strings.HasSuffix(name, "ay") && !strings.HasSuffix(name, "ey") && !strings.HasSuffix(name, "oy"):
+		return strings.TrimSuffix(name, "y") + "ies"
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	}
+	return name + "s"
+}
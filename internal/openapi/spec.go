@@ -0,0 +1,516 @@
+// Package openapi describes the API a blueprint generates as an OpenAPI 3 document
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/module"
+)
+
+const header = "# Code generated by blueprint from the blueprint file. DO NOT EDIT.\n"
+
+// Generate returns the OpenAPI document of config as YAML
+func Generate(config *domain.Config) []byte {
+	return append([]byte(header), Build(config).YAML()...)
+}
+
+// Build describes every route the generated cmd/api/main.go registers
+func Build(config *domain.Config) *Map {
+	doc := NewMap().
+		Set("openapi", "3.0.3").
+		Set("info", NewMap().
+			Set("title", config.ProjectName+" API").
+			Set("version", "1.0.0")).
+		Set("servers", []interface{}{NewMap().Set("url", "http://localhost:8080")})
+
+	var tags []interface{}
+	paths := NewMap()
+	// A blueprint whose modules can't contribute fails validation before this
+	contributions, _ := module.Contributions(config)
+	for _, c := range contributions {
+		if len(c.Endpoints) > 0 {
+			tags = append(tags, NewMap().Set("name", c.Module))
+			addModulePaths(paths, c)
+		}
+	}
+	for _, model := range config.Models {
+		tags = append(tags, NewMap().Set("name", model.Name))
+		addModelPaths(paths, config, model)
+	}
+	doc.Set("tags", tags)
+	doc.Set("paths", paths)
+	doc.Set("components", components(config))
+	return doc
+}
+
+func authEnabled(config *domain.Config) bool {
+	return config.Auth != nil && config.Auth.Enabled
+}
+
+func ref(name string) *Map {
+	return NewMap().Set("$ref", "#/components/schemas/"+name)
+}
+
+func responseRef(name string) *Map {
+	return NewMap().Set("$ref", "#/components/responses/"+name)
+}
+
+func jsonContent(schema interface{}) *Map {
+	return NewMap().Set("application/json", NewMap().Set("schema", schema))
+}
+
+func response(description string, schema interface{}) *Map {
+	return NewMap().Set("description", description).Set("content", jsonContent(schema))
+}
+
+func body(schema interface{}) *Map {
+	return NewMap().Set("required", true).Set("content", jsonContent(schema))
+}
+
+// status builds the {"status": "..."} object the handlers answer with
+func status(value string) *Map {
+	return object(NewMap().Set("status", NewMap().Set("type", "string").Set("example", value)))
+}
+
+func object(properties *Map, required ...string) *Map {
+	schema := NewMap().Set("type", "object")
+	if len(required) > 0 {
+		schema.Set("required", required)
+	}
+	return schema.Set("properties", properties)
+}
+
+func stringSchema() *Map {
+	return NewMap().Set("type", "string")
+}
+
+func operation(tag, id, summary string) *Map {
+	return NewMap().
+		Set("tags", []string{tag}).
+		Set("operationId", id).
+		Set("summary", summary)
+}
+
+func bearer(op *Map) *Map {
+	op.Set("security", []interface{}{NewMap().Set("bearerAuth", []interface{}{})})
+	responses := op.Get("responses").(*Map)
+	responses.Set("401", responseRef("Unauthorized"))
+	return op
+}
+
+// errorResponses names the shared responses of the error codes a module
+// endpoint may list
+var errorResponses = map[int]string{
+	http.StatusBadRequest:          "BadRequest",
+	http.StatusUnauthorized:        "Unauthorized",
+	http.StatusNotFound:            "NotFound",
+	http.StatusUnprocessableEntity: "ValidationFailed",
+	http.StatusInternalServerError: "InternalError",
+}
+
+// addModulePaths documents the endpoints of a module, with the bodies it
+// describes; an undescribed answer is any JSON object
+func addModulePaths(paths *Map, c *module.Contribution) {
+	for _, e := range c.Endpoints {
+		var params []interface{}
+		segments := strings.Split(e.Path, "/")
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+				segments[i] = "{" + segment[1:] + "}"
+				params = append(params, pathParameter(segment[1:]))
+			}
+		}
+		for _, header := range e.Headers {
+			params = append(params, NewMap().
+				Set("name", header).
+				Set("in", "header").
+				Set("required", true).
+				Set("schema", stringSchema()))
+		}
+		path := strings.Join(segments, "/")
+
+		op := operation(c.Module, e.ID, e.Summary)
+		if len(params) > 0 {
+			op.Set("parameters", params)
+		}
+		var answer interface{} = NewMap().Set("type", "object").Set("additionalProperties", true)
+		if e.Response != nil {
+			answer = e.Response
+		}
+		success := e.Status
+		if success == 0 {
+			success = http.StatusOK
+		}
+		description := e.Description
+		if description == "" {
+			description = http.StatusText(success)
+		}
+		responses := NewMap().Set(strconv.Itoa(success), response(description, answer))
+		if e.Request != nil {
+			requestBody := body(e.Request)
+			if e.RequestOptional {
+				requestBody.Set("required", false)
+			} else {
+				responses.Set("400", responseRef("BadRequest"))
+			}
+			op.Set("requestBody", requestBody)
+		}
+		for _, code := range e.Errors {
+			if name, ok := errorResponses[code]; ok {
+				responses.Set(strconv.Itoa(code), responseRef(name))
+			}
+		}
+		op.Set("responses", responses.Set("500", responseRef("InternalError")))
+		if e.Bearer {
+			bearer(op)
+		}
+
+		item, ok := paths.Get(path).(*Map)
+		if !ok {
+			item = NewMap()
+			paths.Set(path, item)
+		}
+		item.Set(strings.ToLower(e.Method), op)
+	}
+}
+
+func addModelPaths(paths *Map, config *domain.Config, model domain.Model) {
+	schema := schemaName(model.Name)
+	base := "/api/" + model.Name
+	protect := func(op *Map) *Map {
+		if model.Protected {
+			return bearer(op)
+		}
+		return op
+	}
+
+	list := operation(model.Name, "list"+schema, "List "+model.Name).
+		Set("parameters", listParameters(config, model))
+	if config.CursorPagination() {
+		page := object(NewMap().
+			Set("data", NewMap().Set("type", "array").Set("items", ref(schema))).
+			Set("next_cursor", stringSchema().Set("nullable", true).Set("description", "Pass as ?cursor= to get the next page; null on the last page")).
+			Set("total", NewMap().Set("type", "integer").Set("description", "Items matching the filters, across all pages")),
+			"data", "next_cursor", "total")
+		list.Set("responses", NewMap().
+			Set("200", response("A page of "+model.Name, page).
+				Set("headers", NewMap().Set("Link", NewMap().
+					Set("description", "RFC 5988 links to the first and next pages").
+					Set("schema", stringSchema())))).
+			Set("400", responseRef("BadRequest")).
+			Set("500", responseRef("InternalError")))
+	} else {
+		list.Set("responses", NewMap().
+			Set("200", response("A page of "+model.Name, NewMap().Set("type", "array").Set("items", ref(schema)))).
+			Set("400", responseRef("BadRequest")).
+			Set("500", responseRef("InternalError")))
+	}
+
+	create := operation(model.Name, "create"+schema, "Create a record of "+model.Name).
+		Set("requestBody", body(ref(schema))).
+		Set("responses", NewMap().
+			Set("201", response("Created record", ref(schema))).
+			Set("400", responseRef("BadRequest")).
+			Set("422", responseRef("ValidationFailed")).
+			Set("500", responseRef("InternalError")))
+
+	paths.Set(base, NewMap().
+		Set("get", protect(list)).
+		Set("post", protect(create)))
+
+	get := operation(model.Name, "get"+schema, "Get a record of "+model.Name).
+		Set("parameters", []interface{}{includeParameter()}).
+		Set("responses", NewMap().
+			Set("200", response("The record", ref(schema))).
+			Set("400", responseRef("BadRequest")).
+			Set("404", responseRef("NotFound")))
+
+	update := operation(model.Name, "update"+schema, "Replace a record of "+model.Name).
+		Set("requestBody", body(ref(schema))).
+		Set("responses", NewMap().
+			Set("200", response("Updated", status("updated"))).
+			Set("400", responseRef("BadRequest")).
+			Set("422", responseRef("ValidationFailed")).
+			Set("500", responseRef("InternalError")))
+
+	remove := operation(model.Name, "delete"+schema, "Delete a record of "+model.Name).
+		Set("responses", NewMap().
+			Set("200", response("Deleted", status("deleted"))).
+			Set("500", responseRef("InternalError")))
+
+	paths.Set(base+"/{id}", NewMap().
+		Set("parameters", []interface{}{pathParameter("id")}).
+		Set("get", protect(get)).
+		Set("put", protect(update)).
+		Set("delete", protect(remove)))
+
+	for _, name := range sortedKeys(model.Relations) {
+		rel := domain.ParseRelation(model.Relations[name])
+		if rel.Kind != domain.ManyToMany {
+			continue
+		}
+		suffix := schema + pascal(name)
+		link := operation(model.Name, "link"+suffix, "Link a record of "+rel.Target+" through "+name).
+			Set("responses", NewMap().
+				Set("200", response("Linked", status("linked"))).
+				Set("500", responseRef("InternalError")))
+		unlink := operation(model.Name, "unlink"+suffix, "Unlink a record of "+rel.Target+" from "+name).
+			Set("responses", NewMap().
+				Set("200", response("Unlinked", status("unlinked"))).
+				Set("500", responseRef("InternalError")))
+
+		paths.Set(base+"/{id}/"+name+"/{targetId}", NewMap().
+			Set("parameters", []interface{}{pathParameter("id"), pathParameter("targetId")}).
+			Set("post", protect(link)).
+			Set("delete", protect(unlink)))
+	}
+}
+
+func pathParameter(name string) *Map {
+	return NewMap().
+		Set("name", name).
+		Set("in", "path").
+		Set("required", true).
+		Set("schema", stringSchema())
+}
+
+func queryParameter(name, description string, schema *Map) *Map {
+	return NewMap().
+		Set("name", name).
+		Set("in", "query").
+		Set("description", description).
+		Set("schema", schema)
+}
+
+func includeParameter() *Map {
+	return queryParameter("include", "Comma-separated relations to embed; nest with dots, e.g. author,comments.author", stringSchema())
+}
+
+func listParameters(config *domain.Config, model domain.Model) []interface{} {
+	limit := 10
+	if config.Pagination != nil && config.Pagination.DefaultLimit > 0 {
+		limit = config.Pagination.DefaultLimit
+	}
+
+	params := []interface{}{
+		queryParameter("limit", "Maximum number of items to return", NewMap().Set("type", "integer").Set("minimum", 1).Set("default", limit)),
+	}
+	if config.CursorPagination() {
+		params = append(params, queryParameter("cursor", "The next_cursor of the previous page", stringSchema()))
+	} else {
+		params = append(params, queryParameter("page", "Page number, starting at 1", NewMap().Set("type", "integer").Set("minimum", 1).Set("default", 1)))
+	}
+	params = append(params,
+		queryParameter("sort", "Comma-separated fields to sort by; prefix with - for descending order", stringSchema()),
+		queryParameter("fields", "Comma-separated fields to return besides id", stringSchema()),
+		includeParameter(),
+	)
+
+	// Equality filters; the other operators use keys such as price[gte], which
+	// OpenAPI 3.0 cannot declare one by one
+	for _, name := range sortedKeys(model.Fields) {
+		t := domain.ParseFieldType(model.Fields[name])
+		if info, ok := t.Info(); !ok || !info.Scalar {
+			continue
+		}
+		params = append(params, queryParameter(name,
+			"Filter by "+name+"; also "+name+"[ne|gt|gte|lt|lte|in]",
+			fieldSchema(t, domain.FieldRules{}, false)))
+	}
+	return params
+}
+
+func components(config *domain.Config) *Map {
+	schemas := NewMap()
+	for _, model := range config.Models {
+		schemas.Set(schemaName(model.Name), modelSchema(model))
+	}
+	if usesGeoPoint(config) {
+		schemas.Set("GeoPoint", geoPointSchema())
+	}
+	schemas.Set("Error", object(NewMap().Set("error", stringSchema()), "error"))
+	schemas.Set("FieldError", object(NewMap().
+		Set("field", stringSchema()).
+		Set("rule", stringSchema().Set("example", "required")).
+		Set("message", stringSchema().Set("example", "is required")),
+		"field", "rule", "message"))
+	schemas.Set("ValidationError", object(NewMap().
+		Set("error", stringSchema().Set("example", "validation failed")).
+		Set("fields", NewMap().Set("type", "array").Set("items", ref("FieldError"))),
+		"error", "fields"))
+
+	errorResponse := func(description string) *Map {
+		return response(description, ref("Error"))
+	}
+	responses := NewMap().
+		Set("BadRequest", errorResponse("Malformed body or invalid query parameter")).
+		Set("Unauthorized", errorResponse("Missing or invalid bearer token")).
+		Set("NotFound", errorResponse("No record with this id")).
+		Set("ValidationFailed", response("One or more fields failed validation", ref("ValidationError"))).
+		Set("InternalError", errorResponse("Database or provider failure"))
+
+	out := NewMap().Set("schemas", schemas).Set("responses", responses)
+	if needsBearer(config) {
+		scheme := NewMap().Set("type", "http").Set("scheme", "bearer")
+		if authEnabled(config) && config.Auth.Provider == "jwt" {
+			scheme.Set("bearerFormat", "JWT")
+		} else if authEnabled(config) {
+			scheme.Set("description", "Firebase ID token")
+		}
+		out.Set("securitySchemes", NewMap().Set("bearerAuth", scheme))
+	}
+	return out
+}
+
+func geoPointSchema() *Map {
+	return object(NewMap().
+		Set("lat", NewMap().Set("type", "number").Set("format", "double").Set("minimum", -90).Set("maximum", 90)).
+		Set("lng", NewMap().Set("type", "number").Set("format", "double").Set("minimum", -180).Set("maximum", 180)),
+		"lat", "lng")
+}
+
+func usesGeoPoint(config *domain.Config) bool {
+	for _, model := range config.Models {
+		for _, spec := range model.Fields {
+			if domain.ParseFieldType(spec).Name == "geopoint" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func needsBearer(config *domain.Config) bool {
+	if authEnabled(config) {
+		return true
+	}
+	for _, model := range config.Models {
+		if model.Protected {
+			return true
+		}
+	}
+	return false
+}
+
+func modelSchema(model domain.Model) *Map {
+	properties := NewMap().Set("id", stringSchema().Set("readOnly", true))
+	var required []string
+
+	for _, name := range sortedKeys(model.Fields) {
+		rules := model.Rules[name]
+		t := domain.ParseFieldType(model.Fields[name])
+		schema := fieldSchema(t, rules, true)
+		if inferred, _ := inferType(schema.generic()); t.Name != "geopoint" && inferred != t.String() {
+			// Such as text, which reads back as string
+			schema.Set(typeExtension, t.String())
+		}
+		properties.Set(name, schema)
+		if rules.Required {
+			required = append(required, name)
+		}
+	}
+	for _, name := range sortedKeys(model.Relations) {
+		rel := domain.ParseRelation(model.Relations[name])
+		id := stringSchema().Set("description", "id of a record of "+rel.Target)
+		if rel.Kind == domain.HasMany || rel.Kind == domain.ManyToMany {
+			properties.Set(name, NewMap().Set("type", "array").Set("items", id).Set(relationExtension, rel.String()))
+			continue
+		}
+		properties.Set(name, id.Set(relationExtension, rel.String()))
+	}
+	return object(properties, required...)
+}
+
+// fieldSchema describes a field type, with its rules when withRules is set
+func fieldSchema(t domain.FieldType, rules domain.FieldRules, withRules bool) *Map {
+	info, _ := t.Info()
+	var schema *Map
+	switch t.Name {
+	case "geopoint":
+		schema = ref("GeoPoint")
+	case "array":
+		schema = NewMap().Set("type", "array").Set("items", fieldSchema(domain.ParseFieldType(t.Elem), domain.FieldRules{}, false))
+	default:
+		schema = NewMap()
+		if info.Schema != "" {
+			kind, format, _ := strings.Cut(info.Schema, ":")
+			schema.Set("type", kind)
+			if format != "" {
+				schema.Set("format", format)
+			}
+		}
+		if t.Name == "enum" {
+			schema.Set("enum", t.Values)
+		}
+	}
+	if !withRules {
+		return schema
+	}
+
+	if info.Null && t.Name != "geopoint" {
+		schema.Set("nullable", true)
+	}
+	if rules.Min != nil {
+		schema.Set("minimum", *rules.Min)
+	}
+	if rules.Max != nil {
+		schema.Set("maximum", *rules.Max)
+	}
+	if rules.MinLength != nil {
+		schema.Set("minLength", *rules.MinLength)
+	}
+	if rules.MaxLength != nil {
+		schema.Set("maxLength", *rules.MaxLength)
+	}
+	if rules.Pattern != "" {
+		schema.Set("pattern", "^(?:"+rules.Pattern+")$")
+	}
+	if len(rules.Enum) > 0 {
+		schema.Set("enum", rules.Enum)
+	}
+	if rules.Email {
+		schema.Set("format", "email")
+	}
+	if rules.URL {
+		schema.Set("format", "uri")
+	}
+	if rules.Default != nil && rules.Default != "now" {
+		schema.Set("default", rules.Default)
+	}
+	if info.Null && t.Name == "geopoint" {
+		// $ref siblings are ignored in OpenAPI 3.0: wrap it to mark it nullable
+		return NewMap().Set("nullable", true).Set("allOf", []interface{}{schema})
+	}
+	return schema
+}
+
+// schemaName matches the name of the generated Go struct
+func schemaName(model string) string {
+	if model == "" {
+		return model
+	}
+	return strings.ToUpper(model[:1]) + model[1:]
+}
+
+func pascal(s string) string {
+	parts := strings.Split(s, "_")
+	for i := range parts {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,71 @@
+package openapi
+
+import "github.com/eduardo/blueprint/internal/yaml"
+
+// Map is a YAML mapping that keeps its keys in insertion order, so the same
+// blueprint always produces the same document
+type Map struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func NewMap() *Map {
+	return &Map{values: make(map[string]interface{})}
+}
+
+// Set adds or replaces key; values are *Map, []interface{}, []string, string,
+// bool, int or float64
+func (m *Map) Set(key string, value interface{}) *Map {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+	return m
+}
+
+// Get returns the value of key, or nil
+func (m *Map) Get(key string) interface{} {
+	return m.values[key]
+}
+
+// Keys lists the keys in insertion order
+func (m *Map) Keys() []string {
+	return m.keys
+}
+
+// generic converts the mapping to the values encoding/json decodes the same
+// document to, which is what the importer reads
+func (m *Map) generic() map[string]interface{} {
+	out := make(map[string]interface{}, len(m.keys))
+	for _, key := range m.keys {
+		out[key] = generic(m.values[key])
+	}
+	return out
+}
+
+func generic(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *Map:
+		return v.generic()
+	case []string:
+		items := make([]interface{}, len(v))
+		for i, s := range v {
+			items[i] = s
+		}
+		return items
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = generic(item)
+		}
+		return items
+	case int:
+		return float64(v)
+	}
+	return value
+}
+
+// YAML encodes the mapping as a block-style YAML document
+func (m *Map) YAML() []byte {
+	return yaml.Encode(m)
+}
@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/yaml"
+)
+
+// FileParser implements domain.ParserPort, choosing the format of a blueprint
+// from its extension: .json and .yaml/.yml files hold the configuration alone,
+// any other file is Markdown with the configuration in a ```json fence
+type FileParser struct {
+	markdown *MarkdownParser
+	json     *JSONParser
+	yaml     *YAMLParser
+}
+
+func NewFileParser(fs domain.FileSystemPort) *FileParser {
+	return &FileParser{
+		markdown: NewMarkdownParser(fs),
+		json:     NewJSONParser(fs),
+		yaml:     NewYAMLParser(fs),
+	}
+}
+
+// Parse reads filename with the parser of its format, merging the models of
+// the files it includes
+func (p *FileParser) Parse(filename string) (*domain.Config, error) {
+	return p.parseIncluding(filename, nil, make(map[string]bool))
+}
+
+// parseFile reads a single file with the parser of its format
+func (p *FileParser) parseFile(filename string) (*domain.Config, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return p.json.Parse(filename)
+	case ".yaml", ".yml":
+		return p.yaml.Parse(filename)
+	}
+	return p.markdown.Parse(filename)
+}
+
+// JSONParser implements domain.ParserPort for blueprint.json files
+type JSONParser struct {
+	fs domain.FileSystemPort
+}
+
+func NewJSONParser(fs domain.FileSystemPort) *JSONParser {
+	return &JSONParser{fs: fs}
+}
+
+// Parse reads a file holding the blueprint configuration as a JSON document
+func (p *JSONParser) Parse(filename string) (*domain.Config, error) {
+	content, err := p.fs.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if strings.TrimSpace(string(content)) == "" {
+		return nil, fmt.Errorf("no blueprint found in %s", filename)
+	}
+	return decodeJSON(filename, content, content, 0)
+}
+
+// YAMLParser implements domain.ParserPort for blueprint.yaml files
+type YAMLParser struct {
+	fs domain.FileSystemPort
+}
+
+func NewYAMLParser(fs domain.FileSystemPort) *YAMLParser {
+	return &YAMLParser{fs: fs}
+}
+
+// Parse reads a file holding the blueprint configuration as a YAML document,
+// with the same keys as the JSON form
+func (p *YAMLParser) Parse(filename string) (*domain.Config, error) {
+	content, err := p.fs.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	value, positions, err := yaml.DecodePositions(content)
+	if err != nil {
+		var syntaxErr *yaml.Error
+		if errors.As(err, &syntaxErr) {
+			return nil, fmt.Errorf("failed to parse YAML: %s: %s", lineStart(filename, content, syntaxErr.Line), syntaxErr.Message)
+		}
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if value == nil {
+		return nil, fmt.Errorf("no blueprint found in %s", filename)
+	}
+
+	source := domain.NewSourceMap(filename)
+	paths := make([]string, 0, len(positions))
+	for path := range positions {
+		paths = append(paths, path)
+	}
+	// Parents come first, as they do in the JSON source map
+	sort.Slice(paths, func(i, j int) bool {
+		a, b := positions[paths[i]], positions[paths[j]]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return len(paths[i]) < len(paths[j])
+	})
+	for _, path := range paths {
+		pos := positions[path]
+		source.Add(path, domain.Position{File: filename, Line: pos.Line, Column: pos.Column})
+	}
+
+	// Decoding the JSON equivalent keeps the JSON form's semantics, including
+	// type errors, which are placed at the key they were found under
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	var config domain.Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		if path, ok := modelErrorPath(data, err); ok {
+			return nil, fmt.Errorf("failed to parse YAML: %s: %s: %w", source.Lookup(path), path, err)
+		}
+		if offset, ok := jsonErrorOffset(err); ok {
+			return nil, fmt.Errorf("failed to parse YAML: %s: %w", source.Lookup(pathAt(data, offset)), err)
+		}
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	config.Source = source
+	return finish(&config), nil
+}
+
+// pathAt returns the path of the innermost key or item of a compact JSON
+// document that starts before offset
+func pathAt(data []byte, offset int) string {
+	source, err := buildSourceMap("", data, data, 0)
+	if err != nil {
+		return ""
+	}
+	column := position("", data, offset).Column
+	found := ""
+	for _, path := range source.Paths() {
+		if source.Lookup(path).Column <= column {
+			found = path
+		}
+	}
+	return found
+}
+
+// lineStart is the position of the first character of a line
+func lineStart(filename string, content []byte, line int) domain.Position {
+	lines := strings.Split(string(content), "\n")
+	column := 1
+	if line >= 1 && line <= len(lines) {
+		text := lines[line-1]
+		column += len(text) - len(strings.TrimLeft(text, " "))
+	}
+	return domain.Position{File: filename, Line: line, Column: column}
+}
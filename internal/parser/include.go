@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// includedKeys are the top-level keys an included blueprint may declare; the
+// project settings belong to the file that includes it
+var includedKeys = map[string]bool{"$schema": true, "models": true, "include": true}
+
+// parseIncluding parses filename and merges in the models of its includes.
+// stack holds the files being included, to report cycles; seen the files
+// already merged, so a file included twice only adds its models once.
+func (p *FileParser) parseIncluding(filename string, stack []string, seen map[string]bool) (*domain.Config, error) {
+	config, err := p.parseFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	stack = append(stack, filepath.Clean(filename))
+	seen[filepath.Clean(filename)] = true
+
+	for i, include := range config.Include {
+		pos := config.Source.Lookup(fmt.Sprintf("include[%d]", i))
+		path := include
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(filename), path)
+		}
+		path = filepath.Clean(path)
+
+		for _, open := range stack {
+			if open == path {
+				return nil, fmt.Errorf("%s: include cycle: %s -> %s", pos, strings.Join(stack, " -> "), path)
+			}
+		}
+		if seen[path] {
+			continue
+		}
+
+		included, err := p.parseIncluding(path, stack, seen)
+		if err != nil {
+			return nil, fmt.Errorf("%s: include %q: %w", pos, include, err)
+		}
+		if err := merge(config, included); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// merge appends the models of an included blueprint to config, keeping the
+// position and the path of every key in the file it was declared in. A model
+// name declared again is an error naming both files.
+func merge(config, included *domain.Config) error {
+	for _, path := range included.Source.Paths() {
+		key := path
+		if end := strings.IndexAny(key, ".["); end >= 0 {
+			key = key[:end]
+		}
+		if key != "" && !includedKeys[key] {
+			return fmt.Errorf("%s: included blueprints declare models only, found %q", included.Source.Lookup(path), key)
+		}
+	}
+
+	declared := make(map[string]int, len(config.Models))
+	for i, model := range config.Models {
+		if _, ok := declared[strings.ToLower(model.Name)]; !ok && model.Name != "" {
+			declared[strings.ToLower(model.Name)] = i
+		}
+	}
+	for i, model := range included.Models {
+		if prev, ok := declared[strings.ToLower(model.Name)]; ok {
+			name := fmt.Sprintf("models[%d].name", i)
+			return fmt.Errorf("%s: %s: duplicate model %q (already declared at %s)",
+				included.Source.Lookup(name), included.Source.Name(name), model.Name,
+				config.Source.Lookup(fmt.Sprintf("models[%d].name", prev)))
+		}
+	}
+
+	offset := len(config.Models)
+	config.Models = append(config.Models, included.Models...)
+	for i := range included.Models {
+		config.Source.Rename(fmt.Sprintf("models[%d]", i+offset), included.Source.Name(fmt.Sprintf("models[%d]", i)))
+	}
+	for _, path := range included.Source.Paths() {
+		if !strings.HasPrefix(path, "models[") {
+			continue
+		}
+		end := strings.IndexByte(path, ']')
+		index, err := strconv.Atoi(path[len("models["):end])
+		if err != nil {
+			continue
+		}
+		config.Source.Add(fmt.Sprintf("models[%d]%s", index+offset, path[end+1:]), included.Source.Lookup(path))
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// locator walks a JSON document token by token and records the offset of every key
+type locator struct {
+	dec     *json.Decoder
+	data    []byte
+	content []byte
+	base    int
+	source  *domain.SourceMap
+}
+
+// buildSourceMap maps every JSON path of data to its line and column inside content.
+// base is the byte offset at which data starts within content.
+func buildSourceMap(filename string, content, data []byte, base int) (*domain.SourceMap, error) {
+	l := &locator{
+		dec:     json.NewDecoder(bytes.NewReader(data)),
+		data:    data,
+		content: content,
+		base:    base,
+		source:  domain.NewSourceMap(filename),
+	}
+	l.add("", skipSeparators(data, 0))
+	if err := l.value(""); err != nil {
+		return nil, err
+	}
+	return l.source, nil
+}
+
+func (l *locator) value(path string) error {
+	tok, err := l.dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		for l.dec.More() {
+			keyTok, err := l.dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := keyTok.(string)
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			l.add(child, quoteStart(l.data, int(l.dec.InputOffset())))
+			if err := l.value(child); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; l.dec.More(); i++ {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			l.add(child, skipSeparators(l.data, int(l.dec.InputOffset())))
+			if err := l.value(child); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Consume the closing delimiter
+	_, err = l.dec.Token()
+	return err
+}
+
+func (l *locator) add(path string, offset int) {
+	l.source.Add(path, position(l.source.File, l.content, l.base+offset))
+}
+
+// quoteStart returns the offset of the opening quote of the string ending right before end
+func quoteStart(data []byte, end int) int {
+	for i := end - 2; i >= 0; i-- {
+		if data[i] != '"' {
+			continue
+		}
+		backslashes := 0
+		for j := i - 1; j >= 0 && data[j] == '\\'; j-- {
+			backslashes++
+		}
+		if backslashes%2 == 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// skipSeparators advances offset past whitespace and commas
+func skipSeparators(data []byte, offset int) int {
+	for offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// position converts a byte offset into a 1-based line and column
+func position(filename string, content []byte, offset int) domain.Position {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	line := 1 + bytes.Count(content[:offset], []byte("\n"))
+	lineStart := bytes.LastIndexByte(content[:offset], '\n') + 1
+	return domain.Position{
+		File:   filename,
+		Line:   line,
+		Column: utf8.RuneCount(content[lineStart:offset]) + 1,
+	}
+}
+
+// jsonErrorOffset extracts the byte offset carried by encoding/json errors
+func jsonErrorOffset(err error) (int, bool) {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return int(syntaxErr.Offset), true
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return int(typeErr.Offset), true
+	}
+	return 0, false
+}
+
+// modelErrorPath returns the path in data of a domain.ModelError, which only
+// knows its path within the model that failed to decode
+func modelErrorPath(data []byte, err error) (string, bool) {
+	var modelErr *domain.ModelError
+	if !errors.As(err, &modelErr) {
+		return "", false
+	}
+	var doc struct {
+		Models []json.RawMessage `json:"models"`
+	}
+	if json.Unmarshal(data, &doc) != nil {
+		return "", false
+	}
+	// encoding/json reports the first model that fails, as does this loop
+	for i, raw := range doc.Models {
+		var model domain.Model
+		if json.Unmarshal(raw, &model) != nil {
+			path := fmt.Sprintf("models[%d]", i)
+			if modelErr.Path != "" {
+				path += "." + modelErr.Path
+			}
+			return path, true
+		}
+	}
+	return "", false
+}
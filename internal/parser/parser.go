@@ -26,19 +26,56 @@ func (p *MarkdownParser) Parse(filename string) (*domain.Config, error) {
 
 	// Regex to find the JSON block between ```json and ```
 	re := regexp.MustCompile("(?s)```json\\s*(.*?)\\s*```")
-	matches := re.FindSubmatch(content)
+	loc := re.FindSubmatchIndex(content)
 
-	if len(matches) < 2 {
+	if loc == nil {
 		return nil, fmt.Errorf("no JSON block found in %s", filename)
 	}
 
-	jsonContent := matches[1]
+	jsonStart := loc[2]
+	jsonContent := content[loc[2]:loc[3]]
+	return decodeJSON(filename, content, jsonContent, jsonStart)
+}
 
+// decodeJSON decodes the blueprint held in data, which starts at byte offset
+// base of the file content
+func decodeJSON(filename string, content, data []byte, base int) (*domain.Config, error) {
 	var config domain.Config
-	if err := json.Unmarshal(jsonContent, &config); err != nil {
+	if err := json.Unmarshal(data, &config); err != nil {
+		if path, ok := modelErrorPath(data, err); ok {
+			if source, indexErr := buildSourceMap(filename, content, data, base); indexErr == nil {
+				return nil, fmt.Errorf("failed to parse JSON: %s: %s: %w", source.Lookup(path), path, err)
+			}
+		}
+		if offset, ok := jsonErrorOffset(err); ok {
+			return nil, fmt.Errorf("failed to parse JSON: %s: %w", position(filename, content, base+offset), err)
+		}
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
+	source, err := buildSourceMap(filename, content, data, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to index JSON: %w", err)
+	}
+	config.Source = source
+	return finish(&config), nil
+}
+
+// finish applies what every blueprint format shares once the configuration is decoded
+func finish(config *domain.Config) *domain.Config {
+	// Aliases such as "int" become canonical names here, so the generators only
+	// see those; unknown types are left for the validator to report in place
+	for _, model := range config.Models {
+		for name, spec := range model.Fields {
+			if normalized, err := domain.NormalizeFieldType(spec); err == nil {
+				model.Fields[name] = normalized
+			}
+		}
+	}
+
+	moveAuth(config)
+	movePayments(config)
+
 	// Default to Firestore if no database is specified
 	if config.Database.Type == "" {
 		config.Database.Type = "firestore"
@@ -47,5 +84,73 @@ func (p *MarkdownParser) Parse(filename string) (*domain.Config, error) {
 		}
 	}
 
-	return &config, nil
+	return config
+}
+
+// moveAuth hands the auth key, which predates modules, to the auth module,
+// like movePayments
+func moveAuth(config *domain.Config) {
+	a := config.LegacyAuth
+	if a == nil || a.Enabled && config.Modules["auth"] != nil {
+		return
+	}
+	config.LegacyAuth = nil
+	if !a.Enabled {
+		return
+	}
+
+	settings := make(map[string]string)
+	if a.Provider != "" {
+		settings["provider"] = a.Provider
+	}
+	if a.UserCollection != "" {
+		settings["user_collection"] = a.UserCollection
+	}
+	data, _ := json.Marshal(settings)
+	if config.Modules == nil {
+		config.Modules = make(map[string]json.RawMessage)
+	}
+	config.Modules["auth"] = data
+
+	if config.Source != nil {
+		for _, key := range []string{"", ".provider", ".user_collection"} {
+			if pos := config.Source.Lookup("auth" + key); pos.Line > 0 {
+				config.Source.Add("modules.auth"+key, pos)
+			}
+		}
+		config.Source.Rename("modules.auth", "auth")
+	}
+}
+
+// movePayments hands the payments key, which predates modules, to the payments
+// module. Its diagnostics keep pointing at the payments key. When
+// modules.payments is declared too, the key is left for the validator to report.
+func movePayments(config *domain.Config) {
+	p := config.Payments
+	if p == nil || p.Enabled && config.Modules["payments"] != nil {
+		return
+	}
+	config.Payments = nil
+	if !p.Enabled {
+		return
+	}
+
+	settings := map[string]string{"provider": p.Provider}
+	if p.TransactionsColl != "" {
+		settings["transactions_collection"] = p.TransactionsColl
+	}
+	data, _ := json.Marshal(settings)
+	if config.Modules == nil {
+		config.Modules = make(map[string]json.RawMessage)
+	}
+	config.Modules["payments"] = data
+
+	if config.Source != nil {
+		for _, key := range []string{"", ".provider", ".transactions_collection"} {
+			if pos := config.Source.Lookup("payments" + key); pos.Line > 0 {
+				config.Source.Add("modules.payments"+key, pos)
+			}
+		}
+		config.Source.Rename("modules.payments", "payments")
+	}
 }
@@ -0,0 +1,244 @@
+package regen
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/diff"
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// Strategy decides what happens to a generated file the user has modified
+type Strategy string
+
+const (
+	StrategyOverwrite Strategy = "overwrite" // replace the user's version
+	StrategySkip      Strategy = "skip"      // keep the user's version untouched
+	StrategySideFile  Strategy = "side"      // keep the user's version and write <file>.new next to it
+	StrategyMerge     Strategy = "merge"     // three-way merge, falling back to <file>.new on conflicts
+)
+
+// ParseStrategy validates a strategy name coming from the command line
+func ParseStrategy(s string) (Strategy, error) {
+	switch Strategy(s) {
+	case StrategyOverwrite, StrategySkip, StrategySideFile, StrategyMerge:
+		return Strategy(s), nil
+	default:
+		return "", fmt.Errorf("unknown conflict strategy %q (expected overwrite, skip, side or merge)", s)
+	}
+}
+
+// Action is what the regeneration did with a single file
+type Action string
+
+const (
+	ActionCreated     Action = "created"
+	ActionUpdated     Action = "updated"
+	ActionUnchanged   Action = "unchanged"
+	ActionOverwritten Action = "overwritten"
+	ActionSkipped     Action = "skipped"
+	ActionSideFile    Action = "side-file"
+	ActionMerged      Action = "merged"
+	ActionConflict    Action = "conflict"
+	ActionStale       Action = "stale"
+	ActionOrphaned    Action = "orphaned-region"
+)
+
+// Result reports the action taken for a file, relative to the project root
+type Result struct {
+	Path   string
+	Action Action
+}
+
+// NeedsAttention reports whether the user should look at the file after regeneration
+func (r Result) NeedsAttention() bool {
+	switch r.Action {
+	case ActionCreated, ActionUpdated, ActionUnchanged:
+		return false
+	}
+	return true
+}
+
+// Suffixes of the files written next to a generated file that needs attention
+const (
+	SideFileSuffix = ".new"     // generated version that could not be applied
+	RegionsSuffix  = ".regions" // custom regions whose markers disappeared from the template
+)
+
+// FileSystem implements domain.FileSystemPort on top of another port, protecting
+// files under root that were modified since they were last generated.
+type FileSystem struct {
+	domain.FileSystemPort
+	root     string
+	strategy Strategy
+	previous *Manifest
+	current  *Manifest
+	results  []Result
+}
+
+// NewFileSystem loads the manifest of the project at root and wraps fs
+func NewFileSystem(fs domain.FileSystemPort, root string, strategy Strategy) (*FileSystem, error) {
+	previous, err := loadManifest(fs, root)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSystem{
+		FileSystemPort: fs,
+		root:           filepath.Clean(root),
+		strategy:       strategy,
+		previous:       previous,
+		current:        newManifest(),
+	}, nil
+}
+
+func (fs *FileSystem) WriteFile(path string, data []byte) error {
+	rel, ok := fs.relative(path)
+	if !ok {
+		return fs.FileSystemPort.WriteFile(path, data)
+	}
+
+	existing, err := fs.FileSystemPort.ReadFile(path)
+	if err != nil {
+		fs.current.Files[rel] = fingerprint(data)
+		fs.record(rel, ActionCreated)
+		return fs.writeGenerated(path, rel, data)
+	}
+
+	// Custom regions always survive, whatever the strategy
+	merged, orphaned := applyRegions(string(data), string(existing))
+	data = []byte(merged)
+	fs.current.Files[rel] = fingerprint(data)
+	if len(orphaned) > 0 {
+		if err := fs.saveOrphanedRegions(path, string(existing), orphaned); err != nil {
+			return err
+		}
+		fs.record(rel, ActionOrphaned)
+	}
+
+	if bytes.Equal(existing, data) {
+		fs.record(rel, ActionUnchanged)
+		return fs.saveBase(rel, data)
+	}
+	if previousHash, ok := fs.previous.Files[rel]; ok && previousHash == fingerprint(existing) {
+		fs.record(rel, ActionUpdated)
+		return fs.writeGenerated(path, rel, data)
+	}
+
+	// The file on disk is not what we generated last time: the user owns those changes
+	switch fs.strategy {
+	case StrategyOverwrite:
+		fs.record(rel, ActionOverwritten)
+		return fs.writeGenerated(path, rel, data)
+	case StrategySkip:
+		fs.record(rel, ActionSkipped)
+		return fs.saveBase(rel, data)
+	case StrategySideFile:
+		fs.record(rel, ActionSideFile)
+		return fs.writeSideFile(path, rel, data)
+	default:
+		return fs.merge(path, rel, existing, data)
+	}
+}
+
+func (fs *FileSystem) merge(path, rel string, existing, data []byte) error {
+	base, err := fs.FileSystemPort.ReadFile(filepath.Join(fs.root, StateDir, baseDir, rel))
+	if err != nil {
+		// Without the previously generated version there is nothing to merge against
+		fs.record(rel, ActionSideFile)
+		return fs.writeSideFile(path, rel, data)
+	}
+
+	merged, conflicts := diff.Merge3(string(base), string(existing), string(data))
+	if conflicts > 0 {
+		fs.record(rel, ActionConflict)
+		if err := fs.FileSystemPort.WriteFile(path+SideFileSuffix, []byte(merged)); err != nil {
+			return err
+		}
+		return fs.saveBase(rel, data)
+	}
+
+	fs.record(rel, ActionMerged)
+	if err := fs.FileSystemPort.WriteFile(path, []byte(merged)); err != nil {
+		return err
+	}
+	return fs.saveBase(rel, data)
+}
+
+// saveOrphanedRegions keeps the code of regions the template no longer declares
+func (fs *FileSystem) saveOrphanedRegions(path, existing string, names []string) error {
+	bodies := extractRegions(existing)
+	var buf bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&buf, "// %s %s\n%s// %s %s\n", RegionBegin, name, bodies[name], RegionEnd, name)
+	}
+	return fs.FileSystemPort.WriteFile(path+RegionsSuffix, buf.Bytes())
+}
+
+func (fs *FileSystem) writeGenerated(path, rel string, data []byte) error {
+	if err := fs.FileSystemPort.WriteFile(path, data); err != nil {
+		return err
+	}
+	return fs.saveBase(rel, data)
+}
+
+func (fs *FileSystem) writeSideFile(path, rel string, data []byte) error {
+	if err := fs.FileSystemPort.WriteFile(path+SideFileSuffix, data); err != nil {
+		return err
+	}
+	return fs.saveBase(rel, data)
+}
+
+// saveBase keeps a copy of the generated content to merge against next time
+func (fs *FileSystem) saveBase(rel string, data []byte) error {
+	path := filepath.Join(fs.root, StateDir, baseDir, rel)
+	if err := fs.FileSystemPort.MkdirAll(filepath.Dir(path)); err != nil {
+		return err
+	}
+	return fs.FileSystemPort.WriteFile(path, data)
+}
+
+func (fs *FileSystem) record(rel string, action Action) {
+	fs.results = append(fs.results, Result{Path: rel, Action: action})
+}
+
+// relative returns path relative to the project root, if it is a generated file inside it
+func (fs *FileSystem) relative(path string) (string, bool) {
+	rel, err := filepath.Rel(fs.root, filepath.Clean(path))
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	if IsState(fs.root, path) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// IsState reports whether path is part of the regeneration state kept in the
+// project at root, such as the manifest and the base copies, rather than a
+// file of the project
+func IsState(root, path string) bool {
+	rel, err := filepath.Rel(root, filepath.Clean(path))
+	return err == nil && (rel == StateDir || strings.HasPrefix(rel, StateDir+string(filepath.Separator)))
+}
+
+// Commit saves the manifest and reports files generated previously but not anymore
+func (fs *FileSystem) Commit() error {
+	for rel := range fs.previous.Files {
+		if _, ok := fs.current.Files[rel]; !ok {
+			fs.record(rel, ActionStale)
+		}
+	}
+	return fs.current.save(fs.FileSystemPort, fs.root)
+}
+
+// Results lists what happened to every file, sorted by path
+func (fs *FileSystem) Results() []Result {
+	results := append([]Result(nil), fs.results...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Path < results[j].Path
+	})
+	return results
+}
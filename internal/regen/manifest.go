@@ -0,0 +1,68 @@
+package regen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// StateDir holds the regeneration state inside the generated project
+const StateDir = ".blueprint"
+
+const (
+	manifestFile    = "manifest.json"
+	baseDir         = "base"
+	manifestVersion = 1
+)
+
+// Manifest records the fingerprint of every file as it was last generated
+type Manifest struct {
+	Version int               `json:"version"`
+	Files   map[string]string `json:"files"`
+}
+
+func newManifest() *Manifest {
+	return &Manifest{Version: manifestVersion, Files: make(map[string]string)}
+}
+
+// loadManifest reads the manifest of the project at root, returning an empty one if missing
+func loadManifest(fs domain.FileSystemPort, root string) (*Manifest, error) {
+	data, err := fs.ReadFile(filepath.Join(root, StateDir, manifestFile))
+	if err != nil {
+		return newManifest(), nil
+	}
+
+	m := newManifest()
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid regeneration manifest: %w", err)
+	}
+	if m.Files == nil {
+		m.Files = make(map[string]string)
+	}
+	return m, nil
+}
+
+func (m *Manifest) save(fs domain.FileSystemPort, root string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := fs.MkdirAll(filepath.Join(root, StateDir)); err != nil {
+		return err
+	}
+	return fs.WriteFile(filepath.Join(root, StateDir, manifestFile), append(data, '\n'))
+}
+
+// fingerprint hashes a file ignoring the content of its custom regions
+func fingerprint(data []byte) string {
+	return hash([]byte(stripRegions(string(data))))
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
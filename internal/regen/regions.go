@@ -0,0 +1,104 @@
+package regen
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/diff"
+)
+
+// Markers delimiting a custom code region inside a generated file. They can follow any
+// comment prefix (//, #, ...); the lines between them are kept verbatim on regeneration.
+const (
+	RegionBegin = "blueprint:custom-begin"
+	RegionEnd   = "blueprint:custom-end"
+)
+
+var regionMarkerRe = regexp.MustCompile(`blueprint:custom-(begin|end)\s+([A-Za-z0-9_.-]+)`)
+
+// region is a parsed custom block: lines[bodyStart:bodyEnd] are the user's code
+type region struct {
+	name      string
+	bodyStart int
+	bodyEnd   int
+}
+
+// findRegions locates well-formed regions; unbalanced markers are ignored
+func findRegions(lines []string) []region {
+	var regions []region
+	open := ""
+	start := 0
+	for i, line := range lines {
+		m := regionMarkerRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		switch {
+		case m[1] == "begin" && open == "":
+			open, start = m[2], i+1
+		case m[1] == "end" && m[2] == open:
+			regions = append(regions, region{name: open, bodyStart: start, bodyEnd: i})
+			open = ""
+		}
+	}
+	return regions
+}
+
+// extractRegions returns the body of every custom region in content, keyed by name
+func extractRegions(content string) map[string]string {
+	lines := diff.SplitLines(content)
+	bodies := make(map[string]string)
+	for _, r := range findRegions(lines) {
+		bodies[r.name] = strings.Join(lines[r.bodyStart:r.bodyEnd], "")
+	}
+	return bodies
+}
+
+// replaceRegions rewrites the body of every region of content through fill
+func replaceRegions(content string, fill func(name, body string) string) string {
+	lines := diff.SplitLines(content)
+	var out strings.Builder
+	pos := 0
+	for _, r := range findRegions(lines) {
+		out.WriteString(strings.Join(lines[pos:r.bodyStart], ""))
+		out.WriteString(fill(r.name, strings.Join(lines[r.bodyStart:r.bodyEnd], "")))
+		pos = r.bodyEnd
+	}
+	out.WriteString(strings.Join(lines[pos:], ""))
+	return out.String()
+}
+
+// applyRegions carries the custom regions of existing over into generated.
+// It returns the merged content and the names of regions that no longer exist.
+func applyRegions(generated, existing string) (string, []string) {
+	saved := extractRegions(existing)
+	if len(saved) == 0 {
+		return generated, nil
+	}
+
+	used := make(map[string]bool)
+	merged := replaceRegions(generated, func(name, body string) string {
+		if custom, ok := saved[name]; ok {
+			used[name] = true
+			return custom
+		}
+		return body
+	})
+
+	var orphaned []string
+	for name, body := range saved {
+		if !used[name] && strings.TrimSpace(body) != "" {
+			orphaned = append(orphaned, name)
+		}
+	}
+	sort.Strings(orphaned)
+	return merged, orphaned
+}
+
+// stripRegions empties every region so edits inside them don't count as modifications
+func stripRegions(content string) string {
+	return replaceRegions(content, func(name, body string) string {
+		return ""
+	})
+}
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+// checkUnknownKeys warns about JSON keys that don't map to any field of domain.Config.
+// Allowed keys are derived from the json tags so the check follows the Go types.
+func checkUnknownKeys(r *report, source *domain.SourceMap) {
+	if source == nil {
+		return
+	}
+	types := map[string]reflect.Type{"": reflect.TypeOf(domain.Config{})}
+
+	for _, path := range source.Paths() {
+		if path == "" {
+			continue
+		}
+		parent, ok := types[domain.ParentPath(path)]
+		if !ok {
+			continue
+		}
+		parent = indirect(parent)
+
+		segment := path[len(domain.ParentPath(path)):]
+		switch {
+		case strings.HasPrefix(segment, "["):
+			if parent.Kind() == reflect.Slice || parent.Kind() == reflect.Array {
+				types[path] = parent.Elem()
+			}
+		case parent.Kind() == reflect.Map:
+			types[path] = parent.Elem()
+			if strings.HasSuffix(domain.ParentPath(path), ".fields") {
+				// A field is a type name or, when it has keys, FieldRules
+				types[path] = reflect.TypeOf(domain.FieldRules{})
+			}
+		case parent.Kind() == reflect.Struct:
+			key := strings.TrimPrefix(segment, ".")
+			fields := jsonFields(parent)
+			if t, ok := fields[key]; ok {
+				types[path] = t
+				continue
+			}
+			r.warnf(path, "unknown key %q%s", key, suggest(key, sortedFieldNames(fields)))
+		}
+	}
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// jsonFields maps the json names of a struct to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func sortedFieldNames(fields map[string]reflect.Type) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// suggest returns a " (did you mean ...?)" hint when s is close to one of the candidates
+func suggest(s string, candidates []string) string {
+	if s == "" {
+		return ""
+	}
+	best := ""
+	bestDist := 3
+	for _, c := range candidates {
+		if strings.EqualFold(s, c) {
+			return fmt.Sprintf(" (did you mean %q?)", c)
+		}
+		if d := levenshtein(strings.ToLower(s), strings.ToLower(c)); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,438 @@
+package validator
+
+import (
+	"fmt"
+	"go/token"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/module"
+)
+
+var (
+	decimalRe     = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+	projectNameRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+	identifierRe  = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+)
+
+// reservedModelNames collide with packages the generator always emits
+var reservedModelNames = []string{"auth"}
+
+// Validator implements domain.ValidatorPort
+type Validator struct{}
+
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// report collects diagnostics and resolves their positions through the source map
+type report struct {
+	source *domain.SourceMap
+	diags  []domain.Diagnostic
+}
+
+func (r *report) add(severity domain.Severity, path, format string, args ...interface{}) {
+	r.diags = append(r.diags, domain.Diagnostic{
+		Pos:      r.source.Lookup(path),
+		Path:     r.source.Name(path),
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (r *report) errorf(path, format string, args ...interface{}) {
+	r.add(domain.SeverityError, path, format, args...)
+}
+
+func (r *report) warnf(path, format string, args ...interface{}) {
+	r.add(domain.SeverityWarning, path, format, args...)
+}
+
+// Validate checks the whole blueprint and returns every problem found, sorted by position
+func (v *Validator) Validate(config *domain.Config) []domain.Diagnostic {
+	r := &report{source: config.Source}
+
+	checkUnknownKeys(r, config.Source)
+	v.validateProject(r, config)
+	v.validateDatabase(r, config)
+	v.validateAuth(r, config)
+	v.validatePayments(r, config)
+	v.validatePagination(r, config)
+	v.validateModels(r, config)
+	v.validateModules(r, config)
+
+	sort.SliceStable(r.diags, func(i, j int) bool {
+		a, b := r.diags[i].Pos, r.diags[j].Pos
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return r.diags
+}
+
+func (v *Validator) validateProject(r *report, config *domain.Config) {
+	if config.ProjectName == "" {
+		r.errorf("project_name", "project_name is required")
+		return
+	}
+	if !projectNameRe.MatchString(config.ProjectName) {
+		r.errorf("project_name", "invalid project name %q: use letters, digits, '-' and '_' only, starting with a letter", config.ProjectName)
+	}
+}
+
+func (v *Validator) validateDatabase(r *report, config *domain.Config) {
+	if !contains(domain.DatabaseTypes, config.Database.Type) {
+		r.errorf("database.type", "unsupported database type %q%s", config.Database.Type, suggest(config.Database.Type, domain.DatabaseTypes))
+	}
+}
+
+// validateAuth reports an auth key the parser couldn't hand to the auth
+// module, because modules.auth configures it already
+func (v *Validator) validateAuth(r *report, config *domain.Config) {
+	if config.LegacyAuth != nil && config.LegacyAuth.Enabled {
+		r.errorf("auth", "auth and modules.auth both configure the auth module, keep modules.auth")
+	}
+}
+
+// validatePayments reports a payments key the parser couldn't hand to the
+// payments module, because modules.payments configures it already
+func (v *Validator) validatePayments(r *report, config *domain.Config) {
+	if config.Payments != nil && config.Payments.Enabled {
+		r.errorf("payments", "payments and modules.payments both configure the payments module, keep modules.payments")
+	}
+}
+
+func (v *Validator) validatePagination(r *report, config *domain.Config) {
+	if config.Pagination == nil {
+		return
+	}
+	if config.Pagination.DefaultLimit < 0 {
+		r.errorf("pagination.default_limit", "default_limit must not be negative, got %d", config.Pagination.DefaultLimit)
+	}
+	if config.Pagination.Mode != "" && !contains(domain.PaginationModes, config.Pagination.Mode) {
+		r.errorf("pagination.mode", "unsupported pagination mode %q%s", config.Pagination.Mode, suggest(config.Pagination.Mode, domain.PaginationModes))
+	}
+}
+
+func (v *Validator) validateModels(r *report, config *domain.Config) {
+	if len(config.Models) == 0 {
+		r.warnf("models", "no models defined")
+	}
+
+	known := knownModels(config)
+	seen := make(map[string]string)
+
+	for i, model := range config.Models {
+		path := fmt.Sprintf("models[%d]", i)
+
+		switch {
+		case model.Name == "":
+			r.errorf(path, "model name is required")
+		case !identifierRe.MatchString(model.Name):
+			r.errorf(path+".name", "invalid model name %q: use letters, digits and '_' only, starting with a letter", model.Name)
+		case token.IsKeyword(strings.ToLower(model.Name)) || contains(reservedModelNames, strings.ToLower(model.Name)):
+			r.errorf(path+".name", "model name %q is reserved", model.Name)
+		}
+
+		if model.Name != "" {
+			key := strings.ToLower(model.Name)
+			if prev, ok := seen[key]; ok {
+				r.errorf(path+".name", "duplicate model %q (already declared at %s)", model.Name, r.source.Lookup(prev).String())
+			} else {
+				seen[key] = path + ".name"
+			}
+		}
+
+		v.validateMembers(r, path, model, known)
+	}
+}
+
+// validateMembers checks fields and relations, which share the generated struct namespace
+func (v *Validator) validateMembers(r *report, path string, model domain.Model, known []string) {
+	goNames := make(map[string]string)
+	claim := func(memberPath, name string) {
+		goName := pascal(name)
+		if prev, ok := goNames[goName]; ok {
+			r.errorf(memberPath, "%q collides with %s (both generate the Go field %s)", name, prev, goName)
+			return
+		}
+		goNames[goName] = memberPath
+	}
+
+	for _, name := range sortedKeys(model.Fields) {
+		fieldPath := path + ".fields." + name
+		v.validateMemberName(r, fieldPath, name)
+		claim(fieldPath, name)
+
+		fieldType := model.Fields[name]
+		v.validateFieldType(r, fieldPath, fieldType)
+		if rules, ok := model.Rules[name]; ok {
+			v.validateRules(r, fieldPath, fieldType, rules)
+		}
+	}
+
+	for _, name := range sortedKeys(model.Relations) {
+		relPath := path + ".relations." + name
+		v.validateMemberName(r, relPath, name)
+		claim(relPath, name)
+
+		spec := model.Relations[name]
+		rel := domain.ParseRelation(spec)
+		if rel.Target == "" {
+			r.errorf(relPath, "invalid relation %q: expected \"<kind>:<model>\", e.g. \"belongsTo:users\"", spec)
+			continue
+		}
+		if !contains(domain.RelationKinds, rel.Kind) {
+			r.errorf(relPath, "unknown relation kind %q%s", rel.Kind, suggest(rel.Kind, domain.RelationKinds))
+		}
+		if !contains(known, rel.Target) {
+			r.errorf(relPath, "relation targets unknown model %q%s", rel.Target, suggest(rel.Target, known))
+		}
+		if rel.OnDelete != "" {
+			actions := sortedKeys(domain.OnDeleteActions)
+			switch {
+			case rel.Kind != domain.BelongsTo:
+				r.errorf(relPath, "only belongsTo relations accept an on-delete action, got %q", spec)
+			case !contains(actions, rel.OnDelete):
+				r.errorf(relPath, "unknown on-delete action %q%s (expected %s)", rel.OnDelete, suggest(rel.OnDelete, actions), strings.Join(actions, ", "))
+			}
+		}
+	}
+}
+
+func (v *Validator) validateFieldType(r *report, path, fieldType string) {
+	t := domain.ParseFieldType(fieldType)
+	if _, ok := t.Info(); !ok {
+		r.errorf(path, "unknown field type %q%s", fieldType, suggest(t.Name, domain.FieldTypeNames()))
+		return
+	}
+	switch t.Name {
+	case "enum":
+		if len(t.Values) == 0 {
+			r.errorf(path, "enum needs its values, e.g. \"enum(draft,active)\"")
+		}
+		seen := make(map[string]bool)
+		for _, value := range t.Values {
+			if strings.ContainsAny(value, " ()") {
+				r.errorf(path, "enum value %q must not contain spaces or parentheses", value)
+			}
+			if seen[value] {
+				r.errorf(path, "duplicate enum value %q", value)
+			}
+			seen[value] = true
+		}
+	case "array":
+		if t.Elem == "" {
+			r.errorf(path, "array needs its element type, e.g. \"array<string>\"")
+		} else if elem, ok := domain.LookupFieldType(t.Elem); !ok || !contains(domain.ArrayElemTypes(), elem.Name) {
+			r.errorf(path, "unsupported array element type %q (available: %s)", t.Elem, strings.Join(domain.ArrayElemTypes(), ", "))
+		}
+	}
+}
+
+// validateRules checks that each rule applies to the field's type and that the rules agree
+func (v *Validator) validateRules(r *report, path, fieldType string, rules domain.FieldRules) {
+	if rules.Type == "" {
+		r.errorf(path, "field object needs a \"type\"")
+	}
+	info, _ := domain.ParseFieldType(fieldType).Info()
+	numeric := info.Numeric
+	textual := info.Text
+
+	only := func(applies bool, rule, kind string) {
+		if !applies {
+			r.errorf(path+"."+rule, "%s only applies to %s fields, not %q", rule, kind, fieldType)
+		}
+	}
+	if rules.Min != nil {
+		only(numeric, "min", "numeric")
+	}
+	if rules.Max != nil {
+		only(numeric, "max", "numeric")
+	}
+	if rules.Min != nil && rules.Max != nil && *rules.Min > *rules.Max {
+		r.errorf(path+".min", "min %v is greater than max %v", *rules.Min, *rules.Max)
+	}
+	if rules.MinLength != nil {
+		only(textual, "min_length", "string")
+		if *rules.MinLength < 0 {
+			r.errorf(path+".min_length", "min_length must not be negative")
+		}
+	}
+	if rules.MaxLength != nil {
+		only(textual, "max_length", "string")
+	}
+	if rules.MinLength != nil && rules.MaxLength != nil && *rules.MinLength > *rules.MaxLength {
+		r.errorf(path+".min_length", "min_length %d is greater than max_length %d", *rules.MinLength, *rules.MaxLength)
+	}
+	if rules.Pattern != "" {
+		only(textual, "pattern", "string")
+		if _, err := regexp.Compile(rules.Pattern); err != nil {
+			r.errorf(path+".pattern", "invalid pattern: %v", err)
+		}
+	}
+	if rules.Enum != nil {
+		only(textual, "enum", "string")
+		if strings.HasPrefix(fieldType, "enum") {
+			r.errorf(path+".enum", "the values of an enum type are listed in the type")
+		}
+		if len(rules.Enum) == 0 {
+			r.errorf(path+".enum", "enum needs at least one value")
+		}
+		for _, value := range rules.Enum {
+			if strings.ContainsAny(value, " ,") {
+				r.errorf(path+".enum", "enum value %q must not contain spaces or commas", value)
+			}
+		}
+	}
+	if rules.Email {
+		only(textual, "email", "string")
+	}
+	if rules.URL {
+		only(textual, "url", "string")
+	}
+	if rules.Required && rules.Default != nil {
+		r.errorf(path+".required", "a field with a default is never missing; drop required or default")
+	}
+	if rules.Default != nil {
+		v.validateDefault(r, path+".default", fieldType, rules)
+	}
+}
+
+func (v *Validator) validateDefault(r *report, path, fieldType string, rules domain.FieldRules) {
+	t := domain.ParseFieldType(fieldType)
+	info, known := t.Info()
+	if !known {
+		return
+	}
+	var ok bool
+	switch {
+	case !info.Scalar:
+		r.errorf(path, "%s fields cannot have a default", info.Name)
+		return
+	case t.Name == "decimal":
+		switch value := rules.Default.(type) {
+		case float64:
+			ok = true
+		case string:
+			ok = decimalRe.MatchString(value)
+		}
+	case t.Name == "date":
+		var s string
+		if s, ok = rules.Default.(string); ok {
+			_, err := time.Parse("2006-01-02", s)
+			ok = err == nil
+		}
+	case t.Name == "enum":
+		var s string
+		if s, ok = rules.Default.(string); ok && !contains(t.Values, s) {
+			r.errorf(path, "default %q is not one of the enum values", s)
+		}
+	case info.Numeric:
+		var n float64
+		n, ok = rules.Default.(float64)
+		if ok && info.Name != "float" && n != math.Trunc(n) {
+			r.errorf(path, "default %v is not a whole number", n)
+		}
+	case info.Name == "boolean":
+		_, ok = rules.Default.(bool)
+	case info.Name == "datetime":
+		ok = rules.Default == "now"
+	default:
+		var s string
+		s, ok = rules.Default.(string)
+		if ok && rules.Enum != nil && !contains(rules.Enum, s) {
+			r.errorf(path, "default %q is not one of the enum values", s)
+		}
+	}
+	if !ok {
+		r.errorf(path, "default %v does not match the field type %q", rules.Default, fieldType)
+	}
+}
+
+func (v *Validator) validateMemberName(r *report, path, name string) {
+	if !identifierRe.MatchString(name) {
+		r.errorf(path, "invalid name %q: use letters, digits and '_' only, starting with a letter", name)
+		return
+	}
+	if strings.EqualFold(name, "id") {
+		r.errorf(path, "%q is reserved for the generated primary key", name)
+	}
+}
+
+// validateModules reports the modules that aren't registered and the
+// diagnostics of the others, placed under their key
+func (v *Validator) validateModules(r *report, config *domain.Config) {
+	names := make([]string, 0, len(config.Modules))
+	for name := range config.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		base := "modules." + name
+		m, ok := module.Lookup(name)
+		if !ok {
+			r.errorf(base, "unknown module %q%s", name, suggest(name, module.Names()))
+			continue
+		}
+		for _, d := range m.Validate(config, config.Modules[name]) {
+			path := base
+			switch {
+			case strings.HasPrefix(d.Path, "["):
+				path += d.Path
+			case d.Path != "":
+				path += "." + d.Path
+			}
+			severity := d.Severity
+			if severity == "" {
+				severity = domain.SeverityError
+			}
+			r.add(severity, path, "%s", d.Message)
+		}
+	}
+}
+
+// knownModels lists the models, the ones modules add included
+func knownModels(config *domain.Config) []string {
+	var names []string
+	for _, m := range config.Models {
+		names = append(names, m.Name)
+	}
+	return names
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// pascal mirrors the template function used to derive Go field names
+func pascal(s string) string {
+	parts := strings.Split(s, "_")
+	for i := range parts {
+		if parts[i] == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,13 @@
+//go:build ignore
+
+// Package firestorepb stubs the part of cloud.google.com/go/firestore/apiv1/firestorepb v1.14.0 the templates use
+package firestorepb
+
+type Value struct{}
+
+func (x *Value) GetNullValue() int32     { return 0 }
+func (x *Value) GetBooleanValue() bool   { return false }
+func (x *Value) GetIntegerValue() int64  { return 0 }
+func (x *Value) GetDoubleValue() float64 { return 0 }
+func (x *Value) GetStringValue() string  { return "" }
+func (x *Value) GetBytesValue() []byte   { return nil }
@@ -0,0 +1,147 @@
+//go:build ignore
+
+// Package firestore stubs the part of cloud.google.com/go/firestore v1.14.0 the templates use
+package firestore
+
+import (
+	"context"
+	"time"
+)
+
+const DocumentID = "__name__"
+
+type Direction int32
+
+const (
+	Asc  Direction = 1
+	Desc Direction = 2
+)
+
+type FieldPath []string
+
+type Update struct {
+	Path      string
+	FieldPath FieldPath
+	Value     interface{}
+}
+
+type arrayUnion struct{ elems []interface{} }
+
+type arrayRemove struct{ elems []interface{} }
+
+func ArrayUnion(elems ...interface{}) arrayUnion   { return arrayUnion{} }
+func ArrayRemove(elems ...interface{}) arrayRemove { return arrayRemove{} }
+
+type sentinel int
+
+const (
+	Delete sentinel = iota
+	ServerTimestamp
+)
+
+type SetOption interface {
+	fieldPaths() (fps []FieldPath, all bool, err error)
+}
+
+type merge struct{}
+
+func (merge) fieldPaths() ([]FieldPath, bool, error) { return nil, true, nil }
+
+var MergeAll SetOption = merge{}
+
+type Precondition interface {
+	preconditionProto() (int, error)
+}
+
+type WriteResult struct {
+	UpdateTime time.Time
+}
+
+type Client struct{}
+
+func NewClient(ctx context.Context, projectID string) (*Client, error) { return nil, nil }
+
+func (c *Client) Close() error                          { return nil }
+func (c *Client) Collection(path string) *CollectionRef { return nil }
+func (c *Client) Doc(path string) *DocumentRef          { return nil }
+func (c *Client) GetAll(ctx context.Context, docRefs []*DocumentRef) (_ []*DocumentSnapshot, err error) {
+	return nil, nil
+}
+
+type CollectionRef struct {
+	Parent *DocumentRef
+	Path   string
+	ID     string
+	Query
+}
+
+func (c *CollectionRef) Doc(id string) *DocumentRef { return nil }
+func (c *CollectionRef) NewDoc() *DocumentRef       { return nil }
+func (c *CollectionRef) Add(ctx context.Context, data interface{}) (*DocumentRef, *WriteResult, error) {
+	return nil, nil, nil
+}
+
+type DocumentRef struct {
+	Parent *CollectionRef
+	Path   string
+	ID     string
+}
+
+func (d *DocumentRef) Collection(id string) *CollectionRef { return nil }
+func (d *DocumentRef) Get(ctx context.Context) (_ *DocumentSnapshot, err error) {
+	return nil, nil
+}
+func (d *DocumentRef) Create(ctx context.Context, data interface{}) (_ *WriteResult, err error) {
+	return nil, nil
+}
+func (d *DocumentRef) Set(ctx context.Context, data interface{}, opts ...SetOption) (_ *WriteResult, err error) {
+	return nil, nil
+}
+func (d *DocumentRef) Update(ctx context.Context, updates []Update, preconds ...Precondition) (_ *WriteResult, err error) {
+	return nil, nil
+}
+func (d *DocumentRef) Delete(ctx context.Context, preconds ...Precondition) (_ *WriteResult, err error) {
+	return nil, nil
+}
+
+type DocumentSnapshot struct {
+	Ref        *DocumentRef
+	CreateTime time.Time
+	UpdateTime time.Time
+	ReadTime   time.Time
+}
+
+func (d *DocumentSnapshot) Exists() bool                            { return false }
+func (d *DocumentSnapshot) Data() map[string]interface{}            { return nil }
+func (d *DocumentSnapshot) DataTo(p interface{}) error              { return nil }
+func (d *DocumentSnapshot) DataAt(path string) (interface{}, error) { return nil, nil }
+
+type Query struct{}
+
+func (q Query) Select(paths ...string) Query                             { return q }
+func (q Query) Where(path, op string, value interface{}) Query           { return q }
+func (q Query) OrderBy(path string, dir Direction) Query                 { return q }
+func (q Query) Offset(n int) Query                                       { return q }
+func (q Query) Limit(n int) Query                                        { return q }
+func (q Query) LimitToLast(n int) Query                                  { return q }
+func (q Query) StartAt(docSnapshotOrFieldValues ...interface{}) Query    { return q }
+func (q Query) StartAfter(docSnapshotOrFieldValues ...interface{}) Query { return q }
+func (q Query) EndAt(docSnapshotOrFieldValues ...interface{}) Query      { return q }
+func (q Query) EndBefore(docSnapshotOrFieldValues ...interface{}) Query  { return q }
+func (q Query) Documents(ctx context.Context) *DocumentIterator          { return nil }
+func (q Query) NewAggregationQuery() *AggregationQuery                   { return nil }
+
+type DocumentIterator struct{}
+
+func (it *DocumentIterator) Next() (*DocumentSnapshot, error)     { return nil, nil }
+func (it *DocumentIterator) Stop()                                {}
+func (it *DocumentIterator) GetAll() ([]*DocumentSnapshot, error) { return nil, nil }
+
+type AggregationQuery struct{}
+
+type AggregationResult map[string]interface{}
+
+func (a *AggregationQuery) WithCount(alias string) *AggregationQuery { return a }
+func (a *AggregationQuery) Get(ctx context.Context) (AggregationResult, error) {
+	return nil, nil
+}
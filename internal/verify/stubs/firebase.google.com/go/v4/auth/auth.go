@@ -0,0 +1,37 @@
+//go:build ignore
+
+// Package auth stubs the part of firebase.google.com/go/v4/auth v4.13.0 the templates use
+package auth
+
+import "context"
+
+type FirebaseInfo struct {
+	SignInProvider string
+	Tenant         string
+	Identities     map[string]interface{}
+}
+
+type Token struct {
+	AuthTime int64
+	Issuer   string
+	Audience string
+	Expires  int64
+	IssuedAt int64
+	Subject  string
+	UID      string
+	Firebase FirebaseInfo
+	Claims   map[string]interface{}
+}
+
+type Client struct{}
+
+func (c *Client) VerifyIDToken(ctx context.Context, idToken string) (*Token, error) {
+	return nil, nil
+}
+func (c *Client) VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (*Token, error) {
+	return nil, nil
+}
+func (c *Client) CustomToken(ctx context.Context, uid string) (string, error) { return "", nil }
+func (c *Client) SetCustomUserClaims(ctx context.Context, uid string, customClaims map[string]interface{}) error {
+	return nil
+}
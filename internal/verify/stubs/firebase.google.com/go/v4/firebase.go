@@ -0,0 +1,29 @@
+//go:build ignore
+
+// Package firebase stubs the part of firebase.google.com/go/v4 v4.13.0 the templates use
+package firebase
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"firebase.google.com/go/v4/auth"
+	"google.golang.org/api/option"
+)
+
+type Config struct {
+	AuthOverride     *map[string]interface{} `json:"databaseAuthVariableOverride"`
+	DatabaseURL      string                  `json:"databaseURL"`
+	ProjectID        string                  `json:"projectId"`
+	ServiceAccountID string                  `json:"serviceAccountId"`
+	StorageBucket    string                  `json:"storageBucket"`
+}
+
+type App struct{}
+
+func NewApp(ctx context.Context, config *Config, opts ...option.ClientOption) (*App, error) {
+	return nil, nil
+}
+
+func (a *App) Auth(ctx context.Context) (*auth.Client, error)           { return nil, nil }
+func (a *App) Firestore(ctx context.Context) (*firestore.Client, error) { return nil, nil }
@@ -0,0 +1,11 @@
+//go:build ignore
+
+// Package binding stubs the part of github.com/gin-gonic/gin/binding v1.9.1 the templates use
+package binding
+
+type StructValidator interface {
+	ValidateStruct(any) error
+	Engine() any
+}
+
+var Validator StructValidator
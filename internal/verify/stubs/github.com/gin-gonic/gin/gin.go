@@ -0,0 +1,156 @@
+//go:build ignore
+
+// Package gin stubs the part of github.com/gin-gonic/gin v1.9.1 the templates use
+package gin
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	DebugMode   = "debug"
+	ReleaseMode = "release"
+	TestMode    = "test"
+)
+
+func SetMode(value string) {}
+
+type H map[string]any
+
+type HandlerFunc func(*Context)
+
+type HandlersChain []HandlerFunc
+
+type ResponseWriter interface {
+	http.ResponseWriter
+	http.Hijacker
+	http.Flusher
+	http.CloseNotifier
+	Status() int
+	Size() int
+	WriteString(string) (int, error)
+	Written() bool
+	WriteHeaderNow()
+	Pusher() http.Pusher
+}
+
+type Param struct {
+	Key   string
+	Value string
+}
+
+type Params []Param
+
+type ErrorType uint64
+
+type Error struct {
+	Err  error
+	Type ErrorType
+	Meta any
+}
+
+func (msg *Error) Error() string { return "" }
+
+type errorMsgs []*Error
+
+func (a errorMsgs) Last() *Error     { return nil }
+func (a errorMsgs) Errors() []string { return nil }
+func (a errorMsgs) String() string   { return "" }
+
+type Context struct {
+	Request *http.Request
+	Writer  ResponseWriter
+	Params  Params
+	Keys    map[string]any
+	Errors  errorMsgs
+}
+
+func (c *Context) Copy() *Context                     { return nil }
+func (c *Context) HandlerName() string                { return "" }
+func (c *Context) FullPath() string                   { return "" }
+func (c *Context) Error(err error) *Error             { return nil }
+func (c *Context) ClientIP() string                   { return "" }
+func (c *Context) ContentType() string                { return "" }
+func (c *Context) PostForm(key string) string         { return "" }
+func (c *Context) Cookie(name string) (string, error) { return "", nil }
+func (c *Context) SetCookie(name, value string, maxAge int, path, domain string, secure, httpOnly bool) {
+}
+
+func (c *Context) Next()                                          {}
+func (c *Context) Abort()                                         {}
+func (c *Context) AbortWithStatus(code int)                       {}
+func (c *Context) AbortWithStatusJSON(code int, jsonObj any)      {}
+func (c *Context) IsAborted() bool                                { return false }
+func (c *Context) Set(key string, value any)                      {}
+func (c *Context) Get(key string) (value any, exists bool)        { return nil, false }
+func (c *Context) MustGet(key string) any                         { return nil }
+func (c *Context) GetString(key string) string                    { return "" }
+func (c *Context) Param(key string) string                        { return "" }
+func (c *Context) Query(key string) string                        { return "" }
+func (c *Context) DefaultQuery(key, defaultValue string) string   { return "" }
+func (c *Context) GetQuery(key string) (string, bool)             { return "", false }
+func (c *Context) QueryArray(key string) []string                 { return nil }
+func (c *Context) GetHeader(key string) string                    { return "" }
+func (c *Context) GetRawData() ([]byte, error)                    { return nil, nil }
+func (c *Context) Bind(obj any) error                             { return nil }
+func (c *Context) BindJSON(obj any) error                         { return nil }
+func (c *Context) ShouldBind(obj any) error                       { return nil }
+func (c *Context) ShouldBindJSON(obj any) error                   { return nil }
+func (c *Context) ShouldBindQuery(obj any) error                  { return nil }
+func (c *Context) ShouldBindUri(obj any) error                    { return nil }
+func (c *Context) Status(code int)                                {}
+func (c *Context) Header(key, value string)                       {}
+func (c *Context) JSON(code int, obj any)                         {}
+func (c *Context) IndentedJSON(code int, obj any)                 {}
+func (c *Context) String(code int, format string, values ...any)  {}
+func (c *Context) Data(code int, contentType string, data []byte) {}
+func (c *Context) Deadline() (deadline time.Time, ok bool)        { return time.Time{}, false }
+func (c *Context) Done() <-chan struct{}                          { return nil }
+func (c *Context) Err() error                                     { return nil }
+func (c *Context) Value(key any) any                              { return nil }
+func (c *Context) Redirect(code int, location string)             {}
+
+type IRoutes interface {
+	Use(...HandlerFunc) IRoutes
+	Handle(string, string, ...HandlerFunc) IRoutes
+	Any(string, ...HandlerFunc) IRoutes
+	GET(string, ...HandlerFunc) IRoutes
+	POST(string, ...HandlerFunc) IRoutes
+	DELETE(string, ...HandlerFunc) IRoutes
+	PATCH(string, ...HandlerFunc) IRoutes
+	PUT(string, ...HandlerFunc) IRoutes
+	OPTIONS(string, ...HandlerFunc) IRoutes
+	HEAD(string, ...HandlerFunc) IRoutes
+}
+
+type RouterGroup struct {
+	Handlers HandlersChain
+}
+
+func (group *RouterGroup) Use(middleware ...HandlerFunc) IRoutes { return nil }
+func (group *RouterGroup) Group(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return nil
+}
+func (group *RouterGroup) BasePath() string { return "" }
+func (group *RouterGroup) Handle(httpMethod, relativePath string, handlers ...HandlerFunc) IRoutes {
+	return nil
+}
+func (group *RouterGroup) Any(relativePath string, handlers ...HandlerFunc) IRoutes     { return nil }
+func (group *RouterGroup) GET(relativePath string, handlers ...HandlerFunc) IRoutes     { return nil }
+func (group *RouterGroup) POST(relativePath string, handlers ...HandlerFunc) IRoutes    { return nil }
+func (group *RouterGroup) DELETE(relativePath string, handlers ...HandlerFunc) IRoutes  { return nil }
+func (group *RouterGroup) PATCH(relativePath string, handlers ...HandlerFunc) IRoutes   { return nil }
+func (group *RouterGroup) PUT(relativePath string, handlers ...HandlerFunc) IRoutes     { return nil }
+func (group *RouterGroup) OPTIONS(relativePath string, handlers ...HandlerFunc) IRoutes { return nil }
+func (group *RouterGroup) HEAD(relativePath string, handlers ...HandlerFunc) IRoutes    { return nil }
+
+type Engine struct {
+	RouterGroup
+}
+
+func New() *Engine                                                        { return nil }
+func Default() *Engine                                                    { return nil }
+func (engine *Engine) Use(middleware ...HandlerFunc) IRoutes              { return nil }
+func (engine *Engine) Run(addr ...string) (err error)                     { return nil }
+func (engine *Engine) ServeHTTP(w http.ResponseWriter, req *http.Request) {}
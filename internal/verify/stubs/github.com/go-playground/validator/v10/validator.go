@@ -0,0 +1,33 @@
+//go:build ignore
+
+// Package validator stubs the part of github.com/go-playground/validator/v10 v10.14.0 the templates use
+package validator
+
+import "reflect"
+
+type Validate struct{}
+
+type TagNameFunc func(field reflect.StructField) string
+
+func New() *Validate                                   { return nil }
+func (v *Validate) RegisterTagNameFunc(fn TagNameFunc) {}
+func (v *Validate) Struct(s any) error                 { return nil }
+func (v *Validate) Var(field any, tag string) error    { return nil }
+
+type FieldError interface {
+	Tag() string
+	ActualTag() string
+	Namespace() string
+	StructNamespace() string
+	Field() string
+	StructField() string
+	Value() any
+	Param() string
+	Kind() reflect.Kind
+	Type() reflect.Type
+	Error() string
+}
+
+type ValidationErrors []FieldError
+
+func (ve ValidationErrors) Error() string { return "" }
@@ -0,0 +1,38 @@
+//go:build ignore
+
+// Package pgx stubs the part of github.com/jackc/pgx/v5 v5.5.0 the templates use
+package pgx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var ErrNoRows = errors.New("no rows in result set")
+
+type Row interface {
+	Scan(dest ...any) error
+}
+
+type Rows interface {
+	Close()
+	Err() error
+	CommandTag() pgconn.CommandTag
+	Next() bool
+	Scan(dest ...any) error
+	Values() ([]any, error)
+	RawValues() [][]byte
+}
+
+type Tx interface {
+	Begin(ctx context.Context) (Tx, error)
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+	Exec(ctx context.Context, sql string, arguments ...any) (commandTag pgconn.CommandTag, err error)
+	Query(ctx context.Context, sql string, args ...any) (Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) Row
+}
+
+type TxOptions struct{}
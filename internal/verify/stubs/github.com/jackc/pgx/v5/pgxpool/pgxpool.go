@@ -0,0 +1,27 @@
+//go:build ignore
+
+// Package pgxpool stubs the part of github.com/jackc/pgx/v5/pgxpool v5.5.0 the templates use
+package pgxpool
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type Pool struct{}
+
+func New(ctx context.Context, connString string) (*Pool, error) { return nil, nil }
+
+func (p *Pool) Close()                                    {}
+func (p *Pool) Ping(ctx context.Context) error            { return nil }
+func (p *Pool) Begin(ctx context.Context) (pgx.Tx, error) { return nil, nil }
+func (p *Pool) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	return nil, nil
+}
+func (p *Pool) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+func (p *Pool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) { return nil, nil }
+func (p *Pool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row        { return nil }
@@ -0,0 +1,6 @@
+//go:build ignore
+
+// Package godotenv stubs the part of github.com/joho/godotenv v1.5.1 the templates use
+package godotenv
+
+func Load(filenames ...string) (err error) { return nil }
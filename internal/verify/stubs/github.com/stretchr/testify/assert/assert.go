@@ -0,0 +1,22 @@
+//go:build ignore
+
+// Package assert stubs the part of github.com/stretchr/testify/assert v1.8.4 the templates use
+package assert
+
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+func Equal(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool    { return true }
+func NotEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) bool { return true }
+func Contains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) bool      { return true }
+func NotContains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) bool   { return true }
+func True(t TestingT, value bool, msgAndArgs ...interface{}) bool                       { return true }
+func False(t TestingT, value bool, msgAndArgs ...interface{}) bool                      { return true }
+func Nil(t TestingT, object interface{}, msgAndArgs ...interface{}) bool                { return true }
+func NotNil(t TestingT, object interface{}, msgAndArgs ...interface{}) bool             { return true }
+func NoError(t TestingT, err error, msgAndArgs ...interface{}) bool                     { return true }
+func Error(t TestingT, err error, msgAndArgs ...interface{}) bool                       { return true }
+func Len(t TestingT, object interface{}, length int, msgAndArgs ...interface{}) bool    { return true }
+func Empty(t TestingT, object interface{}, msgAndArgs ...interface{}) bool              { return true }
+func NotEmpty(t TestingT, object interface{}, msgAndArgs ...interface{}) bool           { return true }
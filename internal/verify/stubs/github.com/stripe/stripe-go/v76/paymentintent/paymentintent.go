@@ -0,0 +1,12 @@
+//go:build ignore
+
+// Package paymentintent stubs the part of github.com/stripe/stripe-go/v76/paymentintent v76.0.0 the templates use
+package paymentintent
+
+import stripe "github.com/stripe/stripe-go/v76"
+
+func New(params *stripe.PaymentIntentParams) (*stripe.PaymentIntent, error) { return nil, nil }
+
+func Get(id string, params *stripe.PaymentIntentParams) (*stripe.PaymentIntent, error) {
+	return nil, nil
+}
@@ -0,0 +1,67 @@
+//go:build ignore
+
+// Package stripe stubs the part of github.com/stripe/stripe-go/v76 v76.0.0 the templates use
+package stripe
+
+import "encoding/json"
+
+var Key string
+
+func String(v string) *string    { return &v }
+func Int64(v int64) *int64       { return &v }
+func Bool(v bool) *bool          { return &v }
+func Float64(v float64) *float64 { return &v }
+
+type Params struct {
+	Expand         []*string         `form:"expand"`
+	Metadata       map[string]string `form:"metadata"`
+	IdempotencyKey *string           `form:"-"`
+}
+
+func (p *Params) AddMetadata(key, value string) {}
+func (p *Params) AddExpand(f string)            {}
+
+type Currency string
+
+type PaymentIntentStatus string
+
+type PaymentIntentAutomaticPaymentMethodsParams struct {
+	AllowRedirects *string `form:"allow_redirects"`
+	Enabled        *bool   `form:"enabled"`
+}
+
+type PaymentIntentParams struct {
+	Params                  `form:"*"`
+	Amount                  *int64                                      `form:"amount"`
+	AutomaticPaymentMethods *PaymentIntentAutomaticPaymentMethodsParams `form:"automatic_payment_methods"`
+	Currency                *string                                     `form:"currency"`
+	Customer                *string                                     `form:"customer"`
+	Description             *string                                     `form:"description"`
+	ReceiptEmail            *string                                     `form:"receipt_email"`
+}
+
+type PaymentIntent struct {
+	ID           string              `json:"id"`
+	Amount       int64               `json:"amount"`
+	ClientSecret string              `json:"client_secret"`
+	Currency     Currency            `json:"currency"`
+	Description  string              `json:"description"`
+	Metadata     map[string]string   `json:"metadata"`
+	Status       PaymentIntentStatus `json:"status"`
+}
+
+type EventType string
+
+type EventData struct {
+	Object             map[string]interface{} `json:"-"`
+	PreviousAttributes map[string]interface{} `json:"previous_attributes"`
+	Raw                json.RawMessage        `json:"object"`
+}
+
+type Event struct {
+	ID       string     `json:"id"`
+	Created  int64      `json:"created"`
+	Data     *EventData `json:"data"`
+	Livemode bool       `json:"livemode"`
+	Type     EventType  `json:"type"`
+}
@@ -0,0 +1,8 @@
+//go:build ignore
+
+// Package swaggerFiles stubs the part of github.com/swaggo/files v1.0.1 the templates use
+package swaggerFiles
+
+import "golang.org/x/net/webdav"
+
+var Handler *webdav.Handler
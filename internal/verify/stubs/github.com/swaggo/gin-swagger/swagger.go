@@ -0,0 +1,26 @@
+//go:build ignore
+
+// Package ginSwagger stubs the part of github.com/swaggo/gin-swagger v1.6.0 the templates use
+package ginSwagger
+
+import (
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/webdav"
+)
+
+type Config struct {
+	URL                      string
+	DocExpansion             string
+	InstanceName             string
+	Title                    string
+	DefaultModelsExpandDepth int
+	DeepLinking              bool
+	PersistAuthorization     bool
+	Oauth2DefaultClientID    string
+}
+
+func URL(url string) func(*Config)                   { return nil }
+func DocExpansion(docExpansion string) func(*Config) { return nil }
+func InstanceName(name string) func(*Config)         { return nil }
+
+func WrapHandler(handler *webdav.Handler, options ...func(*Config)) gin.HandlerFunc { return nil }
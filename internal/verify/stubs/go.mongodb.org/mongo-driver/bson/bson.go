@@ -0,0 +1,16 @@
+//go:build ignore
+
+// Package bson stubs the part of go.mongodb.org/mongo-driver/bson v1.13.0 the templates use
+package bson
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type (
+	M = primitive.M
+	E = primitive.E
+	D = primitive.D
+	A = primitive.A
+)
+
+func Marshal(val interface{}) ([]byte, error)      { return nil, nil }
+func Unmarshal(data []byte, val interface{}) error { return nil }
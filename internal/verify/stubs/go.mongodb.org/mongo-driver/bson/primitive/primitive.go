@@ -0,0 +1,34 @@
+//go:build ignore
+
+// Package primitive stubs the part of go.mongodb.org/mongo-driver/bson/primitive v1.13.0 the templates use
+package primitive
+
+import "errors"
+
+var ErrInvalidHex = errors.New("the provided hex string is not a valid ObjectID")
+
+type ObjectID [12]byte
+
+var NilObjectID ObjectID
+
+func NewObjectID() ObjectID                       { return ObjectID{} }
+func ObjectIDFromHex(s string) (ObjectID, error)  { return ObjectID{}, nil }
+func IsValidObjectID(s string) bool               { return false }
+func (id ObjectID) Hex() string                   { return "" }
+func (id ObjectID) String() string                { return "" }
+func (id ObjectID) IsZero() bool                  { return false }
+func (id ObjectID) MarshalJSON() ([]byte, error)  { return nil, nil }
+func (id *ObjectID) UnmarshalJSON(b []byte) error { return nil }
+
+type M map[string]interface{}
+
+type E struct {
+	Key   string
+	Value interface{}
+}
+
+type D []E
+
+func (d D) Map() M { return nil }
+
+type A []interface{}
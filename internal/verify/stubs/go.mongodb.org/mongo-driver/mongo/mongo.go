@@ -0,0 +1,98 @@
+//go:build ignore
+
+// Package mongo stubs the part of go.mongodb.org/mongo-driver/mongo v1.13.0 the templates use
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrNoDocuments = errors.New("mongo: no documents in result")
+
+type Client struct{}
+
+func Connect(ctx context.Context, opts ...*options.ClientOptions) (*Client, error) { return nil, nil }
+
+func (c *Client) Database(name string, opts ...*options.DatabaseOptions) *Database { return nil }
+func (c *Client) Disconnect(ctx context.Context) error                             { return nil }
+
+type Database struct{}
+
+func (db *Database) Name() string    { return "" }
+func (db *Database) Client() *Client { return nil }
+func (db *Database) Collection(name string, opts ...*options.CollectionOptions) *Collection {
+	return nil
+}
+func (db *Database) Drop(ctx context.Context) error { return nil }
+
+type Collection struct{}
+
+func (coll *Collection) Name() string { return "" }
+func (coll *Collection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*Cursor, error) {
+	return nil, nil
+}
+func (coll *Collection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *SingleResult {
+	return nil
+}
+func (coll *Collection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*InsertOneResult, error) {
+	return nil, nil
+}
+func (coll *Collection) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*InsertManyResult, error) {
+	return nil, nil
+}
+func (coll *Collection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*UpdateResult, error) {
+	return nil, nil
+}
+func (coll *Collection) UpdateByID(ctx context.Context, id interface{}, update interface{}, opts ...*options.UpdateOptions) (*UpdateResult, error) {
+	return nil, nil
+}
+func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*UpdateResult, error) {
+	return nil, nil
+}
+func (coll *Collection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*DeleteResult, error) {
+	return nil, nil
+}
+func (coll *Collection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*DeleteResult, error) {
+	return nil, nil
+}
+func (coll *Collection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	return 0, nil
+}
+
+type Cursor struct {
+	Current []byte
+}
+
+func (c *Cursor) ID() int64                                          { return 0 }
+func (c *Cursor) Next(ctx context.Context) bool                      { return false }
+func (c *Cursor) Decode(val interface{}) error                       { return nil }
+func (c *Cursor) Err() error                                         { return nil }
+func (c *Cursor) Close(ctx context.Context) error                    { return nil }
+func (c *Cursor) All(ctx context.Context, results interface{}) error { return nil }
+
+type SingleResult struct{}
+
+func (sr *SingleResult) Decode(v interface{}) error { return nil }
+func (sr *SingleResult) Err() error                 { return nil }
+
+type InsertOneResult struct {
+	InsertedID interface{}
+}
+
+type InsertManyResult struct {
+	InsertedIDs []interface{}
+}
+
+type UpdateResult struct {
+	MatchedCount  int64
+	ModifiedCount int64
+	UpsertedCount int64
+	UpsertedID    interface{}
+}
+
+type DeleteResult struct {
+	DeletedCount int64
+}
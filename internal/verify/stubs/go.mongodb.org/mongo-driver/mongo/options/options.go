@@ -0,0 +1,42 @@
+//go:build ignore
+
+// Package options stubs the part of go.mongodb.org/mongo-driver/mongo/options v1.13.0 the templates use
+package options
+
+type ClientOptions struct{}
+
+func Client() *ClientOptions                                { return nil }
+func (c *ClientOptions) ApplyURI(uri string) *ClientOptions { return c }
+
+type DatabaseOptions struct{}
+
+type CollectionOptions struct{}
+
+type FindOptions struct {
+	Limit *int64
+	Skip  *int64
+	Sort  interface{}
+}
+
+func Find() *FindOptions                                                 { return nil }
+func (f *FindOptions) SetLimit(i int64) *FindOptions                     { return f }
+func (f *FindOptions) SetSkip(i int64) *FindOptions                      { return f }
+func (f *FindOptions) SetSort(sort interface{}) *FindOptions             { return f }
+func (f *FindOptions) SetProjection(projection interface{}) *FindOptions { return f }
+
+type FindOneOptions struct{}
+
+func FindOne() *FindOneOptions { return nil }
+
+type InsertOneOptions struct{}
+
+type InsertManyOptions struct{}
+
+type UpdateOptions struct{}
+
+func Update() *UpdateOptions                             { return nil }
+func (u *UpdateOptions) SetUpsert(b bool) *UpdateOptions { return u }
+
+type DeleteOptions struct{}
+
+type CountOptions struct{}
@@ -0,0 +1,17 @@
+//go:build ignore
+
+// Package bcrypt stubs the part of golang.org/x/crypto/bcrypt v0.19.0 the templates use
+package bcrypt
+
+import "errors"
+
+const (
+	MinCost     int = 4
+	MaxCost     int = 31
+	DefaultCost int = 10
+)
+
+var ErrMismatchedHashAndPassword = errors.New("crypto/bcrypt: hashedPassword is not the hash of the given password")
+
+func GenerateFromPassword(password []byte, cost int) ([]byte, error) { return nil, nil }
+func CompareHashAndPassword(hashedPassword, password []byte) error   { return nil }
@@ -0,0 +1,13 @@
+//go:build ignore
+
+// Package webdav stubs the part of golang.org/x/net/webdav that github.com/swaggo/files exposes
+package webdav
+
+import "net/http"
+
+type Handler struct {
+	Prefix string
+	Logger func(*http.Request, error)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
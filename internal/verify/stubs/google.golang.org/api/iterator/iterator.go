@@ -0,0 +1,8 @@
+//go:build ignore
+
+// Package iterator stubs the part of google.golang.org/api/iterator v0.150.0 the templates use
+package iterator
+
+import "errors"
+
+var Done = errors.New("no more items in iterator")
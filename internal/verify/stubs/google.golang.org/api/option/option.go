@@ -0,0 +1,12 @@
+//go:build ignore
+
+// Package option stubs the part of google.golang.org/api/option v0.150.0 the templates use
+package option
+
+type ClientOption interface {
+	Apply(settings any)
+}
+
+func WithCredentialsFile(filename string) ClientOption { return nil }
+func WithCredentialsJSON(p []byte) ClientOption        { return nil }
+func WithEndpoint(url string) ClientOption             { return nil }
@@ -0,0 +1,426 @@
+// Package verify type-checks a generated project offline, so template mistakes
+// surface at generation time instead of on the first go build of the output.
+//
+// The third-party packages the templates import are checked against the stubs
+// under stubs/, by import path: declarations of the part of their API the
+// templates use, with the signatures of the versions go.mod requires. The
+// stubs are build-ignored, so the go command never compiles them.
+package verify
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/gosrc"
+)
+
+//go:embed stubs
+var stubs embed.FS
+
+// Problem is a type error in a generated file, with the template and blueprint
+// model that produced the file when they are known
+type Problem struct {
+	Pos      token.Position
+	Template string
+	Model    string
+	Message  string
+}
+
+func (p Problem) String() string {
+	var origin string
+	switch {
+	case p.Model != "":
+		origin = fmt.Sprintf(" (template %s, model %s)", p.Template, p.Model)
+	case p.Template != "":
+		origin = fmt.Sprintf(" (template %s)", p.Template)
+	}
+	return fmt.Sprintf("%s: error: %s%s", p.Pos, p.Message, origin)
+}
+
+// Recorder implements domain.TemplatePort around another engine and remembers
+// which template rendered each output, and for which model, to attribute
+// problems to them
+type Recorder struct {
+	engine  domain.TemplatePort
+	sources map[string]origin
+}
+
+type origin struct {
+	template string
+	model    string
+}
+
+func NewRecorder(engine domain.TemplatePort) *Recorder {
+	return &Recorder{engine: engine, sources: make(map[string]origin)}
+}
+
+func (r *Recorder) Render(name, tmpl string, data interface{}) ([]byte, error) {
+	content, err := r.engine.Render(name, tmpl, data)
+	if err == nil {
+		r.sources[string(content)] = origin{template: name, model: modelOf(data)}
+	}
+	return content, err
+}
+
+// formattedSources indexes the outputs also the way the generator writes Go
+// files, formatted with the imports of module grouped last
+func (r *Recorder) formattedSources(module string) map[string]origin {
+	sources := make(map[string]origin, 2*len(r.sources))
+	for content, source := range r.sources {
+		sources[content] = source
+		if formatted, err := gosrc.Format("", []byte(content), module); err == nil {
+			sources[string(formatted)] = source
+		}
+	}
+	return sources
+}
+
+// modelOf returns the name of the model the per-model templates receive in
+// their Model field
+func modelOf(data interface{}) string {
+	v := reflect.Indirect(reflect.ValueOf(data))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	field := v.FieldByName("Model")
+	if !field.IsValid() || !field.CanInterface() {
+		return ""
+	}
+	model, _ := field.Interface().(domain.Model)
+	return model.Name
+}
+
+// Check type-checks the Go files of a project, keyed by project-relative
+// slash path. Packages of the project and of the standard library are
+// resolved, and third-party packages with a stub are checked against it;
+// other imports, such as those of a module's own dependencies, can't be loaded
+// offline, so uses of them are not checked, nor are unused variables in the
+// functions making them. recorder, if not nil, attributes each problem to its
+// template.
+func Check(files map[string][]byte, recorder *Recorder) ([]Problem, error) {
+	c := &checker{
+		fset:     token.NewFileSet(),
+		module:   modulePath(files["go.mod"]),
+		files:    make(map[string][]*ast.File),
+		tests:    make(map[string][]*ast.File),
+		packages: make(map[string]*types.Package),
+		stubbed:  make(map[string]*types.Package),
+	}
+	c.std = importer.ForCompiler(c.fset, "source", nil)
+
+	for _, name := range sortedPaths(files) {
+		if !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		file, err := parser.ParseFile(c.fset, name, files[name], parser.ParseComments)
+		if err != nil {
+			c.syntaxErrors(err)
+			continue
+		}
+		c.nameImports(file)
+		dir := path.Join(c.module, path.Dir(name))
+		if strings.HasSuffix(name, "_test.go") {
+			c.tests[dir] = append(c.tests[dir], file)
+		} else {
+			c.files[dir] = append(c.files[dir], file)
+		}
+	}
+
+	for _, dir := range sortedPaths(c.files) {
+		c.check(dir)
+	}
+	for _, dir := range sortedPaths(c.tests) {
+		c.checkTests(dir)
+	}
+
+	if recorder != nil {
+		sources := recorder.formattedSources(c.module)
+		for i, p := range c.problems {
+			if source, ok := sources[string(files[p.Pos.Filename])]; ok {
+				c.problems[i].Template = source.template
+				c.problems[i].Model = source.model
+			}
+		}
+	}
+	sort.SliceStable(c.problems, func(i, j int) bool {
+		a, b := c.problems[i].Pos, c.problems[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return c.problems, c.stdErr
+}
+
+type checker struct {
+	fset     *token.FileSet
+	module   string
+	std      types.Importer
+	files    map[string][]*ast.File // by import path
+	tests    map[string][]*ast.File
+	packages map[string]*types.Package
+	stubbed  map[string]*types.Package // by third-party import path
+	problems []Problem
+	stdErr   error
+}
+
+// check type-checks the package at import path dir once, with its imports first
+func (c *checker) check(dir string) *types.Package {
+	if pkg, ok := c.packages[dir]; ok {
+		return pkg
+	}
+	c.packages[dir] = nil // Reserved: an import cycle resolves to a missing package
+	pkg := c.typeCheck(dir, c.files[dir])
+	c.packages[dir] = pkg
+	return pkg
+}
+
+// checkTests type-checks the test files of dir, in the package or next to it
+func (c *checker) checkTests(dir string) {
+	internal, external := c.files[dir], []*ast.File(nil)
+	for _, file := range c.tests[dir] {
+		if strings.HasSuffix(file.Name.Name, "_test") {
+			external = append(external, file)
+		} else {
+			internal = append(internal, file)
+		}
+	}
+	if len(internal) > len(c.files[dir]) {
+		// Errors in the package files were reported already
+		before := len(c.problems)
+		c.typeCheck(dir, internal)
+		kept := c.problems[:before]
+		for _, p := range c.problems[before:] {
+			if strings.HasSuffix(p.Pos.Filename, "_test.go") {
+				kept = append(kept, p)
+			}
+		}
+		c.problems = kept
+	}
+	if len(external) > 0 {
+		c.typeCheck(dir+"_test", external)
+	}
+}
+
+func (c *checker) typeCheck(dir string, files []*ast.File) *types.Package {
+	config := &types.Config{
+		Importer: importerFunc(c.importPackage),
+		Error: func(err error) {
+			if !isUnresolvedImport(err) && !c.unusedNearUnresolved(files, err) {
+				c.typeError(err)
+			}
+		},
+	}
+	pkg, _ := config.Check(dir, c.fset, files, nil)
+	return pkg
+}
+
+// unusedNearUnresolved tells the "declared and not used" errors of functions
+// using a third-party package: a variable only used where its type is unknown,
+// such as a key of bson.M{key: value}, is never checked, so it reads as unused
+func (c *checker) unusedNearUnresolved(files []*ast.File, err error) bool {
+	var typeErr types.Error
+	if !errors.As(err, &typeErr) || !strings.HasPrefix(typeErr.Msg, "declared and not used") {
+		return false
+	}
+	for _, file := range files {
+		if typeErr.Pos < file.FileStart || typeErr.Pos >= file.FileEnd {
+			continue
+		}
+		unresolved := make(map[string]bool)
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err == nil && spec.Name != nil && !c.isLocal(importPath) && !isStandard(importPath) && !hasStub(importPath) {
+				unresolved[spec.Name.Name] = true
+			}
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil || typeErr.Pos < fn.Pos() || typeErr.Pos >= fn.End() {
+				continue
+			}
+			found := false
+			ast.Inspect(fn, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if x, ok := sel.X.(*ast.Ident); ok && unresolved[x.Name] {
+						found = true
+					}
+				}
+				return !found
+			})
+			return found
+		}
+	}
+	return false
+}
+
+func (c *checker) importPackage(importPath string) (*types.Package, error) {
+	switch {
+	case c.isLocal(importPath):
+		if _, ok := c.files[importPath]; !ok {
+			return nil, fmt.Errorf("package %s is not part of the project", importPath)
+		}
+		if pkg := c.check(importPath); pkg != nil {
+			return pkg, nil
+		}
+		return nil, fmt.Errorf("import cycle through %s", importPath)
+	case isStandard(importPath):
+		pkg, err := c.std.Import(importPath)
+		if err != nil && c.stdErr == nil {
+			c.stdErr = fmt.Errorf("failed to load the standard library, is the Go toolchain installed? %w", err)
+		}
+		return pkg, err
+	case hasStub(importPath):
+		return c.stub(importPath)
+	}
+	return nil, errUnresolved
+}
+
+// hasStub reports whether stubs/ declares the third-party package importPath
+func hasStub(importPath string) bool {
+	entries, err := stubs.ReadDir("stubs/" + importPath)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".go") {
+			return true
+		}
+	}
+	return false
+}
+
+// stub type-checks the stub of importPath once, with the stubs it imports
+func (c *checker) stub(importPath string) (*types.Package, error) {
+	if pkg, ok := c.stubbed[importPath]; ok {
+		if pkg == nil {
+			return nil, fmt.Errorf("import cycle through the stub of %s", importPath)
+		}
+		return pkg, nil
+	}
+	c.stubbed[importPath] = nil
+	dir := "stubs/" + importPath
+	entries, err := stubs.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		src, err := stubs.ReadFile(dir + "/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(c.fset, dir+"/"+entry.Name(), src, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	config := &types.Config{Importer: importerFunc(c.importPackage)}
+	pkg, err := config.Check(importPath, c.fset, files, nil)
+	if err != nil {
+		return nil, fmt.Errorf("stub of %s: %w", importPath, err)
+	}
+	c.stubbed[importPath] = pkg
+	return pkg, nil
+}
+
+// nameImports names the unnamed imports of third-party packages after the
+// package they are assumed to declare, since go/types names a package it can't
+// load after the last element of its path, /v5 included; the name of a stubbed
+// package is the same
+func (c *checker) nameImports(file *ast.File) {
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil || err != nil || c.isLocal(importPath) || isStandard(importPath) {
+			continue
+		}
+		spec.Name = &ast.Ident{NamePos: spec.Path.Pos(), Name: gosrc.PackageName(importPath)}
+	}
+}
+
+var errUnresolved = errors.New("third-party package, not checked offline")
+
+func (c *checker) isLocal(importPath string) bool {
+	return importPath == c.module || strings.HasPrefix(importPath, c.module+"/")
+}
+
+// isUnresolvedImport tells the errors that only say a third-party package wasn't loaded
+func isUnresolvedImport(err error) bool {
+	return strings.Contains(err.Error(), errUnresolved.Error())
+}
+
+func (c *checker) typeError(err error) {
+	var typeErr types.Error
+	if errors.As(err, &typeErr) {
+		pos := c.fset.Position(typeErr.Pos)
+		// go/types continues the previous error on messages starting with a tab
+		if last := len(c.problems) - 1; last >= 0 && strings.HasPrefix(typeErr.Msg, "\t") {
+			c.problems[last].Message += fmt.Sprintf(" (%s at %s)", strings.TrimSpace(typeErr.Msg), pos)
+			return
+		}
+		c.problems = append(c.problems, Problem{Pos: pos, Message: typeErr.Msg})
+		return
+	}
+	c.problems = append(c.problems, Problem{Message: err.Error()})
+}
+
+func (c *checker) syntaxErrors(err error) {
+	var list scanner.ErrorList
+	if errors.As(err, &list) {
+		for _, e := range list {
+			c.problems = append(c.problems, Problem{Pos: e.Pos, Message: e.Msg})
+		}
+		return
+	}
+	c.problems = append(c.problems, Problem{Message: err.Error()})
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+// isStandard reports whether importPath belongs to the standard library, whose
+// paths have no dot in their first element
+func isStandard(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+// modulePath reads the module directive of a go.mod file
+func modulePath(goMod []byte) string {
+	for _, line := range strings.Split(string(goMod), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+func sortedPaths[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
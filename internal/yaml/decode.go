@@ -0,0 +1,266 @@
+// Package yaml reads the YAML configuration files and API documents are
+// written in, into the values encoding/json decodes the same JSON to:
+// map[string]interface{}, []interface{}, string, float64, bool and nil, and
+// writes such values back with their keys in order.
+//
+// Parsing and encoding are left to gopkg.in/yaml.v3, so anchors, aliases and
+// merge keys work; the package keeps the JSON view of the document and
+// records where every key and sequence item starts, for diagnostics.
+// Multi-document streams and keys that aren't scalars are reported as errors.
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Decode parses a single YAML document
+func Decode(data []byte) (interface{}, error) {
+	value, _, err := decode(data, false)
+	return value, err
+}
+
+// Position is the 1-based line and column a key or sequence item starts at
+type Position struct {
+	Line   int
+	Column int
+}
+
+// DecodePositions is Decode, also returning where every key and sequence item
+// starts, by path in the form "models[0].fields.title". Block sequence items
+// start at their dash. Keys and items reached through an alias are placed
+// where the anchored node declares them.
+func DecodePositions(data []byte) (interface{}, map[string]Position, error) {
+	return decode(data, true)
+}
+
+// ToJSON re-encodes a YAML document as JSON, so it can be decoded into structs
+// through their json tags
+func ToJSON(data []byte) ([]byte, error) {
+	value, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// Error is a syntax error at a line of the document
+type Error struct {
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("yaml: line %d: %s", e.Line, e.Message)
+}
+
+// directive12 is a %YAML 1.2 directive, which yaml.v3 refuses although it
+// reads YAML 1.2 documents
+var directive12 = regexp.MustCompile(`(?m)^%YAML 1\.2\b`)
+
+func decode(data []byte, withPositions bool) (interface{}, map[string]Position, error) {
+	data = directive12.ReplaceAll(data, []byte("%YAML 1.1"))
+	dec := yamlv3.NewDecoder(bytes.NewReader(data))
+	var doc yamlv3.Node
+	if err := dec.Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, map[string]Position{}, nil
+		}
+		return nil, nil, syntaxError(err)
+	}
+	var next yamlv3.Node
+	switch err := dec.Decode(&next); {
+	case err == nil:
+		return nil, nil, &Error{next.Line, "multiple documents are not supported"}
+	case err != io.EOF:
+		return nil, nil, syntaxError(err)
+	}
+
+	c := &converter{lines: strings.Split(string(data), "\n")}
+	if withPositions {
+		c.positions = make(map[string]Position)
+	}
+	root := &doc
+	if root.Kind == yamlv3.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil, c.positions, nil
+		}
+		root = root.Content[0]
+	}
+	c.record("", root.Line, root.Column)
+	value, err := c.value(root, "", 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, c.positions, nil
+}
+
+// lineRe reads the line yaml.v3 puts in front of its messages
+var lineRe = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// syntaxError turns a yaml.v3 error into an Error when it names a line
+func syntaxError(err error) error {
+	if m := lineRe.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return &Error{line, m[2]}
+	}
+	return err
+}
+
+// maxDepth bounds the nesting of a document, aliases included, so an alias
+// can't expand without end
+const maxDepth = 100
+
+type converter struct {
+	lines     []string
+	positions map[string]Position // nil unless requested
+}
+
+func (c *converter) record(path string, line, column int) {
+	if c.positions != nil {
+		if _, ok := c.positions[path]; !ok {
+			c.positions[path] = Position{Line: line, Column: column}
+		}
+	}
+}
+
+// value converts the node at path to its JSON form
+func (c *converter) value(n *yamlv3.Node, path string, depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, &Error{n.Line, "document nested too deeply"}
+	}
+	switch n.Kind {
+	case yamlv3.AliasNode:
+		return c.value(n.Alias, path, depth+1)
+	case yamlv3.MappingNode:
+		m := make(map[string]interface{})
+		if err := c.mapping(m, make(map[string]bool), n, path, depth); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case yamlv3.SequenceNode:
+		items := make([]interface{}, 0, len(n.Content))
+		for i, item := range n.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			line, column := item.Line, item.Column
+			if n.Style&yamlv3.FlowStyle == 0 {
+				column = c.dash(line, column)
+			}
+			c.record(itemPath, line, column)
+			value, err := c.value(item, itemPath, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case yamlv3.ScalarNode:
+		return resolve(n)
+	}
+	return nil, &Error{n.Line, "unexpected node"}
+}
+
+// mapping adds the pairs of n to m. Keys declared in n win over those merged
+// in with "<<", whatever their order; declared records the former.
+func (c *converter) mapping(m map[string]interface{}, declared map[string]bool, n *yamlv3.Node, path string, depth int) error {
+	var merges []*yamlv3.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, node := n.Content[i], n.Content[i+1]
+		if key.Kind != yamlv3.ScalarNode {
+			return &Error{key.Line, "keys must be scalars"}
+		}
+		if key.Tag == "!!merge" {
+			merges = append(merges, node)
+			continue
+		}
+		if declared[key.Value] {
+			return &Error{key.Line, fmt.Sprintf("duplicate key %q", key.Value)}
+		}
+		declared[key.Value] = true
+
+		keyPath := key.Value
+		if path != "" {
+			keyPath = path + "." + key.Value
+		}
+		c.record(keyPath, key.Line, key.Column)
+		value, err := c.value(node, keyPath, depth+1)
+		if err != nil {
+			return err
+		}
+		m[key.Value] = value
+	}
+
+	for _, merge := range merges {
+		sources := []*yamlv3.Node{merge}
+		if merge.Kind == yamlv3.SequenceNode {
+			sources = merge.Content
+		}
+		for _, source := range sources {
+			for source.Kind == yamlv3.AliasNode {
+				source = source.Alias
+			}
+			if source.Kind != yamlv3.MappingNode {
+				return &Error{merge.Line, "only mappings can be merged with <<"}
+			}
+			merged := make(map[string]interface{})
+			if err := c.mapping(merged, make(map[string]bool), source, path, depth+1); err != nil {
+				return err
+			}
+			for key, value := range merged {
+				if _, ok := m[key]; !ok {
+					m[key] = value
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// dash returns the column of the "-" in front of the block sequence item
+// starting at line and column
+func (c *converter) dash(line, column int) int {
+	if line < 1 || line > len(c.lines) {
+		return column
+	}
+	text := []rune(c.lines[line-1])
+	if column-1 > len(text) {
+		return column
+	}
+	before := strings.TrimRight(string(text[:column-1]), " ")
+	if strings.HasSuffix(before, "-") {
+		return len([]rune(before))
+	}
+	return column
+}
+
+// resolve reads a scalar by its tag; numbers become float64 as in JSON
+func resolve(n *yamlv3.Node) (interface{}, error) {
+	switch n.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool", "!!int", "!!float":
+		var value interface{}
+		if err := n.Decode(&value); err != nil {
+			return nil, &Error{n.Line, err.Error()}
+		}
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case uint64:
+			return float64(v), nil
+		}
+		return value, nil
+	case "!!str", "!!timestamp", "!!binary":
+		return n.Value, nil
+	}
+	return nil, &Error{n.Line, fmt.Sprintf("unsupported tag %s", n.Tag)}
+}
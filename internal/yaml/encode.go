@@ -0,0 +1,148 @@
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Mapping is a mapping whose keys are written in the order Keys returns them
+type Mapping interface {
+	Keys() []string
+	Get(key string) interface{}
+}
+
+// Encode writes value as a block-style YAML document. Mappings are a Mapping,
+// or a map[string]interface{} written with sorted keys; sequences are
+// []interface{} or []string; scalars are string, bool, int, float64,
+// json.Number or nil.
+func Encode(value interface{}) []byte {
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node(value)); err != nil {
+		// The tree only holds nodes built above, which always encode
+		panic(err)
+	}
+	enc.Close()
+	return buf.Bytes()
+}
+
+// FromJSON re-encodes a JSON document as YAML, keeping the order of its keys
+func FromJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := orderedValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the JSON document")
+	}
+	return Encode(value), nil
+}
+
+// ordered is a decoded JSON object, in the order its keys were written
+type ordered struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (o *ordered) Keys() []string             { return o.keys }
+func (o *ordered) Get(key string) interface{} { return o.values[key] }
+
+func orderedValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		o := &ordered{values: make(map[string]interface{})}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyTok.(string)
+			value, err := orderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := o.values[key]; !dup {
+				o.keys = append(o.keys, key)
+			}
+			o.values[key] = value
+		}
+		_, err = dec.Token()
+		return o, err
+	case json.Delim('['):
+		items := []interface{}{}
+		for dec.More() {
+			item, err := orderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err = dec.Token()
+		return items, err
+	}
+	return tok, nil
+}
+
+// node builds the YAML tree of value. Strings are tagged !!str, so yaml.v3
+// quotes the ones that would read back as another type; other scalars are
+// left untagged and resolve to their own type.
+func node(value interface{}) *yamlv3.Node {
+	switch v := value.(type) {
+	case Mapping:
+		return mappingNode(v.Keys(), v.Get)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return mappingNode(keys, func(key string) interface{} { return v[key] })
+	case []string:
+		n := &yamlv3.Node{Kind: yamlv3.SequenceNode}
+		for _, s := range v {
+			n.Content = append(n.Content, node(s))
+		}
+		return n
+	case []interface{}:
+		n := &yamlv3.Node{Kind: yamlv3.SequenceNode}
+		for _, item := range v {
+			n.Content = append(n.Content, node(item))
+		}
+		return n
+	case string:
+		return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: v}
+	case bool:
+		return &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: strconv.FormatBool(v)}
+	case int:
+		return &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: strconv.Itoa(v)}
+	case float64:
+		return &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: strconv.FormatFloat(v, 'f', -1, 64)}
+	case json.Number:
+		return &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: v.String()}
+	case nil:
+		return &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: "null"}
+	default:
+		return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: fmt.Sprint(v)}
+	}
+}
+
+func mappingNode(keys []string, get func(string) interface{}) *yamlv3.Node {
+	n := &yamlv3.Node{Kind: yamlv3.MappingNode}
+	for _, key := range keys {
+		n.Content = append(n.Content, node(key), node(get(key)))
+	}
+	return n
+}
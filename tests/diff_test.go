@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/diff"
+	"github.com/eduardo/blueprint/internal/domain"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n"
+
+	want := "--- a/f\n+++ b/f\n" +
+		"@@ -2,3 +2,3 @@\n 2\n-3\n+three\n 4\n" +
+		"@@ -10 +10,2 @@\n 10\n+11\n"
+	if got := diff.Unified("a/f", "b/f", a, b, 1); got != want {
+		t.Errorf("unexpected diff:\n%s", got)
+	}
+	if got := diff.Unified("a/f", "b/f", a, a, 3); got != "" {
+		t.Errorf("expected no diff for equal texts, got:\n%s", got)
+	}
+}
+
+func TestCompareRoutes(t *testing.T) {
+	old := &domain.Config{Models: []domain.Model{{Name: "posts"}}}
+	new := &domain.Config{Models: []domain.Model{{Name: "comments"}}}
+
+	changes := application.CompareRoutes(old, new)
+	if len(changes) != 10 {
+		t.Fatalf("expected 10 route changes, got %d: %v", len(changes), changes)
+	}
+	if got := changes[0].String(); got != "+ route DELETE /api/comments/:id" {
+		t.Errorf("unexpected first change %q", got)
+	}
+}
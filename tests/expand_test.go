@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/diff"
+	"github.com/eduardo/blueprint/internal/generator"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/parser"
+	"github.com/eduardo/blueprint/internal/validator"
+)
+
+const includeBlueprint = `{
+  "project_name": "Blog",
+  "database": {"type": "postgresql"},
+  "models": [
+    {"name": "users", "protected": true, "fields": {"name": "string"}, "relations": {"posts": "hasMany:posts"}},
+    {"name": "posts", "fields": {"title": "string"}, "relations": {"author_id": "belongsTo:users", "tags": "manyToMany:tags", "comments": "hasMany:comments"}},
+    {"name": "comments", "fields": {"body": "string"}, "relations": {"post_id": "belongsTo:posts"}},
+    {"name": "tags", "fields": {"name": "string"}}
+  ]
+}
+`
+
+// includeTest drives the generated expand package with in-memory fetchers
+// counting their calls, and writes the expanded posts to $EXPAND_OUT
+const includeTest = `package expand_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"Blog/internal/domain"
+	"Blog/internal/expand"
+)
+
+func store[T any](calls map[string][]string, model string, docs map[string]*T) expand.Fetcher {
+	return expand.Fetch(func(ctx context.Context, ids []string) ([]*T, error) {
+		calls[model] = append(calls[model], strings.Join(ids, ","))
+		var found []*T
+		for _, id := range ids {
+			if doc, ok := docs[id]; ok {
+				found = append(found, doc)
+			}
+		}
+		return found, nil
+	})
+}
+
+func TestInclude(t *testing.T) {
+	posts := map[string]*domain.Posts{
+		"p1": {ID: "p1", Title: "First", AuthorId: "u1", Tags: []string{"t1", "t2"}, Comments: []string{"c1"}},
+		"p2": {ID: "p2", Title: "Second", AuthorId: "u1", Tags: []string{"t2", "missing"}},
+	}
+	calls := make(map[string][]string)
+	e := expand.New()
+	e.Register("users", store(calls, "users", map[string]*domain.Users{"u1": {ID: "u1", Name: "Ada", Posts: []string{"p1", "p2"}}}))
+	e.Register("posts", store(calls, "posts", posts))
+	e.Register("comments", store(calls, "comments", map[string]*domain.Comments{"c1": {ID: "c1", Body: "Nice", PostId: "p1"}}))
+	e.Register("tags", store(calls, "tags", map[string]*domain.Tags{"t1": {ID: "t1", Name: "go"}, "t2": {ID: "t2", Name: "api"}}))
+
+	ctx := context.Background()
+	docs := []*domain.Posts{posts["p1"], posts["p2"]}
+	expanded, err := e.Many(ctx, "posts", docs, "author, tags,comments.post", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.MarshalIndent(expanded, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(os.Getenv("EXPAND_OUT"), append(data, '\n'), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// One batched call per relation and level, each id loaded once
+	want := map[string][]string{"users": {"u1"}, "tags": {"t1,t2,missing"}, "comments": {"c1"}, "posts": {"p1"}}
+	for model, ids := range want {
+		if strings.Join(calls[model], ";") != strings.Join(ids, ";") {
+			t.Errorf("%s fetched with %q, want %q", model, calls[model], ids)
+		}
+	}
+
+	for include, msg := range map[string]string{
+		"writer":                      "posts has no relation \"writer\" (available: author, comments, tags)",
+		"comments.writer":             "comments has no relation \"writer\" (available: post)",
+		"comments.post.comments.post": "include \"comments.post.comments.post\" is nested deeper than 3 levels",
+		"tags,author":                 "users cannot be included from a public endpoint",
+	} {
+		_, err := e.One(ctx, "posts", posts["p1"], include, false)
+		if err == nil || err.Error() != msg {
+			t.Errorf("include %q: got error %v, want %q", include, err, msg)
+		} else if expand.Status(err) != 400 {
+			t.Errorf("include %q answers %d, want 400", include, expand.Status(err))
+		}
+	}
+}
+`
+
+func TestIncludeExpansion(t *testing.T) {
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	files := generateGolden(t, service, writeBlueprint(t, includeBlueprint))
+
+	out := filepath.Join(t.TempDir(), "expanded.json")
+	runGenerated(t, "Blog", files, []string{"internal/domain", "internal/expand"}, map[string]string{
+		"internal/expand/include_test.go": includeTest,
+	}, "EXPAND_OUT="+out)
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGoldenFile(t, "testdata/include.golden.json", string(got))
+}
+
+// runGenerated copies files of a generated project, given by package
+// directory or file path, into a module of their own with the extra files,
+// and runs their tests. The copied files must only import the standard
+// library and each other.
+func runGenerated(t *testing.T, projectName string, files map[string]string, paths []string, extra map[string]string, env ...string) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go command is not available")
+	}
+	dir := t.TempDir()
+	write := func(path, content string) {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module "+projectName+"\n\ngo 1.23\n")
+	for path, content := range files {
+		for _, p := range paths {
+			if (path == p || filepath.ToSlash(filepath.Dir(path)) == p) && !strings.HasSuffix(path, "_test.go") {
+				write(path, content)
+			}
+		}
+	}
+	for path, content := range extra {
+		write(path, content)
+	}
+
+	cmd := exec.Command("go", "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off", "GOTOOLCHAIN=local")
+	cmd.Env = append(cmd.Env, env...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test in the generated packages: %v\n%s", err, out)
+	}
+}
+
+// checkGoldenFile compares got with a golden file, rewritten with -update
+func checkGoldenFile(t *testing.T, path, got string) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v; create it with -update", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs:\n%s", path, diff.Unified("golden", "got", string(want), got, 3))
+	}
+}
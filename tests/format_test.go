@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/generator"
+	"github.com/eduardo/blueprint/internal/gosrc"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+)
+
+func TestFormatPrunesAndGroupsImports(t *testing.T) {
+	src := `package main
+import (
+	"Shop/internal/domain"
+	"github.com/gin-gonic/gin"
+
+	"strings"
+	"github.com/jackc/pgx/v5"
+
+
+	"os"
+	_ "embed"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+func main() {
+		os.Exit(len(domain.Name)+len(gin.Version))
+}
+`
+	want := `package main
+
+import (
+	_ "embed"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"Shop/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+func main() {
+	os.Exit(len(domain.Name) + len(gin.Version))
+}
+`
+	got, err := gosrc.Format("main.go", []byte(src), "Shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("formatted:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// breakingEngine renders one template with a syntax error
+type breakingEngine struct {
+	domain.TemplatePort
+	name string
+}
+
+func (e breakingEngine) Render(name, tmpl string, data interface{}) ([]byte, error) {
+	content, err := e.TemplatePort.Render(name, tmpl, data)
+	if name == e.name {
+		content = append(content, "\nfunc {\n"...)
+	}
+	return content, err
+}
+
+func TestInvalidGoNamesTheTemplate(t *testing.T) {
+	config := &domain.Config{
+		ProjectName: "Shop",
+		Database:    domain.Database{Type: "postgresql"},
+		Models:      []domain.Model{{Name: "posts", Fields: map[string]string{"title": "string"}}},
+	}
+	engine := breakingEngine{TemplatePort: infrastructure.NewGoTemplateEngine(), name: "handler"}
+
+	err := generator.Generate(config, ".", infrastructure.NewMemoryFileSystem(nil), engine)
+	if err == nil || !strings.Contains(err.Error(), "template handler rendered invalid Go: Shop/internal/handlers/posts/handler.go:") {
+		t.Errorf("expected the error to name the template and file, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/diff"
+	"github.com/eduardo/blueprint/internal/generator"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/parser"
+	"github.com/eduardo/blueprint/internal/validator"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden projects in testdata/golden")
+
+const goldenDir = "testdata/golden"
+
+// TestGoldenProjects generates every example in memory and compares the
+// project with the tree committed under testdata/golden. After an intended
+// change to the templates, refresh the trees with:
+//
+//	go test ./tests -run TestGoldenProjects -update
+func TestGoldenProjects(t *testing.T) {
+	blueprints, err := filepath.Glob("../examples/*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	split, err := filepath.Glob("../examples/*/blueprint.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blueprints = append(blueprints, split...)
+
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+
+	for _, blueprint := range blueprints {
+		name := strings.TrimSuffix(filepath.Base(blueprint), ".md")
+		if name == "blueprint" {
+			name = filepath.Base(filepath.Dir(blueprint))
+		}
+
+		t.Run(name, func(t *testing.T) {
+			got := generateGolden(t, service, blueprint)
+			dir := filepath.Join(goldenDir, name)
+			if *update {
+				writeGolden(t, dir, got)
+				return
+			}
+
+			want := readGolden(t, dir)
+			for _, path := range unionPaths(got, want) {
+				content, generated := got[path]
+				golden, committed := want[path]
+				switch {
+				case !committed:
+					t.Errorf("%s: generated but missing from %s", path, dir)
+				case !generated:
+					t.Errorf("%s: in %s but no longer generated", path, dir)
+				case content != golden:
+					t.Errorf("%s differs from the golden file:\n%s", path, diff.Unified("golden/"+path, "generated/"+path, golden, content, 3))
+				}
+			}
+			if t.Failed() {
+				t.Log("if the change is intended, run: go test ./tests -run TestGoldenProjects -update")
+			}
+		})
+	}
+}
+
+// generateGolden renders blueprint without touching disk and returns its files keyed by project-relative path
+func generateGolden(t *testing.T, service *application.BlueprintService, blueprint string) map[string]string {
+	t.Helper()
+	ctx := context.Background()
+	config, err := service.Load(ctx, blueprint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := infrastructure.NewMemoryFileSystem(nil)
+	if err := service.GenerateTo(ctx, config, ".", fs); err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string]string)
+	for _, path := range fs.Files() {
+		rel, err := filepath.Rel(config.ProjectName, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := fs.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[filepath.ToSlash(rel)] = string(data)
+	}
+	return files
+}
+
+func readGolden(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if os.IsNotExist(err) {
+		t.Fatalf("no golden project in %s, create it with -update", dir)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func writeGolden(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range files {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func unionPaths(a, b map[string]string) []string {
+	keys := make(map[string]interface{}, len(a)+len(b))
+	for path := range a {
+		keys[path] = nil
+	}
+	for path := range b {
+		keys[path] = nil
+	}
+	return sortedKeys(keys)
+}
@@ -1,3 +1,10 @@
+//go:build integration
+
+// TestGeneratedAPI drives an API generated from a blueprint into ../TestRelationsAPI
+// against MongoDB on port 27018. It is not part of the default suite; run it with:
+//
+//	go test -tags integration ./tests -run TestGeneratedAPI
+
 package tests
 
 import (
@@ -0,0 +1,246 @@
+package tests
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/migration"
+	"github.com/eduardo/blueprint/internal/validator"
+)
+
+func TestMigrationHistory(t *testing.T) {
+	config := &domain.Config{Models: []domain.Model{
+		{Name: "users", Fields: map[string]string{"email": "string"}},
+		{Name: "posts", Fields: map[string]string{"title": "string", "views": "int"}, Relations: map[string]string{"user_id": "belongsTo:users"}},
+	}}
+
+	history, err := migration.LoadHistory(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, ok := history.Advance(migration.FromConfig(config))
+	if !ok || first.UpFile() != "000001_init.up.sql" || !strings.Contains(first.Up, "CREATE INDEX IF NOT EXISTS idx_posts_user_id ON posts (user_id)") {
+		t.Fatalf("unexpected initial migration %+v", first)
+	}
+	if _, ok := history.Advance(migration.FromConfig(config)); ok {
+		t.Fatal("an unchanged blueprint must not add a migration")
+	}
+
+	// Round-trip through the history file like a regeneration does
+	data, err := history.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	history, err = migration.LoadHistory(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.Models[0].Fields["name"] = "string"
+	config.Models[1].Fields["views"] = "float"
+	delete(config.Models[1].Fields, "title")
+	next, ok := history.Advance(migration.FromConfig(config))
+	if !ok || next.Version != 2 || next.Name != "update_schema" {
+		t.Fatalf("unexpected migration %+v", next)
+	}
+
+	wantUp := "ALTER TABLE posts DROP COLUMN IF EXISTS title;\n\n" +
+		"ALTER TABLE posts ALTER COLUMN views TYPE DOUBLE PRECISION USING views::DOUBLE PRECISION;\n\n" +
+		"ALTER TABLE users ADD COLUMN IF NOT EXISTS name TEXT;\n"
+	if next.Up != wantUp {
+		t.Errorf("unexpected up migration:\n%s", next.Up)
+	}
+	wantDown := "ALTER TABLE users DROP COLUMN IF EXISTS name;\n\n" +
+		"ALTER TABLE posts ALTER COLUMN views TYPE INTEGER USING views::INTEGER;\n\n" +
+		"ALTER TABLE posts ADD COLUMN IF NOT EXISTS title TEXT;\n"
+	if next.Down != wantDown {
+		t.Errorf("unexpected down migration:\n%s", next.Down)
+	}
+}
+
+func TestRelationConstraints(t *testing.T) {
+	config := &domain.Config{Models: []domain.Model{
+		{Name: "users", Relations: map[string]string{"posts": "hasMany:posts"}},
+		{Name: "posts", Relations: map[string]string{"author_id": "belongsTo:users:cascade", "tags": "manyToMany:tags"}},
+		{Name: "tags"},
+	}}
+
+	m, _ := (&migration.History{}).Advance(migration.FromConfig(config))
+	for _, want := range []string{
+		"ALTER TABLE posts ADD CONSTRAINT fk_posts_author_id FOREIGN KEY (author_id) REFERENCES users (id) ON DELETE CASCADE",
+		"CREATE TABLE IF NOT EXISTS posts_tags (\n    post_id TEXT,\n    tag_id TEXT,\n    PRIMARY KEY (post_id, tag_id)\n)",
+		"FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE",
+	} {
+		if !strings.Contains(m.Up, want) {
+			t.Errorf("missing %q in:\n%s", want, m.Up)
+		}
+	}
+	// hasMany is read through the inverse foreign key, not stored in an array column
+	if strings.Contains(m.Up, "TEXT[]") {
+		t.Errorf("hasMany with an inverse belongsTo must not create an array column:\n%s", m.Up)
+	}
+
+	// Declared on both sides, the relation keeps a single join table
+	config.Models[2].Relations = map[string]string{"posts": "manyToMany:posts"}
+	both, _ := (&migration.History{}).Advance(migration.FromConfig(config))
+	if both.Up != m.Up || strings.Contains(both.Up, "tags_posts") {
+		t.Errorf("the inverse manyToMany changed the schema:\n%s", both.Up)
+	}
+}
+
+func TestSQLTypes(t *testing.T) {
+	for fieldType, want := range map[string]string{
+		"text":                  "TEXT",
+		"decimal":               "NUMERIC",
+		"uuid":                  "UUID",
+		"json":                  "JSONB",
+		"enum(draft,published)": "TEXT",
+		"array<integer>":        "INTEGER[]",
+		"array<date>":           "DATE[]",
+		"geopoint":              "POINT",
+	} {
+		if got := migration.SQLType(fieldType); got != want {
+			t.Errorf("SQLType(%q) = %s, want %s", fieldType, got, want)
+		}
+	}
+}
+
+func TestReverseDDL(t *testing.T) {
+	// The generated schema reads back as the relations it was generated from
+	config := &domain.Config{Models: []domain.Model{
+		{Name: "users", Fields: map[string]string{"email": "string", "scores": "array<integer>"}, Relations: map[string]string{"posts": "hasMany:posts"}},
+		{Name: "posts", Relations: map[string]string{"author_id": "belongsTo:users:cascade", "tags": "manyToMany:tags"}},
+		{Name: "tags"},
+	}}
+	m, _ := (&migration.History{}).Advance(migration.FromConfig(config))
+	dump, notes, err := migration.ParseDDL("up.sql", []byte(m.Up))
+	if err != nil || len(notes) > 0 {
+		t.Fatalf("unexpected result %v %v", notes, err)
+	}
+	reversed, notes := migration.Reverse(dump)
+	if len(notes) > 0 || len(reversed.Models) != 3 {
+		t.Fatalf("unexpected models %+v, notes %v", reversed.Models, notes)
+	}
+	for _, got := range reversed.Models {
+		want := map[string]domain.Model{"users": config.Models[0], "posts": config.Models[1]}[got.Name]
+		if len(want.Fields) > 0 && !reflect.DeepEqual(got.Fields, want.Fields) || len(want.Relations) > 0 && !reflect.DeepEqual(got.Relations, want.Relations) {
+			t.Errorf("model %s read back as %+v", got.Name, got)
+		}
+	}
+
+	// pg_dump writes keys, defaults and enums apart from the tables
+	dump, notes, err = migration.ParseDDL("shop.sql", []byte(`
+CREATE TYPE public.status AS ENUM ('draft', 'paid');
+CREATE FUNCTION public.touch() RETURNS trigger LANGUAGE plpgsql AS $$ BEGIN RETURN NEW; END; $$;
+CREATE TABLE public.orders (
+    id uuid DEFAULT gen_random_uuid() NOT NULL,
+    code character varying(12) NOT NULL,
+    status public.status DEFAULT 'draft'::public.status NOT NULL,
+    placed_at timestamp with time zone DEFAULT now(),
+    customer_id bigint
+);
+CREATE TABLE public.customers (id bigint PRIMARY KEY);
+ALTER TABLE ONLY public.orders ADD CONSTRAINT orders_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.orders ADD CONSTRAINT orders_code_key UNIQUE (code);
+ALTER TABLE ONLY public.orders
+    ADD CONSTRAINT orders_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES public.customers(id);
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reversed, more := migration.Reverse(dump)
+	orders, customers := reversed.Models[0], reversed.Models[1]
+	maxLength := 12
+	wantFields := map[string]string{"code": "string", "status": "enum(draft,paid)", "placed_at": "datetime"}
+	wantRules := map[string]domain.FieldRules{
+		"code":      {Required: true, MaxLength: &maxLength, Unique: true},
+		"status":    {Default: "draft"},
+		"placed_at": {Default: "now"},
+	}
+	if reversed.ProjectName != "Shop" || !reflect.DeepEqual(orders.Fields, wantFields) || !reflect.DeepEqual(orders.Rules, wantRules) {
+		t.Errorf("unexpected orders %+v", orders)
+	}
+	if orders.Relations["customer_id"] != "belongsTo:customers:no_action" || customers.Relations["orders"] != "hasMany:orders" {
+		t.Errorf("unexpected relations %v %v", orders.Relations, customers.Relations)
+	}
+	// The function and the customers' bigint primary key are reported
+	if notes = append(notes, more...); len(notes) != 2 {
+		t.Errorf("expected 2 warnings, got %v", notes)
+	}
+}
+
+func TestReverseDDLWarningOrder(t *testing.T) {
+	// The join table is only linked once posts is read, but is reported first
+	dump, _, err := migration.ParseDDL("blog.sql", []byte(`
+CREATE TABLE tags (id text PRIMARY KEY, label text);
+CREATE TABLE posts_tags (
+    post_id text NOT NULL REFERENCES posts(id),
+    tag_id text NOT NULL REFERENCES tags(id),
+    PRIMARY KEY (post_id, tag_id)
+);
+CREATE TABLE posts (
+    id text PRIMARY KEY,
+    tags text,
+    body xml
+);
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, notes := migration.Reverse(dump)
+	var got []string
+	for _, d := range notes {
+		got = append(got, d.String())
+	}
+	want := []string{
+		"blog.sql:3:1: warning: posts_tags: posts already has a field tags, join table skipped",
+		"blog.sql:11:1: warning: posts.body: type xml has no field type, imported as string",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("notes = %q, want %q", got, want)
+	}
+}
+
+func TestReverseDDLNumberAndBooleanColumns(t *testing.T) {
+	// NOT NULL and defaults carry over whatever the type, since the generated
+	// API tells 0 and false apart from a missing value
+	dump, _, err := migration.ParseDDL("shop.sql", []byte(`
+CREATE TABLE products (
+    id uuid PRIMARY KEY,
+    title text NOT NULL,
+    active boolean NOT NULL,
+    listed boolean DEFAULT true,
+    stock integer NOT NULL,
+    price double precision NOT NULL DEFAULT 0,
+    rating integer NOT NULL DEFAULT 5
+);
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, notes := migration.Reverse(dump)
+	wantRules := map[string]domain.FieldRules{
+		"title":  {Required: true},
+		"active": {Required: true},
+		"listed": {Default: true},
+		"stock":  {Required: true},
+		"price":  {Default: 0.0},
+		"rating": {Default: 5.0},
+	}
+	if !reflect.DeepEqual(config.Models[0].Rules, wantRules) || len(notes) > 0 {
+		t.Errorf("rules = %+v, notes %v, want %+v", config.Models[0].Rules, notes, wantRules)
+	}
+
+	// As the import command writes it
+	config.Database.Type = "postgresql"
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range validator.NewValidator().Validate(parseBlueprint(t, "# Shop\n\n```json\n"+string(data)+"\n```\n")) {
+		t.Errorf("the imported blueprint is invalid: %s", d)
+	}
+}
@@ -0,0 +1,345 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/generator"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/jsonschema"
+	"github.com/eduardo/blueprint/internal/module"
+	_ "github.com/eduardo/blueprint/internal/modules/auth"
+	_ "github.com/eduardo/blueprint/internal/modules/payments"
+	"github.com/eduardo/blueprint/internal/parser"
+	"github.com/eduardo/blueprint/internal/validator"
+	"github.com/eduardo/blueprint/internal/verify"
+)
+
+// auditModule records every request in a model of the project, the way a
+// team would ship a module of its own
+type auditModule struct{}
+
+type auditSettings struct {
+	Model string `json:"model"`
+}
+
+func (auditModule) Name() string { return "audit" }
+
+func (auditModule) Schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"model": map[string]interface{}{"type": "string", "description": "Model recording the requests, \"audits\" by default."},
+		},
+		"additionalProperties": false,
+	}
+}
+
+func (auditModule) Templates() map[string]string {
+	return map[string]string{"audit_middleware": auditMiddlewareTemplate}
+}
+
+func (auditModule) settings(raw json.RawMessage) (auditSettings, error) {
+	settings := auditSettings{Model: "audits"}
+	err := module.Decode(raw, &settings)
+	return settings, err
+}
+
+func (m auditModule) Validate(config *domain.Config, raw json.RawMessage) []domain.Diagnostic {
+	if _, err := m.settings(raw); err != nil {
+		return []domain.Diagnostic{{Message: err.Error()}}
+	}
+	return nil
+}
+
+func (m auditModule) Enrich(config *domain.Config, raw json.RawMessage) error {
+	settings, err := m.settings(raw)
+	if err != nil {
+		return err
+	}
+	config.Models = append(config.Models, domain.Model{
+		Name:      settings.Model,
+		Protected: true,
+		Fields:    map[string]string{"method": "string", "path": "string", "status": "integer", "created_at": "datetime"},
+	})
+	return nil
+}
+
+func (m auditModule) Contribute(config *domain.Config, raw json.RawMessage) (*module.Contribution, error) {
+	settings, err := m.settings(raw)
+	if err != nil {
+		return nil, err
+	}
+	typeName := strings.ToUpper(settings.Model[:1]) + settings.Model[1:]
+	repository := map[string]string{"firestore": "FirestoreRepository", "postgresql": "PostgresRepository", "mongodb": "MongoRepository"}[config.Database.Type]
+	return &module.Contribution{
+		Files: []module.File{{
+			Path:     "internal/audit/middleware.go",
+			Template: "audit_middleware",
+			Data:     struct{ ProjectName, Type string }{config.ProjectName, typeName},
+		}},
+		Requires:  []string{"github.com/gin-gonic/gin v1.10.0", "github.com/google/uuid v1.6.0"},
+		Imports:   []string{fmt.Sprintf("%q", config.ProjectName+"/internal/audit")},
+		Setup:     fmt.Sprintf("auditRepo := db.New%sRepository(baseRepo.(*db.%s))", typeName, repository),
+		Router:    "r.Use(audit.Middleware(auditRepo))\nr.GET(\"/audit/:id\", audit.Get(auditRepo))",
+		Endpoints: []module.Endpoint{{Method: "GET", Path: "/audit/:id", ID: "getAudit", Summary: "Get a recorded request"}},
+		Env:       []module.EnvVar{{Name: "AUDIT_DISABLED", Value: "false"}},
+	}, nil
+}
+
+const auditMiddlewareTemplate = `package audit
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"{{.ProjectName}}/internal/domain"
+)
+
+// Middleware records every request once it is answered
+func Middleware(repo domain.{{.Type}}Repository) gin.HandlerFunc {
+	disabled := os.Getenv("AUDIT_DISABLED") == "true"
+	return func(c *gin.Context) {
+		c.Next()
+		if disabled {
+			return
+		}
+		entry := &domain.{{.Type}}{Method: c.Request.Method, Path: c.FullPath(), Status: c.Writer.Status(), CreatedAt: time.Now()}
+		if _, err := repo.Create(c.Request.Context(), entry); err != nil {
+			c.Error(err)
+		}
+	}
+}
+
+// Get answers a recorded request
+func Get(repo domain.{{.Type}}Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entry, err := repo.Get(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, entry)
+	}
+}
+`
+
+const auditBlueprint = `{
+  "project_name": "Shop",
+  "database": {"type": "postgresql"},
+  "models": [{"name": "products", "fields": {"title": "string"}}],
+  "modules": {"audit": {}}
+}
+`
+
+func registerAudit(t *testing.T) {
+	t.Helper()
+	module.Register(auditModule{})
+	t.Cleanup(func() { module.Unregister("audit") })
+}
+
+// auditCopyModule is another module shipping a template called audit_middleware
+type auditCopyModule struct{ auditModule }
+
+func (auditCopyModule) Name() string { return "audit_copy" }
+
+func TestRegisterRejectsTakenTemplateNames(t *testing.T) {
+	registerAudit(t)
+	defer func() {
+		want := `module: template "audit_middleware" of module audit_copy is already registered by module audit`
+		if r := recover(); r != want {
+			t.Errorf("Register panicked with %v, want %q", r, want)
+		}
+		if _, ok := module.Lookup("audit_copy"); ok {
+			t.Error("the module was registered")
+		}
+	}()
+	module.Register(auditCopyModule{})
+}
+
+func writeBlueprint(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "blueprint.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestModuleContributesToTheProject(t *testing.T) {
+	registerAudit(t)
+	osFS := infrastructure.NewOSFileSystem()
+	recorder := verify.NewRecorder(infrastructure.NewGoTemplateEngine())
+	service := application.NewBlueprintService(osFS, recorder, parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	files := generateGolden(t, service, writeBlueprint(t, auditBlueprint))
+
+	for path, fragments := range map[string][]string{
+		"internal/audit/middleware.go": {"func Middleware(repo domain.AuditsRepository) gin.HandlerFunc"},
+		"internal/domain/audits.go":    {"type Audits struct"},
+		"cmd/api/main.go": {
+			`"Shop/internal/audit"`,
+			"auditRepo := db.NewAuditsRepository(baseRepo.(*db.PostgresRepository))",
+			"r := gin.Default()\n\n\t// Module audit\n\tr.Use(audit.Middleware(auditRepo))",
+		},
+		"go.mod":            {"github.com/gin-gonic/gin v1.9.1", "github.com/google/uuid v1.6.0"},
+		".env":              {"AUDIT_DISABLED=false\n"},
+		"docs/openapi.yaml": {"/audit/{id}:", "operationId: getAudit"},
+	} {
+		for _, fragment := range fragments {
+			if !strings.Contains(files[path], fragment) {
+				t.Errorf("%s doesn't contain %q", path, fragment)
+			}
+		}
+	}
+	if strings.Contains(files["go.mod"], "gin v1.10.0") {
+		t.Error("go.mod requires gin twice")
+	}
+
+	project := make(map[string][]byte, len(files))
+	for path, content := range files {
+		project[path] = []byte(content)
+	}
+	problems, err := verify.Check(project, recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range problems {
+		t.Error(p)
+	}
+
+	if _, ok := generator.DefaultTemplate("audit_middleware"); !ok {
+		t.Error("the module template can't be ejected")
+	}
+	schema := string(jsonschema.BlueprintJSON())
+	if !strings.Contains(schema, `"Model recording the requests, \"audits\" by default."`) {
+		t.Error("the blueprint schema doesn't describe the module settings")
+	}
+}
+
+func TestModuleSettingsAreValidated(t *testing.T) {
+	registerAudit(t)
+	config := parseBlueprint(t, "# Shop\n\n```json\n"+strings.Replace(auditBlueprint, `"audit": {}`, `"audit": {"modle": "requests"}, "audti": {}`, 1)+"```\n")
+
+	var got []string
+	for _, d := range validator.NewValidator().Validate(config) {
+		got = append(got, d.String())
+	}
+	want := []string{
+		`error: modules.audit: json: unknown field "modle"`,
+		`error: modules.audti: unknown module "audti" (did you mean "audit"?)`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got diagnostics %q, want %d", got, len(want))
+	}
+	for i := range want {
+		if !strings.HasSuffix(got[i], want[i]) {
+			t.Errorf("diagnostic %d = %q, want suffix %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestModuleModelsAreValidated(t *testing.T) {
+	registerAudit(t)
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	path := writeBlueprint(t, strings.Replace(auditBlueprint, `"audit": {}`, `"audit": {"model": "auth"}`, 1))
+
+	diags, err := service.Validate(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) != 1 || diags[0].Path != "models[1].name" || diags[0].Message != `model name "auth" is reserved` {
+		t.Errorf("expected the model added by the module to be rejected, got %v", diags)
+	}
+	if _, err := service.Load(context.Background(), path); err == nil {
+		t.Error("a blueprint whose module adds an invalid model loaded")
+	}
+}
+
+func TestPaymentsKeyConfiguresTheModule(t *testing.T) {
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	blueprint := `{
+  "project_name": "Shop",
+  "database": {"type": "postgresql"},
+  "payments": {"enabled": true, "provider": "stripe", "transactions_collection": "charges"},
+  "models": [{"name": "products", "fields": {"title": "string"}}]
+}
+`
+	config, err := service.Load(context.Background(), writeBlueprint(t, blueprint))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Payments != nil || string(config.Modules["payments"]) != `{"provider":"stripe","transactions_collection":"charges"}` {
+		t.Errorf("payments = %+v, modules = %s", config.Payments, config.Modules["payments"])
+	}
+	if len(config.Models) != 2 || config.Models[1].Name != "charges" {
+		t.Errorf("the payments module didn't add its model: %+v", config.Models)
+	}
+
+	// Diagnostics point at the payments key the blueprint declares
+	for _, c := range []struct{ old, new, want string }{
+		{`"provider": "stripe"`, `"provider": "strip"`, `:4:33: error: payments.provider: unsupported payment provider "strip" (available: mercadopago, stripe)`},
+		{`"models"`, `"modules": {"payments": {"provider": "stripe"}}, "models"`, `:4:3: error: payments: payments and modules.payments both configure the payments module, keep modules.payments`},
+	} {
+		diags, err := service.Validate(context.Background(), writeBlueprint(t, strings.Replace(blueprint, c.old, c.new, 1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diags) != 1 || !strings.HasSuffix(diags[0].String(), c.want) {
+			t.Errorf("got %v, want a diagnostic ending with %s", diags, c.want)
+		}
+	}
+}
+
+func TestAuthKeyConfiguresTheModule(t *testing.T) {
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	blueprint := `{
+  "project_name": "Shop",
+  "database": {"type": "postgresql"},
+  "auth": {"enabled": true, "provider": "jwt", "user_collection": "members"},
+  "models": [{"name": "products", "fields": {"title": "string"}, "protected": true}]
+}
+`
+	path := writeBlueprint(t, blueprint)
+	config, err := service.Load(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.LegacyAuth != nil || string(config.Modules["auth"]) != `{"provider":"jwt","user_collection":"members"}` {
+		t.Errorf("auth = %+v, modules = %s", config.LegacyAuth, config.Modules["auth"])
+	}
+	if len(config.Models) != 2 || config.Models[1].Name != "members" || config.Models[1].Fields["password"] != "string" {
+		t.Errorf("the auth module didn't add its user model: %+v", config.Models)
+	}
+
+	files := generateGolden(t, service, path)
+	if main := files["cmd/api/main.go"]; !strings.Contains(main, "group.Use(authService.AuthMiddleware(authSvc))") || !strings.Contains(main, `authGroup.POST("/register", userHdl.Register)`) {
+		t.Errorf("main.go doesn't guard the protected model with the auth module:\n%s", main)
+	}
+
+	// Diagnostics point at the auth key the blueprint declares
+	for _, c := range []struct{ old, new, want string }{
+		{`"provider": "jwt"`, `"provider": "jtw"`, `:4:29: error: auth.provider: unsupported auth provider "jtw" (available: firebase, jwt)`},
+		{`"models"`, `"modules": {"auth": {"provider": "jwt"}}, "models"`, `:4:3: error: auth: auth and modules.auth both configure the auth module, keep modules.auth`},
+	} {
+		diags, err := service.Validate(context.Background(), writeBlueprint(t, strings.Replace(blueprint, c.old, c.new, 1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diags) != 1 || !strings.HasSuffix(diags[0].String(), c.want) {
+			t.Errorf("got %v, want a diagnostic ending with %s", diags, c.want)
+		}
+	}
+}
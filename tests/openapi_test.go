@@ -0,0 +1,290 @@
+package tests
+
+import (
+	"context"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/generator"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/openapi"
+	"github.com/eduardo/blueprint/internal/parser"
+	"github.com/eduardo/blueprint/internal/validator"
+)
+
+// TestOpenAPICoversRoutes checks the document of every example against the
+// routes its generated main.go registers, read from the code itself, and
+// checks that application.Routes, which blueprint diff relies on, lists the same
+func TestOpenAPICoversRoutes(t *testing.T) {
+	files, err := filepath.Glob("../examples/*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := regexp.MustCompile(`:(\w+)`)
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+
+	for _, file := range files {
+		name := filepath.Base(file)
+		generated := generateGolden(t, service, file)
+		routes := routerRoutes(t, generated["cmd/api/main.go"])
+		config, err := service.Load(context.Background(), file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		documented := make(map[string]bool)
+		paths := openapi.Build(config).Get("paths").(*openapi.Map)
+		for _, path := range paths.Keys() {
+			item := paths.Get(path).(*openapi.Map)
+			for _, method := range item.Keys() {
+				switch method {
+				case "get", "post", "put", "patch", "delete":
+					documented[strings.ToUpper(method)+" "+path] = true
+				}
+			}
+		}
+
+		registered := make(map[string]bool)
+		for _, route := range routes {
+			if route.Path == "/openapi.yaml" || strings.HasPrefix(route.Path, "/swagger") {
+				continue
+			}
+			key := route.Method + " " + params.ReplaceAllString(route.Path, "{$1}")
+			registered[key] = true
+			if !documented[key] {
+				t.Errorf("%s: %s is not documented", name, route)
+			}
+		}
+		for key := range documented {
+			if !registered[key] {
+				t.Errorf("%s: %s is documented but not registered", name, key)
+			}
+		}
+
+		var listed, read []string
+		for _, route := range application.Routes(config) {
+			listed = append(listed, route.String())
+		}
+		for _, route := range routes {
+			read = append(read, route.String())
+		}
+		sort.Strings(listed)
+		sort.Strings(read)
+		if !reflect.DeepEqual(listed, read) {
+			t.Errorf("%s: application.Routes lists\n%s\nmain.go registers\n%s", name, strings.Join(listed, "\n"), strings.Join(read, "\n"))
+		}
+	}
+}
+
+// routerRoutes reads the routes a generated main.go registers on the gin
+// engine r and the groups derived from it
+func routerRoutes(t *testing.T, source string) []application.Route {
+	t.Helper()
+	file, err := goparser.ParseFile(token.NewFileSet(), "main.go", source, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	literal := func(args []ast.Expr) (string, bool) {
+		if len(args) == 0 {
+			return "", false
+		}
+		lit, ok := args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(lit.Value)
+		return value, err == nil
+	}
+	// call returns the receiver, method and path of recv.Method("path", ...)
+	call := func(expr ast.Expr) (string, string, string, bool) {
+		c, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return "", "", "", false
+		}
+		sel, ok := c.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return "", "", "", false
+		}
+		recv, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return "", "", "", false
+		}
+		path, ok := literal(c.Args)
+		return recv.Name, sel.Sel.Name, path, ok
+	}
+
+	prefixes := map[string]string{"r": ""}
+	var routes []application.Route
+	// Groups are assigned before use, so source order resolves them
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != 1 || len(n.Rhs) != 1 {
+				break
+			}
+			recv, method, path, ok := call(n.Rhs[0])
+			prefix, known := prefixes[recv]
+			if ident, isIdent := n.Lhs[0].(*ast.Ident); ok && known && isIdent && method == "Group" {
+				prefixes[ident.Name] = prefix + path
+			}
+		case *ast.ExprStmt:
+			recv, method, path, ok := call(n.X)
+			prefix, known := prefixes[recv]
+			switch method {
+			case "GET", "POST", "PUT", "PATCH", "DELETE":
+				if ok && known {
+					routes = append(routes, application.Route{Method: method, Path: prefix + path})
+				}
+			}
+		}
+		return true
+	})
+	if len(routes) == 0 {
+		t.Fatal("no route found in main.go")
+	}
+	return routes
+}
+
+// TestOpenAPIImportRoundTrip imports the document of every example back and
+// expects the same models; unique and "now" defaults have no OpenAPI keyword
+func TestOpenAPIImportRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../examples/*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		config, err := parser.NewMarkdownParser(infrastructure.NewOSFileSystem()).Parse(file)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		imported, notes, err := openapi.Import([]openapi.Document{{Name: "openapi.yaml", Data: openapi.Generate(config)}})
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		for _, note := range notes {
+			t.Errorf("%s: unexpected warning: %s", filepath.Base(file), note)
+		}
+
+		got := make(map[string]domain.Model)
+		for _, m := range imported.Models {
+			got[m.Name] = m
+		}
+		for _, want := range config.Models {
+			m := got[want.Name]
+			if !reflect.DeepEqual(m.Fields, want.Fields) {
+				t.Errorf("%s: %s fields = %v, want %v", filepath.Base(file), want.Name, m.Fields, want.Fields)
+			}
+			if len(m.Relations)+len(want.Relations) > 0 && !reflect.DeepEqual(m.Relations, want.Relations) {
+				t.Errorf("%s: %s relations = %v, want %v", filepath.Base(file), want.Name, m.Relations, want.Relations)
+			}
+			if m.Protected != want.Protected {
+				t.Errorf("%s: %s protected = %v, want %v", filepath.Base(file), want.Name, m.Protected, want.Protected)
+			}
+			for name, rules := range want.Rules {
+				rules.Unique = false
+				if rules.Default == "now" {
+					rules.Default = nil
+				}
+				rules.Type = ""
+				if !reflect.DeepEqual(m.Rules[name], rules) && len(rules.RuleNames()) > 0 {
+					t.Errorf("%s: %s.%s rules = %+v, want %+v", filepath.Base(file), want.Name, name, m.Rules[name], rules)
+				}
+			}
+		}
+		if len(got) != len(config.Models) {
+			t.Errorf("%s: imported %d models, want %d", filepath.Base(file), len(got), len(config.Models))
+		}
+	}
+}
+
+func TestJSONSchemaImport(t *testing.T) {
+	customer := `{
+  "title": "Customer",
+  "type": "object",
+  "required": ["email"],
+  "properties": {
+    "id": {"type": "string", "format": "uuid"},
+    "email": {"type": "string", "format": "email"},
+    "tier": {"type": "string", "enum": ["free", "pro"]},
+    "tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true},
+    "orders": {"type": "array", "items": {"$ref": "order.json"}}
+  }
+}`
+	order := `
+title: Order
+type: object
+properties:
+  total: {type: string, format: decimal}
+  customer: {$ref: customer.json}
+  location: {$ref: "#/$defs/Point"}
+$defs:
+  Point:
+    type: object
+    properties: {lat: {type: number}, lng: {type: number}}
+`
+	config, notes, err := openapi.Import([]openapi.Document{
+		{Name: "schemas/customer.json", Data: []byte(customer)},
+		{Name: "schemas/order.json", Data: []byte(order)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []domain.Model{
+		{
+			Name:      "customers",
+			Fields:    map[string]string{"email": "string", "tier": "enum(free,pro)", "tags": "array<string>"},
+			Relations: map[string]string{"orders": "hasMany:orders"},
+			Rules:     map[string]domain.FieldRules{"email": {Required: true, Email: true}},
+		},
+		{
+			Name:      "orders",
+			Fields:    map[string]string{"total": "decimal", "location": "geopoint"},
+			Relations: map[string]string{"customer": "belongsTo:customers"},
+		},
+	}
+	if !reflect.DeepEqual(config.Models, want) {
+		t.Errorf("models = %+v\nwant %+v", config.Models, want)
+	}
+	if len(notes) != 1 || !strings.Contains(notes[0].String(), "customer.json: warning: #/properties/tags: uniqueItems") {
+		t.Errorf("notes = %v, want the dropped uniqueItems only", notes)
+	}
+}
+
+func TestJSONSchemaImportKeepsSameNamedFilesApart(t *testing.T) {
+	config, _, err := openapi.Import([]openapi.Document{
+		{Name: "billing/order.json", Data: []byte(`{"title": "Order", "type": "object", "properties": {"item": {"$ref": "item.json"}}}`)},
+		{Name: "billing/item.json", Data: []byte(`{"title": "Invoice", "type": "object", "properties": {"amount": {"type": "number"}}}`)},
+		{Name: "catalog/product.json", Data: []byte(`{"title": "Product", "type": "object", "properties": {"item": {"$ref": "./item.json"}, "order": {"$ref": "../billing/order.json"}}}`)},
+		{Name: "catalog/item.json", Data: []byte(`{"title": "Variant", "type": "object", "properties": {"sku": {"type": "string"}}}`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	relations := make(map[string]map[string]string)
+	for _, m := range config.Models {
+		relations[m.Name] = m.Relations
+	}
+	want := map[string]map[string]string{
+		"invoices": {},
+		"orders":   {"item": "belongsTo:invoices"},
+		"products": {"item": "belongsTo:variants", "order": "belongsTo:orders"},
+		"variants": {},
+	}
+	if !reflect.DeepEqual(relations, want) {
+		t.Errorf("relations = %v, want %v", relations, want)
+	}
+}
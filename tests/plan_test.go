@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/regen"
+)
+
+func TestDryRunPlan(t *testing.T) {
+	disk := infrastructure.NewMemoryFileSystem(nil)
+	for path, content := range map[string]string{
+		"/out/Shop/same.txt":         "same",
+		"/out/Shop/changed.txt":      "old",
+		"/out/Shop/docs/rewrite.txt": "old",
+		"/out/Shop/docs/keep.txt":    "kept",
+	} {
+		if err := disk.WriteFile(path, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := disk.MkdirAll("/out/Shop/docs"); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := infrastructure.NewMemoryFileSystem(disk)
+	steps := []error{
+		fs.MkdirAll("/out/Shop"),
+		fs.WriteFile("/out/Shop/same.txt", []byte("same")),
+		fs.WriteFile("/out/Shop/changed.txt", []byte("new")),
+		fs.WriteFile("/out/Shop/added.txt", []byte("added")),
+		// Written again after the directory is cleared, as on regeneration
+		fs.RemoveAll("/out/Shop/docs"),
+		fs.MkdirAll("/out/Shop/docs"),
+		fs.WriteFile("/out/Shop/docs/rewrite.txt", []byte("new")),
+		fs.WriteFile("/out/Shop/docs/keep.txt", []byte("kept")),
+		fs.Chmod("/out/Shop/added.txt", 0755),
+	}
+	for _, err := range steps {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	for _, op := range fs.Plan() {
+		got = append(got, string(op.Kind)+" "+op.Path+" "+string(op.Status))
+	}
+	want := []string{
+		"dir /out/Shop new",
+		"file /out/Shop/added.txt new",
+		"chmod /out/Shop/added.txt ",
+		"file /out/Shop/changed.txt overwrite",
+		"remove /out/Shop/docs exists",
+		"dir /out/Shop/docs exists",
+		"file /out/Shop/docs/keep.txt unchanged",
+		"file /out/Shop/docs/rewrite.txt overwrite",
+		"file /out/Shop/same.txt unchanged",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("plan:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	var out bytes.Buffer
+	infrastructure.WritePlan(&out, "/out", fs.Plan(), nil)
+	wantOut := `dir     Shop                         new
+file    Shop/added.txt         5 B   new
+chmod   Shop/added.txt         0755
+file    Shop/changed.txt       3 B   overwrite
+remove  Shop/docs                    exists
+dir     Shop/docs                    exists
+file    Shop/docs/keep.txt     4 B   unchanged
+file    Shop/docs/rewrite.txt  3 B   overwrite
+file    Shop/same.txt          4 B   unchanged
+
+Dry run: 5 file(s), 2 director(ies), 1 chmod(s), 1 removal(s); nothing was written.
+`
+	// tabwriter pads the empty last column of chmod lines
+	trimmed := regexp.MustCompile(` +\n`).ReplaceAllString(out.String(), "\n")
+	if trimmed != wantOut {
+		t.Errorf("dry-run output:\n%s\nwant:\n%s", trimmed, wantOut)
+	}
+}
+
+func TestDryRunListsRegenerationStateApart(t *testing.T) {
+	fs := infrastructure.NewMemoryFileSystem(nil)
+	writer, err := regen.NewFileSystem(fs, "/out/Shop", regen.StrategyMerge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.WriteFile("/out/Shop/go.mod", []byte("module Shop\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	infrastructure.WritePlan(&out, "/out", fs.Plan(), func(path string) bool {
+		return regen.IsState("/out/Shop", path)
+	})
+	project, state, found := strings.Cut(out.String(), "\nRegeneration state:\n")
+	if !found {
+		t.Fatalf("no regeneration state section:\n%s", out.String())
+	}
+	if strings.Contains(project, regen.StateDir) || !strings.Contains(project, "Shop/go.mod") {
+		t.Errorf("project section lists bookkeeping files:\n%s", project)
+	}
+	for _, path := range []string{"Shop/.blueprint/manifest.json", "Shop/.blueprint/base/go.mod"} {
+		if !strings.Contains(state, path) {
+			t.Errorf("state section doesn't list %s:\n%s", path, state)
+		}
+	}
+	if !strings.Contains(state, "Dry run: 1 file(s),") {
+		t.Errorf("the count includes bookkeeping files:\n%s", state)
+	}
+}
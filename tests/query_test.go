@@ -0,0 +1,203 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/generator"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/parser"
+	"github.com/eduardo/blueprint/internal/validator"
+)
+
+const queryBlueprint = `{
+  "project_name": "Shop",
+  "database": {"type": "postgresql"},
+  "models": [
+    {"name": "customers", "fields": {"name": "string"}},
+    {"name": "products", "fields": {"title": "string"}},
+    {
+      "name": "orders",
+      "fields": {"status": "enum(pending,paid)", "total": "decimal", "quantity": "integer", "weight": "float", "paid": "boolean", "created_at": "datetime", "due": "date", "notes": "array<string>"},
+      "relations": {"customer_id": "belongsTo:customers", "products": "manyToMany:products"}
+    }
+  ]
+}
+`
+
+// listSQLTest parses query strings with the generated parser, turns them into
+// SQL with the generated PostgreSQL builder, and writes each outcome to
+// $QUERY_OUT
+const listSQLTest = `package db
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"Shop/internal/domain"
+	"Shop/internal/query"
+)
+
+var queries = []string{
+	// Accepted
+	"",
+	"status=paid",
+	"status[ne]=pending&total[gte]=10.50&quantity[lt]=3",
+	"created_at[gt]=2024-01-02T15:04:05Z&due[lte]=2024-02-01",
+	"paid=true&customer_id[in]=c1,c2",
+	"weight[in]=",
+	"quantity[gt]=1&quantity[lte]=5&quantity[eq]=3",
+	"sort=-created_at,total&fields=status,total",
+	"limit=5&page=2&include=customer&sort=id",
+	// Rejected
+	"state=paid",
+	"total[like]=1",
+	"total[]=1",
+	"quantity=many",
+	"total=ten",
+	"weight[in]=1,x",
+	"created_at[gt]=yesterday",
+	"due=2024-13-01",
+	"paid=maybe",
+	"notes=x",
+	"products=p1",
+	"sort=notes",
+	"sort=-missing",
+	"fields=title",
+}
+
+func TestListSQL(t *testing.T) {
+	var out strings.Builder
+	for _, raw := range queries {
+		fmt.Fprintf(&out, "?%s\n", raw)
+		values, err := url.ParseQuery(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := query.Parse(values, domain.OrdersFields)
+		var invalid *query.Error
+		if errors.As(err, &invalid) {
+			fmt.Fprintf(&out, "  400: %s\n\n", err)
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		q.Limit, q.Offset = 20, 40
+		sql, args, err := listSQL("SELECT id, status FROM orders", "orders", q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&out, "  %s\n", sql)
+		var typed []string
+		for _, arg := range args {
+			typed = append(typed, fmt.Sprintf("%T(%v)", arg, arg))
+		}
+		fmt.Fprintf(&out, "  args: %s\n", strings.Join(typed, ", "))
+		if len(q.Fields) > 0 {
+			fmt.Fprintf(&out, "  fields: %s\n", strings.Join(q.Fields, ", "))
+		}
+		out.WriteString("\n")
+	}
+
+	// A filter built by hand rather than parsed can hold a single value under OpIn
+	q := domain.ListQuery{Filters: []domain.Filter{{Field: "status", Op: domain.OpIn, Value: "paid"}}}
+	_, _, err := listSQL("SELECT id, status FROM orders", "orders", q)
+	fmt.Fprintf(&out, "status in \"paid\"\n  %d: %v\n", query.Status(err), err)
+	if err := os.WriteFile(os.Getenv("QUERY_OUT"), []byte(out.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+`
+
+func TestListQueries(t *testing.T) {
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	files := generateGolden(t, service, writeBlueprint(t, queryBlueprint))
+
+	out := filepath.Join(t.TempDir(), "queries.txt")
+	runGenerated(t, "Shop", files, []string{"internal/domain", "internal/query", "internal/infrastructure/db/query.go"}, map[string]string{
+		"internal/infrastructure/db/query_test.go": listSQLTest,
+	}, "QUERY_OUT="+out)
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGoldenFile(t, "testdata/list_queries.golden", string(got))
+}
+
+// textDecimalTest checks that the parser of a MongoDB project refuses to order
+// decimals, which are stored as text there
+const textDecimalTest = `package query
+
+import (
+	"net/url"
+	"testing"
+
+	"Shop/internal/domain"
+)
+
+func TestTextDecimals(t *testing.T) {
+	for raw, want := range map[string]string{
+		"total=10.50":          "",
+		"total[in]=1,2":        "",
+		"quantity[gt]=1":       "",
+		"total[gte]=10":        "cannot filter total with gte: decimals are stored as text in MongoDB, so only eq, ne and in apply",
+		"sort=quantity,-total": "cannot sort on total: decimals are stored as text in MongoDB, where \"10\" sorts before \"9\"",
+	} {
+		values, err := url.ParseQuery(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = Parse(values, domain.OrdersFields)
+		if got := errorText(err); got != want {
+			t.Errorf("?%s: got error %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+`
+
+func TestMongoDecimalsAreNotOrdered(t *testing.T) {
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	blueprint := strings.Replace(queryBlueprint, `"postgresql"`, `"mongodb"`, 1)
+	files := generateGolden(t, service, writeBlueprint(t, blueprint))
+
+	runGenerated(t, "Shop", files, []string{"internal/domain", "internal/query"}, map[string]string{
+		"internal/query/decimal_test.go": textDecimalTest,
+	})
+}
+
+func TestPostgresUUIDColumnsAreNullable(t *testing.T) {
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	blueprint := strings.Replace(queryBlueprint, `"status": "enum(pending,paid)"`, `"ref": "uuid", "status": "enum(pending,paid)"`, 1)
+	repository := generateGolden(t, service, writeBlueprint(t, blueprint))["internal/infrastructure/db/orders_repository.go"]
+
+	// An omitted optional uuid is stored as NULL and read back as ""
+	for _, fragment := range []string{
+		"COALESCE(ref::text, '') AS ref",
+		"VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, NULLIF($7, '')::uuid, $8, $9, $10)",
+		"ref = NULLIF($7, '')::uuid",
+	} {
+		if !strings.Contains(repository, fragment) {
+			t.Errorf("the repository doesn't contain %q", fragment)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/diff"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/regen"
+)
+
+func TestMerge3(t *testing.T) {
+	base := "a\nb\nc\nd\ne\n"
+
+	merged, conflicts := diff.Merge3(base, "a\nB\nc\nd\ne\n", "a\nb\nc\nd\nE\n")
+	if conflicts != 0 || merged != "a\nB\nc\nd\nE\n" {
+		t.Errorf("expected clean merge, got %d conflict(s):\n%s", conflicts, merged)
+	}
+
+	merged, conflicts = diff.Merge3(base, "a\nmine\nc\nd\ne\n", "a\ntheirs\nc\nd\ne\n")
+	if conflicts != 1 || !strings.Contains(merged, diff.MarkerOurs+"mine\n") || !strings.Contains(merged, diff.MarkerSplit+"theirs\n"+diff.MarkerTheirs) {
+		t.Errorf("expected one conflict, got %d:\n%s", conflicts, merged)
+	}
+}
+
+func TestRegenerationPreservesUserChanges(t *testing.T) {
+	fs := infrastructure.NewMemoryFileSystem(nil)
+
+	generate := func(strategy regen.Strategy, files map[string]string) *regen.FileSystem {
+		t.Helper()
+		writer, err := regen.NewFileSystem(fs, "/project", strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for path, content := range files {
+			if err := writer.WriteFile("/project/"+path, []byte(content)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := writer.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		return writer
+	}
+	read := func(path string) string {
+		data, _ := fs.ReadFile("/project/" + path)
+		return string(data)
+	}
+	actions := func(w *regen.FileSystem) map[string]regen.Action {
+		m := make(map[string]regen.Action)
+		for _, r := range w.Results() {
+			m[r.Path] = r.Action
+		}
+		return m
+	}
+
+	generate(regen.StrategyMerge, map[string]string{
+		"handler.go": "func Create() {\n\tbind()\n\tsave()\n}\n",
+		"main.go":    "func main() {\n\trun()\n}\n",
+		"old.go":     "package old\n",
+	})
+
+	// The user adds a validation call; the blueprint changes the save step
+	fs.WriteFile("/project/handler.go", []byte("func Create() {\n\tbind()\n\tvalidate()\n\tsave()\n}\n"))
+
+	w := generate(regen.StrategyMerge, map[string]string{
+		"handler.go": "func Create() {\n\tbind()\n\tsaveWithAudit()\n}\n",
+		"main.go":    "func main() {\n\trouter()\n}\n",
+	})
+	got := actions(w)
+	if got["handler.go"] != regen.ActionMerged || got["main.go"] != regen.ActionUpdated || got["old.go"] != regen.ActionStale {
+		t.Fatalf("unexpected actions: %v", got)
+	}
+	if want := "func Create() {\n\tbind()\n\tvalidate()\n\tsaveWithAudit()\n}\n"; read("handler.go") != want {
+		t.Errorf("merge lost changes:\n%s", read("handler.go"))
+	}
+
+	// Skip leaves the edited file alone
+	fs.WriteFile("/project/main.go", []byte("func main() {\n\tcustom()\n}\n"))
+	w = generate(regen.StrategySkip, map[string]string{
+		"handler.go": "func Create() {\n\tbind()\n\tsaveWithAudit()\n}\n",
+		"main.go":    "func main() {\n\trouter(v2)\n}\n",
+	})
+	if actions(w)["main.go"] != regen.ActionSkipped || read("main.go") != "func main() {\n\tcustom()\n}\n" {
+		t.Errorf("skip strategy overwrote the file: %s", read("main.go"))
+	}
+
+	// Code inside custom regions survives even a forced regeneration
+	fs.WriteFile("/project/main.go", []byte("func main() {\n\t// blueprint:custom-begin routes\n\tmine()\n\t// blueprint:custom-end routes\n}\n"))
+	w = generate(regen.StrategyOverwrite, map[string]string{
+		"main.go": "func main() {\n\trouter(v3)\n\t// blueprint:custom-begin routes\n\t// blueprint:custom-end routes\n}\n",
+	})
+	if want := "func main() {\n\trouter(v3)\n\t// blueprint:custom-begin routes\n\tmine()\n\t// blueprint:custom-end routes\n}\n"; read("main.go") != want {
+		t.Errorf("custom region lost:\n%s", read("main.go"))
+	}
+
+	// A region edit alone is not a user modification, and removed regions are kept aside
+	fs.WriteFile("/project/main.go", []byte(strings.Replace(read("main.go"), "mine()", "mine(2)", 1)))
+	w = generate(regen.StrategySkip, map[string]string{
+		"main.go": "func main() {\n\trouter(v4)\n}\n",
+	})
+	if actions(w)["main.go"] != regen.ActionUpdated || read("main.go") != "func main() {\n\trouter(v4)\n}\n" {
+		t.Errorf("unexpected regeneration of main.go (%s):\n%s", actions(w)["main.go"], read("main.go"))
+	}
+	if !strings.Contains(read("main.go"+regen.RegionsSuffix), "mine(2)") {
+		t.Errorf("orphaned region was not saved: %q", read("main.go"+regen.RegionsSuffix))
+	}
+}
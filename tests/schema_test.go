@@ -0,0 +1,224 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/jsonschema"
+)
+
+var jsonFence = regexp.MustCompile("(?s)```json\n(.*?)```")
+
+func TestShippedSchemaIsCurrent(t *testing.T) {
+	shipped, err := os.ReadFile("../blueprint.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(shipped, jsonschema.BlueprintJSON()) {
+		t.Error("blueprint.schema.json is stale, run: blueprint schema -o blueprint.schema.json")
+	}
+}
+
+func TestSchemaEnumsFollowTheDomain(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(jsonschema.BlueprintJSON(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string][]string{
+		"Database.type":     domain.DatabaseTypes,
+		"Auth.provider":     domain.AuthProviders,
+		"Payments.provider": domain.PaymentProviders,
+		"Pagination.mode":   domain.PaginationModes,
+	} {
+		def, property, _ := strings.Cut(path, ".")
+		got := lookup(schema, "$defs", def, "properties", property, "enum")
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("%s: enum %v, want %v", path, got, want)
+		}
+	}
+	pattern, _ := lookup(schema, "$defs", "Model", "properties", "relations", "additionalProperties", "pattern").(string)
+	for _, kind := range domain.RelationKinds {
+		if !strings.Contains(pattern, kind) {
+			t.Errorf("relation pattern %q misses %s", pattern, kind)
+		}
+	}
+}
+
+func TestExamplesMatchSchema(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(jsonschema.BlueprintJSON(), &schema); err != nil {
+		t.Fatal(err)
+	}
+	files, err := filepath.Glob("../examples/*.md")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no examples: %v", err)
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc interface{}
+		if err := json.Unmarshal(jsonFence.FindSubmatch(content)[1], &doc); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if problems := checkSchema(schema, schema, doc, ""); len(problems) > 0 {
+			t.Errorf("%s:\n%s", file, strings.Join(problems, "\n"))
+		}
+	}
+
+	var broken interface{}
+	if err := json.Unmarshal(jsonFence.FindSubmatch([]byte(invalidBlueprint))[1], &broken); err != nil {
+		t.Fatal(err)
+	}
+	got := checkSchema(schema, schema, broken, "")
+	want := []string{
+		"/database/type: not one of the allowed values",
+		"/models/0/fields/views: matches no alternative",
+		"/models/0/relations/author: does not match pattern",
+		"/models/1: unknown property relation",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("problems in the invalid blueprint:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func lookup(v interface{}, path ...string) interface{} {
+	for _, key := range path {
+		m, _ := v.(map[string]interface{})
+		v = m[key]
+	}
+	return v
+}
+
+// checkSchema is a small validator for the keywords the blueprint schema uses
+func checkSchema(root, schema map[string]interface{}, v interface{}, at string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		def, _ := lookup(root, strings.Split(strings.TrimPrefix(ref, "#/"), "/")...).(map[string]interface{})
+		return checkSchema(root, def, v, at)
+	}
+	if alternatives, ok := schema["anyOf"].([]interface{}); ok {
+		matched := false
+		for _, alternative := range alternatives {
+			if len(checkSchema(root, alternative.(map[string]interface{}), v, at)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return []string{at + ": matches no alternative"}
+		}
+	}
+	if types, ok := schema["type"]; ok && !hasSchemaType(types, v) {
+		return []string{fmt.Sprintf("%s: not of type %v", at, types)}
+	}
+	if values, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, value := range values {
+			found = found || value == v
+		}
+		if !found {
+			return []string{at + ": not one of the allowed values"}
+		}
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if s, _ := v.(string); !regexp.MustCompile(pattern).MatchString(s) {
+			return []string{at + ": does not match pattern"}
+		}
+	}
+
+	var problems []string
+	switch v := v.(type) {
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		for _, name := range schemaStrings(schema["required"]) {
+			if _, ok := v[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing property %s", at, name))
+			}
+		}
+		for _, name := range sortedKeys(v) {
+			if names, ok := schema["propertyNames"].(map[string]interface{}); ok {
+				problems = append(problems, checkSchema(root, names, name, at+"/"+name)...)
+			}
+			if property, ok := properties[name].(map[string]interface{}); ok {
+				problems = append(problems, checkSchema(root, property, v[name], at+"/"+name)...)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					problems = append(problems, fmt.Sprintf("%s: unknown property %s", at, name))
+				}
+			case map[string]interface{}:
+				problems = append(problems, checkSchema(root, additional, v[name], at+"/"+name)...)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				problems = append(problems, checkSchema(root, items, item, fmt.Sprintf("%s/%d", at, i))...)
+			}
+		}
+	}
+	return problems
+}
+
+func hasSchemaType(types interface{}, v interface{}) bool {
+	for _, name := range schemaStrings(types) {
+		switch x := v.(type) {
+		case map[string]interface{}:
+			if name == "object" {
+				return true
+			}
+		case []interface{}:
+			if name == "array" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case float64:
+			if name == "number" || name == "integer" && x == float64(int64(x)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func schemaStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		names := make([]string, len(v))
+		for i, name := range v {
+			names[i], _ = name.(string)
+		}
+		return names
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/generator"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/parser"
+	"github.com/eduardo/blueprint/internal/validator"
+)
+
+// namingEngine records the name of every template rendered
+type namingEngine struct {
+	domain.TemplatePort
+	names map[string]bool
+}
+
+func (e namingEngine) Render(name, tmpl string, data interface{}) ([]byte, error) {
+	e.names[name] = true
+	return e.TemplatePort.Render(name, tmpl, data)
+}
+
+func TestExamplesRenderEveryTemplate(t *testing.T) {
+	blueprints, err := filepath.Glob("../examples/*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	osFS := infrastructure.NewOSFileSystem()
+	engine := namingEngine{TemplatePort: infrastructure.NewGoTemplateEngine(), names: make(map[string]bool)}
+	service := application.NewBlueprintService(osFS, engine, parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	for _, blueprint := range blueprints {
+		generateGolden(t, service, blueprint)
+	}
+	// No example uses JWT auth
+	content, err := os.ReadFile("../examples/marketplace.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwt := strings.Replace(string(content), `"user_collection": "users"`, `"provider": "jwt", "user_collection": "users"`, 1)
+	path := filepath.Join(t.TempDir(), "marketplace.md")
+	if err := os.WriteFile(path, []byte(jwt), 0644); err != nil {
+		t.Fatal(err)
+	}
+	generateGolden(t, service, path)
+
+	var rendered []string
+	for name := range engine.names {
+		rendered = append(rendered, name)
+	}
+	sort.Strings(rendered)
+	if !reflect.DeepEqual(rendered, generator.TemplateNames()) {
+		t.Errorf("rendered templates %v\nregistered templates %v", rendered, generator.TemplateNames())
+	}
+}
+
+func TestTemplateSetOverridesBuiltIns(t *testing.T) {
+	dir := t.TempDir()
+	handler, _ := generator.DefaultTemplate("handler")
+	custom := strings.Replace(handler, "package {{.Model.Name | lower}}\n", "// Package {{.Model.Name | lower}} is customized\npackage {{.Model.Name | lower}}\n", 1)
+	if custom == handler {
+		t.Fatal("the handler template no longer starts with its package clause")
+	}
+	if err := os.WriteFile(filepath.Join(dir, "handler"+infrastructure.TemplateExt), []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	osFS := infrastructure.NewOSFileSystem()
+	templates := infrastructure.NewTemplateSet(infrastructure.NewGoTemplateEngine(), osFS, dir)
+	service := application.NewBlueprintService(osFS, templates, parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	files := generateGolden(t, service, "../examples/social_network.md")
+	want := readGolden(t, filepath.Join(goldenDir, "social_network"))
+
+	for path, content := range files {
+		if strings.HasSuffix(path, "/handler.go") && strings.Contains(path, "internal/handlers/") && !strings.HasSuffix(path, "auth/handler.go") {
+			model := filepath.Base(filepath.Dir(path))
+			if !strings.HasPrefix(content, "// Package "+model+" is customized\n") {
+				t.Errorf("%s doesn't come from the custom template:\n%.200s", path, content)
+			}
+			continue
+		}
+		if content != want[path] {
+			t.Errorf("%s changed although its template wasn't replaced", path)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"BlogAPI/docs"
+	"BlogAPI/internal/expand"
+	"BlogAPI/internal/handlers/authors"
+	"BlogAPI/internal/handlers/posts"
+	"BlogAPI/internal/handlers/tags"
+	"BlogAPI/internal/infrastructure/db"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+func main() {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Initialize Database
+
+	baseRepo, err := db.NewPostgresRepository(os.Getenv("DATABASE_URL"))
+
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer baseRepo.Close()
+
+	// Setup Router
+	r := gin.Default()
+
+	// API docs: the OpenAPI document generated from the blueprint, browsable with Swagger UI
+	r.GET("/openapi.yaml", func(c *gin.Context) {
+		c.Data(200, "application/yaml", docs.OpenAPI)
+	})
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/openapi.yaml")))
+
+	// Related records for ?include=, registered by each model below
+	expander := expand.New()
+
+	// Routes for authors
+	{
+
+		repo := db.NewAuthorsRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("authors", expand.Fetch(repo.GetMany))
+		handler := authors.NewAuthorsHandler(repo, expander)
+
+		group := r.Group("/api/authors")
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for posts
+	{
+
+		repo := db.NewPostsRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("posts", expand.Fetch(repo.GetMany))
+		handler := posts.NewPostsHandler(repo, expander)
+
+		group := r.Group("/api/posts")
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+		group.POST("/:id/tags/:targetId", handler.LinkTags)
+		group.DELETE("/:id/tags/:targetId", handler.UnlinkTags)
+
+	}
+
+	// Routes for tags
+	{
+
+		repo := db.NewTagsRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("tags", expand.Fetch(repo.GetMany))
+		handler := tags.NewTagsHandler(repo, expander)
+
+		group := r.Group("/api/tags")
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+		group.POST("/:id/posts/:targetId", handler.LinkPosts)
+		group.DELETE("/:id/posts/:targetId", handler.UnlinkPosts)
+
+	}
+
+	// blueprint:custom-begin routes
+	// blueprint:custom-end routes
+
+	log.Printf("Starting server for project: BlogAPI")
+	r.Run(":8080")
+}
+
+// AuthMiddleware verifies the Bearer token
+func AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			return
+		}
+		c.Next()
+	}
+}
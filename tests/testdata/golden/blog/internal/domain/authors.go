@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+)
+
+type Authors struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Email string `json:"email" bson:"email" firestore:"email"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	Posts []string `json:"posts" bson:"posts" firestore:"posts"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Authors) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Authors) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// AuthorsFields maps the fields List can filter, sort and select on to their blueprint types
+var AuthorsFields = map[string]string{
+	"id":    "string",
+	"email": "string",
+	"name":  "string",
+	"posts": "array",
+}
+
+type AuthorsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Authors, error)
+	Count(ctx context.Context, q ListQuery) (int64, error)
+	Get(ctx context.Context, id string) (*Authors, error)
+	GetMany(ctx context.Context, ids []string) ([]*Authors, error)
+	Create(ctx context.Context, model *Authors) (string, error)
+	Update(ctx context.Context, id string, model *Authors) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Posts struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Body string `json:"body" bson:"body" firestore:"body"`
+
+	PublishedAt time.Time `json:"published_at" bson:"published_at" firestore:"published_at"`
+
+	Title string `json:"title" bson:"title" firestore:"title" binding:"required,max=200"`
+
+	Author string `json:"author" bson:"author" firestore:"author"`
+
+	Tags []string `json:"tags" bson:"tags" firestore:"tags"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Posts) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Posts) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// PostsFields maps the fields List can filter, sort and select on to their blueprint types
+var PostsFields = map[string]string{
+	"id":           "string",
+	"body":         "text",
+	"published_at": "datetime",
+	"title":        "string",
+	"author":       "string",
+	"tags":         "array",
+}
+
+type PostsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Posts, error)
+	Count(ctx context.Context, q ListQuery) (int64, error)
+	Get(ctx context.Context, id string) (*Posts, error)
+	GetMany(ctx context.Context, ids []string) ([]*Posts, error)
+	Create(ctx context.Context, model *Posts) (string, error)
+	Update(ctx context.Context, id string, model *Posts) error
+	Delete(ctx context.Context, id string) error
+
+	LinkTags(ctx context.Context, id, targetID string) error
+	UnlinkTags(ctx context.Context, id, targetID string) error
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "errors"
+
+// Filter operators, written as field[op]=value in the query string
+const (
+	OpEq  = "eq"
+	OpNe  = "ne"
+	OpGt  = "gt"
+	OpGte = "gte"
+	OpLt  = "lt"
+	OpLte = "lte"
+	OpIn  = "in"
+)
+
+// ArrayField is the type listed in a model's field map for relations holding
+// several ids; like array, json and geopoint fields, they can be selected but
+// not filtered or sorted on
+const ArrayField = "array"
+
+// ErrInvalidQuery is wrapped by the errors of repositories given a ListQuery
+// they cannot run, such as an OpIn filter whose value is not a list
+var ErrInvalidQuery = errors.New("invalid query")
+
+// ListQuery holds the criteria of a List call, already checked against the model's fields
+type ListQuery struct {
+	Filters []Filter
+	Sort    []Sort
+	Fields  []string // fields to return; empty means all of them
+	Limit   int
+	Offset  int
+	After   []interface{} // sort values of the last item of the previous page; the sort always includes id
+}
+
+// Filter compares a field to a value converted to the field's type; for OpIn
+// the value is a []interface{}
+type Filter struct {
+	Field string
+	Op    string
+	Value interface{}
+}
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+)
+
+type Tags struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Name string `json:"name" bson:"name" firestore:"name" binding:"required"`
+
+	Posts []string `json:"posts" bson:"posts" firestore:"posts"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Tags) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Tags) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// TagsFields maps the fields List can filter, sort and select on to their blueprint types
+var TagsFields = map[string]string{
+	"id":    "string",
+	"name":  "string",
+	"posts": "array",
+}
+
+type TagsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Tags, error)
+	Count(ctx context.Context, q ListQuery) (int64, error)
+	Get(ctx context.Context, id string) (*Tags, error)
+	GetMany(ctx context.Context, ids []string) ([]*Tags, error)
+	Create(ctx context.Context, model *Tags) (string, error)
+	Update(ctx context.Context, id string, model *Tags) error
+	Delete(ctx context.Context, id string) error
+
+	LinkPosts(ctx context.Context, id, targetID string) error
+	UnlinkPosts(ctx context.Context, id, targetID string) error
+}
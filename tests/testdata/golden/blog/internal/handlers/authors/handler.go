@@ -0,0 +1,183 @@
+package authors
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"BlogAPI/internal/domain"
+	"BlogAPI/internal/expand"
+	"BlogAPI/internal/query"
+	"BlogAPI/internal/validation"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type AuthorsHandler struct {
+	repo     domain.AuthorsRepository
+	expander *expand.Expander
+}
+
+func NewAuthorsHandler(repo domain.AuthorsRepository, expander *expand.Expander) *AuthorsHandler {
+	return &AuthorsHandler{repo: repo, expander: expander}
+}
+
+func (h *AuthorsHandler) List(c *gin.Context) {
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if val, err := strconv.Atoi(l); err == nil && val > 0 {
+			limit = val
+		}
+	}
+
+	q, err := query.Parse(c.Request.URL.Query(), domain.AuthorsFields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := query.ApplyCursor(&q, c.Query("cursor"), domain.AuthorsFields); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// One extra item tells whether there is a next page
+	q.Limit = limit + 1
+
+	results, err := h.repo.List(c.Request.Context(), q)
+	if err != nil {
+		c.JSON(query.Status(err), gin.H{"error": err.Error()})
+		return
+	}
+	total, err := h.repo.Count(c.Request.Context(), q)
+	if err != nil {
+		c.JSON(query.Status(err), gin.H{"error": err.Error()})
+		return
+	}
+	next := ""
+	if len(results) > limit {
+		results = results[:limit]
+		if next, err = query.NextCursor(q, results[limit-1]); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if results == nil {
+		results = []*domain.Authors{}
+	}
+	c.Header("Link", query.Links(c.Request.URL, next))
+
+	var body interface{} = results
+	include := c.Query("include")
+	if include != "" {
+		expanded, err := h.expander.Many(c.Request.Context(), "authors", results, include, false)
+		if err != nil {
+			c.JSON(expand.Status(err), gin.H{"error": err.Error()})
+			return
+		}
+		body = expanded
+	}
+	if len(q.Fields) > 0 {
+		// Included relations are returned along with the selected fields
+		selected, err := query.Select(body, append(q.Fields, expand.Roots(include)...))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		body = selected
+	}
+
+	envelope := gin.H{"data": body, "next_cursor": nil, "total": total}
+	if next != "" {
+		envelope["next_cursor"] = next
+	}
+	c.JSON(http.StatusOK, envelope)
+}
+
+func (h *AuthorsHandler) Get(c *gin.Context) {
+	id := c.Param("id")
+	result, err := h.repo.Get(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if include := c.Query("include"); include != "" {
+		expanded, err := h.expander.One(c.Request.Context(), "authors", result, include, false)
+		if err != nil {
+			c.JSON(expand.Status(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, expanded)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *AuthorsHandler) Create(c *gin.Context) {
+	var m domain.Authors
+	if !h.bind(c, &m) {
+		return
+	}
+	m.ApplyDefaults()
+	if !h.validate(c, &m, "") {
+		return
+	}
+	// blueprint:custom-begin create
+	// blueprint:custom-end create
+	id, err := h.repo.Create(c.Request.Context(), &m)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	m.ID = id
+	c.JSON(http.StatusCreated, m)
+}
+
+func (h *AuthorsHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	var m domain.Authors
+	if !h.bind(c, &m) || !h.validate(c, &m, id) {
+		return
+	}
+	// blueprint:custom-begin update
+	// blueprint:custom-end update
+	if err := h.repo.Update(c.Request.Context(), id, &m); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "updated"})
+}
+
+func (h *AuthorsHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// bind decodes the request body into m, answering 422 when a binding rule fails
+func (h *AuthorsHandler) bind(c *gin.Context, m *domain.Authors) bool {
+	if err := c.ShouldBindJSON(m); err != nil {
+		if errs, ok := validation.FromBinding(err); ok {
+			validation.Respond(c, errs)
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return false
+	}
+	return true
+}
+
+// validate runs the checks binding cannot do; id is the record being updated, if any
+func (h *AuthorsHandler) validate(c *gin.Context, m *domain.Authors, id string) bool {
+	errs := m.Validate()
+	if len(errs) > 0 {
+		validation.Respond(c, errs)
+		return false
+	}
+	return true
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
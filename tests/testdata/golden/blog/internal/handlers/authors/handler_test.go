@@ -0,0 +1,124 @@
+package authors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"BlogAPI/internal/domain"
+	"BlogAPI/internal/expand"
+)
+
+type MockAuthorsRepository struct {
+	Data map[string]*domain.Authors
+}
+
+func (m *MockAuthorsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Authors, error) {
+	var results []*domain.Authors
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Authors{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockAuthorsRepository) Count(ctx context.Context, q domain.ListQuery) (int64, error) {
+	return int64(len(m.Data)), nil
+}
+
+func (m *MockAuthorsRepository) Get(ctx context.Context, id string) (*domain.Authors, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockAuthorsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Authors, error) {
+	var results []*domain.Authors
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockAuthorsRepository) Create(ctx context.Context, model *domain.Authors) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Authors)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockAuthorsRepository) Update(ctx context.Context, id string, model *domain.Authors) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockAuthorsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestAuthorsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockAuthorsRepository{Data: make(map[string]*domain.Authors)}
+	handler := NewAuthorsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/authors", handler.List)
+	r.POST("/authors", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Authors{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/authors", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/authors?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/authors?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("InvalidCursor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/authors?cursor=invalid", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/authors?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,220 @@
+package tags
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"BlogAPI/internal/domain"
+	"BlogAPI/internal/expand"
+	"BlogAPI/internal/query"
+	"BlogAPI/internal/validation"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type TagsHandler struct {
+	repo     domain.TagsRepository
+	expander *expand.Expander
+}
+
+func NewTagsHandler(repo domain.TagsRepository, expander *expand.Expander) *TagsHandler {
+	return &TagsHandler{repo: repo, expander: expander}
+}
+
+func (h *TagsHandler) List(c *gin.Context) {
+	limit := 20
+	if l := c.Query("limit"); l != "" {
+		if val, err := strconv.Atoi(l); err == nil && val > 0 {
+			limit = val
+		}
+	}
+
+	q, err := query.Parse(c.Request.URL.Query(), domain.TagsFields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := query.ApplyCursor(&q, c.Query("cursor"), domain.TagsFields); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// One extra item tells whether there is a next page
+	q.Limit = limit + 1
+
+	results, err := h.repo.List(c.Request.Context(), q)
+	if err != nil {
+		c.JSON(query.Status(err), gin.H{"error": err.Error()})
+		return
+	}
+	total, err := h.repo.Count(c.Request.Context(), q)
+	if err != nil {
+		c.JSON(query.Status(err), gin.H{"error": err.Error()})
+		return
+	}
+	next := ""
+	if len(results) > limit {
+		results = results[:limit]
+		if next, err = query.NextCursor(q, results[limit-1]); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if results == nil {
+		results = []*domain.Tags{}
+	}
+	c.Header("Link", query.Links(c.Request.URL, next))
+
+	var body interface{} = results
+	include := c.Query("include")
+	if include != "" {
+		expanded, err := h.expander.Many(c.Request.Context(), "tags", results, include, false)
+		if err != nil {
+			c.JSON(expand.Status(err), gin.H{"error": err.Error()})
+			return
+		}
+		body = expanded
+	}
+	if len(q.Fields) > 0 {
+		// Included relations are returned along with the selected fields
+		selected, err := query.Select(body, append(q.Fields, expand.Roots(include)...))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		body = selected
+	}
+
+	envelope := gin.H{"data": body, "next_cursor": nil, "total": total}
+	if next != "" {
+		envelope["next_cursor"] = next
+	}
+	c.JSON(http.StatusOK, envelope)
+}
+
+func (h *TagsHandler) Get(c *gin.Context) {
+	id := c.Param("id")
+	result, err := h.repo.Get(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if include := c.Query("include"); include != "" {
+		expanded, err := h.expander.One(c.Request.Context(), "tags", result, include, false)
+		if err != nil {
+			c.JSON(expand.Status(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, expanded)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *TagsHandler) Create(c *gin.Context) {
+	var m domain.Tags
+	if !h.bind(c, &m) {
+		return
+	}
+	m.ApplyDefaults()
+	if !h.validate(c, &m, "") {
+		return
+	}
+	// blueprint:custom-begin create
+	// blueprint:custom-end create
+	id, err := h.repo.Create(c.Request.Context(), &m)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	m.ID = id
+	c.JSON(http.StatusCreated, m)
+}
+
+func (h *TagsHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	var m domain.Tags
+	if !h.bind(c, &m) || !h.validate(c, &m, id) {
+		return
+	}
+	// blueprint:custom-begin update
+	// blueprint:custom-end update
+	if err := h.repo.Update(c.Request.Context(), id, &m); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "updated"})
+}
+
+func (h *TagsHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// bind decodes the request body into m, answering 422 when a binding rule fails
+func (h *TagsHandler) bind(c *gin.Context, m *domain.Tags) bool {
+	if err := c.ShouldBindJSON(m); err != nil {
+		if errs, ok := validation.FromBinding(err); ok {
+			validation.Respond(c, errs)
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return false
+	}
+	return true
+}
+
+// validate runs the checks binding cannot do; id is the record being updated, if any
+func (h *TagsHandler) validate(c *gin.Context, m *domain.Tags, id string) bool {
+	errs := m.Validate()
+	if taken, err := h.taken(c, "name", m.Name, id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	} else if taken {
+		errs = append(errs, domain.FieldError{Field: "name", Rule: "unique", Message: "is already taken"})
+	}
+	if len(errs) > 0 {
+		validation.Respond(c, errs)
+		return false
+	}
+	return true
+}
+
+// taken reports whether a record other than id already has value in field
+func (h *TagsHandler) taken(c *gin.Context, field string, value interface{}, id string) (bool, error) {
+	q := domain.ListQuery{Filters: []domain.Filter{{Field: field, Op: domain.OpEq, Value: value}}, Limit: 2}
+	existing, err := h.repo.List(c.Request.Context(), q)
+	if err != nil {
+		return false, err
+	}
+	for _, e := range existing {
+		if e.ID != id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (h *TagsHandler) LinkPosts(c *gin.Context) {
+	if err := h.repo.LinkPosts(c.Request.Context(), c.Param("id"), c.Param("targetId")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "linked"})
+}
+
+func (h *TagsHandler) UnlinkPosts(c *gin.Context) {
+	if err := h.repo.UnlinkPosts(c.Request.Context(), c.Param("id"), c.Param("targetId")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "unlinked"})
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
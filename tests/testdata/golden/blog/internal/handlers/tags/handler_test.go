@@ -0,0 +1,134 @@
+package tags
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"BlogAPI/internal/domain"
+	"BlogAPI/internal/expand"
+)
+
+type MockTagsRepository struct {
+	Data map[string]*domain.Tags
+}
+
+func (m *MockTagsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Tags, error) {
+	var results []*domain.Tags
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Tags{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockTagsRepository) Count(ctx context.Context, q domain.ListQuery) (int64, error) {
+	return int64(len(m.Data)), nil
+}
+
+func (m *MockTagsRepository) Get(ctx context.Context, id string) (*domain.Tags, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockTagsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Tags, error) {
+	var results []*domain.Tags
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockTagsRepository) Create(ctx context.Context, model *domain.Tags) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Tags)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockTagsRepository) Update(ctx context.Context, id string, model *domain.Tags) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockTagsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func (m *MockTagsRepository) LinkPosts(ctx context.Context, id, targetID string) error {
+	return nil
+}
+
+func (m *MockTagsRepository) UnlinkPosts(ctx context.Context, id, targetID string) error {
+	return nil
+}
+
+func TestTagsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockTagsRepository{Data: make(map[string]*domain.Tags)}
+	handler := NewTagsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/tags", handler.List)
+	r.POST("/tags", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Tags{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/tags", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		// An empty body misses the required fields
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), `"rule":"required"`)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tags?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tags?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("InvalidCursor", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tags?cursor=invalid", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tags?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
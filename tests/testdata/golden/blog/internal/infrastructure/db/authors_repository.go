@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"BlogAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type AuthorsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAuthorsRepository expects the authors table to exist; see migrations.go
+func NewAuthorsRepository(repo *PostgresRepository) *AuthorsRepository {
+	return &AuthorsRepository{db: repo.Pool}
+}
+
+// Count returns how many records match the filters of q, ignoring the page
+func (r *AuthorsRepository) Count(ctx context.Context, q domain.ListQuery) (int64, error) {
+	query, args, err := countSQL("authors", q)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&total)
+	return total, err
+}
+
+func (r *AuthorsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Authors, error) {
+	query, args, err := listSQL("SELECT id, email, name, ARRAY(SELECT id FROM posts WHERE author = authors.id) AS posts FROM authors", "authors", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Authors
+	for rows.Next() {
+		var m domain.Authors
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Email)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Posts)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *AuthorsRepository) Get(ctx context.Context, id string) (*domain.Authors, error) {
+	var m domain.Authors
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Email)
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.Posts)
+
+	query := "SELECT id, email, name, ARRAY(SELECT id FROM posts WHERE author = authors.id) AS posts FROM authors WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *AuthorsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Authors, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, email, name, ARRAY(SELECT id FROM posts WHERE author = authors.id) AS posts FROM authors WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Authors
+	for rows.Next() {
+		var m domain.Authors
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Email)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Posts)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *AuthorsRepository) Create(ctx context.Context, m *domain.Authors) (string, error) {
+	query := "INSERT INTO authors (email, name) VALUES ($1, $2) RETURNING id"
+
+	values := []interface{}{
+		m.Email,
+		m.Name,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *AuthorsRepository) Update(ctx context.Context, id string, m *domain.Authors) error {
+
+	query := "UPDATE authors SET email = $1, name = $2 WHERE id = $3"
+
+	values := []interface{}{
+		m.Email,
+		m.Name,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *AuthorsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM authors WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
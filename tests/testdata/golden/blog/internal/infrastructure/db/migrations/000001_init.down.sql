@@ -0,0 +1,19 @@
+ALTER TABLE posts_tags DROP CONSTRAINT IF EXISTS fk_posts_tags_tag_id;
+
+ALTER TABLE posts_tags DROP CONSTRAINT IF EXISTS fk_posts_tags_post_id;
+
+ALTER TABLE posts DROP CONSTRAINT IF EXISTS fk_posts_author;
+
+DROP INDEX IF EXISTS uniq_tags_name;
+
+DROP INDEX IF EXISTS idx_posts_tags_tag_id;
+
+DROP INDEX IF EXISTS idx_posts_author;
+
+DROP TABLE IF EXISTS tags;
+
+DROP TABLE IF EXISTS posts_tags;
+
+DROP TABLE IF EXISTS posts;
+
+DROP TABLE IF EXISTS authors;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS authors (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    email TEXT,
+    name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS posts (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    author TEXT,
+    body TEXT,
+    published_at TIMESTAMP,
+    title TEXT
+);
+
+CREATE TABLE IF NOT EXISTS posts_tags (
+    post_id TEXT,
+    tag_id TEXT,
+    PRIMARY KEY (post_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    name TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_posts_author ON posts (author);
+
+CREATE INDEX IF NOT EXISTS idx_posts_tags_tag_id ON posts_tags (tag_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_tags_name ON tags (name);
+
+ALTER TABLE posts ADD CONSTRAINT fk_posts_author FOREIGN KEY (author) REFERENCES authors (id) ON DELETE SET NULL;
+
+ALTER TABLE posts_tags ADD CONSTRAINT fk_posts_tags_post_id FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE;
+
+ALTER TABLE posts_tags ADD CONSTRAINT fk_posts_tags_tag_id FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE;
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository interface {
+	List(ctx context.Context, collection string) ([]map[string]interface{}, error)
+	Get(ctx context.Context, collection, id string) (map[string]interface{}, error)
+	Create(ctx context.Context, collection string, data map[string]interface{}) (string, error)
+	Update(ctx context.Context, collection, id string, data map[string]interface{}) error
+	Delete(ctx context.Context, collection, id string) error
+	Close()
+}
+
+type PostgresRepository struct {
+	Pool *pgxpool.Pool
+}
+
+func NewPostgresRepository(url string) (Repository, error) {
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %v", err)
+	}
+	if err := Migrate(ctx, pool); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("unable to migrate database: %v", err)
+	}
+
+	return &PostgresRepository{Pool: pool}, nil
+}
+
+func (r *PostgresRepository) Close() {
+	if r.Pool != nil {
+		r.Pool.Close()
+	}
+}
+
+// Helper methods for generic operations (simplified for this template)
+func (r *PostgresRepository) List(ctx context.Context, table string) ([]map[string]interface{}, error) {
+	// Implementation would use dynamic SQL
+	return nil, fmt.Errorf("generic List not implemented for Postgres adapter")
+}
+
+func (r *PostgresRepository) Get(ctx context.Context, table, id string) (map[string]interface{}, error) {
+	return nil, fmt.Errorf("generic Get not implemented for Postgres adapter")
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, table string, data map[string]interface{}) (string, error) {
+	return "", fmt.Errorf("generic Create not implemented for Postgres adapter")
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, table, id string, data map[string]interface{}) error {
+	return fmt.Errorf("generic Update not implemented for Postgres adapter")
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, table, id string) error {
+	return fmt.Errorf("generic Delete not implemented for Postgres adapter")
+}
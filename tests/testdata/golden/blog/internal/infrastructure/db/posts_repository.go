@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"BlogAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type PostsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostsRepository expects the posts table to exist; see migrations.go
+func NewPostsRepository(repo *PostgresRepository) *PostsRepository {
+	return &PostsRepository{db: repo.Pool}
+}
+
+// Count returns how many records match the filters of q, ignoring the page
+func (r *PostsRepository) Count(ctx context.Context, q domain.ListQuery) (int64, error) {
+	query, args, err := countSQL("posts", q)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&total)
+	return total, err
+}
+
+func (r *PostsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Posts, error) {
+	query, args, err := listSQL("SELECT id, COALESCE(author, '') AS author, body, published_at, ARRAY(SELECT tag_id FROM posts_tags WHERE post_id = posts.id) AS tags, title FROM posts", "posts", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Posts
+	for rows.Next() {
+		var m domain.Posts
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Author)
+
+		fields = append(fields, &m.Body)
+
+		fields = append(fields, &m.PublishedAt)
+
+		fields = append(fields, &m.Tags)
+
+		fields = append(fields, &m.Title)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *PostsRepository) Get(ctx context.Context, id string) (*domain.Posts, error) {
+	var m domain.Posts
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Author)
+
+	fields = append(fields, &m.Body)
+
+	fields = append(fields, &m.PublishedAt)
+
+	fields = append(fields, &m.Tags)
+
+	fields = append(fields, &m.Title)
+
+	query := "SELECT id, COALESCE(author, '') AS author, body, published_at, ARRAY(SELECT tag_id FROM posts_tags WHERE post_id = posts.id) AS tags, title FROM posts WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *PostsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Posts, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, COALESCE(author, '') AS author, body, published_at, ARRAY(SELECT tag_id FROM posts_tags WHERE post_id = posts.id) AS tags, title FROM posts WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Posts
+	for rows.Next() {
+		var m domain.Posts
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Author)
+
+		fields = append(fields, &m.Body)
+
+		fields = append(fields, &m.PublishedAt)
+
+		fields = append(fields, &m.Tags)
+
+		fields = append(fields, &m.Title)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *PostsRepository) Create(ctx context.Context, m *domain.Posts) (string, error) {
+	query := "INSERT INTO posts (author, body, published_at, title) VALUES (NULLIF($1, ''), $2, $3, $4) RETURNING id"
+
+	values := []interface{}{
+		m.Author,
+		m.Body,
+		m.PublishedAt,
+		m.Title,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *PostsRepository) Update(ctx context.Context, id string, m *domain.Posts) error {
+
+	query := "UPDATE posts SET author = NULLIF($1, ''), body = $2, published_at = $3, title = $4 WHERE id = $5"
+
+	values := []interface{}{
+		m.Author,
+		m.Body,
+		m.PublishedAt,
+		m.Title,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *PostsRepository) LinkTags(ctx context.Context, id, targetID string) error {
+	_, err := r.db.Exec(ctx, "INSERT INTO posts_tags (post_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", id, targetID)
+	return err
+}
+
+func (r *PostsRepository) UnlinkTags(ctx context.Context, id, targetID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM posts_tags WHERE post_id = $1 AND tag_id = $2", id, targetID)
+	return err
+}
+
+func (r *PostsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM posts WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"BlogAPI/internal/domain"
+)
+
+var sqlOperators = map[string]string{
+	domain.OpEq:  "=",
+	domain.OpNe:  "IS DISTINCT FROM",
+	domain.OpGt:  ">",
+	domain.OpGte: ">=",
+	domain.OpLt:  "<",
+	domain.OpLte: "<=",
+}
+
+// listSQL appends the filters, sort and paging of q to a SELECT on table
+func listSQL(selectSQL, table string, q domain.ListQuery) (string, []interface{}, error) {
+	var args []interface{}
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where, err := filterSQL(table, q.Filters, placeholder)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(q.After) > 0 {
+		where = append(where, afterSQL(table, q, placeholder))
+	}
+
+	query := selectSQL
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	if len(q.Sort) > 0 {
+		var order []string
+		for _, s := range q.Sort {
+			direction := "ASC"
+			if s.Desc {
+				direction = "DESC"
+			}
+			order = append(order, table+"."+s.Field+" "+direction)
+		}
+		query += " ORDER BY " + strings.Join(order, ", ")
+	}
+	query += " LIMIT " + placeholder(q.Limit) + " OFFSET " + placeholder(q.Offset)
+	return query, args, nil
+}
+
+func filterSQL(table string, filters []domain.Filter, placeholder func(interface{}) string) ([]string, error) {
+	var where []string
+	for _, f := range filters {
+		column := table + "." + f.Field
+		if f.Op == domain.OpIn {
+			values, ok := f.Value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%w: %s[in] needs a list of values, got %T", domain.ErrInvalidQuery, f.Field, f.Value)
+			}
+			var placeholders []string
+			for _, value := range values {
+				placeholders = append(placeholders, placeholder(value))
+			}
+			if len(placeholders) == 0 {
+				where = append(where, "FALSE")
+				continue
+			}
+			where = append(where, column+" IN ("+strings.Join(placeholders, ", ")+")")
+			continue
+		}
+		where = append(where, column+" "+sqlOperators[f.Op]+" "+placeholder(f.Value))
+	}
+	return where, nil
+}
+
+// countSQL counts the rows of table matching the filters of q, whatever the page
+func countSQL(table string, q domain.ListQuery) (string, []interface{}, error) {
+	var args []interface{}
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where, err := filterSQL(table, q.Filters, placeholder)
+	if err != nil {
+		return "", nil, err
+	}
+	query := "SELECT COUNT(*) FROM " + table
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	return query, args, nil
+}
+
+// afterSQL matches the rows sorted after q.After: (a > $1) OR (a = $1 AND b > $2) ...
+func afterSQL(table string, q domain.ListQuery, placeholder func(interface{}) string) string {
+	var alternatives []string
+	for i, s := range q.Sort {
+		var conditions []string
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, table+"."+q.Sort[j].Field+" = "+placeholder(q.After[j]))
+		}
+		operator := ">"
+		if s.Desc {
+			operator = "<"
+		}
+		conditions = append(conditions, table+"."+s.Field+" "+operator+" "+placeholder(q.After[i]))
+		alternatives = append(alternatives, "("+strings.Join(conditions, " AND ")+")")
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
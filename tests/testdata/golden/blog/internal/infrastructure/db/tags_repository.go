@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"BlogAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type TagsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTagsRepository expects the tags table to exist; see migrations.go
+func NewTagsRepository(repo *PostgresRepository) *TagsRepository {
+	return &TagsRepository{db: repo.Pool}
+}
+
+// Count returns how many records match the filters of q, ignoring the page
+func (r *TagsRepository) Count(ctx context.Context, q domain.ListQuery) (int64, error) {
+	query, args, err := countSQL("tags", q)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	err = r.db.QueryRow(ctx, query, args...).Scan(&total)
+	return total, err
+}
+
+func (r *TagsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Tags, error) {
+	query, args, err := listSQL("SELECT id, name, ARRAY(SELECT post_id FROM posts_tags WHERE tag_id = tags.id) AS posts FROM tags", "tags", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Tags
+	for rows.Next() {
+		var m domain.Tags
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Posts)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *TagsRepository) Get(ctx context.Context, id string) (*domain.Tags, error) {
+	var m domain.Tags
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.Posts)
+
+	query := "SELECT id, name, ARRAY(SELECT post_id FROM posts_tags WHERE tag_id = tags.id) AS posts FROM tags WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *TagsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Tags, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, name, ARRAY(SELECT post_id FROM posts_tags WHERE tag_id = tags.id) AS posts FROM tags WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Tags
+	for rows.Next() {
+		var m domain.Tags
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Posts)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *TagsRepository) Create(ctx context.Context, m *domain.Tags) (string, error) {
+	query := "INSERT INTO tags (name) VALUES ($1) RETURNING id"
+
+	values := []interface{}{
+		m.Name,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *TagsRepository) Update(ctx context.Context, id string, m *domain.Tags) error {
+
+	query := "UPDATE tags SET name = $1 WHERE id = $2"
+
+	values := []interface{}{
+		m.Name,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *TagsRepository) LinkPosts(ctx context.Context, id, targetID string) error {
+	_, err := r.db.Exec(ctx, "INSERT INTO posts_tags (tag_id, post_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", id, targetID)
+	return err
+}
+
+func (r *TagsRepository) UnlinkPosts(ctx context.Context, id, targetID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM posts_tags WHERE tag_id = $1 AND post_id = $2", id, targetID)
+	return err
+}
+
+func (r *TagsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM tags WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,344 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"BlogAPI/internal/domain"
+)
+
+// reserved parameters configure the request instead of filtering it
+var reserved = map[string]bool{
+	"limit":   true,
+	"page":    true,
+	"sort":    true,
+	"fields":  true,
+	"include": true,
+	"cursor":  true,
+}
+
+var operators = map[string]bool{
+	domain.OpEq:  true,
+	domain.OpNe:  true,
+	domain.OpGt:  true,
+	domain.OpGte: true,
+	domain.OpLt:  true,
+	domain.OpLte: true,
+	domain.OpIn:  true,
+}
+
+// Error reports an invalid query parameter
+type Error struct {
+	msg string
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Status maps an error of Parse or of a repository List to the HTTP status to answer with
+func Status(err error) int {
+	var invalid *Error
+	if errors.As(err, &invalid) || errors.Is(err, domain.ErrInvalidQuery) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// Parse reads ?status=paid&price[gte]=10&sort=-created_at&fields=name,price,
+// checking every field against fields, the model's field types
+func Parse(values url.Values, fields map[string]string) (domain.ListQuery, error) {
+	var q domain.ListQuery
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if reserved[key] {
+			continue
+		}
+		name, op := key, domain.OpEq
+		if i := strings.Index(key, "["); i > 0 && strings.HasSuffix(key, "]") {
+			name, op = key[:i], key[i+1:len(key)-1]
+		}
+		if !operators[op] {
+			return q, &Error{fmt.Sprintf("unknown operator %q in %s (available: eq, ne, gt, gte, lt, lte, in)", op, key)}
+		}
+		fieldType, err := lookup(fields, name, "filter")
+		if err != nil {
+			return q, err
+		}
+		for _, raw := range values[key] {
+			value, err := convert(name, fieldType, op, raw)
+			if err != nil {
+				return q, err
+			}
+			q.Filters = append(q.Filters, domain.Filter{Field: name, Op: op, Value: value})
+		}
+	}
+
+	for _, name := range split(values.Get("sort")) {
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		if _, err := lookup(fields, name, "sort"); err != nil {
+			return q, err
+		}
+		q.Sort = append(q.Sort, domain.Sort{Field: name, Desc: desc})
+	}
+
+	for _, name := range split(values.Get("fields")) {
+		if _, ok := fields[name]; !ok {
+			return q, unknownField(fields, name)
+		}
+		q.Fields = append(q.Fields, name)
+	}
+	return q, nil
+}
+
+func lookup(fields map[string]string, name, action string) (string, error) {
+	fieldType, ok := fields[name]
+	if !ok {
+		return "", unknownField(fields, name)
+	}
+	if !scalar(fieldType) {
+		return "", &Error{fmt.Sprintf("cannot %s on %s, it holds a list or a structured value", action, name)}
+	}
+	return fieldType, nil
+}
+
+// structured types hold lists or objects rather than a single comparable value
+var structured = map[string]bool{
+	"json":     true,
+	"array":    true,
+	"geopoint": true,
+}
+
+// scalar reports whether a field type, such as "array<string>" or "enum(a,b)", can be compared
+func scalar(fieldType string) bool {
+	if i := strings.IndexAny(fieldType, "<("); i > 0 {
+		fieldType = fieldType[:i]
+	}
+	return !structured[fieldType]
+}
+
+func unknownField(fields map[string]string, name string) error {
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+	return &Error{fmt.Sprintf("unknown field %q (available: %s)", name, strings.Join(names, ", "))}
+}
+
+// convert parses a raw value as the field's type; "in" takes a comma-separated list
+func convert(name, fieldType, op, raw string) (interface{}, error) {
+	if op == domain.OpIn {
+		var values []interface{}
+		for _, item := range split(raw) {
+			value, err := convert(name, fieldType, domain.OpEq, item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	var value interface{}
+	var err error
+	switch fieldType {
+	case "integer":
+		value, err = strconv.Atoi(raw)
+	case "float":
+		value, err = strconv.ParseFloat(raw, 64)
+	case "boolean":
+		value, err = strconv.ParseBool(raw)
+	case "datetime":
+		value, err = time.Parse(time.RFC3339, raw)
+	case "date":
+		_, err = time.Parse(domain.DateLayout, raw)
+		value = domain.Date(raw)
+	case "decimal":
+		_, err = strconv.ParseFloat(raw, 64)
+		value = domain.Decimal(raw)
+	default:
+		value = raw
+	}
+	if err != nil {
+		return nil, &Error{fmt.Sprintf("invalid %s value %q for %s", fieldType, raw, name)}
+	}
+	return value, nil
+}
+
+func split(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Select keeps only the id and the given fields of each document. It runs on
+// the response: repositories still read whole rows, since include needs their
+// relation ids and the cursor their sort values.
+func Select(docs interface{}, fields []string) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(docs)
+	if err != nil {
+		return nil, err
+	}
+	var all []map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	selected := make([]map[string]interface{}, len(all))
+	for i, doc := range all {
+		selected[i] = map[string]interface{}{"id": doc["id"]}
+		for _, field := range fields {
+			if value, ok := doc[field]; ok {
+				selected[i][field] = value
+			}
+		}
+	}
+	return selected, nil
+}
+
+// cursor is the decoded form of the opaque next_cursor token
+type cursor struct {
+	Sort   string        `json:"s"`
+	Values []interface{} `json:"v"`
+}
+
+// ApplyCursor makes the sort of q total by ending it with the id, then resumes
+// after the item encoded in token, if any
+func ApplyCursor(q *domain.ListQuery, token string, fields map[string]string) error {
+	hasID := false
+	for _, s := range q.Sort {
+		hasID = hasID || s.Field == "id"
+	}
+	if !hasID {
+		q.Sort = append(q.Sort, domain.Sort{Field: "id"})
+	}
+	if token == "" {
+		return nil
+	}
+
+	invalid := &Error{"invalid cursor"}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return invalid
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return invalid
+	}
+	if c.Sort != sortKey(q.Sort) {
+		return &Error{"cursor was issued for a different sort"}
+	}
+	if len(c.Values) != len(q.Sort) {
+		return invalid
+	}
+	for i, s := range q.Sort {
+		value, err := decodeValue(fields[s.Field], c.Values[i])
+		if err != nil {
+			return invalid
+		}
+		q.After = append(q.After, value)
+	}
+	return nil
+}
+
+// NextCursor encodes the position of last, the last item of a page sorted by q
+func NextCursor(q domain.ListQuery, last interface{}) (string, error) {
+	data, err := json.Marshal(last)
+	if err != nil {
+		return "", err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return "", err
+	}
+
+	c := cursor{Sort: sortKey(q.Sort)}
+	for _, s := range q.Sort {
+		c.Values = append(c.Values, doc[s.Field])
+	}
+	data, err = json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeValue restores the type a JSON-decoded cursor value had in the document
+func decodeValue(fieldType string, value interface{}) (interface{}, error) {
+	switch fieldType {
+	case "integer":
+		if n, ok := value.(float64); ok {
+			return int(n), nil
+		}
+	case "float":
+		if _, ok := value.(float64); ok {
+			return value, nil
+		}
+	case "boolean":
+		if _, ok := value.(bool); ok {
+			return value, nil
+		}
+	case "datetime":
+		if s, ok := value.(string); ok {
+			return time.Parse(time.RFC3339Nano, s)
+		}
+	default:
+		if _, ok := value.(string); ok {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected cursor value %v", value)
+}
+
+func sortKey(sorts []domain.Sort) string {
+	var keys []string
+	for _, s := range sorts {
+		if s.Desc {
+			keys = append(keys, "-"+s.Field)
+		} else {
+			keys = append(keys, s.Field)
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// Links builds an RFC 5988 Link header with the first and next pages of the
+// request URL u; next is omitted on the last page
+func Links(u *url.URL, next string) string {
+	link := func(token, rel string) string {
+		page := *u
+		values := page.Query()
+		values.Del("cursor")
+		if token != "" {
+			values.Set("cursor", token)
+		}
+		page.RawQuery = values.Encode()
+		return fmt.Sprintf("<%s>; rel=\"%s\"", page.String(), rel)
+	}
+
+	links := []string{link("", "first")}
+	if next != "" {
+		links = append(links, link(next, "next"))
+	}
+	return strings.Join(links, ", ")
+}
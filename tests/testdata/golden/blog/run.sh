@@ -0,0 +1,4 @@
+#!/bin/bash
+
+echo "Starting server..."
+go run cmd/api/main.go
@@ -0,0 +1,25 @@
+#!/bin/bash
+
+echo "Installing dependencies..."
+go mod tidy
+
+echo "Starting server in background..."
+export MOCK_AUTH=true
+go run cmd/api/main.go &
+PID=$!
+sleep 5
+
+echo "Running tests..."
+
+echo "Testing POST /api/authors"
+curl -X POST -H "Content-Type: application/json" -d '{"email": "test_email", "name": "test_name"}' http://localhost:8080/api/authors
+echo "\n"
+echo "Testing POST /api/posts"
+curl -X POST -H "Content-Type: application/json" -d '{"body": "test_body", "published_at": "2023-01-01T00:00:00Z", "title": "test_title"}' http://localhost:8080/api/posts
+echo "\n"
+echo "Testing POST /api/tags"
+curl -X POST -H "Content-Type: application/json" -d '{"name": "test_name"}' http://localhost:8080/api/tags
+echo "\n"
+
+echo "Killing server (PID: $PID)..."
+kill $PID
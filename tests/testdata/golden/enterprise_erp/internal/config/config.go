@@ -0,0 +1,11 @@
+package config
+
+import "os"
+
+func GetJWTSecret() string {
+	token := os.Getenv("JWT_SECRET")
+	if token == "" {
+		return "your_jwt_secret_key"
+	}
+	return token
+}
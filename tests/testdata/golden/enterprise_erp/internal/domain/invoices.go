@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Invoices struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	DueDate time.Time `json:"due_date" bson:"due_date" firestore:"due_date"`
+
+	InvoiceNumber string `json:"invoice_number" bson:"invoice_number" firestore:"invoice_number"`
+
+	IssuedAt time.Time `json:"issued_at" bson:"issued_at" firestore:"issued_at"`
+
+	PdfUrl string `json:"pdf_url" bson:"pdf_url" firestore:"pdf_url"`
+
+	Status string `json:"status" bson:"status" firestore:"status"`
+
+	Total float64 `json:"total" bson:"total" firestore:"total"`
+
+	Order string `json:"order" bson:"order" firestore:"order"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Invoices) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Invoices) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// InvoicesFields maps the fields List can filter, sort and select on to their blueprint types
+var InvoicesFields = map[string]string{
+	"id":             "string",
+	"due_date":       "datetime",
+	"invoice_number": "string",
+	"issued_at":      "datetime",
+	"pdf_url":        "string",
+	"status":         "string",
+	"total":          "float",
+	"order":          "string",
+}
+
+type InvoicesRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Invoices, error)
+	Get(ctx context.Context, id string) (*Invoices, error)
+	GetMany(ctx context.Context, ids []string) ([]*Invoices, error)
+	Create(ctx context.Context, model *Invoices) (string, error)
+	Update(ctx context.Context, id string, model *Invoices) error
+	Delete(ctx context.Context, id string) error
+}
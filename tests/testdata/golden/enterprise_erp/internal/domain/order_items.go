@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+)
+
+type Order_items struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Discount float64 `json:"discount" bson:"discount" firestore:"discount"`
+
+	Quantity int `json:"quantity" bson:"quantity" firestore:"quantity"`
+
+	Total float64 `json:"total" bson:"total" firestore:"total"`
+
+	UnitPrice float64 `json:"unit_price" bson:"unit_price" firestore:"unit_price"`
+
+	Order string `json:"order" bson:"order" firestore:"order"`
+
+	Product string `json:"product" bson:"product" firestore:"product"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Order_items) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Order_items) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// Order_itemsFields maps the fields List can filter, sort and select on to their blueprint types
+var Order_itemsFields = map[string]string{
+	"id":         "string",
+	"discount":   "float",
+	"quantity":   "integer",
+	"total":      "float",
+	"unit_price": "float",
+	"order":      "string",
+	"product":    "string",
+}
+
+type Order_itemsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Order_items, error)
+	Get(ctx context.Context, id string) (*Order_items, error)
+	GetMany(ctx context.Context, ids []string) ([]*Order_items, error)
+	Create(ctx context.Context, model *Order_items) (string, error)
+	Update(ctx context.Context, id string, model *Order_items) error
+	Delete(ctx context.Context, id string) error
+}
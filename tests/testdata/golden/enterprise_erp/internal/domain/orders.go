@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Orders struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	OrderNumber string `json:"order_number" bson:"order_number" firestore:"order_number"`
+
+	PlacedAt time.Time `json:"placed_at" bson:"placed_at" firestore:"placed_at"`
+
+	ShippingAddress string `json:"shipping_address" bson:"shipping_address" firestore:"shipping_address"`
+
+	Status string `json:"status" bson:"status" firestore:"status"`
+
+	TaxAmount float64 `json:"tax_amount" bson:"tax_amount" firestore:"tax_amount"`
+
+	TotalAmount float64 `json:"total_amount" bson:"total_amount" firestore:"total_amount"`
+
+	Coupon string `json:"coupon" bson:"coupon" firestore:"coupon"`
+
+	Customer string `json:"customer" bson:"customer" firestore:"customer"`
+
+	Invoice []string `json:"invoice" bson:"invoice" firestore:"invoice"`
+
+	Items []string `json:"items" bson:"items" firestore:"items"`
+
+	Payment string `json:"payment" bson:"payment" firestore:"payment"`
+
+	Shipment []string `json:"shipment" bson:"shipment" firestore:"shipment"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Orders) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Orders) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// OrdersFields maps the fields List can filter, sort and select on to their blueprint types
+var OrdersFields = map[string]string{
+	"id":               "string",
+	"order_number":     "string",
+	"placed_at":        "datetime",
+	"shipping_address": "text",
+	"status":           "string",
+	"tax_amount":       "float",
+	"total_amount":     "float",
+	"coupon":           "string",
+	"customer":         "string",
+	"invoice":          "array",
+	"items":            "array",
+	"payment":          "string",
+	"shipment":         "array",
+}
+
+type OrdersRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Orders, error)
+	Get(ctx context.Context, id string) (*Orders, error)
+	GetMany(ctx context.Context, ids []string) ([]*Orders, error)
+	Create(ctx context.Context, model *Orders) (string, error)
+	Update(ctx context.Context, id string, model *Orders) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Reviews struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Approved bool `json:"approved" bson:"approved" firestore:"approved"`
+
+	Comment string `json:"comment" bson:"comment" firestore:"comment"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	Likes int `json:"likes" bson:"likes" firestore:"likes"`
+
+	Rating int `json:"rating" bson:"rating" firestore:"rating"`
+
+	Product string `json:"product" bson:"product" firestore:"product"`
+
+	User string `json:"user" bson:"user" firestore:"user"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Reviews) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Reviews) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// ReviewsFields maps the fields List can filter, sort and select on to their blueprint types
+var ReviewsFields = map[string]string{
+	"id":         "string",
+	"approved":   "boolean",
+	"comment":    "text",
+	"created_at": "datetime",
+	"likes":      "integer",
+	"rating":     "integer",
+	"product":    "string",
+	"user":       "string",
+}
+
+type ReviewsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Reviews, error)
+	Get(ctx context.Context, id string) (*Reviews, error)
+	GetMany(ctx context.Context, ids []string) ([]*Reviews, error)
+	Create(ctx context.Context, model *Reviews) (string, error)
+	Update(ctx context.Context, id string, model *Reviews) error
+	Delete(ctx context.Context, id string) error
+}
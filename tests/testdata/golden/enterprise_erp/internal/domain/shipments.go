@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Shipments struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Carrier string `json:"carrier" bson:"carrier" firestore:"carrier"`
+
+	EstimatedArrival time.Time `json:"estimated_arrival" bson:"estimated_arrival" firestore:"estimated_arrival"`
+
+	ShippedAt time.Time `json:"shipped_at" bson:"shipped_at" firestore:"shipped_at"`
+
+	Status string `json:"status" bson:"status" firestore:"status"`
+
+	TrackingNumber string `json:"tracking_number" bson:"tracking_number" firestore:"tracking_number"`
+
+	Weight float64 `json:"weight" bson:"weight" firestore:"weight"`
+
+	Order string `json:"order" bson:"order" firestore:"order"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Shipments) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Shipments) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// ShipmentsFields maps the fields List can filter, sort and select on to their blueprint types
+var ShipmentsFields = map[string]string{
+	"id":                "string",
+	"carrier":           "string",
+	"estimated_arrival": "datetime",
+	"shipped_at":        "datetime",
+	"status":            "string",
+	"tracking_number":   "string",
+	"weight":            "float",
+	"order":             "string",
+}
+
+type ShipmentsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Shipments, error)
+	Get(ctx context.Context, id string) (*Shipments, error)
+	GetMany(ctx context.Context, ids []string) ([]*Shipments, error)
+	Create(ctx context.Context, model *Shipments) (string, error)
+	Update(ctx context.Context, id string, model *Shipments) error
+	Delete(ctx context.Context, id string) error
+}
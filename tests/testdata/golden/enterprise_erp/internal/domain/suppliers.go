@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Suppliers struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	CompanyName string `json:"company_name" bson:"company_name" firestore:"company_name"`
+
+	ContactName string `json:"contact_name" bson:"contact_name" firestore:"contact_name"`
+
+	ContractEnd time.Time `json:"contract_end" bson:"contract_end" firestore:"contract_end"`
+
+	Email string `json:"email" bson:"email" firestore:"email"`
+
+	Phone string `json:"phone" bson:"phone" firestore:"phone"`
+
+	TaxId string `json:"tax_id" bson:"tax_id" firestore:"tax_id"`
+
+	Products []string `json:"products" bson:"products" firestore:"products"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Suppliers) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Suppliers) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// SuppliersFields maps the fields List can filter, sort and select on to their blueprint types
+var SuppliersFields = map[string]string{
+	"id":           "string",
+	"company_name": "string",
+	"contact_name": "string",
+	"contract_end": "datetime",
+	"email":        "string",
+	"phone":        "string",
+	"tax_id":       "string",
+	"products":     "array",
+}
+
+type SuppliersRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Suppliers, error)
+	Get(ctx context.Context, id string) (*Suppliers, error)
+	GetMany(ctx context.Context, ids []string) ([]*Suppliers, error)
+	Create(ctx context.Context, model *Suppliers) (string, error)
+	Update(ctx context.Context, id string, model *Suppliers) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Users struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Address string `json:"address" bson:"address" firestore:"address"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	Email string `json:"email" bson:"email" firestore:"email"`
+
+	FullName string `json:"full_name" bson:"full_name" firestore:"full_name"`
+
+	IsVerified bool `json:"is_verified" bson:"is_verified" firestore:"is_verified"`
+
+	LastLogin time.Time `json:"last_login" bson:"last_login" firestore:"last_login"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	Phone string `json:"phone" bson:"phone" firestore:"phone"`
+
+	Picture string `json:"picture" bson:"picture" firestore:"picture"`
+
+	RoleId string `json:"role_id" bson:"role_id" firestore:"role_id"`
+
+	Uid string `json:"uid" bson:"uid" firestore:"uid"`
+
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at" firestore:"updated_at"`
+
+	AuditLogs []string `json:"audit_logs" bson:"audit_logs" firestore:"audit_logs"`
+
+	Orders []string `json:"orders" bson:"orders" firestore:"orders"`
+
+	Reviews []string `json:"reviews" bson:"reviews" firestore:"reviews"`
+
+	Tickets []string `json:"tickets" bson:"tickets" firestore:"tickets"`
+
+	Wishlists []string `json:"wishlists" bson:"wishlists" firestore:"wishlists"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Users) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Users) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// UsersFields maps the fields List can filter, sort and select on to their blueprint types
+var UsersFields = map[string]string{
+	"id":          "string",
+	"address":     "text",
+	"created_at":  "datetime",
+	"email":       "string",
+	"full_name":   "string",
+	"is_verified": "boolean",
+	"last_login":  "datetime",
+	"name":        "string",
+	"phone":       "string",
+	"picture":     "string",
+	"role_id":     "string",
+	"uid":         "string",
+	"updated_at":  "datetime",
+	"audit_logs":  "array",
+	"orders":      "array",
+	"reviews":     "array",
+	"tickets":     "array",
+	"wishlists":   "array",
+}
+
+type UsersRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Users, error)
+	Get(ctx context.Context, id string) (*Users, error)
+	GetMany(ctx context.Context, ids []string) ([]*Users, error)
+	Create(ctx context.Context, model *Users) (string, error)
+	Update(ctx context.Context, id string, model *Users) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Wishlist_items struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	AddedAt time.Time `json:"added_at" bson:"added_at" firestore:"added_at"`
+
+	Priority string `json:"priority" bson:"priority" firestore:"priority"`
+
+	Product string `json:"product" bson:"product" firestore:"product"`
+
+	Wishlist string `json:"wishlist" bson:"wishlist" firestore:"wishlist"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Wishlist_items) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Wishlist_items) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// Wishlist_itemsFields maps the fields List can filter, sort and select on to their blueprint types
+var Wishlist_itemsFields = map[string]string{
+	"id":       "string",
+	"added_at": "datetime",
+	"priority": "string",
+	"product":  "string",
+	"wishlist": "string",
+}
+
+type Wishlist_itemsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Wishlist_items, error)
+	Get(ctx context.Context, id string) (*Wishlist_items, error)
+	GetMany(ctx context.Context, ids []string) ([]*Wishlist_items, error)
+	Create(ctx context.Context, model *Wishlist_items) (string, error)
+	Update(ctx context.Context, id string, model *Wishlist_items) error
+	Delete(ctx context.Context, id string) error
+}
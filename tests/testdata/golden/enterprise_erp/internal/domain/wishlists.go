@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Wishlists struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	IsPublic bool `json:"is_public" bson:"is_public" firestore:"is_public"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	Items []string `json:"items" bson:"items" firestore:"items"`
+
+	User string `json:"user" bson:"user" firestore:"user"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Wishlists) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Wishlists) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// WishlistsFields maps the fields List can filter, sort and select on to their blueprint types
+var WishlistsFields = map[string]string{
+	"id":         "string",
+	"created_at": "datetime",
+	"is_public":  "boolean",
+	"name":       "string",
+	"items":      "array",
+	"user":       "string",
+}
+
+type WishlistsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Wishlists, error)
+	Get(ctx context.Context, id string) (*Wishlists, error)
+	GetMany(ctx context.Context, ids []string) ([]*Wishlists, error)
+	Create(ctx context.Context, model *Wishlists) (string, error)
+	Update(ctx context.Context, id string, model *Wishlists) error
+	Delete(ctx context.Context, id string) error
+}
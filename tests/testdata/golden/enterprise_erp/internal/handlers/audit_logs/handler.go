@@ -0,0 +1,163 @@
+package audit_logs
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+	"EnterpriseERP/internal/query"
+	"EnterpriseERP/internal/validation"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type Audit_logsHandler struct {
+	repo     domain.Audit_logsRepository
+	expander *expand.Expander
+}
+
+func NewAudit_logsHandler(repo domain.Audit_logsRepository, expander *expand.Expander) *Audit_logsHandler {
+	return &Audit_logsHandler{repo: repo, expander: expander}
+}
+
+func (h *Audit_logsHandler) List(c *gin.Context) {
+	limit := 10
+	if l := c.Query("limit"); l != "" {
+		if val, err := strconv.Atoi(l); err == nil && val > 0 {
+			limit = val
+		}
+	}
+	page := 1
+	if p := c.Query("page"); p != "" {
+		if val, err := strconv.Atoi(p); err == nil && val > 0 {
+			page = val
+		}
+	}
+	offset := (page - 1) * limit
+
+	q, err := query.Parse(c.Request.URL.Query(), domain.Audit_logsFields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	q.Limit, q.Offset = limit, offset
+
+	results, err := h.repo.List(c.Request.Context(), q)
+	if err != nil {
+		c.JSON(query.Status(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	var body interface{} = results
+	include := c.Query("include")
+	if include != "" {
+		expanded, err := h.expander.Many(c.Request.Context(), "audit_logs", results, include, true)
+		if err != nil {
+			c.JSON(expand.Status(err), gin.H{"error": err.Error()})
+			return
+		}
+		body = expanded
+	}
+	if len(q.Fields) > 0 {
+		// Included relations are returned along with the selected fields
+		selected, err := query.Select(body, append(q.Fields, expand.Roots(include)...))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		body = selected
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+func (h *Audit_logsHandler) Get(c *gin.Context) {
+	id := c.Param("id")
+	result, err := h.repo.Get(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if include := c.Query("include"); include != "" {
+		expanded, err := h.expander.One(c.Request.Context(), "audit_logs", result, include, true)
+		if err != nil {
+			c.JSON(expand.Status(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, expanded)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Audit_logsHandler) Create(c *gin.Context) {
+	var m domain.Audit_logs
+	if !h.bind(c, &m) {
+		return
+	}
+	m.ApplyDefaults()
+	if !h.validate(c, &m, "") {
+		return
+	}
+	// blueprint:custom-begin create
+	// blueprint:custom-end create
+	id, err := h.repo.Create(c.Request.Context(), &m)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	m.ID = id
+	c.JSON(http.StatusCreated, m)
+}
+
+func (h *Audit_logsHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	var m domain.Audit_logs
+	if !h.bind(c, &m) || !h.validate(c, &m, id) {
+		return
+	}
+	// blueprint:custom-begin update
+	// blueprint:custom-end update
+	if err := h.repo.Update(c.Request.Context(), id, &m); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "updated"})
+}
+
+func (h *Audit_logsHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// bind decodes the request body into m, answering 422 when a binding rule fails
+func (h *Audit_logsHandler) bind(c *gin.Context, m *domain.Audit_logs) bool {
+	if err := c.ShouldBindJSON(m); err != nil {
+		if errs, ok := validation.FromBinding(err); ok {
+			validation.Respond(c, errs)
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return false
+	}
+	return true
+}
+
+// validate runs the checks binding cannot do; id is the record being updated, if any
+func (h *Audit_logsHandler) validate(c *gin.Context, m *domain.Audit_logs, id string) bool {
+	errs := m.Validate()
+	if len(errs) > 0 {
+		validation.Respond(c, errs)
+		return false
+	}
+	return true
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
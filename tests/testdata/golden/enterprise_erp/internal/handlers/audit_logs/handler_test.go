@@ -0,0 +1,114 @@
+package audit_logs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockAudit_logsRepository struct {
+	Data map[string]*domain.Audit_logs
+}
+
+func (m *MockAudit_logsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Audit_logs, error) {
+	var results []*domain.Audit_logs
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Audit_logs{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockAudit_logsRepository) Get(ctx context.Context, id string) (*domain.Audit_logs, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockAudit_logsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Audit_logs, error) {
+	var results []*domain.Audit_logs
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockAudit_logsRepository) Create(ctx context.Context, model *domain.Audit_logs) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Audit_logs)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockAudit_logsRepository) Update(ctx context.Context, id string, model *domain.Audit_logs) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockAudit_logsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestAudit_logsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockAudit_logsRepository{Data: make(map[string]*domain.Audit_logs)}
+	handler := NewAudit_logsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/audit_logs", handler.List)
+	r.POST("/audit_logs", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Audit_logs{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/audit_logs", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/audit_logs?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/audit_logs?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/audit_logs?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package categories
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockCategoriesRepository struct {
+	Data map[string]*domain.Categories
+}
+
+func (m *MockCategoriesRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Categories, error) {
+	var results []*domain.Categories
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Categories{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockCategoriesRepository) Get(ctx context.Context, id string) (*domain.Categories, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCategoriesRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Categories, error) {
+	var results []*domain.Categories
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockCategoriesRepository) Create(ctx context.Context, model *domain.Categories) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Categories)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockCategoriesRepository) Update(ctx context.Context, id string, model *domain.Categories) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockCategoriesRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestCategoriesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockCategoriesRepository{Data: make(map[string]*domain.Categories)}
+	handler := NewCategoriesHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/categories", handler.List)
+	r.POST("/categories", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Categories{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/categories", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/categories?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/categories?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/categories?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
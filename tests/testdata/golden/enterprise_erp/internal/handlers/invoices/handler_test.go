@@ -0,0 +1,114 @@
+package invoices
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockInvoicesRepository struct {
+	Data map[string]*domain.Invoices
+}
+
+func (m *MockInvoicesRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Invoices, error) {
+	var results []*domain.Invoices
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Invoices{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockInvoicesRepository) Get(ctx context.Context, id string) (*domain.Invoices, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockInvoicesRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Invoices, error) {
+	var results []*domain.Invoices
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockInvoicesRepository) Create(ctx context.Context, model *domain.Invoices) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Invoices)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockInvoicesRepository) Update(ctx context.Context, id string, model *domain.Invoices) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockInvoicesRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestInvoicesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockInvoicesRepository{Data: make(map[string]*domain.Invoices)}
+	handler := NewInvoicesHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/invoices", handler.List)
+	r.POST("/invoices", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Invoices{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/invoices", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/invoices?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/invoices?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/invoices?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
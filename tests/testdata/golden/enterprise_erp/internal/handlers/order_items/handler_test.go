@@ -0,0 +1,114 @@
+package order_items
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockOrder_itemsRepository struct {
+	Data map[string]*domain.Order_items
+}
+
+func (m *MockOrder_itemsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Order_items, error) {
+	var results []*domain.Order_items
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Order_items{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockOrder_itemsRepository) Get(ctx context.Context, id string) (*domain.Order_items, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockOrder_itemsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Order_items, error) {
+	var results []*domain.Order_items
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockOrder_itemsRepository) Create(ctx context.Context, model *domain.Order_items) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Order_items)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockOrder_itemsRepository) Update(ctx context.Context, id string, model *domain.Order_items) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockOrder_itemsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestOrder_itemsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockOrder_itemsRepository{Data: make(map[string]*domain.Order_items)}
+	handler := NewOrder_itemsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/order_items", handler.List)
+	r.POST("/order_items", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Order_items{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/order_items", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/order_items?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/order_items?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/order_items?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package suppliers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockSuppliersRepository struct {
+	Data map[string]*domain.Suppliers
+}
+
+func (m *MockSuppliersRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Suppliers, error) {
+	var results []*domain.Suppliers
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Suppliers{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockSuppliersRepository) Get(ctx context.Context, id string) (*domain.Suppliers, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockSuppliersRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Suppliers, error) {
+	var results []*domain.Suppliers
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockSuppliersRepository) Create(ctx context.Context, model *domain.Suppliers) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Suppliers)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockSuppliersRepository) Update(ctx context.Context, id string, model *domain.Suppliers) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockSuppliersRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestSuppliersHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockSuppliersRepository{Data: make(map[string]*domain.Suppliers)}
+	handler := NewSuppliersHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/suppliers", handler.List)
+	r.POST("/suppliers", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Suppliers{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/suppliers", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/suppliers?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/suppliers?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/suppliers?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
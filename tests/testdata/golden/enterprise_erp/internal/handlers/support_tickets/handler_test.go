@@ -0,0 +1,114 @@
+package support_tickets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockSupport_ticketsRepository struct {
+	Data map[string]*domain.Support_tickets
+}
+
+func (m *MockSupport_ticketsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Support_tickets, error) {
+	var results []*domain.Support_tickets
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Support_tickets{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockSupport_ticketsRepository) Get(ctx context.Context, id string) (*domain.Support_tickets, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockSupport_ticketsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Support_tickets, error) {
+	var results []*domain.Support_tickets
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockSupport_ticketsRepository) Create(ctx context.Context, model *domain.Support_tickets) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Support_tickets)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockSupport_ticketsRepository) Update(ctx context.Context, id string, model *domain.Support_tickets) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockSupport_ticketsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestSupport_ticketsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockSupport_ticketsRepository{Data: make(map[string]*domain.Support_tickets)}
+	handler := NewSupport_ticketsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/support_tickets", handler.List)
+	r.POST("/support_tickets", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Support_tickets{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/support_tickets", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/support_tickets?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/support_tickets?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/support_tickets?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package warehouses
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockWarehousesRepository struct {
+	Data map[string]*domain.Warehouses
+}
+
+func (m *MockWarehousesRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Warehouses, error) {
+	var results []*domain.Warehouses
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Warehouses{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockWarehousesRepository) Get(ctx context.Context, id string) (*domain.Warehouses, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockWarehousesRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Warehouses, error) {
+	var results []*domain.Warehouses
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockWarehousesRepository) Create(ctx context.Context, model *domain.Warehouses) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Warehouses)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockWarehousesRepository) Update(ctx context.Context, id string, model *domain.Warehouses) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockWarehousesRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestWarehousesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockWarehousesRepository{Data: make(map[string]*domain.Warehouses)}
+	handler := NewWarehousesHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/warehouses", handler.List)
+	r.POST("/warehouses", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Warehouses{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/warehouses", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/warehouses?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/warehouses?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/warehouses?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package wishlist_items
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockWishlist_itemsRepository struct {
+	Data map[string]*domain.Wishlist_items
+}
+
+func (m *MockWishlist_itemsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Wishlist_items, error) {
+	var results []*domain.Wishlist_items
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Wishlist_items{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockWishlist_itemsRepository) Get(ctx context.Context, id string) (*domain.Wishlist_items, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockWishlist_itemsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Wishlist_items, error) {
+	var results []*domain.Wishlist_items
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockWishlist_itemsRepository) Create(ctx context.Context, model *domain.Wishlist_items) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Wishlist_items)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockWishlist_itemsRepository) Update(ctx context.Context, id string, model *domain.Wishlist_items) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockWishlist_itemsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestWishlist_itemsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockWishlist_itemsRepository{Data: make(map[string]*domain.Wishlist_items)}
+	handler := NewWishlist_itemsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/wishlist_items", handler.List)
+	r.POST("/wishlist_items", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Wishlist_items{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/wishlist_items", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/wishlist_items?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/wishlist_items?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/wishlist_items?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
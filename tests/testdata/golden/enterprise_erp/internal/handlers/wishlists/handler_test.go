@@ -0,0 +1,114 @@
+package wishlists
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockWishlistsRepository struct {
+	Data map[string]*domain.Wishlists
+}
+
+func (m *MockWishlistsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Wishlists, error) {
+	var results []*domain.Wishlists
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Wishlists{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockWishlistsRepository) Get(ctx context.Context, id string) (*domain.Wishlists, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockWishlistsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Wishlists, error) {
+	var results []*domain.Wishlists
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockWishlistsRepository) Create(ctx context.Context, model *domain.Wishlists) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Wishlists)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockWishlistsRepository) Update(ctx context.Context, id string, model *domain.Wishlists) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockWishlistsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestWishlistsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockWishlistsRepository{Data: make(map[string]*domain.Wishlists)}
+	handler := NewWishlistsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/wishlists", handler.List)
+	r.POST("/wishlists", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Wishlists{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/wishlists", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/wishlists?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/wishlists?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/wishlists?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
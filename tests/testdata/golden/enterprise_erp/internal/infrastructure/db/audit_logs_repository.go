@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type Audit_logsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewAudit_logsRepository expects the audit_logs table to exist; see migrations.go
+func NewAudit_logsRepository(repo *PostgresRepository) *Audit_logsRepository {
+	return &Audit_logsRepository{db: repo.Pool}
+}
+
+func (r *Audit_logsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Audit_logs, error) {
+	query, args, err := listSQL("SELECT id, action, details, ip_address, resource, resource_id, timestamp, COALESCE(user, '') AS user FROM audit_logs", "audit_logs", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Audit_logs
+	for rows.Next() {
+		var m domain.Audit_logs
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Action)
+
+		fields = append(fields, &m.Details)
+
+		fields = append(fields, &m.IpAddress)
+
+		fields = append(fields, &m.Resource)
+
+		fields = append(fields, &m.ResourceId)
+
+		fields = append(fields, &m.Timestamp)
+
+		fields = append(fields, &m.User)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *Audit_logsRepository) Get(ctx context.Context, id string) (*domain.Audit_logs, error) {
+	var m domain.Audit_logs
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Action)
+
+	fields = append(fields, &m.Details)
+
+	fields = append(fields, &m.IpAddress)
+
+	fields = append(fields, &m.Resource)
+
+	fields = append(fields, &m.ResourceId)
+
+	fields = append(fields, &m.Timestamp)
+
+	fields = append(fields, &m.User)
+
+	query := "SELECT id, action, details, ip_address, resource, resource_id, timestamp, COALESCE(user, '') AS user FROM audit_logs WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *Audit_logsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Audit_logs, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, action, details, ip_address, resource, resource_id, timestamp, COALESCE(user, '') AS user FROM audit_logs WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Audit_logs
+	for rows.Next() {
+		var m domain.Audit_logs
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Action)
+
+		fields = append(fields, &m.Details)
+
+		fields = append(fields, &m.IpAddress)
+
+		fields = append(fields, &m.Resource)
+
+		fields = append(fields, &m.ResourceId)
+
+		fields = append(fields, &m.Timestamp)
+
+		fields = append(fields, &m.User)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *Audit_logsRepository) Create(ctx context.Context, m *domain.Audit_logs) (string, error) {
+	query := "INSERT INTO audit_logs (action, details, ip_address, resource, resource_id, timestamp, user) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')) RETURNING id"
+
+	values := []interface{}{
+		m.Action,
+		m.Details,
+		m.IpAddress,
+		m.Resource,
+		m.ResourceId,
+		m.Timestamp,
+		m.User,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *Audit_logsRepository) Update(ctx context.Context, id string, m *domain.Audit_logs) error {
+
+	query := "UPDATE audit_logs SET action = $1, details = $2, ip_address = $3, resource = $4, resource_id = $5, timestamp = $6, user = NULLIF($7, '') WHERE id = $8"
+
+	values := []interface{}{
+		m.Action,
+		m.Details,
+		m.IpAddress,
+		m.Resource,
+		m.ResourceId,
+		m.Timestamp,
+		m.User,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *Audit_logsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM audit_logs WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
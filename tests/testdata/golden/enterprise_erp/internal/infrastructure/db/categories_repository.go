@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type CategoriesRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCategoriesRepository expects the categories table to exist; see migrations.go
+func NewCategoriesRepository(repo *PostgresRepository) *CategoriesRepository {
+	return &CategoriesRepository{db: repo.Pool}
+}
+
+func (r *CategoriesRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Categories, error) {
+	query, args, err := listSQL("SELECT id, description, is_visible, name, ARRAY(SELECT id FROM products WHERE category = categories.id) AS products, slug FROM categories", "categories", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Categories
+	for rows.Next() {
+		var m domain.Categories
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Description)
+
+		fields = append(fields, &m.IsVisible)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Products)
+
+		fields = append(fields, &m.Slug)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *CategoriesRepository) Get(ctx context.Context, id string) (*domain.Categories, error) {
+	var m domain.Categories
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Description)
+
+	fields = append(fields, &m.IsVisible)
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.Products)
+
+	fields = append(fields, &m.Slug)
+
+	query := "SELECT id, description, is_visible, name, ARRAY(SELECT id FROM products WHERE category = categories.id) AS products, slug FROM categories WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *CategoriesRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Categories, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, description, is_visible, name, ARRAY(SELECT id FROM products WHERE category = categories.id) AS products, slug FROM categories WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Categories
+	for rows.Next() {
+		var m domain.Categories
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Description)
+
+		fields = append(fields, &m.IsVisible)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Products)
+
+		fields = append(fields, &m.Slug)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *CategoriesRepository) Create(ctx context.Context, m *domain.Categories) (string, error) {
+	query := "INSERT INTO categories (description, is_visible, name, slug) VALUES ($1, $2, $3, $4) RETURNING id"
+
+	values := []interface{}{
+		m.Description,
+		m.IsVisible,
+		m.Name,
+		m.Slug,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *CategoriesRepository) Update(ctx context.Context, id string, m *domain.Categories) error {
+
+	query := "UPDATE categories SET description = $1, is_visible = $2, name = $3, slug = $4 WHERE id = $5"
+
+	values := []interface{}{
+		m.Description,
+		m.IsVisible,
+		m.Name,
+		m.Slug,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *CategoriesRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM categories WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
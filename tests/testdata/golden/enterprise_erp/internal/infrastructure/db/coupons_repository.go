@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type CouponsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCouponsRepository expects the coupons table to exist; see migrations.go
+func NewCouponsRepository(repo *PostgresRepository) *CouponsRepository {
+	return &CouponsRepository{db: repo.Pool}
+}
+
+func (r *CouponsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Coupons, error) {
+	query, args, err := listSQL("SELECT id, active, code, discount_percent, expires_at, ARRAY(SELECT id FROM orders WHERE coupon = coupons.id) AS orders, usage_limit FROM coupons", "coupons", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Coupons
+	for rows.Next() {
+		var m domain.Coupons
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Active)
+
+		fields = append(fields, &m.Code)
+
+		fields = append(fields, &m.DiscountPercent)
+
+		fields = append(fields, &m.ExpiresAt)
+
+		fields = append(fields, &m.Orders)
+
+		fields = append(fields, &m.UsageLimit)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *CouponsRepository) Get(ctx context.Context, id string) (*domain.Coupons, error) {
+	var m domain.Coupons
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Active)
+
+	fields = append(fields, &m.Code)
+
+	fields = append(fields, &m.DiscountPercent)
+
+	fields = append(fields, &m.ExpiresAt)
+
+	fields = append(fields, &m.Orders)
+
+	fields = append(fields, &m.UsageLimit)
+
+	query := "SELECT id, active, code, discount_percent, expires_at, ARRAY(SELECT id FROM orders WHERE coupon = coupons.id) AS orders, usage_limit FROM coupons WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *CouponsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Coupons, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, active, code, discount_percent, expires_at, ARRAY(SELECT id FROM orders WHERE coupon = coupons.id) AS orders, usage_limit FROM coupons WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Coupons
+	for rows.Next() {
+		var m domain.Coupons
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Active)
+
+		fields = append(fields, &m.Code)
+
+		fields = append(fields, &m.DiscountPercent)
+
+		fields = append(fields, &m.ExpiresAt)
+
+		fields = append(fields, &m.Orders)
+
+		fields = append(fields, &m.UsageLimit)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *CouponsRepository) Create(ctx context.Context, m *domain.Coupons) (string, error) {
+	query := "INSERT INTO coupons (active, code, discount_percent, expires_at, usage_limit) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+
+	values := []interface{}{
+		m.Active,
+		m.Code,
+		m.DiscountPercent,
+		m.ExpiresAt,
+		m.UsageLimit,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *CouponsRepository) Update(ctx context.Context, id string, m *domain.Coupons) error {
+
+	query := "UPDATE coupons SET active = $1, code = $2, discount_percent = $3, expires_at = $4, usage_limit = $5 WHERE id = $6"
+
+	values := []interface{}{
+		m.Active,
+		m.Code,
+		m.DiscountPercent,
+		m.ExpiresAt,
+		m.UsageLimit,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *CouponsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM coupons WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
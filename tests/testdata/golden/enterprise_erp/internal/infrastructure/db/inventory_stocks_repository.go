@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type Inventory_stocksRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewInventory_stocksRepository expects the inventory_stocks table to exist; see migrations.go
+func NewInventory_stocksRepository(repo *PostgresRepository) *Inventory_stocksRepository {
+	return &Inventory_stocksRepository{db: repo.Pool}
+}
+
+func (r *Inventory_stocksRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Inventory_stocks, error) {
+	query, args, err := listSQL("SELECT id, aisle, bin, last_audited, COALESCE(product, '') AS product, quantity, restock_threshold, COALESCE(warehouse, '') AS warehouse FROM inventory_stocks", "inventory_stocks", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Inventory_stocks
+	for rows.Next() {
+		var m domain.Inventory_stocks
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Aisle)
+
+		fields = append(fields, &m.Bin)
+
+		fields = append(fields, &m.LastAudited)
+
+		fields = append(fields, &m.Product)
+
+		fields = append(fields, &m.Quantity)
+
+		fields = append(fields, &m.RestockThreshold)
+
+		fields = append(fields, &m.Warehouse)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *Inventory_stocksRepository) Get(ctx context.Context, id string) (*domain.Inventory_stocks, error) {
+	var m domain.Inventory_stocks
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Aisle)
+
+	fields = append(fields, &m.Bin)
+
+	fields = append(fields, &m.LastAudited)
+
+	fields = append(fields, &m.Product)
+
+	fields = append(fields, &m.Quantity)
+
+	fields = append(fields, &m.RestockThreshold)
+
+	fields = append(fields, &m.Warehouse)
+
+	query := "SELECT id, aisle, bin, last_audited, COALESCE(product, '') AS product, quantity, restock_threshold, COALESCE(warehouse, '') AS warehouse FROM inventory_stocks WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *Inventory_stocksRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Inventory_stocks, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, aisle, bin, last_audited, COALESCE(product, '') AS product, quantity, restock_threshold, COALESCE(warehouse, '') AS warehouse FROM inventory_stocks WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Inventory_stocks
+	for rows.Next() {
+		var m domain.Inventory_stocks
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Aisle)
+
+		fields = append(fields, &m.Bin)
+
+		fields = append(fields, &m.LastAudited)
+
+		fields = append(fields, &m.Product)
+
+		fields = append(fields, &m.Quantity)
+
+		fields = append(fields, &m.RestockThreshold)
+
+		fields = append(fields, &m.Warehouse)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *Inventory_stocksRepository) Create(ctx context.Context, m *domain.Inventory_stocks) (string, error) {
+	query := "INSERT INTO inventory_stocks (aisle, bin, last_audited, product, quantity, restock_threshold, warehouse) VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, '')) RETURNING id"
+
+	values := []interface{}{
+		m.Aisle,
+		m.Bin,
+		m.LastAudited,
+		m.Product,
+		m.Quantity,
+		m.RestockThreshold,
+		m.Warehouse,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *Inventory_stocksRepository) Update(ctx context.Context, id string, m *domain.Inventory_stocks) error {
+
+	query := "UPDATE inventory_stocks SET aisle = $1, bin = $2, last_audited = $3, product = NULLIF($4, ''), quantity = $5, restock_threshold = $6, warehouse = NULLIF($7, '') WHERE id = $8"
+
+	values := []interface{}{
+		m.Aisle,
+		m.Bin,
+		m.LastAudited,
+		m.Product,
+		m.Quantity,
+		m.RestockThreshold,
+		m.Warehouse,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *Inventory_stocksRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM inventory_stocks WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
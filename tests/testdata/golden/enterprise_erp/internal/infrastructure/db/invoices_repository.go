@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type InvoicesRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewInvoicesRepository expects the invoices table to exist; see migrations.go
+func NewInvoicesRepository(repo *PostgresRepository) *InvoicesRepository {
+	return &InvoicesRepository{db: repo.Pool}
+}
+
+func (r *InvoicesRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Invoices, error) {
+	query, args, err := listSQL("SELECT id, due_date, invoice_number, issued_at, COALESCE(order, '') AS order, pdf_url, status, total FROM invoices", "invoices", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Invoices
+	for rows.Next() {
+		var m domain.Invoices
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.DueDate)
+
+		fields = append(fields, &m.InvoiceNumber)
+
+		fields = append(fields, &m.IssuedAt)
+
+		fields = append(fields, &m.Order)
+
+		fields = append(fields, &m.PdfUrl)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.Total)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *InvoicesRepository) Get(ctx context.Context, id string) (*domain.Invoices, error) {
+	var m domain.Invoices
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.DueDate)
+
+	fields = append(fields, &m.InvoiceNumber)
+
+	fields = append(fields, &m.IssuedAt)
+
+	fields = append(fields, &m.Order)
+
+	fields = append(fields, &m.PdfUrl)
+
+	fields = append(fields, &m.Status)
+
+	fields = append(fields, &m.Total)
+
+	query := "SELECT id, due_date, invoice_number, issued_at, COALESCE(order, '') AS order, pdf_url, status, total FROM invoices WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *InvoicesRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Invoices, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, due_date, invoice_number, issued_at, COALESCE(order, '') AS order, pdf_url, status, total FROM invoices WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Invoices
+	for rows.Next() {
+		var m domain.Invoices
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.DueDate)
+
+		fields = append(fields, &m.InvoiceNumber)
+
+		fields = append(fields, &m.IssuedAt)
+
+		fields = append(fields, &m.Order)
+
+		fields = append(fields, &m.PdfUrl)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.Total)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *InvoicesRepository) Create(ctx context.Context, m *domain.Invoices) (string, error) {
+	query := "INSERT INTO invoices (due_date, invoice_number, issued_at, order, pdf_url, status, total) VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7) RETURNING id"
+
+	values := []interface{}{
+		m.DueDate,
+		m.InvoiceNumber,
+		m.IssuedAt,
+		m.Order,
+		m.PdfUrl,
+		m.Status,
+		m.Total,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *InvoicesRepository) Update(ctx context.Context, id string, m *domain.Invoices) error {
+
+	query := "UPDATE invoices SET due_date = $1, invoice_number = $2, issued_at = $3, order = NULLIF($4, ''), pdf_url = $5, status = $6, total = $7 WHERE id = $8"
+
+	values := []interface{}{
+		m.DueDate,
+		m.InvoiceNumber,
+		m.IssuedAt,
+		m.Order,
+		m.PdfUrl,
+		m.Status,
+		m.Total,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *InvoicesRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM invoices WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,109 @@
+ALTER TABLE wishlists DROP CONSTRAINT IF EXISTS fk_wishlists_user;
+
+ALTER TABLE wishlist_items DROP CONSTRAINT IF EXISTS fk_wishlist_items_wishlist;
+
+ALTER TABLE wishlist_items DROP CONSTRAINT IF EXISTS fk_wishlist_items_product;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS fk_transactions_order;
+
+ALTER TABLE support_tickets DROP CONSTRAINT IF EXISTS fk_support_tickets_user;
+
+ALTER TABLE shipments DROP CONSTRAINT IF EXISTS fk_shipments_order;
+
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS fk_reviews_user;
+
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS fk_reviews_product;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS fk_products_supplier;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS fk_products_category;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS fk_orders_payment;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS fk_orders_customer;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS fk_orders_coupon;
+
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS fk_order_items_product;
+
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS fk_order_items_order;
+
+ALTER TABLE invoices DROP CONSTRAINT IF EXISTS fk_invoices_order;
+
+ALTER TABLE inventory_stocks DROP CONSTRAINT IF EXISTS fk_inventory_stocks_warehouse;
+
+ALTER TABLE inventory_stocks DROP CONSTRAINT IF EXISTS fk_inventory_stocks_product;
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS fk_audit_logs_user;
+
+DROP INDEX IF EXISTS idx_wishlists_user;
+
+DROP INDEX IF EXISTS idx_wishlist_items_wishlist;
+
+DROP INDEX IF EXISTS idx_wishlist_items_product;
+
+DROP INDEX IF EXISTS idx_transactions_order;
+
+DROP INDEX IF EXISTS idx_support_tickets_user;
+
+DROP INDEX IF EXISTS idx_shipments_order;
+
+DROP INDEX IF EXISTS idx_reviews_user;
+
+DROP INDEX IF EXISTS idx_reviews_product;
+
+DROP INDEX IF EXISTS idx_products_supplier;
+
+DROP INDEX IF EXISTS idx_products_category;
+
+DROP INDEX IF EXISTS idx_orders_payment;
+
+DROP INDEX IF EXISTS idx_orders_customer;
+
+DROP INDEX IF EXISTS idx_orders_coupon;
+
+DROP INDEX IF EXISTS idx_order_items_product;
+
+DROP INDEX IF EXISTS idx_order_items_order;
+
+DROP INDEX IF EXISTS idx_invoices_order;
+
+DROP INDEX IF EXISTS idx_inventory_stocks_warehouse;
+
+DROP INDEX IF EXISTS idx_inventory_stocks_product;
+
+DROP INDEX IF EXISTS idx_audit_logs_user;
+
+DROP TABLE IF EXISTS wishlists;
+
+DROP TABLE IF EXISTS wishlist_items;
+
+DROP TABLE IF EXISTS warehouses;
+
+DROP TABLE IF EXISTS users;
+
+DROP TABLE IF EXISTS transactions;
+
+DROP TABLE IF EXISTS support_tickets;
+
+DROP TABLE IF EXISTS suppliers;
+
+DROP TABLE IF EXISTS shipments;
+
+DROP TABLE IF EXISTS reviews;
+
+DROP TABLE IF EXISTS products;
+
+DROP TABLE IF EXISTS orders;
+
+DROP TABLE IF EXISTS order_items;
+
+DROP TABLE IF EXISTS invoices;
+
+DROP TABLE IF EXISTS inventory_stocks;
+
+DROP TABLE IF EXISTS coupons;
+
+DROP TABLE IF EXISTS categories;
+
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type SuppliersRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSuppliersRepository expects the suppliers table to exist; see migrations.go
+func NewSuppliersRepository(repo *PostgresRepository) *SuppliersRepository {
+	return &SuppliersRepository{db: repo.Pool}
+}
+
+func (r *SuppliersRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Suppliers, error) {
+	query, args, err := listSQL("SELECT id, company_name, contact_name, contract_end, email, phone, ARRAY(SELECT id FROM products WHERE supplier = suppliers.id) AS products, tax_id FROM suppliers", "suppliers", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Suppliers
+	for rows.Next() {
+		var m domain.Suppliers
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.CompanyName)
+
+		fields = append(fields, &m.ContactName)
+
+		fields = append(fields, &m.ContractEnd)
+
+		fields = append(fields, &m.Email)
+
+		fields = append(fields, &m.Phone)
+
+		fields = append(fields, &m.Products)
+
+		fields = append(fields, &m.TaxId)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *SuppliersRepository) Get(ctx context.Context, id string) (*domain.Suppliers, error) {
+	var m domain.Suppliers
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.CompanyName)
+
+	fields = append(fields, &m.ContactName)
+
+	fields = append(fields, &m.ContractEnd)
+
+	fields = append(fields, &m.Email)
+
+	fields = append(fields, &m.Phone)
+
+	fields = append(fields, &m.Products)
+
+	fields = append(fields, &m.TaxId)
+
+	query := "SELECT id, company_name, contact_name, contract_end, email, phone, ARRAY(SELECT id FROM products WHERE supplier = suppliers.id) AS products, tax_id FROM suppliers WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *SuppliersRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Suppliers, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, company_name, contact_name, contract_end, email, phone, ARRAY(SELECT id FROM products WHERE supplier = suppliers.id) AS products, tax_id FROM suppliers WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Suppliers
+	for rows.Next() {
+		var m domain.Suppliers
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.CompanyName)
+
+		fields = append(fields, &m.ContactName)
+
+		fields = append(fields, &m.ContractEnd)
+
+		fields = append(fields, &m.Email)
+
+		fields = append(fields, &m.Phone)
+
+		fields = append(fields, &m.Products)
+
+		fields = append(fields, &m.TaxId)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *SuppliersRepository) Create(ctx context.Context, m *domain.Suppliers) (string, error) {
+	query := "INSERT INTO suppliers (company_name, contact_name, contract_end, email, phone, tax_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id"
+
+	values := []interface{}{
+		m.CompanyName,
+		m.ContactName,
+		m.ContractEnd,
+		m.Email,
+		m.Phone,
+		m.TaxId,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *SuppliersRepository) Update(ctx context.Context, id string, m *domain.Suppliers) error {
+
+	query := "UPDATE suppliers SET company_name = $1, contact_name = $2, contract_end = $3, email = $4, phone = $5, tax_id = $6 WHERE id = $7"
+
+	values := []interface{}{
+		m.CompanyName,
+		m.ContactName,
+		m.ContractEnd,
+		m.Email,
+		m.Phone,
+		m.TaxId,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *SuppliersRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM suppliers WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type Support_ticketsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSupport_ticketsRepository expects the support_tickets table to exist; see migrations.go
+func NewSupport_ticketsRepository(repo *PostgresRepository) *Support_ticketsRepository {
+	return &Support_ticketsRepository{db: repo.Pool}
+}
+
+func (r *Support_ticketsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Support_tickets, error) {
+	query, args, err := listSQL("SELECT id, closed_at, created_at, message, priority, status, subject, COALESCE(user, '') AS user FROM support_tickets", "support_tickets", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Support_tickets
+	for rows.Next() {
+		var m domain.Support_tickets
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.ClosedAt)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Message)
+
+		fields = append(fields, &m.Priority)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.Subject)
+
+		fields = append(fields, &m.User)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *Support_ticketsRepository) Get(ctx context.Context, id string) (*domain.Support_tickets, error) {
+	var m domain.Support_tickets
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.ClosedAt)
+
+	fields = append(fields, &m.CreatedAt)
+
+	fields = append(fields, &m.Message)
+
+	fields = append(fields, &m.Priority)
+
+	fields = append(fields, &m.Status)
+
+	fields = append(fields, &m.Subject)
+
+	fields = append(fields, &m.User)
+
+	query := "SELECT id, closed_at, created_at, message, priority, status, subject, COALESCE(user, '') AS user FROM support_tickets WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *Support_ticketsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Support_tickets, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, closed_at, created_at, message, priority, status, subject, COALESCE(user, '') AS user FROM support_tickets WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Support_tickets
+	for rows.Next() {
+		var m domain.Support_tickets
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.ClosedAt)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Message)
+
+		fields = append(fields, &m.Priority)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.Subject)
+
+		fields = append(fields, &m.User)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *Support_ticketsRepository) Create(ctx context.Context, m *domain.Support_tickets) (string, error) {
+	query := "INSERT INTO support_tickets (closed_at, created_at, message, priority, status, subject, user) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')) RETURNING id"
+
+	values := []interface{}{
+		m.ClosedAt,
+		m.CreatedAt,
+		m.Message,
+		m.Priority,
+		m.Status,
+		m.Subject,
+		m.User,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *Support_ticketsRepository) Update(ctx context.Context, id string, m *domain.Support_tickets) error {
+
+	query := "UPDATE support_tickets SET closed_at = $1, created_at = $2, message = $3, priority = $4, status = $5, subject = $6, user = NULLIF($7, '') WHERE id = $8"
+
+	values := []interface{}{
+		m.ClosedAt,
+		m.CreatedAt,
+		m.Message,
+		m.Priority,
+		m.Status,
+		m.Subject,
+		m.User,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *Support_ticketsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM support_tickets WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
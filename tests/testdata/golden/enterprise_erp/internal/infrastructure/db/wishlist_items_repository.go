@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type Wishlist_itemsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWishlist_itemsRepository expects the wishlist_items table to exist; see migrations.go
+func NewWishlist_itemsRepository(repo *PostgresRepository) *Wishlist_itemsRepository {
+	return &Wishlist_itemsRepository{db: repo.Pool}
+}
+
+func (r *Wishlist_itemsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Wishlist_items, error) {
+	query, args, err := listSQL("SELECT id, added_at, priority, COALESCE(product, '') AS product, COALESCE(wishlist, '') AS wishlist FROM wishlist_items", "wishlist_items", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Wishlist_items
+	for rows.Next() {
+		var m domain.Wishlist_items
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.AddedAt)
+
+		fields = append(fields, &m.Priority)
+
+		fields = append(fields, &m.Product)
+
+		fields = append(fields, &m.Wishlist)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *Wishlist_itemsRepository) Get(ctx context.Context, id string) (*domain.Wishlist_items, error) {
+	var m domain.Wishlist_items
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.AddedAt)
+
+	fields = append(fields, &m.Priority)
+
+	fields = append(fields, &m.Product)
+
+	fields = append(fields, &m.Wishlist)
+
+	query := "SELECT id, added_at, priority, COALESCE(product, '') AS product, COALESCE(wishlist, '') AS wishlist FROM wishlist_items WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *Wishlist_itemsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Wishlist_items, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, added_at, priority, COALESCE(product, '') AS product, COALESCE(wishlist, '') AS wishlist FROM wishlist_items WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Wishlist_items
+	for rows.Next() {
+		var m domain.Wishlist_items
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.AddedAt)
+
+		fields = append(fields, &m.Priority)
+
+		fields = append(fields, &m.Product)
+
+		fields = append(fields, &m.Wishlist)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *Wishlist_itemsRepository) Create(ctx context.Context, m *domain.Wishlist_items) (string, error) {
+	query := "INSERT INTO wishlist_items (added_at, priority, product, wishlist) VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, '')) RETURNING id"
+
+	values := []interface{}{
+		m.AddedAt,
+		m.Priority,
+		m.Product,
+		m.Wishlist,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *Wishlist_itemsRepository) Update(ctx context.Context, id string, m *domain.Wishlist_items) error {
+
+	query := "UPDATE wishlist_items SET added_at = $1, priority = $2, product = NULLIF($3, ''), wishlist = NULLIF($4, '') WHERE id = $5"
+
+	values := []interface{}{
+		m.AddedAt,
+		m.Priority,
+		m.Product,
+		m.Wishlist,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *Wishlist_itemsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM wishlist_items WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
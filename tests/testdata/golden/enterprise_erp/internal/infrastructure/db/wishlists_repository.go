@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type WishlistsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWishlistsRepository expects the wishlists table to exist; see migrations.go
+func NewWishlistsRepository(repo *PostgresRepository) *WishlistsRepository {
+	return &WishlistsRepository{db: repo.Pool}
+}
+
+func (r *WishlistsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Wishlists, error) {
+	query, args, err := listSQL("SELECT id, created_at, is_public, ARRAY(SELECT id FROM wishlist_items WHERE wishlist = wishlists.id) AS items, name, COALESCE(user, '') AS user FROM wishlists", "wishlists", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Wishlists
+	for rows.Next() {
+		var m domain.Wishlists
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.IsPublic)
+
+		fields = append(fields, &m.Items)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.User)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *WishlistsRepository) Get(ctx context.Context, id string) (*domain.Wishlists, error) {
+	var m domain.Wishlists
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.CreatedAt)
+
+	fields = append(fields, &m.IsPublic)
+
+	fields = append(fields, &m.Items)
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.User)
+
+	query := "SELECT id, created_at, is_public, ARRAY(SELECT id FROM wishlist_items WHERE wishlist = wishlists.id) AS items, name, COALESCE(user, '') AS user FROM wishlists WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *WishlistsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Wishlists, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, created_at, is_public, ARRAY(SELECT id FROM wishlist_items WHERE wishlist = wishlists.id) AS items, name, COALESCE(user, '') AS user FROM wishlists WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Wishlists
+	for rows.Next() {
+		var m domain.Wishlists
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.IsPublic)
+
+		fields = append(fields, &m.Items)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.User)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *WishlistsRepository) Create(ctx context.Context, m *domain.Wishlists) (string, error) {
+	query := "INSERT INTO wishlists (created_at, is_public, name, user) VALUES ($1, $2, $3, NULLIF($4, '')) RETURNING id"
+
+	values := []interface{}{
+		m.CreatedAt,
+		m.IsPublic,
+		m.Name,
+		m.User,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *WishlistsRepository) Update(ctx context.Context, id string, m *domain.Wishlists) error {
+
+	query := "UPDATE wishlists SET created_at = $1, is_public = $2, name = $3, user = NULLIF($4, '') WHERE id = $5"
+
+	values := []interface{}{
+		m.CreatedAt,
+		m.IsPublic,
+		m.Name,
+		m.User,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *WishlistsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM wishlists WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
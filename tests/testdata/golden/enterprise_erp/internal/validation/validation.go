@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"EnterpriseERP/internal/domain"
+)
+
+func init() {
+	// Report fields by their JSON name, as clients send them
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
+// Respond answers 422 with every field that failed validation
+func Respond(c *gin.Context, errs []domain.FieldError) {
+	c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "validation failed", "fields": errs})
+}
+
+// FromBinding converts the errors of binding tags; ok is false when err is
+// something else, such as malformed JSON
+func FromBinding(err error) (errs []domain.FieldError, ok bool) {
+	var failed validator.ValidationErrors
+	if !errors.As(err, &failed) {
+		return nil, false
+	}
+	for _, fe := range failed {
+		rule, message := describe(fe)
+		errs = append(errs, domain.FieldError{Field: fe.Field(), Rule: rule, Message: message})
+	}
+	return errs, true
+}
+
+// describe names a failed binding tag after the blueprint rule that produced it
+func describe(fe validator.FieldError) (rule, message string) {
+	text := fe.Kind() == reflect.String
+	switch fe.Tag() {
+	case "required":
+		return "required", "is required"
+	case "min":
+		if text {
+			return "min_length", "must be at least " + fe.Param() + " characters long"
+		}
+		return "min", "must be at least " + fe.Param()
+	case "max":
+		if text {
+			return "max_length", "must be at most " + fe.Param() + " characters long"
+		}
+		return "max", "must be at most " + fe.Param()
+	case "oneof":
+		return "enum", "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "email":
+		return "email", "must be a valid email address"
+	case "url":
+		return "url", "must be a valid URL"
+	case "uuid":
+		return "uuid", "must be a valid UUID"
+	}
+	return fe.Tag(), fe.Error()
+}
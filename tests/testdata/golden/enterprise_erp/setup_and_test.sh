@@ -0,0 +1,70 @@
+#!/bin/bash
+
+echo "Installing dependencies..."
+go mod tidy
+
+echo "Starting server in background..."
+export MOCK_AUTH=true
+go run cmd/api/main.go &
+PID=$!
+sleep 5
+
+echo "Running tests..."
+
+echo "Testing POST /auth/login"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"email": "test@example.com", "password": "password123"}' http://localhost:8080/auth/login
+echo "\n"
+echo "Testing POST /api/users"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"address": "test_address", "created_at": "2023-01-01T00:00:00Z", "email": "test_email", "full_name": "test_full_name", "is_verified": true, "last_login": "2023-01-01T00:00:00Z", "name": "test_name", "phone": "test_phone", "picture": "test_picture", "role_id": "test_role_id", "uid": "test_uid", "updated_at": "2023-01-01T00:00:00Z"}' http://localhost:8080/api/users
+echo "\n"
+echo "Testing POST /api/transactions"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"amount": 99.99, "created_at": "2023-01-01T00:00:00Z", "currency": "test_currency", "payload": "test_payload", "provider": "test_provider", "provider_id": "test_provider_id", "status": "test_status"}' http://localhost:8080/api/transactions
+echo "\n"
+echo "Testing POST /api/products"
+curl -X POST -H "Content-Type: application/json" -d '{"cost_price": 99.99, "created_at": "2023-01-01T00:00:00Z", "description": "test_description", "dimensions": "test_dimensions", "is_active": true, "name": "test_name", "price": 99.99, "sku": "test_sku", "weight": 99.99}' http://localhost:8080/api/products
+echo "\n"
+echo "Testing POST /api/categories"
+curl -X POST -H "Content-Type: application/json" -d '{"description": "test_description", "is_visible": true, "name": "test_name", "slug": "test_slug"}' http://localhost:8080/api/categories
+echo "\n"
+echo "Testing POST /api/suppliers"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"company_name": "test_company_name", "contact_name": "test_contact_name", "contract_end": "2023-01-01T00:00:00Z", "email": "test_email", "phone": "test_phone", "tax_id": "test_tax_id"}' http://localhost:8080/api/suppliers
+echo "\n"
+echo "Testing POST /api/warehouses"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"address": "test_address", "capacity": 10, "location_code": "test_location_code", "manager_name": "test_manager_name", "name": "test_name"}' http://localhost:8080/api/warehouses
+echo "\n"
+echo "Testing POST /api/inventory_stocks"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"aisle": "test_aisle", "bin": "test_bin", "last_audited": "2023-01-01T00:00:00Z", "quantity": 10, "restock_threshold": 10}' http://localhost:8080/api/inventory_stocks
+echo "\n"
+echo "Testing POST /api/orders"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"order_number": "test_order_number", "placed_at": "2023-01-01T00:00:00Z", "shipping_address": "test_shipping_address", "status": "test_status", "tax_amount": 99.99, "total_amount": 99.99}' http://localhost:8080/api/orders
+echo "\n"
+echo "Testing POST /api/order_items"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"discount": 99.99, "quantity": 10, "total": 99.99, "unit_price": 99.99}' http://localhost:8080/api/order_items
+echo "\n"
+echo "Testing POST /api/shipments"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"carrier": "test_carrier", "estimated_arrival": "2023-01-01T00:00:00Z", "shipped_at": "2023-01-01T00:00:00Z", "status": "test_status", "tracking_number": "test_tracking_number", "weight": 99.99}' http://localhost:8080/api/shipments
+echo "\n"
+echo "Testing POST /api/invoices"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"due_date": "2023-01-01T00:00:00Z", "invoice_number": "test_invoice_number", "issued_at": "2023-01-01T00:00:00Z", "pdf_url": "test_pdf_url", "status": "test_status", "total": 99.99}' http://localhost:8080/api/invoices
+echo "\n"
+echo "Testing POST /api/support_tickets"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"closed_at": "2023-01-01T00:00:00Z", "created_at": "2023-01-01T00:00:00Z", "message": "test_message", "priority": "test_priority", "status": "test_status", "subject": "test_subject"}' http://localhost:8080/api/support_tickets
+echo "\n"
+echo "Testing POST /api/reviews"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"approved": true, "comment": "test_comment", "created_at": "2023-01-01T00:00:00Z", "likes": 10, "rating": 10}' http://localhost:8080/api/reviews
+echo "\n"
+echo "Testing POST /api/wishlists"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"created_at": "2023-01-01T00:00:00Z", "is_public": true, "name": "test_name"}' http://localhost:8080/api/wishlists
+echo "\n"
+echo "Testing POST /api/wishlist_items"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"added_at": "2023-01-01T00:00:00Z", "priority": "test_priority"}' http://localhost:8080/api/wishlist_items
+echo "\n"
+echo "Testing POST /api/coupons"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"active": true, "code": "test_code", "discount_percent": 99.99, "expires_at": "2023-01-01T00:00:00Z", "usage_limit": 10}' http://localhost:8080/api/coupons
+echo "\n"
+echo "Testing POST /api/audit_logs"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"action": "test_action", "details": "test_details", "ip_address": "test_ip_address", "resource": "test_resource", "resource_id": "test_resource_id", "timestamp": "2023-01-01T00:00:00Z"}' http://localhost:8080/api/audit_logs
+echo "\n"
+
+echo "Killing server (PID: $PID)..."
+kill $PID
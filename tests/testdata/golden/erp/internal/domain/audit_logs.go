@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Audit_logs struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Action string `json:"action" bson:"action" firestore:"action"`
+
+	Details string `json:"details" bson:"details" firestore:"details"`
+
+	IpAddress string `json:"ip_address" bson:"ip_address" firestore:"ip_address"`
+
+	Resource string `json:"resource" bson:"resource" firestore:"resource"`
+
+	ResourceId string `json:"resource_id" bson:"resource_id" firestore:"resource_id"`
+
+	Timestamp time.Time `json:"timestamp" bson:"timestamp" firestore:"timestamp"`
+
+	User string `json:"user" bson:"user" firestore:"user"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Audit_logs) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Audit_logs) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// Audit_logsFields maps the fields List can filter, sort and select on to their blueprint types
+var Audit_logsFields = map[string]string{
+	"id":          "string",
+	"action":      "string",
+	"details":     "text",
+	"ip_address":  "string",
+	"resource":    "string",
+	"resource_id": "string",
+	"timestamp":   "datetime",
+	"user":        "string",
+}
+
+type Audit_logsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Audit_logs, error)
+	Get(ctx context.Context, id string) (*Audit_logs, error)
+	GetMany(ctx context.Context, ids []string) ([]*Audit_logs, error)
+	Create(ctx context.Context, model *Audit_logs) (string, error)
+	Update(ctx context.Context, id string, model *Audit_logs) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Coupons struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Active bool `json:"active" bson:"active" firestore:"active"`
+
+	Code string `json:"code" bson:"code" firestore:"code"`
+
+	DiscountPercent float64 `json:"discount_percent" bson:"discount_percent" firestore:"discount_percent"`
+
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at" firestore:"expires_at"`
+
+	UsageLimit int `json:"usage_limit" bson:"usage_limit" firestore:"usage_limit"`
+
+	Orders []string `json:"orders" bson:"orders" firestore:"orders"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Coupons) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Coupons) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// CouponsFields maps the fields List can filter, sort and select on to their blueprint types
+var CouponsFields = map[string]string{
+	"id":               "string",
+	"active":           "boolean",
+	"code":             "string",
+	"discount_percent": "float",
+	"expires_at":       "datetime",
+	"usage_limit":      "integer",
+	"orders":           "array",
+}
+
+type CouponsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Coupons, error)
+	Get(ctx context.Context, id string) (*Coupons, error)
+	GetMany(ctx context.Context, ids []string) ([]*Coupons, error)
+	Create(ctx context.Context, model *Coupons) (string, error)
+	Update(ctx context.Context, id string, model *Coupons) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Inventory_stocks struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Aisle string `json:"aisle" bson:"aisle" firestore:"aisle"`
+
+	Bin string `json:"bin" bson:"bin" firestore:"bin"`
+
+	LastAudited time.Time `json:"last_audited" bson:"last_audited" firestore:"last_audited"`
+
+	Quantity int `json:"quantity" bson:"quantity" firestore:"quantity"`
+
+	RestockThreshold int `json:"restock_threshold" bson:"restock_threshold" firestore:"restock_threshold"`
+
+	Product string `json:"product" bson:"product" firestore:"product"`
+
+	Warehouse string `json:"warehouse" bson:"warehouse" firestore:"warehouse"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Inventory_stocks) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Inventory_stocks) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// Inventory_stocksFields maps the fields List can filter, sort and select on to their blueprint types
+var Inventory_stocksFields = map[string]string{
+	"id":                "string",
+	"aisle":             "string",
+	"bin":               "string",
+	"last_audited":      "datetime",
+	"quantity":          "integer",
+	"restock_threshold": "integer",
+	"product":           "string",
+	"warehouse":         "string",
+}
+
+type Inventory_stocksRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Inventory_stocks, error)
+	Get(ctx context.Context, id string) (*Inventory_stocks, error)
+	GetMany(ctx context.Context, ids []string) ([]*Inventory_stocks, error)
+	Create(ctx context.Context, model *Inventory_stocks) (string, error)
+	Update(ctx context.Context, id string, model *Inventory_stocks) error
+	Delete(ctx context.Context, id string) error
+}
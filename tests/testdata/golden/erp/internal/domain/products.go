@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Products struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	CostPrice float64 `json:"cost_price" bson:"cost_price" firestore:"cost_price"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	Description string `json:"description" bson:"description" firestore:"description"`
+
+	Dimensions string `json:"dimensions" bson:"dimensions" firestore:"dimensions"`
+
+	IsActive bool `json:"is_active" bson:"is_active" firestore:"is_active"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	Price float64 `json:"price" bson:"price" firestore:"price"`
+
+	Sku string `json:"sku" bson:"sku" firestore:"sku"`
+
+	Weight float64 `json:"weight" bson:"weight" firestore:"weight"`
+
+	Category string `json:"category" bson:"category" firestore:"category"`
+
+	Inventory []string `json:"inventory" bson:"inventory" firestore:"inventory"`
+
+	OrderItems []string `json:"order_items" bson:"order_items" firestore:"order_items"`
+
+	Reviews []string `json:"reviews" bson:"reviews" firestore:"reviews"`
+
+	Supplier string `json:"supplier" bson:"supplier" firestore:"supplier"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Products) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Products) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// ProductsFields maps the fields List can filter, sort and select on to their blueprint types
+var ProductsFields = map[string]string{
+	"id":          "string",
+	"cost_price":  "float",
+	"created_at":  "datetime",
+	"description": "text",
+	"dimensions":  "string",
+	"is_active":   "boolean",
+	"name":        "string",
+	"price":       "float",
+	"sku":         "string",
+	"weight":      "float",
+	"category":    "string",
+	"inventory":   "array",
+	"order_items": "array",
+	"reviews":     "array",
+	"supplier":    "string",
+}
+
+type ProductsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Products, error)
+	Get(ctx context.Context, id string) (*Products, error)
+	GetMany(ctx context.Context, ids []string) ([]*Products, error)
+	Create(ctx context.Context, model *Products) (string, error)
+	Update(ctx context.Context, id string, model *Products) error
+	Delete(ctx context.Context, id string) error
+}
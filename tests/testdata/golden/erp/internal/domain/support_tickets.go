@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Support_tickets struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	ClosedAt time.Time `json:"closed_at" bson:"closed_at" firestore:"closed_at"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	Message string `json:"message" bson:"message" firestore:"message"`
+
+	Priority string `json:"priority" bson:"priority" firestore:"priority"`
+
+	Status string `json:"status" bson:"status" firestore:"status"`
+
+	Subject string `json:"subject" bson:"subject" firestore:"subject"`
+
+	User string `json:"user" bson:"user" firestore:"user"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Support_tickets) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Support_tickets) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// Support_ticketsFields maps the fields List can filter, sort and select on to their blueprint types
+var Support_ticketsFields = map[string]string{
+	"id":         "string",
+	"closed_at":  "datetime",
+	"created_at": "datetime",
+	"message":    "text",
+	"priority":   "string",
+	"status":     "string",
+	"subject":    "string",
+	"user":       "string",
+}
+
+type Support_ticketsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Support_tickets, error)
+	Get(ctx context.Context, id string) (*Support_tickets, error)
+	GetMany(ctx context.Context, ids []string) ([]*Support_tickets, error)
+	Create(ctx context.Context, model *Support_tickets) (string, error)
+	Update(ctx context.Context, id string, model *Support_tickets) error
+	Delete(ctx context.Context, id string) error
+}
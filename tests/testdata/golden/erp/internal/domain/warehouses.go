@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+)
+
+type Warehouses struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Address string `json:"address" bson:"address" firestore:"address"`
+
+	Capacity int `json:"capacity" bson:"capacity" firestore:"capacity"`
+
+	LocationCode string `json:"location_code" bson:"location_code" firestore:"location_code"`
+
+	ManagerName string `json:"manager_name" bson:"manager_name" firestore:"manager_name"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	Stocks []string `json:"stocks" bson:"stocks" firestore:"stocks"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Warehouses) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Warehouses) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// WarehousesFields maps the fields List can filter, sort and select on to their blueprint types
+var WarehousesFields = map[string]string{
+	"id":            "string",
+	"address":       "text",
+	"capacity":      "integer",
+	"location_code": "string",
+	"manager_name":  "string",
+	"name":          "string",
+	"stocks":        "array",
+}
+
+type WarehousesRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Warehouses, error)
+	Get(ctx context.Context, id string) (*Warehouses, error)
+	GetMany(ctx context.Context, ids []string) ([]*Warehouses, error)
+	Create(ctx context.Context, model *Warehouses) (string, error)
+	Update(ctx context.Context, id string, model *Warehouses) error
+	Delete(ctx context.Context, id string) error
+}
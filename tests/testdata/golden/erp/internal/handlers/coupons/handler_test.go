@@ -0,0 +1,114 @@
+package coupons
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockCouponsRepository struct {
+	Data map[string]*domain.Coupons
+}
+
+func (m *MockCouponsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Coupons, error) {
+	var results []*domain.Coupons
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Coupons{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockCouponsRepository) Get(ctx context.Context, id string) (*domain.Coupons, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCouponsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Coupons, error) {
+	var results []*domain.Coupons
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockCouponsRepository) Create(ctx context.Context, model *domain.Coupons) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Coupons)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockCouponsRepository) Update(ctx context.Context, id string, model *domain.Coupons) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockCouponsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestCouponsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockCouponsRepository{Data: make(map[string]*domain.Coupons)}
+	handler := NewCouponsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/coupons", handler.List)
+	r.POST("/coupons", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Coupons{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/coupons", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/coupons?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/coupons?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/coupons?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
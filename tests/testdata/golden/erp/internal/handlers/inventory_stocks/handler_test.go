@@ -0,0 +1,114 @@
+package inventory_stocks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockInventory_stocksRepository struct {
+	Data map[string]*domain.Inventory_stocks
+}
+
+func (m *MockInventory_stocksRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Inventory_stocks, error) {
+	var results []*domain.Inventory_stocks
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Inventory_stocks{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockInventory_stocksRepository) Get(ctx context.Context, id string) (*domain.Inventory_stocks, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockInventory_stocksRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Inventory_stocks, error) {
+	var results []*domain.Inventory_stocks
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockInventory_stocksRepository) Create(ctx context.Context, model *domain.Inventory_stocks) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Inventory_stocks)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockInventory_stocksRepository) Update(ctx context.Context, id string, model *domain.Inventory_stocks) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockInventory_stocksRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestInventory_stocksHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockInventory_stocksRepository{Data: make(map[string]*domain.Inventory_stocks)}
+	handler := NewInventory_stocksHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/inventory_stocks", handler.List)
+	r.POST("/inventory_stocks", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Inventory_stocks{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/inventory_stocks", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/inventory_stocks?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/inventory_stocks?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/inventory_stocks?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package reviews
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockReviewsRepository struct {
+	Data map[string]*domain.Reviews
+}
+
+func (m *MockReviewsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Reviews, error) {
+	var results []*domain.Reviews
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Reviews{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockReviewsRepository) Get(ctx context.Context, id string) (*domain.Reviews, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockReviewsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Reviews, error) {
+	var results []*domain.Reviews
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockReviewsRepository) Create(ctx context.Context, model *domain.Reviews) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Reviews)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockReviewsRepository) Update(ctx context.Context, id string, model *domain.Reviews) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockReviewsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestReviewsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockReviewsRepository{Data: make(map[string]*domain.Reviews)}
+	handler := NewReviewsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/reviews", handler.List)
+	r.POST("/reviews", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Reviews{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/reviews", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/reviews?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/reviews?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/reviews?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
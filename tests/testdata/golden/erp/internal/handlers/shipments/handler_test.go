@@ -0,0 +1,114 @@
+package shipments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"EnterpriseERP/internal/domain"
+	"EnterpriseERP/internal/expand"
+)
+
+type MockShipmentsRepository struct {
+	Data map[string]*domain.Shipments
+}
+
+func (m *MockShipmentsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Shipments, error) {
+	var results []*domain.Shipments
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Shipments{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockShipmentsRepository) Get(ctx context.Context, id string) (*domain.Shipments, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockShipmentsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Shipments, error) {
+	var results []*domain.Shipments
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockShipmentsRepository) Create(ctx context.Context, model *domain.Shipments) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Shipments)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockShipmentsRepository) Update(ctx context.Context, id string, model *domain.Shipments) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockShipmentsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestShipmentsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockShipmentsRepository{Data: make(map[string]*domain.Shipments)}
+	handler := NewShipmentsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/shipments", handler.List)
+	r.POST("/shipments", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Shipments{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/shipments", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/shipments?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/shipments?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/shipments?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,259 @@
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    action TEXT,
+    details TEXT,
+    ip_address TEXT,
+    resource TEXT,
+    resource_id TEXT,
+    timestamp TIMESTAMP,
+    user TEXT
+);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    description TEXT,
+    is_visible BOOLEAN,
+    name TEXT,
+    slug TEXT
+);
+
+CREATE TABLE IF NOT EXISTS coupons (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    active BOOLEAN,
+    code TEXT,
+    discount_percent DOUBLE PRECISION,
+    expires_at TIMESTAMP,
+    usage_limit INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS inventory_stocks (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    aisle TEXT,
+    bin TEXT,
+    last_audited TIMESTAMP,
+    product TEXT,
+    quantity INTEGER,
+    restock_threshold INTEGER,
+    warehouse TEXT
+);
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    due_date TIMESTAMP,
+    invoice_number TEXT,
+    issued_at TIMESTAMP,
+    order TEXT,
+    pdf_url TEXT,
+    status TEXT,
+    total DOUBLE PRECISION
+);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    discount DOUBLE PRECISION,
+    order TEXT,
+    product TEXT,
+    quantity INTEGER,
+    total DOUBLE PRECISION,
+    unit_price DOUBLE PRECISION
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    coupon TEXT,
+    customer TEXT,
+    order_number TEXT,
+    payment TEXT,
+    placed_at TIMESTAMP,
+    shipping_address TEXT,
+    status TEXT,
+    tax_amount DOUBLE PRECISION,
+    total_amount DOUBLE PRECISION
+);
+
+CREATE TABLE IF NOT EXISTS products (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    category TEXT,
+    cost_price DOUBLE PRECISION,
+    created_at TIMESTAMP,
+    description TEXT,
+    dimensions TEXT,
+    is_active BOOLEAN,
+    name TEXT,
+    price DOUBLE PRECISION,
+    sku TEXT,
+    supplier TEXT,
+    weight DOUBLE PRECISION
+);
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    approved BOOLEAN,
+    comment TEXT,
+    created_at TIMESTAMP,
+    likes INTEGER,
+    product TEXT,
+    rating INTEGER,
+    user TEXT
+);
+
+CREATE TABLE IF NOT EXISTS shipments (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    carrier TEXT,
+    estimated_arrival TIMESTAMP,
+    order TEXT,
+    shipped_at TIMESTAMP,
+    status TEXT,
+    tracking_number TEXT,
+    weight DOUBLE PRECISION
+);
+
+CREATE TABLE IF NOT EXISTS suppliers (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    company_name TEXT,
+    contact_name TEXT,
+    contract_end TIMESTAMP,
+    email TEXT,
+    phone TEXT,
+    tax_id TEXT
+);
+
+CREATE TABLE IF NOT EXISTS support_tickets (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    closed_at TIMESTAMP,
+    created_at TIMESTAMP,
+    message TEXT,
+    priority TEXT,
+    status TEXT,
+    subject TEXT,
+    user TEXT
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    amount DOUBLE PRECISION,
+    created_at TIMESTAMP,
+    currency TEXT,
+    order TEXT,
+    payload TEXT,
+    provider TEXT,
+    provider_id TEXT,
+    status TEXT
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    address TEXT,
+    created_at TIMESTAMP,
+    email TEXT,
+    full_name TEXT,
+    is_verified BOOLEAN,
+    last_login TIMESTAMP,
+    name TEXT,
+    phone TEXT,
+    picture TEXT,
+    role_id TEXT,
+    uid TEXT,
+    updated_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS warehouses (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    address TEXT,
+    capacity INTEGER,
+    location_code TEXT,
+    manager_name TEXT,
+    name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS wishlist_items (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    added_at TIMESTAMP,
+    priority TEXT,
+    product TEXT,
+    wishlist TEXT
+);
+
+CREATE TABLE IF NOT EXISTS wishlists (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    created_at TIMESTAMP,
+    is_public BOOLEAN,
+    name TEXT,
+    user TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user ON audit_logs (user);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_stocks_product ON inventory_stocks (product);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_stocks_warehouse ON inventory_stocks (warehouse);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_order ON invoices (order);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order ON order_items (order);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_product ON order_items (product);
+
+CREATE INDEX IF NOT EXISTS idx_orders_coupon ON orders (coupon);
+
+CREATE INDEX IF NOT EXISTS idx_orders_customer ON orders (customer);
+
+CREATE INDEX IF NOT EXISTS idx_orders_payment ON orders (payment);
+
+CREATE INDEX IF NOT EXISTS idx_products_category ON products (category);
+
+CREATE INDEX IF NOT EXISTS idx_products_supplier ON products (supplier);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_product ON reviews (product);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_user ON reviews (user);
+
+CREATE INDEX IF NOT EXISTS idx_shipments_order ON shipments (order);
+
+CREATE INDEX IF NOT EXISTS idx_support_tickets_user ON support_tickets (user);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_order ON transactions (order);
+
+CREATE INDEX IF NOT EXISTS idx_wishlist_items_product ON wishlist_items (product);
+
+CREATE INDEX IF NOT EXISTS idx_wishlist_items_wishlist ON wishlist_items (wishlist);
+
+CREATE INDEX IF NOT EXISTS idx_wishlists_user ON wishlists (user);
+
+ALTER TABLE audit_logs ADD CONSTRAINT fk_audit_logs_user FOREIGN KEY (user) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE inventory_stocks ADD CONSTRAINT fk_inventory_stocks_product FOREIGN KEY (product) REFERENCES products (id) ON DELETE SET NULL;
+
+ALTER TABLE inventory_stocks ADD CONSTRAINT fk_inventory_stocks_warehouse FOREIGN KEY (warehouse) REFERENCES warehouses (id) ON DELETE SET NULL;
+
+ALTER TABLE invoices ADD CONSTRAINT fk_invoices_order FOREIGN KEY (order) REFERENCES orders (id) ON DELETE SET NULL;
+
+ALTER TABLE order_items ADD CONSTRAINT fk_order_items_order FOREIGN KEY (order) REFERENCES orders (id) ON DELETE SET NULL;
+
+ALTER TABLE order_items ADD CONSTRAINT fk_order_items_product FOREIGN KEY (product) REFERENCES products (id) ON DELETE SET NULL;
+
+ALTER TABLE orders ADD CONSTRAINT fk_orders_coupon FOREIGN KEY (coupon) REFERENCES coupons (id) ON DELETE SET NULL;
+
+ALTER TABLE orders ADD CONSTRAINT fk_orders_customer FOREIGN KEY (customer) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE orders ADD CONSTRAINT fk_orders_payment FOREIGN KEY (payment) REFERENCES transactions (id) ON DELETE SET NULL;
+
+ALTER TABLE products ADD CONSTRAINT fk_products_category FOREIGN KEY (category) REFERENCES categories (id) ON DELETE SET NULL;
+
+ALTER TABLE products ADD CONSTRAINT fk_products_supplier FOREIGN KEY (supplier) REFERENCES suppliers (id) ON DELETE SET NULL;
+
+ALTER TABLE reviews ADD CONSTRAINT fk_reviews_product FOREIGN KEY (product) REFERENCES products (id) ON DELETE SET NULL;
+
+ALTER TABLE reviews ADD CONSTRAINT fk_reviews_user FOREIGN KEY (user) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE shipments ADD CONSTRAINT fk_shipments_order FOREIGN KEY (order) REFERENCES orders (id) ON DELETE SET NULL;
+
+ALTER TABLE support_tickets ADD CONSTRAINT fk_support_tickets_user FOREIGN KEY (user) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE transactions ADD CONSTRAINT fk_transactions_order FOREIGN KEY (order) REFERENCES orders (id) ON DELETE SET NULL;
+
+ALTER TABLE wishlist_items ADD CONSTRAINT fk_wishlist_items_product FOREIGN KEY (product) REFERENCES products (id) ON DELETE SET NULL;
+
+ALTER TABLE wishlist_items ADD CONSTRAINT fk_wishlist_items_wishlist FOREIGN KEY (wishlist) REFERENCES wishlists (id) ON DELETE SET NULL;
+
+ALTER TABLE wishlists ADD CONSTRAINT fk_wishlists_user FOREIGN KEY (user) REFERENCES users (id) ON DELETE SET NULL;
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type Order_itemsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOrder_itemsRepository expects the order_items table to exist; see migrations.go
+func NewOrder_itemsRepository(repo *PostgresRepository) *Order_itemsRepository {
+	return &Order_itemsRepository{db: repo.Pool}
+}
+
+func (r *Order_itemsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Order_items, error) {
+	query, args, err := listSQL("SELECT id, discount, COALESCE(order, '') AS order, COALESCE(product, '') AS product, quantity, total, unit_price FROM order_items", "order_items", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Order_items
+	for rows.Next() {
+		var m domain.Order_items
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Discount)
+
+		fields = append(fields, &m.Order)
+
+		fields = append(fields, &m.Product)
+
+		fields = append(fields, &m.Quantity)
+
+		fields = append(fields, &m.Total)
+
+		fields = append(fields, &m.UnitPrice)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *Order_itemsRepository) Get(ctx context.Context, id string) (*domain.Order_items, error) {
+	var m domain.Order_items
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Discount)
+
+	fields = append(fields, &m.Order)
+
+	fields = append(fields, &m.Product)
+
+	fields = append(fields, &m.Quantity)
+
+	fields = append(fields, &m.Total)
+
+	fields = append(fields, &m.UnitPrice)
+
+	query := "SELECT id, discount, COALESCE(order, '') AS order, COALESCE(product, '') AS product, quantity, total, unit_price FROM order_items WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *Order_itemsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Order_items, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, discount, COALESCE(order, '') AS order, COALESCE(product, '') AS product, quantity, total, unit_price FROM order_items WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Order_items
+	for rows.Next() {
+		var m domain.Order_items
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Discount)
+
+		fields = append(fields, &m.Order)
+
+		fields = append(fields, &m.Product)
+
+		fields = append(fields, &m.Quantity)
+
+		fields = append(fields, &m.Total)
+
+		fields = append(fields, &m.UnitPrice)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *Order_itemsRepository) Create(ctx context.Context, m *domain.Order_items) (string, error) {
+	query := "INSERT INTO order_items (discount, order, product, quantity, total, unit_price) VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6) RETURNING id"
+
+	values := []interface{}{
+		m.Discount,
+		m.Order,
+		m.Product,
+		m.Quantity,
+		m.Total,
+		m.UnitPrice,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *Order_itemsRepository) Update(ctx context.Context, id string, m *domain.Order_items) error {
+
+	query := "UPDATE order_items SET discount = $1, order = NULLIF($2, ''), product = NULLIF($3, ''), quantity = $4, total = $5, unit_price = $6 WHERE id = $7"
+
+	values := []interface{}{
+		m.Discount,
+		m.Order,
+		m.Product,
+		m.Quantity,
+		m.Total,
+		m.UnitPrice,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *Order_itemsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM order_items WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
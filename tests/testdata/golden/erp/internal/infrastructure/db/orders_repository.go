@@ -0,0 +1,203 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type OrdersRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOrdersRepository expects the orders table to exist; see migrations.go
+func NewOrdersRepository(repo *PostgresRepository) *OrdersRepository {
+	return &OrdersRepository{db: repo.Pool}
+}
+
+func (r *OrdersRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Orders, error) {
+	query, args, err := listSQL("SELECT id, COALESCE(coupon, '') AS coupon, COALESCE(customer, '') AS customer, ARRAY(SELECT id FROM invoices WHERE order = orders.id) AS invoice, ARRAY(SELECT id FROM order_items WHERE order = orders.id) AS items, order_number, COALESCE(payment, '') AS payment, placed_at, ARRAY(SELECT id FROM shipments WHERE order = orders.id) AS shipment, shipping_address, status, tax_amount, total_amount FROM orders", "orders", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Orders
+	for rows.Next() {
+		var m domain.Orders
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Coupon)
+
+		fields = append(fields, &m.Customer)
+
+		fields = append(fields, &m.Invoice)
+
+		fields = append(fields, &m.Items)
+
+		fields = append(fields, &m.OrderNumber)
+
+		fields = append(fields, &m.Payment)
+
+		fields = append(fields, &m.PlacedAt)
+
+		fields = append(fields, &m.Shipment)
+
+		fields = append(fields, &m.ShippingAddress)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.TaxAmount)
+
+		fields = append(fields, &m.TotalAmount)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *OrdersRepository) Get(ctx context.Context, id string) (*domain.Orders, error) {
+	var m domain.Orders
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Coupon)
+
+	fields = append(fields, &m.Customer)
+
+	fields = append(fields, &m.Invoice)
+
+	fields = append(fields, &m.Items)
+
+	fields = append(fields, &m.OrderNumber)
+
+	fields = append(fields, &m.Payment)
+
+	fields = append(fields, &m.PlacedAt)
+
+	fields = append(fields, &m.Shipment)
+
+	fields = append(fields, &m.ShippingAddress)
+
+	fields = append(fields, &m.Status)
+
+	fields = append(fields, &m.TaxAmount)
+
+	fields = append(fields, &m.TotalAmount)
+
+	query := "SELECT id, COALESCE(coupon, '') AS coupon, COALESCE(customer, '') AS customer, ARRAY(SELECT id FROM invoices WHERE order = orders.id) AS invoice, ARRAY(SELECT id FROM order_items WHERE order = orders.id) AS items, order_number, COALESCE(payment, '') AS payment, placed_at, ARRAY(SELECT id FROM shipments WHERE order = orders.id) AS shipment, shipping_address, status, tax_amount, total_amount FROM orders WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *OrdersRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Orders, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, COALESCE(coupon, '') AS coupon, COALESCE(customer, '') AS customer, ARRAY(SELECT id FROM invoices WHERE order = orders.id) AS invoice, ARRAY(SELECT id FROM order_items WHERE order = orders.id) AS items, order_number, COALESCE(payment, '') AS payment, placed_at, ARRAY(SELECT id FROM shipments WHERE order = orders.id) AS shipment, shipping_address, status, tax_amount, total_amount FROM orders WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Orders
+	for rows.Next() {
+		var m domain.Orders
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Coupon)
+
+		fields = append(fields, &m.Customer)
+
+		fields = append(fields, &m.Invoice)
+
+		fields = append(fields, &m.Items)
+
+		fields = append(fields, &m.OrderNumber)
+
+		fields = append(fields, &m.Payment)
+
+		fields = append(fields, &m.PlacedAt)
+
+		fields = append(fields, &m.Shipment)
+
+		fields = append(fields, &m.ShippingAddress)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.TaxAmount)
+
+		fields = append(fields, &m.TotalAmount)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *OrdersRepository) Create(ctx context.Context, m *domain.Orders) (string, error) {
+	query := "INSERT INTO orders (coupon, customer, order_number, payment, placed_at, shipping_address, status, tax_amount, total_amount) VALUES (NULLIF($1, ''), NULLIF($2, ''), $3, NULLIF($4, ''), $5, $6, $7, $8, $9) RETURNING id"
+
+	values := []interface{}{
+		m.Coupon,
+		m.Customer,
+		m.OrderNumber,
+		m.Payment,
+		m.PlacedAt,
+		m.ShippingAddress,
+		m.Status,
+		m.TaxAmount,
+		m.TotalAmount,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *OrdersRepository) Update(ctx context.Context, id string, m *domain.Orders) error {
+
+	query := "UPDATE orders SET coupon = NULLIF($1, ''), customer = NULLIF($2, ''), order_number = $3, payment = NULLIF($4, ''), placed_at = $5, shipping_address = $6, status = $7, tax_amount = $8, total_amount = $9 WHERE id = $10"
+
+	values := []interface{}{
+		m.Coupon,
+		m.Customer,
+		m.OrderNumber,
+		m.Payment,
+		m.PlacedAt,
+		m.ShippingAddress,
+		m.Status,
+		m.TaxAmount,
+		m.TotalAmount,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *OrdersRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM orders WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
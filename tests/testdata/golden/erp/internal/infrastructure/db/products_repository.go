@@ -0,0 +1,219 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type ProductsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewProductsRepository expects the products table to exist; see migrations.go
+func NewProductsRepository(repo *PostgresRepository) *ProductsRepository {
+	return &ProductsRepository{db: repo.Pool}
+}
+
+func (r *ProductsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Products, error) {
+	query, args, err := listSQL("SELECT id, COALESCE(category, '') AS category, cost_price, created_at, description, dimensions, ARRAY(SELECT id FROM inventory_stocks WHERE product = products.id) AS inventory, is_active, name, ARRAY(SELECT id FROM order_items WHERE product = products.id) AS order_items, price, ARRAY(SELECT id FROM reviews WHERE product = products.id) AS reviews, sku, COALESCE(supplier, '') AS supplier, weight FROM products", "products", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Products
+	for rows.Next() {
+		var m domain.Products
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Category)
+
+		fields = append(fields, &m.CostPrice)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Description)
+
+		fields = append(fields, &m.Dimensions)
+
+		fields = append(fields, &m.Inventory)
+
+		fields = append(fields, &m.IsActive)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.OrderItems)
+
+		fields = append(fields, &m.Price)
+
+		fields = append(fields, &m.Reviews)
+
+		fields = append(fields, &m.Sku)
+
+		fields = append(fields, &m.Supplier)
+
+		fields = append(fields, &m.Weight)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *ProductsRepository) Get(ctx context.Context, id string) (*domain.Products, error) {
+	var m domain.Products
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Category)
+
+	fields = append(fields, &m.CostPrice)
+
+	fields = append(fields, &m.CreatedAt)
+
+	fields = append(fields, &m.Description)
+
+	fields = append(fields, &m.Dimensions)
+
+	fields = append(fields, &m.Inventory)
+
+	fields = append(fields, &m.IsActive)
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.OrderItems)
+
+	fields = append(fields, &m.Price)
+
+	fields = append(fields, &m.Reviews)
+
+	fields = append(fields, &m.Sku)
+
+	fields = append(fields, &m.Supplier)
+
+	fields = append(fields, &m.Weight)
+
+	query := "SELECT id, COALESCE(category, '') AS category, cost_price, created_at, description, dimensions, ARRAY(SELECT id FROM inventory_stocks WHERE product = products.id) AS inventory, is_active, name, ARRAY(SELECT id FROM order_items WHERE product = products.id) AS order_items, price, ARRAY(SELECT id FROM reviews WHERE product = products.id) AS reviews, sku, COALESCE(supplier, '') AS supplier, weight FROM products WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *ProductsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Products, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, COALESCE(category, '') AS category, cost_price, created_at, description, dimensions, ARRAY(SELECT id FROM inventory_stocks WHERE product = products.id) AS inventory, is_active, name, ARRAY(SELECT id FROM order_items WHERE product = products.id) AS order_items, price, ARRAY(SELECT id FROM reviews WHERE product = products.id) AS reviews, sku, COALESCE(supplier, '') AS supplier, weight FROM products WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Products
+	for rows.Next() {
+		var m domain.Products
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Category)
+
+		fields = append(fields, &m.CostPrice)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Description)
+
+		fields = append(fields, &m.Dimensions)
+
+		fields = append(fields, &m.Inventory)
+
+		fields = append(fields, &m.IsActive)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.OrderItems)
+
+		fields = append(fields, &m.Price)
+
+		fields = append(fields, &m.Reviews)
+
+		fields = append(fields, &m.Sku)
+
+		fields = append(fields, &m.Supplier)
+
+		fields = append(fields, &m.Weight)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *ProductsRepository) Create(ctx context.Context, m *domain.Products) (string, error) {
+	query := "INSERT INTO products (category, cost_price, created_at, description, dimensions, is_active, name, price, sku, supplier, weight) VALUES (NULLIF($1, ''), $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11) RETURNING id"
+
+	values := []interface{}{
+		m.Category,
+		m.CostPrice,
+		m.CreatedAt,
+		m.Description,
+		m.Dimensions,
+		m.IsActive,
+		m.Name,
+		m.Price,
+		m.Sku,
+		m.Supplier,
+		m.Weight,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *ProductsRepository) Update(ctx context.Context, id string, m *domain.Products) error {
+
+	query := "UPDATE products SET category = NULLIF($1, ''), cost_price = $2, created_at = $3, description = $4, dimensions = $5, is_active = $6, name = $7, price = $8, sku = $9, supplier = NULLIF($10, ''), weight = $11 WHERE id = $12"
+
+	values := []interface{}{
+		m.Category,
+		m.CostPrice,
+		m.CreatedAt,
+		m.Description,
+		m.Dimensions,
+		m.IsActive,
+		m.Name,
+		m.Price,
+		m.Sku,
+		m.Supplier,
+		m.Weight,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *ProductsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM products WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type ShipmentsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewShipmentsRepository expects the shipments table to exist; see migrations.go
+func NewShipmentsRepository(repo *PostgresRepository) *ShipmentsRepository {
+	return &ShipmentsRepository{db: repo.Pool}
+}
+
+func (r *ShipmentsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Shipments, error) {
+	query, args, err := listSQL("SELECT id, carrier, estimated_arrival, COALESCE(order, '') AS order, shipped_at, status, tracking_number, weight FROM shipments", "shipments", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Shipments
+	for rows.Next() {
+		var m domain.Shipments
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Carrier)
+
+		fields = append(fields, &m.EstimatedArrival)
+
+		fields = append(fields, &m.Order)
+
+		fields = append(fields, &m.ShippedAt)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.TrackingNumber)
+
+		fields = append(fields, &m.Weight)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *ShipmentsRepository) Get(ctx context.Context, id string) (*domain.Shipments, error) {
+	var m domain.Shipments
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Carrier)
+
+	fields = append(fields, &m.EstimatedArrival)
+
+	fields = append(fields, &m.Order)
+
+	fields = append(fields, &m.ShippedAt)
+
+	fields = append(fields, &m.Status)
+
+	fields = append(fields, &m.TrackingNumber)
+
+	fields = append(fields, &m.Weight)
+
+	query := "SELECT id, carrier, estimated_arrival, COALESCE(order, '') AS order, shipped_at, status, tracking_number, weight FROM shipments WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *ShipmentsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Shipments, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, carrier, estimated_arrival, COALESCE(order, '') AS order, shipped_at, status, tracking_number, weight FROM shipments WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Shipments
+	for rows.Next() {
+		var m domain.Shipments
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Carrier)
+
+		fields = append(fields, &m.EstimatedArrival)
+
+		fields = append(fields, &m.Order)
+
+		fields = append(fields, &m.ShippedAt)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.TrackingNumber)
+
+		fields = append(fields, &m.Weight)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *ShipmentsRepository) Create(ctx context.Context, m *domain.Shipments) (string, error) {
+	query := "INSERT INTO shipments (carrier, estimated_arrival, order, shipped_at, status, tracking_number, weight) VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7) RETURNING id"
+
+	values := []interface{}{
+		m.Carrier,
+		m.EstimatedArrival,
+		m.Order,
+		m.ShippedAt,
+		m.Status,
+		m.TrackingNumber,
+		m.Weight,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *ShipmentsRepository) Update(ctx context.Context, id string, m *domain.Shipments) error {
+
+	query := "UPDATE shipments SET carrier = $1, estimated_arrival = $2, order = NULLIF($3, ''), shipped_at = $4, status = $5, tracking_number = $6, weight = $7 WHERE id = $8"
+
+	values := []interface{}{
+		m.Carrier,
+		m.EstimatedArrival,
+		m.Order,
+		m.ShippedAt,
+		m.Status,
+		m.TrackingNumber,
+		m.Weight,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *ShipmentsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM shipments WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,177 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type TransactionsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewTransactionsRepository expects the transactions table to exist; see migrations.go
+func NewTransactionsRepository(repo *PostgresRepository) *TransactionsRepository {
+	return &TransactionsRepository{db: repo.Pool}
+}
+
+func (r *TransactionsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Transactions, error) {
+	query, args, err := listSQL("SELECT id, amount, created_at, currency, COALESCE(order, '') AS order, payload, provider, provider_id, status FROM transactions", "transactions", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Transactions
+	for rows.Next() {
+		var m domain.Transactions
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Amount)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Currency)
+
+		fields = append(fields, &m.Order)
+
+		fields = append(fields, &m.Payload)
+
+		fields = append(fields, &m.Provider)
+
+		fields = append(fields, &m.ProviderId)
+
+		fields = append(fields, &m.Status)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *TransactionsRepository) Get(ctx context.Context, id string) (*domain.Transactions, error) {
+	var m domain.Transactions
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Amount)
+
+	fields = append(fields, &m.CreatedAt)
+
+	fields = append(fields, &m.Currency)
+
+	fields = append(fields, &m.Order)
+
+	fields = append(fields, &m.Payload)
+
+	fields = append(fields, &m.Provider)
+
+	fields = append(fields, &m.ProviderId)
+
+	fields = append(fields, &m.Status)
+
+	query := "SELECT id, amount, created_at, currency, COALESCE(order, '') AS order, payload, provider, provider_id, status FROM transactions WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *TransactionsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Transactions, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, amount, created_at, currency, COALESCE(order, '') AS order, payload, provider, provider_id, status FROM transactions WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Transactions
+	for rows.Next() {
+		var m domain.Transactions
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Amount)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Currency)
+
+		fields = append(fields, &m.Order)
+
+		fields = append(fields, &m.Payload)
+
+		fields = append(fields, &m.Provider)
+
+		fields = append(fields, &m.ProviderId)
+
+		fields = append(fields, &m.Status)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *TransactionsRepository) Create(ctx context.Context, m *domain.Transactions) (string, error) {
+	query := "INSERT INTO transactions (amount, created_at, currency, order, payload, provider, provider_id, status) VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8) RETURNING id"
+
+	values := []interface{}{
+		m.Amount,
+		m.CreatedAt,
+		m.Currency,
+		m.Order,
+		m.Payload,
+		m.Provider,
+		m.ProviderId,
+		m.Status,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *TransactionsRepository) Update(ctx context.Context, id string, m *domain.Transactions) error {
+
+	query := "UPDATE transactions SET amount = $1, created_at = $2, currency = $3, order = NULLIF($4, ''), payload = $5, provider = $6, provider_id = $7, status = $8 WHERE id = $9"
+
+	values := []interface{}{
+		m.Amount,
+		m.CreatedAt,
+		m.Currency,
+		m.Order,
+		m.Payload,
+		m.Provider,
+		m.ProviderId,
+		m.Status,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *TransactionsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM transactions WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,239 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type UsersRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewUsersRepository expects the users table to exist; see migrations.go
+func NewUsersRepository(repo *PostgresRepository) *UsersRepository {
+	return &UsersRepository{db: repo.Pool}
+}
+
+func (r *UsersRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Users, error) {
+	query, args, err := listSQL("SELECT id, address, ARRAY(SELECT id FROM audit_logs WHERE user = users.id) AS audit_logs, created_at, email, full_name, is_verified, last_login, name, ARRAY(SELECT id FROM orders WHERE customer = users.id) AS orders, phone, picture, ARRAY(SELECT id FROM reviews WHERE user = users.id) AS reviews, role_id, ARRAY(SELECT id FROM support_tickets WHERE user = users.id) AS tickets, uid, updated_at, ARRAY(SELECT id FROM wishlists WHERE user = users.id) AS wishlists FROM users", "users", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Users
+	for rows.Next() {
+		var m domain.Users
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Address)
+
+		fields = append(fields, &m.AuditLogs)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Email)
+
+		fields = append(fields, &m.FullName)
+
+		fields = append(fields, &m.IsVerified)
+
+		fields = append(fields, &m.LastLogin)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Orders)
+
+		fields = append(fields, &m.Phone)
+
+		fields = append(fields, &m.Picture)
+
+		fields = append(fields, &m.Reviews)
+
+		fields = append(fields, &m.RoleId)
+
+		fields = append(fields, &m.Tickets)
+
+		fields = append(fields, &m.Uid)
+
+		fields = append(fields, &m.UpdatedAt)
+
+		fields = append(fields, &m.Wishlists)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *UsersRepository) Get(ctx context.Context, id string) (*domain.Users, error) {
+	var m domain.Users
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Address)
+
+	fields = append(fields, &m.AuditLogs)
+
+	fields = append(fields, &m.CreatedAt)
+
+	fields = append(fields, &m.Email)
+
+	fields = append(fields, &m.FullName)
+
+	fields = append(fields, &m.IsVerified)
+
+	fields = append(fields, &m.LastLogin)
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.Orders)
+
+	fields = append(fields, &m.Phone)
+
+	fields = append(fields, &m.Picture)
+
+	fields = append(fields, &m.Reviews)
+
+	fields = append(fields, &m.RoleId)
+
+	fields = append(fields, &m.Tickets)
+
+	fields = append(fields, &m.Uid)
+
+	fields = append(fields, &m.UpdatedAt)
+
+	fields = append(fields, &m.Wishlists)
+
+	query := "SELECT id, address, ARRAY(SELECT id FROM audit_logs WHERE user = users.id) AS audit_logs, created_at, email, full_name, is_verified, last_login, name, ARRAY(SELECT id FROM orders WHERE customer = users.id) AS orders, phone, picture, ARRAY(SELECT id FROM reviews WHERE user = users.id) AS reviews, role_id, ARRAY(SELECT id FROM support_tickets WHERE user = users.id) AS tickets, uid, updated_at, ARRAY(SELECT id FROM wishlists WHERE user = users.id) AS wishlists FROM users WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *UsersRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Users, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, address, ARRAY(SELECT id FROM audit_logs WHERE user = users.id) AS audit_logs, created_at, email, full_name, is_verified, last_login, name, ARRAY(SELECT id FROM orders WHERE customer = users.id) AS orders, phone, picture, ARRAY(SELECT id FROM reviews WHERE user = users.id) AS reviews, role_id, ARRAY(SELECT id FROM support_tickets WHERE user = users.id) AS tickets, uid, updated_at, ARRAY(SELECT id FROM wishlists WHERE user = users.id) AS wishlists FROM users WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Users
+	for rows.Next() {
+		var m domain.Users
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Address)
+
+		fields = append(fields, &m.AuditLogs)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Email)
+
+		fields = append(fields, &m.FullName)
+
+		fields = append(fields, &m.IsVerified)
+
+		fields = append(fields, &m.LastLogin)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Orders)
+
+		fields = append(fields, &m.Phone)
+
+		fields = append(fields, &m.Picture)
+
+		fields = append(fields, &m.Reviews)
+
+		fields = append(fields, &m.RoleId)
+
+		fields = append(fields, &m.Tickets)
+
+		fields = append(fields, &m.Uid)
+
+		fields = append(fields, &m.UpdatedAt)
+
+		fields = append(fields, &m.Wishlists)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *UsersRepository) Create(ctx context.Context, m *domain.Users) (string, error) {
+	query := "INSERT INTO users (address, created_at, email, full_name, is_verified, last_login, name, phone, picture, role_id, uid, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id"
+
+	values := []interface{}{
+		m.Address,
+		m.CreatedAt,
+		m.Email,
+		m.FullName,
+		m.IsVerified,
+		m.LastLogin,
+		m.Name,
+		m.Phone,
+		m.Picture,
+		m.RoleId,
+		m.Uid,
+		m.UpdatedAt,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *UsersRepository) Update(ctx context.Context, id string, m *domain.Users) error {
+
+	query := "UPDATE users SET address = $1, created_at = $2, email = $3, full_name = $4, is_verified = $5, last_login = $6, name = $7, phone = $8, picture = $9, role_id = $10, uid = $11, updated_at = $12 WHERE id = $13"
+
+	values := []interface{}{
+		m.Address,
+		m.CreatedAt,
+		m.Email,
+		m.FullName,
+		m.IsVerified,
+		m.LastLogin,
+		m.Name,
+		m.Phone,
+		m.Picture,
+		m.RoleId,
+		m.Uid,
+		m.UpdatedAt,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *UsersRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM users WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"EnterpriseERP/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type WarehousesRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWarehousesRepository expects the warehouses table to exist; see migrations.go
+func NewWarehousesRepository(repo *PostgresRepository) *WarehousesRepository {
+	return &WarehousesRepository{db: repo.Pool}
+}
+
+func (r *WarehousesRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Warehouses, error) {
+	query, args, err := listSQL("SELECT id, address, capacity, location_code, manager_name, name, ARRAY(SELECT id FROM inventory_stocks WHERE warehouse = warehouses.id) AS stocks FROM warehouses", "warehouses", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Warehouses
+	for rows.Next() {
+		var m domain.Warehouses
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Address)
+
+		fields = append(fields, &m.Capacity)
+
+		fields = append(fields, &m.LocationCode)
+
+		fields = append(fields, &m.ManagerName)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Stocks)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *WarehousesRepository) Get(ctx context.Context, id string) (*domain.Warehouses, error) {
+	var m domain.Warehouses
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Address)
+
+	fields = append(fields, &m.Capacity)
+
+	fields = append(fields, &m.LocationCode)
+
+	fields = append(fields, &m.ManagerName)
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.Stocks)
+
+	query := "SELECT id, address, capacity, location_code, manager_name, name, ARRAY(SELECT id FROM inventory_stocks WHERE warehouse = warehouses.id) AS stocks FROM warehouses WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *WarehousesRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Warehouses, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, address, capacity, location_code, manager_name, name, ARRAY(SELECT id FROM inventory_stocks WHERE warehouse = warehouses.id) AS stocks FROM warehouses WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Warehouses
+	for rows.Next() {
+		var m domain.Warehouses
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Address)
+
+		fields = append(fields, &m.Capacity)
+
+		fields = append(fields, &m.LocationCode)
+
+		fields = append(fields, &m.ManagerName)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Stocks)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *WarehousesRepository) Create(ctx context.Context, m *domain.Warehouses) (string, error) {
+	query := "INSERT INTO warehouses (address, capacity, location_code, manager_name, name) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+
+	values := []interface{}{
+		m.Address,
+		m.Capacity,
+		m.LocationCode,
+		m.ManagerName,
+		m.Name,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *WarehousesRepository) Update(ctx context.Context, id string, m *domain.Warehouses) error {
+
+	query := "UPDATE warehouses SET address = $1, capacity = $2, location_code = $3, manager_name = $4, name = $5 WHERE id = $6"
+
+	values := []interface{}{
+		m.Address,
+		m.Capacity,
+		m.LocationCode,
+		m.ManagerName,
+		m.Name,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *WarehousesRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM warehouses WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
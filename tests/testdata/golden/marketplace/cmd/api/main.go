@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"MarketplaceAPI/docs"
+	authService "MarketplaceAPI/internal/auth"
+	"MarketplaceAPI/internal/expand"
+	authHandler "MarketplaceAPI/internal/handlers/auth"
+	"MarketplaceAPI/internal/handlers/categories"
+	"MarketplaceAPI/internal/handlers/order_items"
+	"MarketplaceAPI/internal/handlers/orders"
+	"MarketplaceAPI/internal/handlers/products"
+	"MarketplaceAPI/internal/handlers/reviews"
+	"MarketplaceAPI/internal/handlers/transactions"
+	"MarketplaceAPI/internal/handlers/users"
+	"MarketplaceAPI/internal/infrastructure/db"
+	paymentService "MarketplaceAPI/internal/payments"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+func main() {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Initialize Database
+
+	baseRepo, err := db.NewPostgresRepository(os.Getenv("DATABASE_URL"))
+
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer baseRepo.Close()
+
+	// Module auth
+	var authSvc authService.AuthService
+	if os.Getenv("MOCK_AUTH") == "true" {
+		log.Println("Using Mock Auth Service")
+		authSvc = &authService.MockAuthService{}
+	} else {
+		app, err := firebase.NewApp(context.Background(), &firebase.Config{ProjectID: ""})
+		if err != nil {
+			log.Fatalf("error initializing app: %v\n", err)
+		}
+		authClient, err := app.Auth(context.Background())
+		if err != nil {
+			log.Fatalf("error getting Auth client: %v\n", err)
+		}
+		authSvc = &authService.FirebaseAuthService{Client: authClient}
+	}
+	userRepo := db.NewUsersRepository(baseRepo.(*db.PostgresRepository))
+	userHdl := authHandler.NewUserHandler(authSvc, userRepo, "users")
+
+	// Module payments
+	paymentRepo := db.NewTransactionsRepository(baseRepo.(*db.PostgresRepository))
+	mpService := paymentService.NewMercadoPagoService(paymentRepo)
+
+	// Setup Router
+	r := gin.Default()
+
+	// Module auth
+	authGroup := r.Group("/auth")
+	authGroup.POST("/login", authService.AuthMiddleware(authSvc), userHdl.Login)
+	authGroup.GET("/me", authService.AuthMiddleware(authSvc), userHdl.GetMe)
+	authGroup.GET("/roles", authService.AuthMiddleware(authSvc), userHdl.GetRoles)
+
+	// Module payments
+	paymentGroup := r.Group("/payments")
+	paymentGroup.POST("/mercadopago/preference", mpService.CreatePreferenceHandler)
+	paymentGroup.POST("/mercadopago/webhook", mpService.HandleWebhook)
+
+	// API docs: the OpenAPI document generated from the blueprint, browsable with Swagger UI
+	r.GET("/openapi.yaml", func(c *gin.Context) {
+		c.Data(200, "application/yaml", docs.OpenAPI)
+	})
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/openapi.yaml")))
+
+	// Related records for ?include=, registered by each model below
+	expander := expand.New()
+
+	// Routes for users
+	{
+
+		repo := db.NewUsersRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("users", expand.Fetch(repo.GetMany))
+		handler := users.NewUsersHandler(repo, expander)
+
+		group := r.Group("/api/users")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for products
+	{
+
+		repo := db.NewProductsRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("products", expand.Fetch(repo.GetMany))
+		handler := products.NewProductsHandler(repo, expander)
+
+		group := r.Group("/api/products")
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for categories
+	{
+
+		repo := db.NewCategoriesRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("categories", expand.Fetch(repo.GetMany))
+		handler := categories.NewCategoriesHandler(repo, expander)
+
+		group := r.Group("/api/categories")
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for orders
+	{
+
+		repo := db.NewOrdersRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("orders", expand.Fetch(repo.GetMany))
+		handler := orders.NewOrdersHandler(repo, expander)
+
+		group := r.Group("/api/orders")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for order_items
+	{
+
+		repo := db.NewOrder_itemsRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("order_items", expand.Fetch(repo.GetMany))
+		handler := order_items.NewOrder_itemsHandler(repo, expander)
+
+		group := r.Group("/api/order_items")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for reviews
+	{
+
+		repo := db.NewReviewsRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("reviews", expand.Fetch(repo.GetMany))
+		handler := reviews.NewReviewsHandler(repo, expander)
+
+		group := r.Group("/api/reviews")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for transactions
+	{
+
+		repo := db.NewTransactionsRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("transactions", expand.Fetch(repo.GetMany))
+		handler := transactions.NewTransactionsHandler(repo, expander)
+
+		group := r.Group("/api/transactions")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// blueprint:custom-begin routes
+	// blueprint:custom-end routes
+
+	log.Printf("Starting server for project: MarketplaceAPI")
+	r.Run(":8080")
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+)
+
+type Categories struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Description string `json:"description" bson:"description" firestore:"description"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	Products []string `json:"products" bson:"products" firestore:"products"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Categories) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Categories) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// CategoriesFields maps the fields List can filter, sort and select on to their blueprint types
+var CategoriesFields = map[string]string{
+	"id":          "string",
+	"description": "string",
+	"name":        "string",
+	"products":    "array",
+}
+
+type CategoriesRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Categories, error)
+	Get(ctx context.Context, id string) (*Categories, error)
+	GetMany(ctx context.Context, ids []string) ([]*Categories, error)
+	Create(ctx context.Context, model *Categories) (string, error)
+	Update(ctx context.Context, id string, model *Categories) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+)
+
+type Order_items struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Price float64 `json:"price" bson:"price" firestore:"price"`
+
+	Quantity int `json:"quantity" bson:"quantity" firestore:"quantity"`
+
+	OrderId string `json:"order_id" bson:"order_id" firestore:"order_id"`
+
+	ProductId string `json:"product_id" bson:"product_id" firestore:"product_id"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Order_items) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Order_items) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// Order_itemsFields maps the fields List can filter, sort and select on to their blueprint types
+var Order_itemsFields = map[string]string{
+	"id":         "string",
+	"price":      "float",
+	"quantity":   "integer",
+	"order_id":   "string",
+	"product_id": "string",
+}
+
+type Order_itemsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Order_items, error)
+	Get(ctx context.Context, id string) (*Order_items, error)
+	GetMany(ctx context.Context, ids []string) ([]*Order_items, error)
+	Create(ctx context.Context, model *Order_items) (string, error)
+	Update(ctx context.Context, id string, model *Order_items) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Orders struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	Status string `json:"status" bson:"status" firestore:"status"`
+
+	Total float64 `json:"total" bson:"total" firestore:"total"`
+
+	Items []string `json:"items" bson:"items" firestore:"items"`
+
+	TransactionId string `json:"transaction_id" bson:"transaction_id" firestore:"transaction_id"`
+
+	UserId string `json:"user_id" bson:"user_id" firestore:"user_id"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Orders) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Orders) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// OrdersFields maps the fields List can filter, sort and select on to their blueprint types
+var OrdersFields = map[string]string{
+	"id":             "string",
+	"created_at":     "datetime",
+	"status":         "string",
+	"total":          "float",
+	"items":          "array",
+	"transaction_id": "string",
+	"user_id":        "string",
+}
+
+type OrdersRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Orders, error)
+	Get(ctx context.Context, id string) (*Orders, error)
+	GetMany(ctx context.Context, ids []string) ([]*Orders, error)
+	Create(ctx context.Context, model *Orders) (string, error)
+	Update(ctx context.Context, id string, model *Orders) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+)
+
+type Products struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Description string `json:"description" bson:"description" firestore:"description"`
+
+	Name string `json:"name" bson:"name" firestore:"name" binding:"required,max=120"`
+
+	Price *float64 `json:"price" bson:"price" firestore:"price" binding:"required,min=0"`
+
+	Stock int `json:"stock" bson:"stock" firestore:"stock" binding:"omitempty,min=0"`
+
+	CategoryId string `json:"category_id" bson:"category_id" firestore:"category_id"`
+
+	OrderItems []string `json:"order_items" bson:"order_items" firestore:"order_items"`
+
+	Reviews []string `json:"reviews" bson:"reviews" firestore:"reviews"`
+
+	SellerId string `json:"seller_id" bson:"seller_id" firestore:"seller_id"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Products) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Products) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// ProductsFields maps the fields List can filter, sort and select on to their blueprint types
+var ProductsFields = map[string]string{
+	"id":          "string",
+	"description": "string",
+	"name":        "string",
+	"price":       "float",
+	"stock":       "integer",
+	"category_id": "string",
+	"order_items": "array",
+	"reviews":     "array",
+	"seller_id":   "string",
+}
+
+type ProductsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Products, error)
+	Get(ctx context.Context, id string) (*Products, error)
+	GetMany(ctx context.Context, ids []string) ([]*Products, error)
+	Create(ctx context.Context, model *Products) (string, error)
+	Update(ctx context.Context, id string, model *Products) error
+	Delete(ctx context.Context, id string) error
+}
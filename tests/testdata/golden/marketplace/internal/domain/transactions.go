@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Transactions struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Amount float64 `json:"amount" bson:"amount" firestore:"amount"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	Payload string `json:"payload" bson:"payload" firestore:"payload"`
+
+	Provider string `json:"provider" bson:"provider" firestore:"provider"`
+
+	Status string `json:"status" bson:"status" firestore:"status"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Transactions) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Transactions) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// TransactionsFields maps the fields List can filter, sort and select on to their blueprint types
+var TransactionsFields = map[string]string{
+	"id":         "string",
+	"amount":     "float",
+	"created_at": "datetime",
+	"payload":    "text",
+	"provider":   "string",
+	"status":     "string",
+}
+
+type TransactionsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Transactions, error)
+	Get(ctx context.Context, id string) (*Transactions, error)
+	GetMany(ctx context.Context, ids []string) ([]*Transactions, error)
+	Create(ctx context.Context, model *Transactions) (string, error)
+	Update(ctx context.Context, id string, model *Transactions) error
+	Delete(ctx context.Context, id string) error
+}
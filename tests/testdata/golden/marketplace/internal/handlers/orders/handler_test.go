@@ -0,0 +1,114 @@
+package orders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"MarketplaceAPI/internal/domain"
+	"MarketplaceAPI/internal/expand"
+)
+
+type MockOrdersRepository struct {
+	Data map[string]*domain.Orders
+}
+
+func (m *MockOrdersRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Orders, error) {
+	var results []*domain.Orders
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Orders{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockOrdersRepository) Get(ctx context.Context, id string) (*domain.Orders, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockOrdersRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Orders, error) {
+	var results []*domain.Orders
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockOrdersRepository) Create(ctx context.Context, model *domain.Orders) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Orders)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockOrdersRepository) Update(ctx context.Context, id string, model *domain.Orders) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockOrdersRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestOrdersHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockOrdersRepository{Data: make(map[string]*domain.Orders)}
+	handler := NewOrdersHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/orders", handler.List)
+	r.POST("/orders", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Orders{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/orders", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/orders?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/orders?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/orders?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
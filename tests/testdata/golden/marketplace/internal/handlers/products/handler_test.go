@@ -0,0 +1,116 @@
+package products
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"MarketplaceAPI/internal/domain"
+	"MarketplaceAPI/internal/expand"
+)
+
+type MockProductsRepository struct {
+	Data map[string]*domain.Products
+}
+
+func (m *MockProductsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Products, error) {
+	var results []*domain.Products
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Products{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockProductsRepository) Get(ctx context.Context, id string) (*domain.Products, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockProductsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Products, error) {
+	var results []*domain.Products
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockProductsRepository) Create(ctx context.Context, model *domain.Products) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Products)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockProductsRepository) Update(ctx context.Context, id string, model *domain.Products) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockProductsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestProductsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockProductsRepository{Data: make(map[string]*domain.Products)}
+	handler := NewProductsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/products", handler.List)
+	r.POST("/products", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Products{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/products", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		// An empty body misses the required fields
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), `"rule":"required"`)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/products?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/products?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/products?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"MarketplaceAPI/internal/domain"
+	"MarketplaceAPI/internal/expand"
+)
+
+type MockTransactionsRepository struct {
+	Data map[string]*domain.Transactions
+}
+
+func (m *MockTransactionsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Transactions, error) {
+	var results []*domain.Transactions
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Transactions{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockTransactionsRepository) Get(ctx context.Context, id string) (*domain.Transactions, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockTransactionsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Transactions, error) {
+	var results []*domain.Transactions
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockTransactionsRepository) Create(ctx context.Context, model *domain.Transactions) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Transactions)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockTransactionsRepository) Update(ctx context.Context, id string, model *domain.Transactions) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockTransactionsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestTransactionsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockTransactionsRepository{Data: make(map[string]*domain.Transactions)}
+	handler := NewTransactionsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/transactions", handler.List)
+	r.POST("/transactions", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Transactions{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/transactions", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/transactions?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/transactions?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/transactions?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
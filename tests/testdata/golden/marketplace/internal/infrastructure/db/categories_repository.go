@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"MarketplaceAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type CategoriesRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCategoriesRepository expects the categories table to exist; see migrations.go
+func NewCategoriesRepository(repo *PostgresRepository) *CategoriesRepository {
+	return &CategoriesRepository{db: repo.Pool}
+}
+
+func (r *CategoriesRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Categories, error) {
+	query, args, err := listSQL("SELECT id, description, name, ARRAY(SELECT id FROM products WHERE category_id = categories.id) AS products FROM categories", "categories", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Categories
+	for rows.Next() {
+		var m domain.Categories
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Description)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Products)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *CategoriesRepository) Get(ctx context.Context, id string) (*domain.Categories, error) {
+	var m domain.Categories
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Description)
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.Products)
+
+	query := "SELECT id, description, name, ARRAY(SELECT id FROM products WHERE category_id = categories.id) AS products FROM categories WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *CategoriesRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Categories, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, description, name, ARRAY(SELECT id FROM products WHERE category_id = categories.id) AS products FROM categories WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Categories
+	for rows.Next() {
+		var m domain.Categories
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Description)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Products)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *CategoriesRepository) Create(ctx context.Context, m *domain.Categories) (string, error) {
+	query := "INSERT INTO categories (description, name) VALUES ($1, $2) RETURNING id"
+
+	values := []interface{}{
+		m.Description,
+		m.Name,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *CategoriesRepository) Update(ctx context.Context, id string, m *domain.Categories) error {
+
+	query := "UPDATE categories SET description = $1, name = $2 WHERE id = $3"
+
+	values := []interface{}{
+		m.Description,
+		m.Name,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *CategoriesRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM categories WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
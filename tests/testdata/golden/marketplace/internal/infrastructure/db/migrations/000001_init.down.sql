@@ -0,0 +1,45 @@
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS fk_reviews_user_id;
+
+ALTER TABLE reviews DROP CONSTRAINT IF EXISTS fk_reviews_product_id;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS fk_products_seller_id;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS fk_products_category_id;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS fk_orders_user_id;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS fk_orders_transaction_id;
+
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS fk_order_items_product_id;
+
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS fk_order_items_order_id;
+
+DROP INDEX IF EXISTS idx_reviews_user_id;
+
+DROP INDEX IF EXISTS idx_reviews_product_id;
+
+DROP INDEX IF EXISTS idx_products_seller_id;
+
+DROP INDEX IF EXISTS idx_products_category_id;
+
+DROP INDEX IF EXISTS idx_orders_user_id;
+
+DROP INDEX IF EXISTS idx_orders_transaction_id;
+
+DROP INDEX IF EXISTS idx_order_items_product_id;
+
+DROP INDEX IF EXISTS idx_order_items_order_id;
+
+DROP TABLE IF EXISTS users;
+
+DROP TABLE IF EXISTS transactions;
+
+DROP TABLE IF EXISTS reviews;
+
+DROP TABLE IF EXISTS products;
+
+DROP TABLE IF EXISTS orders;
+
+DROP TABLE IF EXISTS order_items;
+
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,94 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    description TEXT,
+    name TEXT
+);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    order_id TEXT,
+    price DOUBLE PRECISION,
+    product_id TEXT,
+    quantity INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    created_at TIMESTAMP,
+    status TEXT,
+    total DOUBLE PRECISION,
+    transaction_id TEXT,
+    user_id TEXT
+);
+
+CREATE TABLE IF NOT EXISTS products (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    category_id TEXT,
+    description TEXT,
+    name TEXT,
+    price DOUBLE PRECISION,
+    seller_id TEXT,
+    stock INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    comment TEXT,
+    created_at TIMESTAMP,
+    product_id TEXT,
+    rating INTEGER,
+    user_id TEXT
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    amount DOUBLE PRECISION,
+    created_at TIMESTAMP,
+    payload TEXT,
+    provider TEXT,
+    status TEXT
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    created_at TIMESTAMP,
+    email TEXT,
+    name TEXT,
+    picture TEXT,
+    role TEXT,
+    role_id TEXT,
+    uid TEXT,
+    updated_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items (order_id);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_product_id ON order_items (product_id);
+
+CREATE INDEX IF NOT EXISTS idx_orders_transaction_id ON orders (transaction_id);
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_id ON orders (user_id);
+
+CREATE INDEX IF NOT EXISTS idx_products_category_id ON products (category_id);
+
+CREATE INDEX IF NOT EXISTS idx_products_seller_id ON products (seller_id);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_product_id ON reviews (product_id);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_user_id ON reviews (user_id);
+
+ALTER TABLE order_items ADD CONSTRAINT fk_order_items_order_id FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE SET NULL;
+
+ALTER TABLE order_items ADD CONSTRAINT fk_order_items_product_id FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE SET NULL;
+
+ALTER TABLE orders ADD CONSTRAINT fk_orders_transaction_id FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE SET NULL;
+
+ALTER TABLE orders ADD CONSTRAINT fk_orders_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE products ADD CONSTRAINT fk_products_category_id FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE SET NULL;
+
+ALTER TABLE products ADD CONSTRAINT fk_products_seller_id FOREIGN KEY (seller_id) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE reviews ADD CONSTRAINT fk_reviews_product_id FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE SET NULL;
+
+ALTER TABLE reviews ADD CONSTRAINT fk_reviews_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL;
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"MarketplaceAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type OrdersRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOrdersRepository expects the orders table to exist; see migrations.go
+func NewOrdersRepository(repo *PostgresRepository) *OrdersRepository {
+	return &OrdersRepository{db: repo.Pool}
+}
+
+func (r *OrdersRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Orders, error) {
+	query, args, err := listSQL("SELECT id, created_at, ARRAY(SELECT id FROM order_items WHERE order_id = orders.id) AS items, status, total, COALESCE(transaction_id, '') AS transaction_id, COALESCE(user_id, '') AS user_id FROM orders", "orders", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Orders
+	for rows.Next() {
+		var m domain.Orders
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Items)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.Total)
+
+		fields = append(fields, &m.TransactionId)
+
+		fields = append(fields, &m.UserId)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *OrdersRepository) Get(ctx context.Context, id string) (*domain.Orders, error) {
+	var m domain.Orders
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.CreatedAt)
+
+	fields = append(fields, &m.Items)
+
+	fields = append(fields, &m.Status)
+
+	fields = append(fields, &m.Total)
+
+	fields = append(fields, &m.TransactionId)
+
+	fields = append(fields, &m.UserId)
+
+	query := "SELECT id, created_at, ARRAY(SELECT id FROM order_items WHERE order_id = orders.id) AS items, status, total, COALESCE(transaction_id, '') AS transaction_id, COALESCE(user_id, '') AS user_id FROM orders WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *OrdersRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Orders, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, created_at, ARRAY(SELECT id FROM order_items WHERE order_id = orders.id) AS items, status, total, COALESCE(transaction_id, '') AS transaction_id, COALESCE(user_id, '') AS user_id FROM orders WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Orders
+	for rows.Next() {
+		var m domain.Orders
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Items)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.Total)
+
+		fields = append(fields, &m.TransactionId)
+
+		fields = append(fields, &m.UserId)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *OrdersRepository) Create(ctx context.Context, m *domain.Orders) (string, error) {
+	query := "INSERT INTO orders (created_at, status, total, transaction_id, user_id) VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, '')) RETURNING id"
+
+	values := []interface{}{
+		m.CreatedAt,
+		m.Status,
+		m.Total,
+		m.TransactionId,
+		m.UserId,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *OrdersRepository) Update(ctx context.Context, id string, m *domain.Orders) error {
+
+	query := "UPDATE orders SET created_at = $1, status = $2, total = $3, transaction_id = NULLIF($4, ''), user_id = NULLIF($5, '') WHERE id = $6"
+
+	values := []interface{}{
+		m.CreatedAt,
+		m.Status,
+		m.Total,
+		m.TransactionId,
+		m.UserId,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *OrdersRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM orders WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,173 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"MarketplaceAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type ProductsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewProductsRepository expects the products table to exist; see migrations.go
+func NewProductsRepository(repo *PostgresRepository) *ProductsRepository {
+	return &ProductsRepository{db: repo.Pool}
+}
+
+func (r *ProductsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Products, error) {
+	query, args, err := listSQL("SELECT id, COALESCE(category_id, '') AS category_id, description, name, ARRAY(SELECT id FROM order_items WHERE product_id = products.id) AS order_items, price, ARRAY(SELECT id FROM reviews WHERE product_id = products.id) AS reviews, COALESCE(seller_id, '') AS seller_id, stock FROM products", "products", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Products
+	for rows.Next() {
+		var m domain.Products
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.CategoryId)
+
+		fields = append(fields, &m.Description)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.OrderItems)
+
+		fields = append(fields, &m.Price)
+
+		fields = append(fields, &m.Reviews)
+
+		fields = append(fields, &m.SellerId)
+
+		fields = append(fields, &m.Stock)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *ProductsRepository) Get(ctx context.Context, id string) (*domain.Products, error) {
+	var m domain.Products
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.CategoryId)
+
+	fields = append(fields, &m.Description)
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.OrderItems)
+
+	fields = append(fields, &m.Price)
+
+	fields = append(fields, &m.Reviews)
+
+	fields = append(fields, &m.SellerId)
+
+	fields = append(fields, &m.Stock)
+
+	query := "SELECT id, COALESCE(category_id, '') AS category_id, description, name, ARRAY(SELECT id FROM order_items WHERE product_id = products.id) AS order_items, price, ARRAY(SELECT id FROM reviews WHERE product_id = products.id) AS reviews, COALESCE(seller_id, '') AS seller_id, stock FROM products WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *ProductsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Products, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, COALESCE(category_id, '') AS category_id, description, name, ARRAY(SELECT id FROM order_items WHERE product_id = products.id) AS order_items, price, ARRAY(SELECT id FROM reviews WHERE product_id = products.id) AS reviews, COALESCE(seller_id, '') AS seller_id, stock FROM products WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Products
+	for rows.Next() {
+		var m domain.Products
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.CategoryId)
+
+		fields = append(fields, &m.Description)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.OrderItems)
+
+		fields = append(fields, &m.Price)
+
+		fields = append(fields, &m.Reviews)
+
+		fields = append(fields, &m.SellerId)
+
+		fields = append(fields, &m.Stock)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *ProductsRepository) Create(ctx context.Context, m *domain.Products) (string, error) {
+	query := "INSERT INTO products (category_id, description, name, price, seller_id, stock) VALUES (NULLIF($1, ''), $2, $3, $4, NULLIF($5, ''), $6) RETURNING id"
+
+	values := []interface{}{
+		m.CategoryId,
+		m.Description,
+		m.Name,
+		m.Price,
+		m.SellerId,
+		m.Stock,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *ProductsRepository) Update(ctx context.Context, id string, m *domain.Products) error {
+
+	query := "UPDATE products SET category_id = NULLIF($1, ''), description = $2, name = $3, price = $4, seller_id = NULLIF($5, ''), stock = $6 WHERE id = $7"
+
+	values := []interface{}{
+		m.CategoryId,
+		m.Description,
+		m.Name,
+		m.Price,
+		m.SellerId,
+		m.Stock,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *ProductsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM products WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"MarketplaceAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type ReviewsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewReviewsRepository expects the reviews table to exist; see migrations.go
+func NewReviewsRepository(repo *PostgresRepository) *ReviewsRepository {
+	return &ReviewsRepository{db: repo.Pool}
+}
+
+func (r *ReviewsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Reviews, error) {
+	query, args, err := listSQL("SELECT id, comment, created_at, COALESCE(product_id, '') AS product_id, rating, COALESCE(user_id, '') AS user_id FROM reviews", "reviews", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Reviews
+	for rows.Next() {
+		var m domain.Reviews
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Comment)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.ProductId)
+
+		fields = append(fields, &m.Rating)
+
+		fields = append(fields, &m.UserId)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *ReviewsRepository) Get(ctx context.Context, id string) (*domain.Reviews, error) {
+	var m domain.Reviews
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Comment)
+
+	fields = append(fields, &m.CreatedAt)
+
+	fields = append(fields, &m.ProductId)
+
+	fields = append(fields, &m.Rating)
+
+	fields = append(fields, &m.UserId)
+
+	query := "SELECT id, comment, created_at, COALESCE(product_id, '') AS product_id, rating, COALESCE(user_id, '') AS user_id FROM reviews WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *ReviewsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Reviews, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, comment, created_at, COALESCE(product_id, '') AS product_id, rating, COALESCE(user_id, '') AS user_id FROM reviews WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Reviews
+	for rows.Next() {
+		var m domain.Reviews
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Comment)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.ProductId)
+
+		fields = append(fields, &m.Rating)
+
+		fields = append(fields, &m.UserId)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *ReviewsRepository) Create(ctx context.Context, m *domain.Reviews) (string, error) {
+	query := "INSERT INTO reviews (comment, created_at, product_id, rating, user_id) VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, '')) RETURNING id"
+
+	values := []interface{}{
+		m.Comment,
+		m.CreatedAt,
+		m.ProductId,
+		m.Rating,
+		m.UserId,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *ReviewsRepository) Update(ctx context.Context, id string, m *domain.Reviews) error {
+
+	query := "UPDATE reviews SET comment = $1, created_at = $2, product_id = NULLIF($3, ''), rating = $4, user_id = NULLIF($5, '') WHERE id = $6"
+
+	values := []interface{}{
+		m.Comment,
+		m.CreatedAt,
+		m.ProductId,
+		m.Rating,
+		m.UserId,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *ReviewsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM reviews WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
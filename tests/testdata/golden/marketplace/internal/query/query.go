@@ -0,0 +1,215 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"MarketplaceAPI/internal/domain"
+)
+
+// reserved parameters configure the request instead of filtering it
+var reserved = map[string]bool{
+	"limit":   true,
+	"page":    true,
+	"sort":    true,
+	"fields":  true,
+	"include": true,
+}
+
+var operators = map[string]bool{
+	domain.OpEq:  true,
+	domain.OpNe:  true,
+	domain.OpGt:  true,
+	domain.OpGte: true,
+	domain.OpLt:  true,
+	domain.OpLte: true,
+	domain.OpIn:  true,
+}
+
+// Error reports an invalid query parameter
+type Error struct {
+	msg string
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Status maps an error of Parse or of a repository List to the HTTP status to answer with
+func Status(err error) int {
+	var invalid *Error
+	if errors.As(err, &invalid) || errors.Is(err, domain.ErrInvalidQuery) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// Parse reads ?status=paid&price[gte]=10&sort=-created_at&fields=name,price,
+// checking every field against fields, the model's field types
+func Parse(values url.Values, fields map[string]string) (domain.ListQuery, error) {
+	var q domain.ListQuery
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if reserved[key] {
+			continue
+		}
+		name, op := key, domain.OpEq
+		if i := strings.Index(key, "["); i > 0 && strings.HasSuffix(key, "]") {
+			name, op = key[:i], key[i+1:len(key)-1]
+		}
+		if !operators[op] {
+			return q, &Error{fmt.Sprintf("unknown operator %q in %s (available: eq, ne, gt, gte, lt, lte, in)", op, key)}
+		}
+		fieldType, err := lookup(fields, name, "filter")
+		if err != nil {
+			return q, err
+		}
+		for _, raw := range values[key] {
+			value, err := convert(name, fieldType, op, raw)
+			if err != nil {
+				return q, err
+			}
+			q.Filters = append(q.Filters, domain.Filter{Field: name, Op: op, Value: value})
+		}
+	}
+
+	for _, name := range split(values.Get("sort")) {
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		if _, err := lookup(fields, name, "sort"); err != nil {
+			return q, err
+		}
+		q.Sort = append(q.Sort, domain.Sort{Field: name, Desc: desc})
+	}
+
+	for _, name := range split(values.Get("fields")) {
+		if _, ok := fields[name]; !ok {
+			return q, unknownField(fields, name)
+		}
+		q.Fields = append(q.Fields, name)
+	}
+	return q, nil
+}
+
+func lookup(fields map[string]string, name, action string) (string, error) {
+	fieldType, ok := fields[name]
+	if !ok {
+		return "", unknownField(fields, name)
+	}
+	if !scalar(fieldType) {
+		return "", &Error{fmt.Sprintf("cannot %s on %s, it holds a list or a structured value", action, name)}
+	}
+	return fieldType, nil
+}
+
+// structured types hold lists or objects rather than a single comparable value
+var structured = map[string]bool{
+	"json":     true,
+	"array":    true,
+	"geopoint": true,
+}
+
+// scalar reports whether a field type, such as "array<string>" or "enum(a,b)", can be compared
+func scalar(fieldType string) bool {
+	if i := strings.IndexAny(fieldType, "<("); i > 0 {
+		fieldType = fieldType[:i]
+	}
+	return !structured[fieldType]
+}
+
+func unknownField(fields map[string]string, name string) error {
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+	return &Error{fmt.Sprintf("unknown field %q (available: %s)", name, strings.Join(names, ", "))}
+}
+
+// convert parses a raw value as the field's type; "in" takes a comma-separated list
+func convert(name, fieldType, op, raw string) (interface{}, error) {
+	if op == domain.OpIn {
+		var values []interface{}
+		for _, item := range split(raw) {
+			value, err := convert(name, fieldType, domain.OpEq, item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	var value interface{}
+	var err error
+	switch fieldType {
+	case "integer":
+		value, err = strconv.Atoi(raw)
+	case "float":
+		value, err = strconv.ParseFloat(raw, 64)
+	case "boolean":
+		value, err = strconv.ParseBool(raw)
+	case "datetime":
+		value, err = time.Parse(time.RFC3339, raw)
+	case "date":
+		_, err = time.Parse(domain.DateLayout, raw)
+		value = domain.Date(raw)
+	case "decimal":
+		_, err = strconv.ParseFloat(raw, 64)
+		value = domain.Decimal(raw)
+	default:
+		value = raw
+	}
+	if err != nil {
+		return nil, &Error{fmt.Sprintf("invalid %s value %q for %s", fieldType, raw, name)}
+	}
+	return value, nil
+}
+
+func split(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Select keeps only the id and the given fields of each document. It runs on
+// the response: repositories still read whole rows, since include needs their
+// relation ids and the cursor their sort values.
+func Select(docs interface{}, fields []string) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(docs)
+	if err != nil {
+		return nil, err
+	}
+	var all []map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	selected := make([]map[string]interface{}, len(all))
+	for i, doc := range all {
+		selected[i] = map[string]interface{}{"id": doc["id"]}
+		for _, field := range fields {
+			if value, ok := doc[field]; ok {
+				selected[i][field] = value
+			}
+		}
+	}
+	return selected, nil
+}
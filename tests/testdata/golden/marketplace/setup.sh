@@ -0,0 +1,9 @@
+#!/bin/bash
+
+echo "[1/2] Installing dependencies..."
+go mod tidy
+
+echo "[2/2] Starting server..."
+echo "Server will be available at http://localhost:8080"
+echo "Swagger docs available at http://localhost:8080/swagger/index.html"
+go run cmd/api/main.go
@@ -0,0 +1,40 @@
+#!/bin/bash
+
+echo "Installing dependencies..."
+go mod tidy
+
+echo "Starting server in background..."
+export MOCK_AUTH=true
+go run cmd/api/main.go &
+PID=$!
+sleep 5
+
+echo "Running tests..."
+
+echo "Testing POST /auth/login"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"email": "test@example.com", "password": "password123"}' http://localhost:8080/auth/login
+echo "\n"
+echo "Testing POST /api/users"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"created_at": "2023-01-01T00:00:00Z", "email": "test_email", "name": "test_name", "picture": "test_picture", "role": "test_role", "role_id": "test_role_id", "uid": "test_uid", "updated_at": "2023-01-01T00:00:00Z"}' http://localhost:8080/api/users
+echo "\n"
+echo "Testing POST /api/products"
+curl -X POST -H "Content-Type: application/json" -d '{"description": "test_description", "name": "test_name", "price": 99.99, "stock": 10}' http://localhost:8080/api/products
+echo "\n"
+echo "Testing POST /api/categories"
+curl -X POST -H "Content-Type: application/json" -d '{"description": "test_description", "name": "test_name"}' http://localhost:8080/api/categories
+echo "\n"
+echo "Testing POST /api/orders"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"created_at": "2023-01-01T00:00:00Z", "status": "test_status", "total": 99.99}' http://localhost:8080/api/orders
+echo "\n"
+echo "Testing POST /api/order_items"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"price": 99.99, "quantity": 10}' http://localhost:8080/api/order_items
+echo "\n"
+echo "Testing POST /api/reviews"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"comment": "test_comment", "created_at": "2023-01-01T00:00:00Z", "rating": 10}' http://localhost:8080/api/reviews
+echo "\n"
+echo "Testing POST /api/transactions"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"amount": 99.99, "created_at": "2023-01-01T00:00:00Z", "payload": "test_payload", "provider": "test_provider", "status": "test_status"}' http://localhost:8080/api/transactions
+echo "\n"
+
+echo "Killing server (PID: $PID)..."
+kill $PID
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"ProjectManagerAPI/docs"
+	authService "ProjectManagerAPI/internal/auth"
+	"ProjectManagerAPI/internal/expand"
+	authHandler "ProjectManagerAPI/internal/handlers/auth"
+	"ProjectManagerAPI/internal/handlers/boards"
+	"ProjectManagerAPI/internal/handlers/columns"
+	"ProjectManagerAPI/internal/handlers/comments"
+	"ProjectManagerAPI/internal/handlers/projects"
+	"ProjectManagerAPI/internal/handlers/tasks"
+	"ProjectManagerAPI/internal/handlers/users"
+	"ProjectManagerAPI/internal/infrastructure/db"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+func main() {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Initialize Database
+
+	baseRepo, err := db.NewMongoRepository(os.Getenv("DATABASE_URL"), "ProjectManagerAPI")
+
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer baseRepo.Close()
+
+	// Module auth
+	var authSvc authService.AuthService
+	if os.Getenv("MOCK_AUTH") == "true" {
+		log.Println("Using Mock Auth Service")
+		authSvc = &authService.MockAuthService{}
+	} else {
+		app, err := firebase.NewApp(context.Background(), &firebase.Config{ProjectID: ""})
+		if err != nil {
+			log.Fatalf("error initializing app: %v\n", err)
+		}
+		authClient, err := app.Auth(context.Background())
+		if err != nil {
+			log.Fatalf("error getting Auth client: %v\n", err)
+		}
+		authSvc = &authService.FirebaseAuthService{Client: authClient}
+	}
+	userRepo := db.NewUsersRepository(baseRepo.(*db.MongoRepository))
+	userHdl := authHandler.NewUserHandler(authSvc, userRepo, "users")
+
+	// Setup Router
+	r := gin.Default()
+
+	// Module auth
+	authGroup := r.Group("/auth")
+	authGroup.POST("/login", authService.AuthMiddleware(authSvc), userHdl.Login)
+	authGroup.GET("/me", authService.AuthMiddleware(authSvc), userHdl.GetMe)
+	authGroup.GET("/roles", authService.AuthMiddleware(authSvc), userHdl.GetRoles)
+
+	// API docs: the OpenAPI document generated from the blueprint, browsable with Swagger UI
+	r.GET("/openapi.yaml", func(c *gin.Context) {
+		c.Data(200, "application/yaml", docs.OpenAPI)
+	})
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/openapi.yaml")))
+
+	// Related records for ?include=, registered by each model below
+	expander := expand.New()
+
+	// Routes for users
+	{
+
+		repo := db.NewUsersRepository(baseRepo.(*db.MongoRepository))
+
+		expander.Register("users", expand.Fetch(repo.GetMany))
+		handler := users.NewUsersHandler(repo, expander)
+
+		group := r.Group("/api/users")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for projects
+	{
+
+		repo := db.NewProjectsRepository(baseRepo.(*db.MongoRepository))
+
+		expander.Register("projects", expand.Fetch(repo.GetMany))
+		handler := projects.NewProjectsHandler(repo, expander)
+
+		group := r.Group("/api/projects")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for boards
+	{
+
+		repo := db.NewBoardsRepository(baseRepo.(*db.MongoRepository))
+
+		expander.Register("boards", expand.Fetch(repo.GetMany))
+		handler := boards.NewBoardsHandler(repo, expander)
+
+		group := r.Group("/api/boards")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for columns
+	{
+
+		repo := db.NewColumnsRepository(baseRepo.(*db.MongoRepository))
+
+		expander.Register("columns", expand.Fetch(repo.GetMany))
+		handler := columns.NewColumnsHandler(repo, expander)
+
+		group := r.Group("/api/columns")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for tasks
+	{
+
+		repo := db.NewTasksRepository(baseRepo.(*db.MongoRepository))
+
+		expander.Register("tasks", expand.Fetch(repo.GetMany))
+		handler := tasks.NewTasksHandler(repo, expander)
+
+		group := r.Group("/api/tasks")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for comments
+	{
+
+		repo := db.NewCommentsRepository(baseRepo.(*db.MongoRepository))
+
+		expander.Register("comments", expand.Fetch(repo.GetMany))
+		handler := comments.NewCommentsHandler(repo, expander)
+
+		group := r.Group("/api/comments")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// blueprint:custom-begin routes
+	// blueprint:custom-end routes
+
+	log.Printf("Starting server for project: ProjectManagerAPI")
+	r.Run(":8080")
+}
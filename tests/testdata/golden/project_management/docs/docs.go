@@ -0,0 +1,8 @@
+package docs
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 document of the API, generated from the blueprint
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
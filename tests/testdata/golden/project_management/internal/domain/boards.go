@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+)
+
+type Boards struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	Type string `json:"type" bson:"type" firestore:"type"`
+
+	Columns []string `json:"columns" bson:"columns" firestore:"columns"`
+
+	ProjectId string `json:"project_id" bson:"project_id" firestore:"project_id"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Boards) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Boards) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// BoardsFields maps the fields List can filter, sort and select on to their blueprint types
+var BoardsFields = map[string]string{
+	"id":         "string",
+	"name":       "string",
+	"type":       "string",
+	"columns":    "array",
+	"project_id": "string",
+}
+
+type BoardsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Boards, error)
+	Get(ctx context.Context, id string) (*Boards, error)
+	GetMany(ctx context.Context, ids []string) ([]*Boards, error)
+	Create(ctx context.Context, model *Boards) (string, error)
+	Update(ctx context.Context, id string, model *Boards) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+)
+
+type Columns struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	Order int `json:"order" bson:"order" firestore:"order"`
+
+	BoardId string `json:"board_id" bson:"board_id" firestore:"board_id"`
+
+	Tasks []string `json:"tasks" bson:"tasks" firestore:"tasks"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Columns) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Columns) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// ColumnsFields maps the fields List can filter, sort and select on to their blueprint types
+var ColumnsFields = map[string]string{
+	"id":       "string",
+	"name":     "string",
+	"order":    "integer",
+	"board_id": "string",
+	"tasks":    "array",
+}
+
+type ColumnsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Columns, error)
+	Get(ctx context.Context, id string) (*Columns, error)
+	GetMany(ctx context.Context, ids []string) ([]*Columns, error)
+	Create(ctx context.Context, model *Columns) (string, error)
+	Update(ctx context.Context, id string, model *Columns) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Projects struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Description string `json:"description" bson:"description" firestore:"description"`
+
+	EndDate time.Time `json:"end_date" bson:"end_date" firestore:"end_date"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	StartDate time.Time `json:"start_date" bson:"start_date" firestore:"start_date"`
+
+	Boards []string `json:"boards" bson:"boards" firestore:"boards"`
+
+	OwnerId string `json:"owner_id" bson:"owner_id" firestore:"owner_id"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Projects) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Projects) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// ProjectsFields maps the fields List can filter, sort and select on to their blueprint types
+var ProjectsFields = map[string]string{
+	"id":          "string",
+	"description": "string",
+	"end_date":    "datetime",
+	"name":        "string",
+	"start_date":  "datetime",
+	"boards":      "array",
+	"owner_id":    "string",
+}
+
+type ProjectsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Projects, error)
+	Get(ctx context.Context, id string) (*Projects, error)
+	GetMany(ctx context.Context, ids []string) ([]*Projects, error)
+	Create(ctx context.Context, model *Projects) (string, error)
+	Update(ctx context.Context, id string, model *Projects) error
+	Delete(ctx context.Context, id string) error
+}
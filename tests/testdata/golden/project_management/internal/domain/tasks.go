@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Tasks struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Description string `json:"description" bson:"description" firestore:"description"`
+
+	DueDate time.Time `json:"due_date" bson:"due_date" firestore:"due_date"`
+
+	Priority string `json:"priority" bson:"priority" firestore:"priority"`
+
+	Title string `json:"title" bson:"title" firestore:"title"`
+
+	AssigneeId string `json:"assignee_id" bson:"assignee_id" firestore:"assignee_id"`
+
+	ColumnId string `json:"column_id" bson:"column_id" firestore:"column_id"`
+
+	Comments []string `json:"comments" bson:"comments" firestore:"comments"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Tasks) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Tasks) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// TasksFields maps the fields List can filter, sort and select on to their blueprint types
+var TasksFields = map[string]string{
+	"id":          "string",
+	"description": "string",
+	"due_date":    "datetime",
+	"priority":    "string",
+	"title":       "string",
+	"assignee_id": "string",
+	"column_id":   "string",
+	"comments":    "array",
+}
+
+type TasksRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Tasks, error)
+	Get(ctx context.Context, id string) (*Tasks, error)
+	GetMany(ctx context.Context, ids []string) ([]*Tasks, error)
+	Create(ctx context.Context, model *Tasks) (string, error)
+	Update(ctx context.Context, id string, model *Tasks) error
+	Delete(ctx context.Context, id string) error
+}
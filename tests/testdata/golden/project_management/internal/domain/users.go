@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Users struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	AvatarUrl string `json:"avatar_url" bson:"avatar_url" firestore:"avatar_url"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	Email string `json:"email" bson:"email" firestore:"email"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	Picture string `json:"picture" bson:"picture" firestore:"picture"`
+
+	RoleId string `json:"role_id" bson:"role_id" firestore:"role_id"`
+
+	Uid string `json:"uid" bson:"uid" firestore:"uid"`
+
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at" firestore:"updated_at"`
+
+	Username string `json:"username" bson:"username" firestore:"username"`
+
+	AssignedTasks []string `json:"assigned_tasks" bson:"assigned_tasks" firestore:"assigned_tasks"`
+
+	Comments []string `json:"comments" bson:"comments" firestore:"comments"`
+
+	OwnedProjects []string `json:"owned_projects" bson:"owned_projects" firestore:"owned_projects"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Users) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Users) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// UsersFields maps the fields List can filter, sort and select on to their blueprint types
+var UsersFields = map[string]string{
+	"id":             "string",
+	"avatar_url":     "string",
+	"created_at":     "datetime",
+	"email":          "string",
+	"name":           "string",
+	"picture":        "string",
+	"role_id":        "string",
+	"uid":            "string",
+	"updated_at":     "datetime",
+	"username":       "string",
+	"assigned_tasks": "array",
+	"comments":       "array",
+	"owned_projects": "array",
+}
+
+type UsersRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Users, error)
+	Get(ctx context.Context, id string) (*Users, error)
+	GetMany(ctx context.Context, ids []string) ([]*Users, error)
+	Create(ctx context.Context, model *Users) (string, error)
+	Update(ctx context.Context, id string, model *Users) error
+	Delete(ctx context.Context, id string) error
+}
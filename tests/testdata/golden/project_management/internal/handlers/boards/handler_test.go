@@ -0,0 +1,114 @@
+package boards
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"ProjectManagerAPI/internal/domain"
+	"ProjectManagerAPI/internal/expand"
+)
+
+type MockBoardsRepository struct {
+	Data map[string]*domain.Boards
+}
+
+func (m *MockBoardsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Boards, error) {
+	var results []*domain.Boards
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Boards{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockBoardsRepository) Get(ctx context.Context, id string) (*domain.Boards, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockBoardsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Boards, error) {
+	var results []*domain.Boards
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockBoardsRepository) Create(ctx context.Context, model *domain.Boards) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Boards)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockBoardsRepository) Update(ctx context.Context, id string, model *domain.Boards) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockBoardsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestBoardsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockBoardsRepository{Data: make(map[string]*domain.Boards)}
+	handler := NewBoardsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/boards", handler.List)
+	r.POST("/boards", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Boards{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/boards", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/boards?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/boards?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/boards?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
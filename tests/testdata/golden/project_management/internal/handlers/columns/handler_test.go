@@ -0,0 +1,114 @@
+package columns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"ProjectManagerAPI/internal/domain"
+	"ProjectManagerAPI/internal/expand"
+)
+
+type MockColumnsRepository struct {
+	Data map[string]*domain.Columns
+}
+
+func (m *MockColumnsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Columns, error) {
+	var results []*domain.Columns
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Columns{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockColumnsRepository) Get(ctx context.Context, id string) (*domain.Columns, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockColumnsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Columns, error) {
+	var results []*domain.Columns
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockColumnsRepository) Create(ctx context.Context, model *domain.Columns) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Columns)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockColumnsRepository) Update(ctx context.Context, id string, model *domain.Columns) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockColumnsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestColumnsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockColumnsRepository{Data: make(map[string]*domain.Columns)}
+	handler := NewColumnsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/columns", handler.List)
+	r.POST("/columns", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Columns{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/columns", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/columns?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/columns?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/columns?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package comments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"ProjectManagerAPI/internal/domain"
+	"ProjectManagerAPI/internal/expand"
+)
+
+type MockCommentsRepository struct {
+	Data map[string]*domain.Comments
+}
+
+func (m *MockCommentsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Comments, error) {
+	var results []*domain.Comments
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Comments{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockCommentsRepository) Get(ctx context.Context, id string) (*domain.Comments, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockCommentsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Comments, error) {
+	var results []*domain.Comments
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockCommentsRepository) Create(ctx context.Context, model *domain.Comments) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Comments)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockCommentsRepository) Update(ctx context.Context, id string, model *domain.Comments) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockCommentsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestCommentsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockCommentsRepository{Data: make(map[string]*domain.Comments)}
+	handler := NewCommentsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/comments", handler.List)
+	r.POST("/comments", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Comments{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/comments", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/comments?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/comments?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/comments?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package projects
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"ProjectManagerAPI/internal/domain"
+	"ProjectManagerAPI/internal/expand"
+)
+
+type MockProjectsRepository struct {
+	Data map[string]*domain.Projects
+}
+
+func (m *MockProjectsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Projects, error) {
+	var results []*domain.Projects
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Projects{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockProjectsRepository) Get(ctx context.Context, id string) (*domain.Projects, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockProjectsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Projects, error) {
+	var results []*domain.Projects
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockProjectsRepository) Create(ctx context.Context, model *domain.Projects) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Projects)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockProjectsRepository) Update(ctx context.Context, id string, model *domain.Projects) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockProjectsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestProjectsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockProjectsRepository{Data: make(map[string]*domain.Projects)}
+	handler := NewProjectsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/projects", handler.List)
+	r.POST("/projects", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Projects{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/projects", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/projects?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/projects?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/projects?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
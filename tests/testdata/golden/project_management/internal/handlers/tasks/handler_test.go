@@ -0,0 +1,114 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"ProjectManagerAPI/internal/domain"
+	"ProjectManagerAPI/internal/expand"
+)
+
+type MockTasksRepository struct {
+	Data map[string]*domain.Tasks
+}
+
+func (m *MockTasksRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Tasks, error) {
+	var results []*domain.Tasks
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Tasks{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockTasksRepository) Get(ctx context.Context, id string) (*domain.Tasks, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockTasksRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Tasks, error) {
+	var results []*domain.Tasks
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockTasksRepository) Create(ctx context.Context, model *domain.Tasks) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Tasks)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockTasksRepository) Update(ctx context.Context, id string, model *domain.Tasks) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockTasksRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestTasksHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockTasksRepository{Data: make(map[string]*domain.Tasks)}
+	handler := NewTasksHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/tasks", handler.List)
+	r.POST("/tasks", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Tasks{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tasks?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tasks?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/tasks?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
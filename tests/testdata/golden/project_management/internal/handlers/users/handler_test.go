@@ -0,0 +1,114 @@
+package users
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"ProjectManagerAPI/internal/domain"
+	"ProjectManagerAPI/internal/expand"
+)
+
+type MockUsersRepository struct {
+	Data map[string]*domain.Users
+}
+
+func (m *MockUsersRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Users, error) {
+	var results []*domain.Users
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Users{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockUsersRepository) Get(ctx context.Context, id string) (*domain.Users, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockUsersRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Users, error) {
+	var results []*domain.Users
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockUsersRepository) Create(ctx context.Context, model *domain.Users) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Users)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockUsersRepository) Update(ctx context.Context, id string, model *domain.Users) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockUsersRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestUsersHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockUsersRepository{Data: make(map[string]*domain.Users)}
+	handler := NewUsersHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/users", handler.List)
+	r.POST("/users", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Users{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/users", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
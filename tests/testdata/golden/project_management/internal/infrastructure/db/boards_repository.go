@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ProjectManagerAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type BoardsRepository struct {
+	repo *MongoRepository
+}
+
+func NewBoardsRepository(repo *MongoRepository) *BoardsRepository {
+	return &BoardsRepository{repo: repo}
+}
+
+func (r *BoardsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Boards, error) {
+	opts := options.Find().SetLimit(int64(q.Limit)).SetSkip(int64(q.Offset)).SetSort(mongoSort(q))
+	cursor, err := r.repo.DB.Collection("boards").Find(ctx, mongoFilter(q), opts)
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Boards
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *BoardsRepository) Get(ctx context.Context, id string) (*domain.Boards, error) {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	var m domain.Boards
+	err := r.repo.DB.Collection("boards").FindOne(ctx, bson.M{"_id": objID}).Decode(&m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the documents with the given ids in a single query, in no particular order
+func (r *BoardsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Boards, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	cursor, err := r.repo.DB.Collection("boards").Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Boards
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *BoardsRepository) Create(ctx context.Context, m *domain.Boards) (string, error) {
+	res, err := r.repo.DB.Collection("boards").InsertOne(ctx, m)
+	if err != nil {
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (r *BoardsRepository) Update(ctx context.Context, id string, m *domain.Boards) error {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.repo.DB.Collection("boards").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": m})
+	return err
+}
+
+func (r *BoardsRepository) Delete(ctx context.Context, id string) error {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.repo.DB.Collection("boards").DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ProjectManagerAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type ColumnsRepository struct {
+	repo *MongoRepository
+}
+
+func NewColumnsRepository(repo *MongoRepository) *ColumnsRepository {
+	return &ColumnsRepository{repo: repo}
+}
+
+func (r *ColumnsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Columns, error) {
+	opts := options.Find().SetLimit(int64(q.Limit)).SetSkip(int64(q.Offset)).SetSort(mongoSort(q))
+	cursor, err := r.repo.DB.Collection("columns").Find(ctx, mongoFilter(q), opts)
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Columns
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *ColumnsRepository) Get(ctx context.Context, id string) (*domain.Columns, error) {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	var m domain.Columns
+	err := r.repo.DB.Collection("columns").FindOne(ctx, bson.M{"_id": objID}).Decode(&m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the documents with the given ids in a single query, in no particular order
+func (r *ColumnsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Columns, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	cursor, err := r.repo.DB.Collection("columns").Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Columns
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *ColumnsRepository) Create(ctx context.Context, m *domain.Columns) (string, error) {
+	res, err := r.repo.DB.Collection("columns").InsertOne(ctx, m)
+	if err != nil {
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (r *ColumnsRepository) Update(ctx context.Context, id string, m *domain.Columns) error {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.repo.DB.Collection("columns").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": m})
+	return err
+}
+
+func (r *ColumnsRepository) Delete(ctx context.Context, id string) error {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.repo.DB.Collection("columns").DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ProjectManagerAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type CommentsRepository struct {
+	repo *MongoRepository
+}
+
+func NewCommentsRepository(repo *MongoRepository) *CommentsRepository {
+	return &CommentsRepository{repo: repo}
+}
+
+func (r *CommentsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Comments, error) {
+	opts := options.Find().SetLimit(int64(q.Limit)).SetSkip(int64(q.Offset)).SetSort(mongoSort(q))
+	cursor, err := r.repo.DB.Collection("comments").Find(ctx, mongoFilter(q), opts)
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Comments
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *CommentsRepository) Get(ctx context.Context, id string) (*domain.Comments, error) {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	var m domain.Comments
+	err := r.repo.DB.Collection("comments").FindOne(ctx, bson.M{"_id": objID}).Decode(&m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the documents with the given ids in a single query, in no particular order
+func (r *CommentsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Comments, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	cursor, err := r.repo.DB.Collection("comments").Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Comments
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *CommentsRepository) Create(ctx context.Context, m *domain.Comments) (string, error) {
+	res, err := r.repo.DB.Collection("comments").InsertOne(ctx, m)
+	if err != nil {
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (r *CommentsRepository) Update(ctx context.Context, id string, m *domain.Comments) error {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.repo.DB.Collection("comments").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": m})
+	return err
+}
+
+func (r *CommentsRepository) Delete(ctx context.Context, id string) error {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.repo.DB.Collection("comments").DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Repository interface {
+	List(ctx context.Context, collection string) ([]map[string]interface{}, error)
+	Get(ctx context.Context, collection, id string) (map[string]interface{}, error)
+	Create(ctx context.Context, collection string, data map[string]interface{}) (string, error)
+	Update(ctx context.Context, collection, id string, data map[string]interface{}) error
+	Delete(ctx context.Context, collection, id string) error
+	Close()
+}
+
+type MongoRepository struct {
+	Client *mongo.Client
+	DB     *mongo.Database
+}
+
+func NewMongoRepository(url, dbName string) (Repository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(url))
+	if err != nil {
+		return nil, err
+	}
+
+	return &MongoRepository{
+		Client: client,
+		DB:     client.Database(dbName),
+	}, nil
+}
+
+func (r *MongoRepository) Close() {
+	if r.Client != nil {
+		r.Client.Disconnect(context.Background())
+	}
+}
+
+func (r *MongoRepository) List(ctx context.Context, collection string) ([]map[string]interface{}, error) {
+	cursor, err := r.DB.Collection(collection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var results []map[string]interface{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *MongoRepository) Get(ctx context.Context, collection, id string) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := r.DB.Collection(collection).FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	return result, err
+}
+
+func (r *MongoRepository) Create(ctx context.Context, collection string, data map[string]interface{}) (string, error) {
+	res, err := r.DB.Collection(collection).InsertOne(ctx, data)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v", res.InsertedID), nil
+}
+
+func (r *MongoRepository) Update(ctx context.Context, collection, id string, data map[string]interface{}) error {
+	_, err := r.DB.Collection(collection).UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": data})
+	return err
+}
+
+func (r *MongoRepository) Delete(ctx context.Context, collection, id string) error {
+	_, err := r.DB.Collection(collection).DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
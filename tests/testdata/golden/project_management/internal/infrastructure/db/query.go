@@ -0,0 +1,69 @@
+package db
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"ProjectManagerAPI/internal/domain"
+)
+
+var mongoOperators = map[string]string{
+	domain.OpEq:  "$eq",
+	domain.OpNe:  "$ne",
+	domain.OpGt:  "$gt",
+	domain.OpGte: "$gte",
+	domain.OpLt:  "$lt",
+	domain.OpLte: "$lte",
+	domain.OpIn:  "$in",
+}
+
+// mongoFilter combines the filters of q; several on one field must all match
+func mongoFilter(q domain.ListQuery) bson.M {
+	filter := bson.M{}
+	for _, f := range q.Filters {
+		field, value := f.Field, f.Value
+		if field == "id" {
+			field, value = "_id", objectIDs(value)
+		}
+		conditions, ok := filter[field].(bson.M)
+		if !ok {
+			conditions = bson.M{}
+			filter[field] = conditions
+		}
+		conditions[mongoOperators[f.Op]] = value
+	}
+	return filter
+}
+
+func mongoSort(q domain.ListQuery) bson.D {
+	sort := bson.D{}
+	for _, s := range q.Sort {
+		field := s.Field
+		if field == "id" {
+			field = "_id"
+		}
+		direction := 1
+		if s.Desc {
+			direction = -1
+		}
+		sort = append(sort, bson.E{Key: field, Value: direction})
+	}
+	return sort
+}
+
+// objectIDs converts an id, or a list of ids, to the ObjectIDs stored in _id
+func objectIDs(value interface{}) interface{} {
+	if list, ok := value.([]interface{}); ok {
+		ids := make([]interface{}, len(list))
+		for i, item := range list {
+			ids[i] = objectIDs(item)
+		}
+		return ids
+	}
+	if id, ok := value.(string); ok {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			return objID
+		}
+	}
+	return value
+}
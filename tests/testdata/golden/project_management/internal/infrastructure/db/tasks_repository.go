@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"ProjectManagerAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type TasksRepository struct {
+	repo *MongoRepository
+}
+
+func NewTasksRepository(repo *MongoRepository) *TasksRepository {
+	return &TasksRepository{repo: repo}
+}
+
+func (r *TasksRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Tasks, error) {
+	opts := options.Find().SetLimit(int64(q.Limit)).SetSkip(int64(q.Offset)).SetSort(mongoSort(q))
+	cursor, err := r.repo.DB.Collection("tasks").Find(ctx, mongoFilter(q), opts)
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Tasks
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *TasksRepository) Get(ctx context.Context, id string) (*domain.Tasks, error) {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	var m domain.Tasks
+	err := r.repo.DB.Collection("tasks").FindOne(ctx, bson.M{"_id": objID}).Decode(&m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the documents with the given ids in a single query, in no particular order
+func (r *TasksRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Tasks, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	cursor, err := r.repo.DB.Collection("tasks").Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Tasks
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *TasksRepository) Create(ctx context.Context, m *domain.Tasks) (string, error) {
+	res, err := r.repo.DB.Collection("tasks").InsertOne(ctx, m)
+	if err != nil {
+		return "", err
+	}
+	return res.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (r *TasksRepository) Update(ctx context.Context, id string, m *domain.Tasks) error {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.repo.DB.Collection("tasks").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": m})
+	return err
+}
+
+func (r *TasksRepository) Delete(ctx context.Context, id string) error {
+	objID, _ := primitive.ObjectIDFromHex(id)
+	_, err := r.repo.DB.Collection("tasks").DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
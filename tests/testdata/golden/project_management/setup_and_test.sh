@@ -0,0 +1,37 @@
+#!/bin/bash
+
+echo "Installing dependencies..."
+go mod tidy
+
+echo "Starting server in background..."
+export MOCK_AUTH=true
+go run cmd/api/main.go &
+PID=$!
+sleep 5
+
+echo "Running tests..."
+
+echo "Testing POST /auth/login"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"email": "test@example.com", "password": "password123"}' http://localhost:8080/auth/login
+echo "\n"
+echo "Testing POST /api/users"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"avatar_url": "test_avatar_url", "created_at": "2023-01-01T00:00:00Z", "email": "test_email", "name": "test_name", "picture": "test_picture", "role_id": "test_role_id", "uid": "test_uid", "updated_at": "2023-01-01T00:00:00Z", "username": "test_username"}' http://localhost:8080/api/users
+echo "\n"
+echo "Testing POST /api/projects"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"description": "test_description", "end_date": "2023-01-01T00:00:00Z", "name": "test_name", "start_date": "2023-01-01T00:00:00Z", "owner_id": "test_owner_id"}' http://localhost:8080/api/projects
+echo "\n"
+echo "Testing POST /api/boards"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"name": "test_name", "type": "test_type", "project_id": "test_project_id"}' http://localhost:8080/api/boards
+echo "\n"
+echo "Testing POST /api/columns"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"name": "test_name", "order": 10, "board_id": "test_board_id"}' http://localhost:8080/api/columns
+echo "\n"
+echo "Testing POST /api/tasks"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"description": "test_description", "due_date": "2023-01-01T00:00:00Z", "priority": "test_priority", "title": "test_title", "assignee_id": "test_assignee_id", "column_id": "test_column_id"}' http://localhost:8080/api/tasks
+echo "\n"
+echo "Testing POST /api/comments"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"content": "test_content", "created_at": "2023-01-01T00:00:00Z", "author_id": "test_author_id", "task_id": "test_task_id"}' http://localhost:8080/api/comments
+echo "\n"
+
+echo "Killing server (PID: $PID)..."
+kill $PID
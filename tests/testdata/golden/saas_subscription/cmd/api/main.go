@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"SaaSBackend/docs"
+	authService "SaaSBackend/internal/auth"
+	"SaaSBackend/internal/expand"
+	authHandler "SaaSBackend/internal/handlers/auth"
+	"SaaSBackend/internal/handlers/payments"
+	"SaaSBackend/internal/handlers/plans"
+	"SaaSBackend/internal/handlers/subscriptions"
+	"SaaSBackend/internal/handlers/users"
+	"SaaSBackend/internal/infrastructure/db"
+	paymentService "SaaSBackend/internal/payments"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+func main() {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Initialize Database
+
+	baseRepo, err := db.NewPostgresRepository(os.Getenv("DATABASE_URL"))
+
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer baseRepo.Close()
+
+	// Module auth
+	var authSvc authService.AuthService
+	if os.Getenv("MOCK_AUTH") == "true" {
+		log.Println("Using Mock Auth Service")
+		authSvc = &authService.MockAuthService{}
+	} else {
+		app, err := firebase.NewApp(context.Background(), &firebase.Config{ProjectID: ""})
+		if err != nil {
+			log.Fatalf("error initializing app: %v\n", err)
+		}
+		authClient, err := app.Auth(context.Background())
+		if err != nil {
+			log.Fatalf("error getting Auth client: %v\n", err)
+		}
+		authSvc = &authService.FirebaseAuthService{Client: authClient}
+	}
+	userRepo := db.NewUsersRepository(baseRepo.(*db.PostgresRepository))
+	userHdl := authHandler.NewUserHandler(authSvc, userRepo, "users")
+
+	// Module payments
+	paymentRepo := db.NewPaymentsRepository(baseRepo.(*db.PostgresRepository))
+	stripeService := paymentService.NewStripeService(paymentRepo)
+
+	// Setup Router
+	r := gin.Default()
+
+	// Module auth
+	authGroup := r.Group("/auth")
+	authGroup.POST("/login", authService.AuthMiddleware(authSvc), userHdl.Login)
+	authGroup.GET("/me", authService.AuthMiddleware(authSvc), userHdl.GetMe)
+	authGroup.GET("/roles", authService.AuthMiddleware(authSvc), userHdl.GetRoles)
+
+	// Module payments
+	paymentGroup := r.Group("/payments")
+	paymentGroup.POST("/stripe/payment-intent", stripeService.CreatePaymentIntentHandler)
+	paymentGroup.POST("/stripe/webhook", stripeService.HandleWebhook)
+
+	// API docs: the OpenAPI document generated from the blueprint, browsable with Swagger UI
+	r.GET("/openapi.yaml", func(c *gin.Context) {
+		c.Data(200, "application/yaml", docs.OpenAPI)
+	})
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/openapi.yaml")))
+
+	// Related records for ?include=, registered by each model below
+	expander := expand.New()
+
+	// Routes for subscriptions
+	{
+
+		repo := db.NewSubscriptionsRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("subscriptions", expand.Fetch(repo.GetMany))
+		handler := subscriptions.NewSubscriptionsHandler(repo, expander)
+
+		group := r.Group("/api/subscriptions")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for plans
+	{
+
+		repo := db.NewPlansRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("plans", expand.Fetch(repo.GetMany))
+		handler := plans.NewPlansHandler(repo, expander)
+
+		group := r.Group("/api/plans")
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for users
+	{
+
+		repo := db.NewUsersRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("users", expand.Fetch(repo.GetMany))
+		handler := users.NewUsersHandler(repo, expander)
+
+		group := r.Group("/api/users")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for payments
+	{
+
+		repo := db.NewPaymentsRepository(baseRepo.(*db.PostgresRepository))
+
+		expander.Register("payments", expand.Fetch(repo.GetMany))
+		handler := payments.NewPaymentsHandler(repo, expander)
+
+		group := r.Group("/api/payments")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// blueprint:custom-begin routes
+	// blueprint:custom-end routes
+
+	log.Printf("Starting server for project: SaaSBackend")
+	r.Run(":8080")
+}
@@ -0,0 +1,15 @@
+module SaaSBackend
+
+go 1.23
+
+require (
+	github.com/gin-gonic/gin v1.9.1
+	github.com/go-playground/validator/v10 v10.14.0
+	github.com/swaggo/files v1.0.1
+	github.com/swaggo/gin-swagger v1.6.0
+	github.com/stretchr/testify v1.8.4
+	github.com/joho/godotenv v1.5.1
+	github.com/jackc/pgx/v5 v5.5.0
+	firebase.google.com/go/v4 v4.13.0
+	github.com/stripe/stripe-go/v76 v76.0.0
+)
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Payments struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Amount float64 `json:"amount" bson:"amount" firestore:"amount"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	Payload string `json:"payload" bson:"payload" firestore:"payload"`
+
+	Provider string `json:"provider" bson:"provider" firestore:"provider"`
+
+	Status string `json:"status" bson:"status" firestore:"status"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Payments) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Payments) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// PaymentsFields maps the fields List can filter, sort and select on to their blueprint types
+var PaymentsFields = map[string]string{
+	"id":         "string",
+	"amount":     "float",
+	"created_at": "datetime",
+	"payload":    "text",
+	"provider":   "string",
+	"status":     "string",
+}
+
+type PaymentsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Payments, error)
+	Get(ctx context.Context, id string) (*Payments, error)
+	GetMany(ctx context.Context, ids []string) ([]*Payments, error)
+	Create(ctx context.Context, model *Payments) (string, error)
+	Update(ctx context.Context, id string, model *Payments) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+)
+
+type Plans struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Interval string `json:"interval" bson:"interval" firestore:"interval"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	Price float64 `json:"price" bson:"price" firestore:"price"`
+
+	StripePriceId string `json:"stripe_price_id" bson:"stripe_price_id" firestore:"stripe_price_id"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Plans) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Plans) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// PlansFields maps the fields List can filter, sort and select on to their blueprint types
+var PlansFields = map[string]string{
+	"id":              "string",
+	"interval":        "string",
+	"name":            "string",
+	"price":           "float",
+	"stripe_price_id": "string",
+}
+
+type PlansRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Plans, error)
+	Get(ctx context.Context, id string) (*Plans, error)
+	GetMany(ctx context.Context, ids []string) ([]*Plans, error)
+	Create(ctx context.Context, model *Plans) (string, error)
+	Update(ctx context.Context, id string, model *Plans) error
+	Delete(ctx context.Context, id string) error
+}
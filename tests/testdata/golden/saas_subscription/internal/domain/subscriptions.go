@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Subscriptions struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	EndDate time.Time `json:"end_date" bson:"end_date" firestore:"end_date"`
+
+	PlanId string `json:"plan_id" bson:"plan_id" firestore:"plan_id"`
+
+	StartDate time.Time `json:"start_date" bson:"start_date" firestore:"start_date"`
+
+	Status string `json:"status" bson:"status" firestore:"status"`
+
+	UserId string `json:"user_id" bson:"user_id" firestore:"user_id"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Subscriptions) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Subscriptions) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// SubscriptionsFields maps the fields List can filter, sort and select on to their blueprint types
+var SubscriptionsFields = map[string]string{
+	"id":         "string",
+	"end_date":   "datetime",
+	"plan_id":    "string",
+	"start_date": "datetime",
+	"status":     "string",
+	"user_id":    "string",
+}
+
+type SubscriptionsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Subscriptions, error)
+	Get(ctx context.Context, id string) (*Subscriptions, error)
+	GetMany(ctx context.Context, ids []string) ([]*Subscriptions, error)
+	Create(ctx context.Context, model *Subscriptions) (string, error)
+	Update(ctx context.Context, id string, model *Subscriptions) error
+	Delete(ctx context.Context, id string) error
+}
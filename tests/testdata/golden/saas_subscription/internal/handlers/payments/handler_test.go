@@ -0,0 +1,114 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"SaaSBackend/internal/domain"
+	"SaaSBackend/internal/expand"
+)
+
+type MockPaymentsRepository struct {
+	Data map[string]*domain.Payments
+}
+
+func (m *MockPaymentsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Payments, error) {
+	var results []*domain.Payments
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Payments{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockPaymentsRepository) Get(ctx context.Context, id string) (*domain.Payments, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockPaymentsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Payments, error) {
+	var results []*domain.Payments
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockPaymentsRepository) Create(ctx context.Context, model *domain.Payments) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Payments)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockPaymentsRepository) Update(ctx context.Context, id string, model *domain.Payments) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockPaymentsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestPaymentsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockPaymentsRepository{Data: make(map[string]*domain.Payments)}
+	handler := NewPaymentsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/payments", handler.List)
+	r.POST("/payments", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Payments{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/payments", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/payments?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/payments?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/payments?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package plans
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"SaaSBackend/internal/domain"
+	"SaaSBackend/internal/expand"
+)
+
+type MockPlansRepository struct {
+	Data map[string]*domain.Plans
+}
+
+func (m *MockPlansRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Plans, error) {
+	var results []*domain.Plans
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Plans{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockPlansRepository) Get(ctx context.Context, id string) (*domain.Plans, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockPlansRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Plans, error) {
+	var results []*domain.Plans
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockPlansRepository) Create(ctx context.Context, model *domain.Plans) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Plans)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockPlansRepository) Update(ctx context.Context, id string, model *domain.Plans) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockPlansRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestPlansHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockPlansRepository{Data: make(map[string]*domain.Plans)}
+	handler := NewPlansHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/plans", handler.List)
+	r.POST("/plans", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Plans{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/plans", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/plans?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/plans?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/plans?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package subscriptions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"SaaSBackend/internal/domain"
+	"SaaSBackend/internal/expand"
+)
+
+type MockSubscriptionsRepository struct {
+	Data map[string]*domain.Subscriptions
+}
+
+func (m *MockSubscriptionsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Subscriptions, error) {
+	var results []*domain.Subscriptions
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Subscriptions{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockSubscriptionsRepository) Get(ctx context.Context, id string) (*domain.Subscriptions, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockSubscriptionsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Subscriptions, error) {
+	var results []*domain.Subscriptions
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockSubscriptionsRepository) Create(ctx context.Context, model *domain.Subscriptions) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Subscriptions)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockSubscriptionsRepository) Update(ctx context.Context, id string, model *domain.Subscriptions) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockSubscriptionsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestSubscriptionsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockSubscriptionsRepository{Data: make(map[string]*domain.Subscriptions)}
+	handler := NewSubscriptionsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/subscriptions", handler.List)
+	r.POST("/subscriptions", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Subscriptions{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/subscriptions", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/subscriptions?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/subscriptions?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/subscriptions?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
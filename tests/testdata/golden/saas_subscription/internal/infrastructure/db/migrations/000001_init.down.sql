@@ -0,0 +1,11 @@
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS fk_subscriptions_user_id;
+
+DROP INDEX IF EXISTS idx_subscriptions_user_id;
+
+DROP TABLE IF EXISTS users;
+
+DROP TABLE IF EXISTS subscriptions;
+
+DROP TABLE IF EXISTS plans;
+
+DROP TABLE IF EXISTS payments;
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS payments (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    amount DOUBLE PRECISION,
+    created_at TIMESTAMP,
+    payload TEXT,
+    provider TEXT,
+    status TEXT
+);
+
+CREATE TABLE IF NOT EXISTS plans (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    interval TEXT,
+    name TEXT,
+    price DOUBLE PRECISION,
+    stripe_price_id TEXT
+);
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    end_date TIMESTAMP,
+    plan_id TEXT,
+    start_date TIMESTAMP,
+    status TEXT,
+    user_id TEXT
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    created_at TIMESTAMP,
+    email TEXT,
+    name TEXT,
+    picture TEXT,
+    role_id TEXT,
+    uid TEXT,
+    updated_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions (user_id);
+
+ALTER TABLE subscriptions ADD CONSTRAINT fk_subscriptions_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL;
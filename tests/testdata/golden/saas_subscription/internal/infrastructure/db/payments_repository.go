@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"SaaSBackend/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type PaymentsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPaymentsRepository expects the payments table to exist; see migrations.go
+func NewPaymentsRepository(repo *PostgresRepository) *PaymentsRepository {
+	return &PaymentsRepository{db: repo.Pool}
+}
+
+func (r *PaymentsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Payments, error) {
+	query, args, err := listSQL("SELECT id, amount, created_at, payload, provider, status FROM payments", "payments", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Payments
+	for rows.Next() {
+		var m domain.Payments
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Amount)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Payload)
+
+		fields = append(fields, &m.Provider)
+
+		fields = append(fields, &m.Status)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *PaymentsRepository) Get(ctx context.Context, id string) (*domain.Payments, error) {
+	var m domain.Payments
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Amount)
+
+	fields = append(fields, &m.CreatedAt)
+
+	fields = append(fields, &m.Payload)
+
+	fields = append(fields, &m.Provider)
+
+	fields = append(fields, &m.Status)
+
+	query := "SELECT id, amount, created_at, payload, provider, status FROM payments WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *PaymentsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Payments, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, amount, created_at, payload, provider, status FROM payments WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Payments
+	for rows.Next() {
+		var m domain.Payments
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Amount)
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Payload)
+
+		fields = append(fields, &m.Provider)
+
+		fields = append(fields, &m.Status)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *PaymentsRepository) Create(ctx context.Context, m *domain.Payments) (string, error) {
+	query := "INSERT INTO payments (amount, created_at, payload, provider, status) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+
+	values := []interface{}{
+		m.Amount,
+		m.CreatedAt,
+		m.Payload,
+		m.Provider,
+		m.Status,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *PaymentsRepository) Update(ctx context.Context, id string, m *domain.Payments) error {
+
+	query := "UPDATE payments SET amount = $1, created_at = $2, payload = $3, provider = $4, status = $5 WHERE id = $6"
+
+	values := []interface{}{
+		m.Amount,
+		m.CreatedAt,
+		m.Payload,
+		m.Provider,
+		m.Status,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *PaymentsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM payments WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"SaaSBackend/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type PlansRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPlansRepository expects the plans table to exist; see migrations.go
+func NewPlansRepository(repo *PostgresRepository) *PlansRepository {
+	return &PlansRepository{db: repo.Pool}
+}
+
+func (r *PlansRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Plans, error) {
+	query, args, err := listSQL("SELECT id, interval, name, price, stripe_price_id FROM plans", "plans", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Plans
+	for rows.Next() {
+		var m domain.Plans
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Interval)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Price)
+
+		fields = append(fields, &m.StripePriceId)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *PlansRepository) Get(ctx context.Context, id string) (*domain.Plans, error) {
+	var m domain.Plans
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.Interval)
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.Price)
+
+	fields = append(fields, &m.StripePriceId)
+
+	query := "SELECT id, interval, name, price, stripe_price_id FROM plans WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *PlansRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Plans, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, interval, name, price, stripe_price_id FROM plans WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Plans
+	for rows.Next() {
+		var m domain.Plans
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.Interval)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Price)
+
+		fields = append(fields, &m.StripePriceId)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *PlansRepository) Create(ctx context.Context, m *domain.Plans) (string, error) {
+	query := "INSERT INTO plans (interval, name, price, stripe_price_id) VALUES ($1, $2, $3, $4) RETURNING id"
+
+	values := []interface{}{
+		m.Interval,
+		m.Name,
+		m.Price,
+		m.StripePriceId,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *PlansRepository) Update(ctx context.Context, id string, m *domain.Plans) error {
+
+	query := "UPDATE plans SET interval = $1, name = $2, price = $3, stripe_price_id = $4 WHERE id = $5"
+
+	values := []interface{}{
+		m.Interval,
+		m.Name,
+		m.Price,
+		m.StripePriceId,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *PlansRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM plans WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
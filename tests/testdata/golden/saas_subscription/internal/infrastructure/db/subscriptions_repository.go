@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"SaaSBackend/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type SubscriptionsRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewSubscriptionsRepository expects the subscriptions table to exist; see migrations.go
+func NewSubscriptionsRepository(repo *PostgresRepository) *SubscriptionsRepository {
+	return &SubscriptionsRepository{db: repo.Pool}
+}
+
+func (r *SubscriptionsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Subscriptions, error) {
+	query, args, err := listSQL("SELECT id, end_date, plan_id, start_date, status, COALESCE(user_id, '') AS user_id FROM subscriptions", "subscriptions", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Subscriptions
+	for rows.Next() {
+		var m domain.Subscriptions
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.EndDate)
+
+		fields = append(fields, &m.PlanId)
+
+		fields = append(fields, &m.StartDate)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.UserId)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *SubscriptionsRepository) Get(ctx context.Context, id string) (*domain.Subscriptions, error) {
+	var m domain.Subscriptions
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.EndDate)
+
+	fields = append(fields, &m.PlanId)
+
+	fields = append(fields, &m.StartDate)
+
+	fields = append(fields, &m.Status)
+
+	fields = append(fields, &m.UserId)
+
+	query := "SELECT id, end_date, plan_id, start_date, status, COALESCE(user_id, '') AS user_id FROM subscriptions WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *SubscriptionsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Subscriptions, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, end_date, plan_id, start_date, status, COALESCE(user_id, '') AS user_id FROM subscriptions WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Subscriptions
+	for rows.Next() {
+		var m domain.Subscriptions
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.EndDate)
+
+		fields = append(fields, &m.PlanId)
+
+		fields = append(fields, &m.StartDate)
+
+		fields = append(fields, &m.Status)
+
+		fields = append(fields, &m.UserId)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *SubscriptionsRepository) Create(ctx context.Context, m *domain.Subscriptions) (string, error) {
+	query := "INSERT INTO subscriptions (end_date, plan_id, start_date, status, user_id) VALUES ($1, $2, $3, $4, NULLIF($5, '')) RETURNING id"
+
+	values := []interface{}{
+		m.EndDate,
+		m.PlanId,
+		m.StartDate,
+		m.Status,
+		m.UserId,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *SubscriptionsRepository) Update(ctx context.Context, id string, m *domain.Subscriptions) error {
+
+	query := "UPDATE subscriptions SET end_date = $1, plan_id = $2, start_date = $3, status = $4, user_id = NULLIF($5, '') WHERE id = $6"
+
+	values := []interface{}{
+		m.EndDate,
+		m.PlanId,
+		m.StartDate,
+		m.Status,
+		m.UserId,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *SubscriptionsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM subscriptions WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
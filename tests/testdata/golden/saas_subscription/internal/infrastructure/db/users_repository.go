@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"SaaSBackend/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type UsersRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewUsersRepository expects the users table to exist; see migrations.go
+func NewUsersRepository(repo *PostgresRepository) *UsersRepository {
+	return &UsersRepository{db: repo.Pool}
+}
+
+func (r *UsersRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Users, error) {
+	query, args, err := listSQL("SELECT id, created_at, email, name, picture, role_id, uid, updated_at FROM users", "users", q)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Users
+	for rows.Next() {
+		var m domain.Users
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Email)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Picture)
+
+		fields = append(fields, &m.RoleId)
+
+		fields = append(fields, &m.Uid)
+
+		fields = append(fields, &m.UpdatedAt)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *UsersRepository) Get(ctx context.Context, id string) (*domain.Users, error) {
+	var m domain.Users
+	fields := []interface{}{&m.ID}
+
+	fields = append(fields, &m.CreatedAt)
+
+	fields = append(fields, &m.Email)
+
+	fields = append(fields, &m.Name)
+
+	fields = append(fields, &m.Picture)
+
+	fields = append(fields, &m.RoleId)
+
+	fields = append(fields, &m.Uid)
+
+	fields = append(fields, &m.UpdatedAt)
+
+	query := "SELECT id, created_at, email, name, picture, role_id, uid, updated_at FROM users WHERE id = $1"
+	err := r.db.QueryRow(ctx, query, id).Scan(fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetMany loads the records with the given ids in a single query, in no particular order
+func (r *UsersRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Users, error) {
+	rows, err := r.db.Query(ctx, "SELECT id, created_at, email, name, picture, role_id, uid, updated_at FROM users WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.Users
+	for rows.Next() {
+		var m domain.Users
+		fields := []interface{}{&m.ID}
+
+		fields = append(fields, &m.CreatedAt)
+
+		fields = append(fields, &m.Email)
+
+		fields = append(fields, &m.Name)
+
+		fields = append(fields, &m.Picture)
+
+		fields = append(fields, &m.RoleId)
+
+		fields = append(fields, &m.Uid)
+
+		fields = append(fields, &m.UpdatedAt)
+
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		results = append(results, &m)
+	}
+	return results, rows.Err()
+}
+
+func (r *UsersRepository) Create(ctx context.Context, m *domain.Users) (string, error) {
+	query := "INSERT INTO users (created_at, email, name, picture, role_id, uid, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id"
+
+	values := []interface{}{
+		m.CreatedAt,
+		m.Email,
+		m.Name,
+		m.Picture,
+		m.RoleId,
+		m.Uid,
+		m.UpdatedAt,
+	}
+
+	var id string
+	err := r.db.QueryRow(ctx, query, values...).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *UsersRepository) Update(ctx context.Context, id string, m *domain.Users) error {
+
+	query := "UPDATE users SET created_at = $1, email = $2, name = $3, picture = $4, role_id = $5, uid = $6, updated_at = $7 WHERE id = $8"
+
+	values := []interface{}{
+		m.CreatedAt,
+		m.Email,
+		m.Name,
+		m.Picture,
+		m.RoleId,
+		m.Uid,
+		m.UpdatedAt,
+
+		id,
+	}
+
+	_, err := r.db.Exec(ctx, query, values...)
+	return err
+
+}
+
+func (r *UsersRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM users WHERE id = $1", id)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
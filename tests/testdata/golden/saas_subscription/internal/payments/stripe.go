@@ -0,0 +1,109 @@
+package payments
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stripe/stripe-go/v76"
+	"github.com/stripe/stripe-go/v76/paymentintent"
+	"github.com/stripe/stripe-go/v76/webhook"
+
+	"SaaSBackend/internal/domain"
+)
+
+type StripeService struct {
+	SecretKey     string
+	WebhookSecret string
+	Repo          domain.PaymentsRepository
+}
+
+func NewStripeService(repo domain.PaymentsRepository) *StripeService {
+	key := getenv("STRIPE_SECRET_KEY", "your_stripe_secret_key")
+	stripe.Key = key
+	return &StripeService{
+		SecretKey:     key,
+		WebhookSecret: getenv("STRIPE_WEBHOOK_SECRET", "your_stripe_webhook_secret"),
+		Repo:          repo,
+	}
+}
+
+type PaymentRequest struct {
+	Amount   int64  `json:"amount"` // in cents
+	Currency string `json:"currency"`
+}
+
+func (s *StripeService) CreatePaymentIntentHandler(c *gin.Context) {
+	var req PaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	params := &stripe.PaymentIntentParams{
+		Amount:   stripe.Int64(req.Amount),
+		Currency: stripe.String(req.Currency),
+		AutomaticPaymentMethods: &stripe.PaymentIntentAutomaticPaymentMethodsParams{
+			Enabled: stripe.Bool(true),
+		},
+	}
+
+	pi, err := paymentintent.New(params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"client_secret": pi.ClientSecret,
+		"id":            pi.ID,
+	})
+}
+
+func (s *StripeService) HandleWebhook(c *gin.Context) {
+	const MaxBodyBytes = int64(65536)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxBodyBytes)
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Verify signature
+	sigHeader := c.GetHeader("Stripe-Signature")
+	event, err := webhook.ConstructEvent(payload, sigHeader, s.WebhookSecret)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Save transaction/event to Firestore (simplified)
+	transaction := &domain.Payments{
+		Provider:  "stripe",
+		Payload:   string(event.Type),
+		Status:    string(event.Type),
+		CreatedAt: time.Now(),
+	}
+
+	// Just logging relevant events for the example
+	if event.Type == "payment_intent.succeeded" {
+		transaction.Status = "paid"
+	}
+
+	_, err = s.Repo.Create(c.Request.Context(), transaction)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "received"})
+}
+
+// getenv reads a setting from the environment, or returns a placeholder
+func getenv(name, placeholder string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return placeholder
+}
@@ -0,0 +1,31 @@
+#!/bin/bash
+
+echo "Installing dependencies..."
+go mod tidy
+
+echo "Starting server in background..."
+export MOCK_AUTH=true
+go run cmd/api/main.go &
+PID=$!
+sleep 5
+
+echo "Running tests..."
+
+echo "Testing POST /auth/login"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"email": "test@example.com", "password": "password123"}' http://localhost:8080/auth/login
+echo "\n"
+echo "Testing POST /api/subscriptions"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"end_date": "2023-01-01T00:00:00Z", "plan_id": "test_plan_id", "start_date": "2023-01-01T00:00:00Z", "status": "test_status"}' http://localhost:8080/api/subscriptions
+echo "\n"
+echo "Testing POST /api/plans"
+curl -X POST -H "Content-Type: application/json" -d '{"interval": "test_interval", "name": "test_name", "price": 99.99, "stripe_price_id": "test_stripe_price_id"}' http://localhost:8080/api/plans
+echo "\n"
+echo "Testing POST /api/users"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"created_at": "2023-01-01T00:00:00Z", "email": "test_email", "name": "test_name", "picture": "test_picture", "role_id": "test_role_id", "uid": "test_uid", "updated_at": "2023-01-01T00:00:00Z"}' http://localhost:8080/api/users
+echo "\n"
+echo "Testing POST /api/payments"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"amount": 99.99, "created_at": "2023-01-01T00:00:00Z", "payload": "test_payload", "provider": "test_provider", "status": "test_status"}' http://localhost:8080/api/payments
+echo "\n"
+
+echo "Killing server (PID: $PID)..."
+kill $PID
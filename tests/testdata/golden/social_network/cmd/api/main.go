@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	firebase "firebase.google.com/go/v4"
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"SocialNetworkAPI/docs"
+	authService "SocialNetworkAPI/internal/auth"
+	"SocialNetworkAPI/internal/expand"
+	authHandler "SocialNetworkAPI/internal/handlers/auth"
+	"SocialNetworkAPI/internal/handlers/comments"
+	"SocialNetworkAPI/internal/handlers/groups"
+	"SocialNetworkAPI/internal/handlers/likes"
+	"SocialNetworkAPI/internal/handlers/posts"
+	"SocialNetworkAPI/internal/handlers/users"
+	"SocialNetworkAPI/internal/infrastructure/db"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+func main() {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Initialize Database
+
+	baseRepo, err := db.NewFirestoreRepository()
+
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer baseRepo.Close()
+
+	// Module auth
+	var authSvc authService.AuthService
+	if os.Getenv("MOCK_AUTH") == "true" {
+		log.Println("Using Mock Auth Service")
+		authSvc = &authService.MockAuthService{}
+	} else {
+		app, err := firebase.NewApp(context.Background(), &firebase.Config{ProjectID: "social-network-demo"})
+		if err != nil {
+			log.Fatalf("error initializing app: %v\n", err)
+		}
+		authClient, err := app.Auth(context.Background())
+		if err != nil {
+			log.Fatalf("error getting Auth client: %v\n", err)
+		}
+		authSvc = &authService.FirebaseAuthService{Client: authClient}
+	}
+	userRepo := db.NewUsersRepository(baseRepo.(*db.FirestoreRepository))
+	userHdl := authHandler.NewUserHandler(authSvc, userRepo, "users")
+
+	// Setup Router
+	r := gin.Default()
+
+	// Module auth
+	authGroup := r.Group("/auth")
+	authGroup.POST("/login", authService.AuthMiddleware(authSvc), userHdl.Login)
+	authGroup.GET("/me", authService.AuthMiddleware(authSvc), userHdl.GetMe)
+	authGroup.GET("/roles", authService.AuthMiddleware(authSvc), userHdl.GetRoles)
+
+	// API docs: the OpenAPI document generated from the blueprint, browsable with Swagger UI
+	r.GET("/openapi.yaml", func(c *gin.Context) {
+		c.Data(200, "application/yaml", docs.OpenAPI)
+	})
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/openapi.yaml")))
+
+	// Related records for ?include=, registered by each model below
+	expander := expand.New()
+
+	// Routes for users
+	{
+
+		repo := db.NewUsersRepository(baseRepo.(*db.FirestoreRepository))
+
+		expander.Register("users", expand.Fetch(repo.GetMany))
+		handler := users.NewUsersHandler(repo, expander)
+
+		group := r.Group("/api/users")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for posts
+	{
+
+		repo := db.NewPostsRepository(baseRepo.(*db.FirestoreRepository))
+
+		expander.Register("posts", expand.Fetch(repo.GetMany))
+		handler := posts.NewPostsHandler(repo, expander)
+
+		group := r.Group("/api/posts")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for comments
+	{
+
+		repo := db.NewCommentsRepository(baseRepo.(*db.FirestoreRepository))
+
+		expander.Register("comments", expand.Fetch(repo.GetMany))
+		handler := comments.NewCommentsHandler(repo, expander)
+
+		group := r.Group("/api/comments")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for likes
+	{
+
+		repo := db.NewLikesRepository(baseRepo.(*db.FirestoreRepository))
+
+		expander.Register("likes", expand.Fetch(repo.GetMany))
+		handler := likes.NewLikesHandler(repo, expander)
+
+		group := r.Group("/api/likes")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// Routes for groups
+	{
+
+		repo := db.NewGroupsRepository(baseRepo.(*db.FirestoreRepository))
+
+		expander.Register("groups", expand.Fetch(repo.GetMany))
+		handler := groups.NewGroupsHandler(repo, expander)
+
+		group := r.Group("/api/groups")
+
+		group.Use(authService.AuthMiddleware(authSvc))
+
+		group.GET("", handler.List)
+		group.GET("/:id", handler.Get)
+		group.POST("", handler.Create)
+		group.PUT("/:id", handler.Update)
+		group.DELETE("/:id", handler.Delete)
+
+	}
+
+	// blueprint:custom-begin routes
+	// blueprint:custom-end routes
+
+	log.Printf("Starting server for project: SocialNetworkAPI")
+	r.Run(":8080")
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Comments struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	Text string `json:"text" bson:"text" firestore:"text"`
+
+	AuthorId string `json:"author_id" bson:"author_id" firestore:"author_id"`
+
+	PostId string `json:"post_id" bson:"post_id" firestore:"post_id"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Comments) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Comments) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// CommentsFields maps the fields List can filter, sort and select on to their blueprint types
+var CommentsFields = map[string]string{
+	"id":         "string",
+	"created_at": "datetime",
+	"text":       "string",
+	"author_id":  "string",
+	"post_id":    "string",
+}
+
+type CommentsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Comments, error)
+	Get(ctx context.Context, id string) (*Comments, error)
+	GetMany(ctx context.Context, ids []string) ([]*Comments, error)
+	Create(ctx context.Context, model *Comments) (string, error)
+	Update(ctx context.Context, id string, model *Comments) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+)
+
+type Groups struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Description string `json:"description" bson:"description" firestore:"description"`
+
+	IsPrivate bool `json:"is_private" bson:"is_private" firestore:"is_private"`
+
+	Name string `json:"name" bson:"name" firestore:"name"`
+
+	AdminId string `json:"admin_id" bson:"admin_id" firestore:"admin_id"`
+
+	Posts []string `json:"posts" bson:"posts" firestore:"posts"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Groups) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Groups) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// GroupsFields maps the fields List can filter, sort and select on to their blueprint types
+var GroupsFields = map[string]string{
+	"id":          "string",
+	"description": "string",
+	"is_private":  "boolean",
+	"name":        "string",
+	"admin_id":    "string",
+	"posts":       "array",
+}
+
+type GroupsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Groups, error)
+	Get(ctx context.Context, id string) (*Groups, error)
+	GetMany(ctx context.Context, ids []string) ([]*Groups, error)
+	Create(ctx context.Context, model *Groups) (string, error)
+	Update(ctx context.Context, id string, model *Groups) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Likes struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	PostId string `json:"post_id" bson:"post_id" firestore:"post_id"`
+
+	UserId string `json:"user_id" bson:"user_id" firestore:"user_id"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Likes) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Likes) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// LikesFields maps the fields List can filter, sort and select on to their blueprint types
+var LikesFields = map[string]string{
+	"id":         "string",
+	"created_at": "datetime",
+	"post_id":    "string",
+	"user_id":    "string",
+}
+
+type LikesRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Likes, error)
+	Get(ctx context.Context, id string) (*Likes, error)
+	GetMany(ctx context.Context, ids []string) ([]*Likes, error)
+	Create(ctx context.Context, model *Likes) (string, error)
+	Update(ctx context.Context, id string, model *Likes) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Posts struct {
+	ID string `json:"id" bson:"_id,omitempty" firestore:"-"`
+
+	Content string `json:"content" bson:"content" firestore:"content"`
+
+	CreatedAt time.Time `json:"created_at" bson:"created_at" firestore:"created_at"`
+
+	ImageUrl string `json:"image_url" bson:"image_url" firestore:"image_url"`
+
+	LikesCount int `json:"likes_count" bson:"likes_count" firestore:"likes_count"`
+
+	AuthorId string `json:"author_id" bson:"author_id" firestore:"author_id"`
+
+	Comments []string `json:"comments" bson:"comments" firestore:"comments"`
+
+	GroupId string `json:"group_id" bson:"group_id" firestore:"group_id"`
+
+	Likes []string `json:"likes" bson:"likes" firestore:"likes"`
+}
+
+// ApplyDefaults fills the empty fields that declare a default value
+func (m *Posts) ApplyDefaults() {
+}
+
+// Validate checks the rules that binding tags cannot express
+func (m *Posts) Validate() []FieldError {
+	var errs []FieldError
+	return errs
+}
+
+// PostsFields maps the fields List can filter, sort and select on to their blueprint types
+var PostsFields = map[string]string{
+	"id":          "string",
+	"content":     "string",
+	"created_at":  "datetime",
+	"image_url":   "string",
+	"likes_count": "integer",
+	"author_id":   "string",
+	"comments":    "array",
+	"group_id":    "string",
+	"likes":       "array",
+}
+
+type PostsRepository interface {
+	List(ctx context.Context, q ListQuery) ([]*Posts, error)
+	Get(ctx context.Context, id string) (*Posts, error)
+	GetMany(ctx context.Context, ids []string) ([]*Posts, error)
+	Create(ctx context.Context, model *Posts) (string, error)
+	Update(ctx context.Context, id string, model *Posts) error
+	Delete(ctx context.Context, id string) error
+}
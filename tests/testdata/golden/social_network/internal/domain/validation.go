@@ -0,0 +1,8 @@
+package domain
+
+// FieldError describes a field that failed validation, as listed in 422 responses
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,114 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"SocialNetworkAPI/internal/domain"
+	"SocialNetworkAPI/internal/expand"
+)
+
+type MockGroupsRepository struct {
+	Data map[string]*domain.Groups
+}
+
+func (m *MockGroupsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Groups, error) {
+	var results []*domain.Groups
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Groups{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockGroupsRepository) Get(ctx context.Context, id string) (*domain.Groups, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockGroupsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Groups, error) {
+	var results []*domain.Groups
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockGroupsRepository) Create(ctx context.Context, model *domain.Groups) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Groups)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockGroupsRepository) Update(ctx context.Context, id string, model *domain.Groups) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockGroupsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestGroupsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockGroupsRepository{Data: make(map[string]*domain.Groups)}
+	handler := NewGroupsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/groups", handler.List)
+	r.POST("/groups", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Groups{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/groups", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/groups?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/groups?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/groups?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package likes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"SocialNetworkAPI/internal/domain"
+	"SocialNetworkAPI/internal/expand"
+)
+
+type MockLikesRepository struct {
+	Data map[string]*domain.Likes
+}
+
+func (m *MockLikesRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Likes, error) {
+	var results []*domain.Likes
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Likes{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockLikesRepository) Get(ctx context.Context, id string) (*domain.Likes, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockLikesRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Likes, error) {
+	var results []*domain.Likes
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockLikesRepository) Create(ctx context.Context, model *domain.Likes) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Likes)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockLikesRepository) Update(ctx context.Context, id string, model *domain.Likes) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockLikesRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestLikesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockLikesRepository{Data: make(map[string]*domain.Likes)}
+	handler := NewLikesHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/likes", handler.List)
+	r.POST("/likes", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Likes{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/likes", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/likes?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/likes?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/likes?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
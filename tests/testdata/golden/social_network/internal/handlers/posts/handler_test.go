@@ -0,0 +1,114 @@
+package posts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"SocialNetworkAPI/internal/domain"
+	"SocialNetworkAPI/internal/expand"
+)
+
+type MockPostsRepository struct {
+	Data map[string]*domain.Posts
+}
+
+func (m *MockPostsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Posts, error) {
+	var results []*domain.Posts
+	for _, v := range m.Data {
+		results = append(results, v)
+	}
+
+	// Simple slicing for mock pagination; filters and sort are left to the real repositories
+	offset, limit := q.Offset, q.Limit
+	if offset >= len(results) {
+		return []*domain.Posts{}, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], nil
+}
+
+func (m *MockPostsRepository) Get(ctx context.Context, id string) (*domain.Posts, error) {
+	if val, ok := m.Data[id]; ok {
+		return val, nil
+	}
+	return nil, nil
+}
+
+func (m *MockPostsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Posts, error) {
+	var results []*domain.Posts
+	for _, id := range ids {
+		if val, ok := m.Data[id]; ok {
+			results = append(results, val)
+		}
+	}
+	return results, nil
+}
+
+func (m *MockPostsRepository) Create(ctx context.Context, model *domain.Posts) (string, error) {
+	id := "test-id"
+	model.ID = id
+	if m.Data == nil {
+		m.Data = make(map[string]*domain.Posts)
+	}
+	m.Data[id] = model
+	return id, nil
+}
+
+func (m *MockPostsRepository) Update(ctx context.Context, id string, model *domain.Posts) error {
+	m.Data[id] = model
+	return nil
+}
+
+func (m *MockPostsRepository) Delete(ctx context.Context, id string) error {
+	delete(m.Data, id)
+	return nil
+}
+
+func TestPostsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockPostsRepository{Data: make(map[string]*domain.Posts)}
+	handler := NewPostsHandler(repo, expand.New())
+	r := gin.Default()
+
+	r.GET("/posts", handler.List)
+	r.POST("/posts", handler.Create)
+
+	t.Run("Create", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := domain.Posts{}
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/posts", bytes.NewBuffer(jsonBody))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/posts?page=1&limit=10", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UnknownFilter", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/posts?unknown[gte]=1", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("UnknownInclude", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/posts?include=unknown", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
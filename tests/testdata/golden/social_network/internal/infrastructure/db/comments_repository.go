@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"SocialNetworkAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type CommentsRepository struct {
+	client *FirestoreRepository
+}
+
+func NewCommentsRepository(client *FirestoreRepository) *CommentsRepository {
+	return &CommentsRepository{client: client}
+}
+
+// GetByEmail is used for JWT auth
+
+func (r *CommentsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Comments, error) {
+	iter := listQuery(r.client.client.Collection("comments"), q).Documents(ctx)
+	var results []*domain.Comments
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var m domain.Comments
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *CommentsRepository) Get(ctx context.Context, id string) (*domain.Comments, error) {
+	doc, err := r.client.client.Collection("comments").Doc(id).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var m domain.Comments
+	if err := doc.DataTo(&m); err != nil {
+		return nil, err
+	}
+	m.ID = doc.Ref.ID
+	return &m, nil
+}
+
+// GetMany loads the documents with the given ids in a single batched call
+func (r *CommentsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Comments, error) {
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = r.client.client.Collection("comments").Doc(id)
+	}
+	docs, err := r.client.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Comments
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var m domain.Comments
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *CommentsRepository) Create(ctx context.Context, model *domain.Comments) (string, error) {
+	ref, _, err := r.client.client.Collection("comments").Add(ctx, model)
+	if err != nil {
+		return "", err
+	}
+	return ref.ID, nil
+}
+
+func (r *CommentsRepository) Update(ctx context.Context, id string, m *domain.Comments) error {
+	_, err := r.client.client.Collection("comments").Doc(id).Set(ctx, m)
+	return err
+}
+
+func (r *CommentsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.client.Collection("comments").Doc(id).Delete(ctx)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"SocialNetworkAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type GroupsRepository struct {
+	client *FirestoreRepository
+}
+
+func NewGroupsRepository(client *FirestoreRepository) *GroupsRepository {
+	return &GroupsRepository{client: client}
+}
+
+// GetByEmail is used for JWT auth
+
+func (r *GroupsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Groups, error) {
+	iter := listQuery(r.client.client.Collection("groups"), q).Documents(ctx)
+	var results []*domain.Groups
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var m domain.Groups
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *GroupsRepository) Get(ctx context.Context, id string) (*domain.Groups, error) {
+	doc, err := r.client.client.Collection("groups").Doc(id).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var m domain.Groups
+	if err := doc.DataTo(&m); err != nil {
+		return nil, err
+	}
+	m.ID = doc.Ref.ID
+	return &m, nil
+}
+
+// GetMany loads the documents with the given ids in a single batched call
+func (r *GroupsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Groups, error) {
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = r.client.client.Collection("groups").Doc(id)
+	}
+	docs, err := r.client.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Groups
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var m domain.Groups
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *GroupsRepository) Create(ctx context.Context, model *domain.Groups) (string, error) {
+	ref, _, err := r.client.client.Collection("groups").Add(ctx, model)
+	if err != nil {
+		return "", err
+	}
+	return ref.ID, nil
+}
+
+func (r *GroupsRepository) Update(ctx context.Context, id string, m *domain.Groups) error {
+	_, err := r.client.client.Collection("groups").Doc(id).Set(ctx, m)
+	return err
+}
+
+func (r *GroupsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.client.Collection("groups").Doc(id).Delete(ctx)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
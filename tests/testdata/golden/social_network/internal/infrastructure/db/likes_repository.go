@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"SocialNetworkAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type LikesRepository struct {
+	client *FirestoreRepository
+}
+
+func NewLikesRepository(client *FirestoreRepository) *LikesRepository {
+	return &LikesRepository{client: client}
+}
+
+// GetByEmail is used for JWT auth
+
+func (r *LikesRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Likes, error) {
+	iter := listQuery(r.client.client.Collection("likes"), q).Documents(ctx)
+	var results []*domain.Likes
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var m domain.Likes
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *LikesRepository) Get(ctx context.Context, id string) (*domain.Likes, error) {
+	doc, err := r.client.client.Collection("likes").Doc(id).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var m domain.Likes
+	if err := doc.DataTo(&m); err != nil {
+		return nil, err
+	}
+	m.ID = doc.Ref.ID
+	return &m, nil
+}
+
+// GetMany loads the documents with the given ids in a single batched call
+func (r *LikesRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Likes, error) {
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = r.client.client.Collection("likes").Doc(id)
+	}
+	docs, err := r.client.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Likes
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var m domain.Likes
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *LikesRepository) Create(ctx context.Context, model *domain.Likes) (string, error) {
+	ref, _, err := r.client.client.Collection("likes").Add(ctx, model)
+	if err != nil {
+		return "", err
+	}
+	return ref.ID, nil
+}
+
+func (r *LikesRepository) Update(ctx context.Context, id string, m *domain.Likes) error {
+	_, err := r.client.client.Collection("likes").Doc(id).Set(ctx, m)
+	return err
+}
+
+func (r *LikesRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.client.Collection("likes").Doc(id).Delete(ctx)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"SocialNetworkAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type PostsRepository struct {
+	client *FirestoreRepository
+}
+
+func NewPostsRepository(client *FirestoreRepository) *PostsRepository {
+	return &PostsRepository{client: client}
+}
+
+// GetByEmail is used for JWT auth
+
+func (r *PostsRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Posts, error) {
+	iter := listQuery(r.client.client.Collection("posts"), q).Documents(ctx)
+	var results []*domain.Posts
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var m domain.Posts
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *PostsRepository) Get(ctx context.Context, id string) (*domain.Posts, error) {
+	doc, err := r.client.client.Collection("posts").Doc(id).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var m domain.Posts
+	if err := doc.DataTo(&m); err != nil {
+		return nil, err
+	}
+	m.ID = doc.Ref.ID
+	return &m, nil
+}
+
+// GetMany loads the documents with the given ids in a single batched call
+func (r *PostsRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Posts, error) {
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = r.client.client.Collection("posts").Doc(id)
+	}
+	docs, err := r.client.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Posts
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var m domain.Posts
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *PostsRepository) Create(ctx context.Context, model *domain.Posts) (string, error) {
+	ref, _, err := r.client.client.Collection("posts").Add(ctx, model)
+	if err != nil {
+		return "", err
+	}
+	return ref.ID, nil
+}
+
+func (r *PostsRepository) Update(ctx context.Context, id string, m *domain.Posts) error {
+	_, err := r.client.client.Collection("posts").Doc(id).Set(ctx, m)
+	return err
+}
+
+func (r *PostsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.client.Collection("posts").Doc(id).Delete(ctx)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"cloud.google.com/go/firestore"
+
+	"SocialNetworkAPI/internal/domain"
+)
+
+var firestoreOperators = map[string]string{
+	domain.OpEq:  "==",
+	domain.OpNe:  "!=",
+	domain.OpGt:  ">",
+	domain.OpGte: ">=",
+	domain.OpLt:  "<",
+	domain.OpLte: "<=",
+	domain.OpIn:  "in",
+}
+
+// listQuery applies the filters, sort and paging of q to a collection
+func listQuery(collection *firestore.CollectionRef, q domain.ListQuery) firestore.Query {
+	query := filterQuery(collection, q)
+	for _, s := range q.Sort {
+		path := s.Field
+		if path == "id" {
+			path = firestore.DocumentID
+		}
+		direction := firestore.Asc
+		if s.Desc {
+			direction = firestore.Desc
+		}
+		query = query.OrderBy(path, direction)
+	}
+	return query.Offset(q.Offset).Limit(q.Limit)
+}
+
+func filterQuery(collection *firestore.CollectionRef, q domain.ListQuery) firestore.Query {
+	query := collection.Query
+	for _, f := range q.Filters {
+		path, value := f.Field, f.Value
+		if path == "id" {
+			path, value = firestore.DocumentID, documentRefs(collection, value)
+		}
+		query = query.Where(path, firestoreOperators[f.Op], value)
+	}
+	return query
+}
+
+// documentRefs converts an id, or a list of ids, to the references DocumentID compares against
+func documentRefs(collection *firestore.CollectionRef, value interface{}) interface{} {
+	if list, ok := value.([]interface{}); ok {
+		refs := make([]interface{}, len(list))
+		for i, item := range list {
+			refs[i] = documentRefs(collection, item)
+		}
+		return refs
+	}
+	if id, ok := value.(string); ok {
+		return collection.Doc(id)
+	}
+	return value
+}
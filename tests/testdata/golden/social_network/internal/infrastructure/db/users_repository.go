@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"SocialNetworkAPI/internal/domain"
+	// blueprint:custom-begin imports
+	// blueprint:custom-end imports
+)
+
+type UsersRepository struct {
+	client *FirestoreRepository
+}
+
+func NewUsersRepository(client *FirestoreRepository) *UsersRepository {
+	return &UsersRepository{client: client}
+}
+
+// GetByEmail is used for JWT auth
+
+func (r *UsersRepository) List(ctx context.Context, q domain.ListQuery) ([]*domain.Users, error) {
+	iter := listQuery(r.client.client.Collection("users"), q).Documents(ctx)
+	var results []*domain.Users
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var m domain.Users
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *UsersRepository) Get(ctx context.Context, id string) (*domain.Users, error) {
+	doc, err := r.client.client.Collection("users").Doc(id).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var m domain.Users
+	if err := doc.DataTo(&m); err != nil {
+		return nil, err
+	}
+	m.ID = doc.Ref.ID
+	return &m, nil
+}
+
+// GetMany loads the documents with the given ids in a single batched call
+func (r *UsersRepository) GetMany(ctx context.Context, ids []string) ([]*domain.Users, error) {
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = r.client.client.Collection("users").Doc(id)
+	}
+	docs, err := r.client.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	var results []*domain.Users
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var m domain.Users
+		if err := doc.DataTo(&m); err != nil {
+			return nil, err
+		}
+		m.ID = doc.Ref.ID
+		results = append(results, &m)
+	}
+	return results, nil
+}
+
+func (r *UsersRepository) Create(ctx context.Context, model *domain.Users) (string, error) {
+	ref, _, err := r.client.client.Collection("users").Add(ctx, model)
+	if err != nil {
+		return "", err
+	}
+	return ref.ID, nil
+}
+
+func (r *UsersRepository) Update(ctx context.Context, id string, m *domain.Users) error {
+	_, err := r.client.client.Collection("users").Doc(id).Set(ctx, m)
+	return err
+}
+
+func (r *UsersRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.client.client.Collection("users").Doc(id).Delete(ctx)
+	return err
+}
+
+// blueprint:custom-begin methods
+// blueprint:custom-end methods
@@ -0,0 +1,34 @@
+#!/bin/bash
+
+echo "Installing dependencies..."
+go mod tidy
+
+echo "Starting server in background..."
+export MOCK_AUTH=true
+go run cmd/api/main.go &
+PID=$!
+sleep 5
+
+echo "Running tests..."
+
+echo "Testing POST /auth/login"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"email": "test@example.com", "password": "password123"}' http://localhost:8080/auth/login
+echo "\n"
+echo "Testing POST /api/users"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"bio": "test_bio", "created_at": "2023-01-01T00:00:00Z", "email": "test_email", "name": "test_name", "picture": "test_picture", "role_id": "test_role_id", "uid": "test_uid", "updated_at": "2023-01-01T00:00:00Z", "username": "test_username", "verified": true}' http://localhost:8080/api/users
+echo "\n"
+echo "Testing POST /api/posts"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"content": "test_content", "created_at": "2023-01-01T00:00:00Z", "image_url": "test_image_url", "likes_count": 10, "author_id": "test_author_id", "group_id": "test_group_id"}' http://localhost:8080/api/posts
+echo "\n"
+echo "Testing POST /api/comments"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"created_at": "2023-01-01T00:00:00Z", "text": "test_text", "author_id": "test_author_id", "post_id": "test_post_id"}' http://localhost:8080/api/comments
+echo "\n"
+echo "Testing POST /api/likes"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"created_at": "2023-01-01T00:00:00Z", "post_id": "test_post_id"}' http://localhost:8080/api/likes
+echo "\n"
+echo "Testing POST /api/groups"
+curl -X POST -H "Authorization: Bearer mock-token" -H "Content-Type: application/json" -d '{"description": "test_description", "is_private": true, "name": "test_name", "admin_id": "test_admin_id"}' http://localhost:8080/api/groups
+echo "\n"
+
+echo "Killing server (PID: $PID)..."
+kill $PID
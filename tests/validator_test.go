@@ -0,0 +1,451 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/generator"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/parser"
+	"github.com/eduardo/blueprint/internal/validator"
+	"github.com/eduardo/blueprint/internal/yaml"
+)
+
+const invalidBlueprint = "# Broken\n\n```json\n" + `{
+  "project_name": "Broken",
+  "database": {"type": "postgres"},
+  "models": [
+    {
+      "name": "posts",
+      "fields": {"title": "string", "views": "number"},
+      "relations": {
+        "author": "belongsto:users",
+        "tags": "hasMany:tag"
+      }
+    },
+    {
+      "name": "Posts",
+      "fields": {"body": "text"},
+      "relation": {}
+    }
+  ]
+}
+` + "```\n"
+
+func parseBlueprint(t *testing.T, content string) *domain.Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "blueprint.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := parser.NewMarkdownParser(infrastructure.NewOSFileSystem()).Parse(path)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	return config
+}
+
+func TestValidatorReportsEveryProblemWithPositions(t *testing.T) {
+	config := parseBlueprint(t, invalidBlueprint)
+	diags := validator.NewValidator().Validate(config)
+
+	want := []struct {
+		line     int
+		column   int
+		severity domain.Severity
+		contains string
+	}{
+		{6, 16, domain.SeverityError, `unsupported database type "postgres" (did you mean "postgresql"?)`},
+		{10, 37, domain.SeverityError, `unknown field type "number"`},
+		{12, 9, domain.SeverityError, `unknown relation kind "belongsto" (did you mean "belongsTo"?)`},
+		{12, 9, domain.SeverityError, `relation targets unknown model "users"`},
+		{13, 9, domain.SeverityError, `relation targets unknown model "tag"`},
+		{17, 7, domain.SeverityError, `duplicate model "Posts"`},
+		{19, 7, domain.SeverityWarning, `unknown key "relation" (did you mean "relations"?)`},
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("expected %d diagnostics, got %d:\n%v", len(want), len(diags), diags)
+	}
+	for i, w := range want {
+		d := diags[i]
+		if d.Pos.Line != w.line || d.Pos.Column != w.column || d.Severity != w.severity || !strings.Contains(d.Message, w.contains) {
+			t.Errorf("diagnostic %d: got %s, want %d:%d %s containing %q", i, d, w.line, w.column, w.severity, w.contains)
+		}
+	}
+}
+
+func TestValidatorChecksPagination(t *testing.T) {
+	config := parseBlueprint(t, "# Paged\n\n```json\n"+`{
+  "project_name": "Paged",
+  "database": {"type": "postgresql"},
+  "pagination": {"default_limit": -1, "mode": "cursors"},
+  "models": [{"name": "posts", "fields": {"title": "string"}}]
+}
+`+"```\n")
+
+	var got []string
+	for _, d := range validator.NewValidator().Validate(config) {
+		got = append(got, d.String())
+	}
+	want := []string{
+		`:7:18: error: pagination.default_limit: default_limit must not be negative, got -1`,
+		`:7:39: error: pagination.mode: unsupported pagination mode "cursors" (did you mean "cursor"?)`,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got diagnostics %q, want %d", got, len(want))
+	}
+	for i := range want {
+		if !strings.HasSuffix(got[i], want[i]) {
+			t.Errorf("diagnostic %d = %q, want suffix %q", i, got[i], want[i])
+		}
+	}
+}
+
+const rulesBlueprint = "# Rules\n\n```json\n" + `{
+  "project_name": "Rules",
+  "database": {"type": "mongodb"},
+  "models": [
+    {
+      "name": "products",
+      "fields": {
+        "name": {"type": "string", "required": true, "max_length": 80},
+        "price": {"type": "float", "min": 10, "max": 1},
+        "stock": {"type": "integer", "pattern": "[0-9]+", "default": 1.5},
+        "status": {"type": "string", "enum": ["draft", "in review"], "maximum": 3}
+      }
+    }
+  ]
+}
+` + "```\n"
+
+func TestValidatorChecksFieldRules(t *testing.T) {
+	config := parseBlueprint(t, rulesBlueprint)
+	rules := config.Models[0].Rules
+	if config.Models[0].Fields["name"] != "string" || !rules["name"].Required || *rules["name"].MaxLength != 80 {
+		t.Fatalf("object field not parsed: %q %+v", config.Models[0].Fields["name"], rules["name"])
+	}
+
+	diags := validator.NewValidator().Validate(config)
+	want := []struct {
+		line     int
+		contains string
+	}{
+		{12, "min 10 is greater than max 1"},
+		{13, "pattern only applies to string fields"},
+		{13, "default 1.5 is not a whole number"},
+		{14, `enum value "in review" must not contain spaces or commas`},
+		{14, `unknown key "maximum"`},
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("expected %d diagnostics, got %d:\n%v", len(want), len(diags), diags)
+	}
+	for i, w := range want {
+		if d := diags[i]; d.Pos.Line != w.line || !strings.Contains(d.Message, w.contains) {
+			t.Errorf("diagnostic %d: got %s, want line %d containing %q", i, d, w.line, w.contains)
+		}
+	}
+}
+
+const zeroValuesBlueprint = `{
+  "project_name": "Zero",
+  "database": {"type": "postgresql"},
+  "models": [
+    {
+      "name": "products",
+      "fields": {
+        "price": {"type": "float", "required": true, "min": 0},
+        "featured": {"type": "boolean", "required": true},
+        "stock": {"type": "integer", "default": 5},
+        "active": {"type": "boolean", "default": true},
+        "rating": {"type": "float", "default": 0}
+      }
+    }
+  ]
+}
+`
+
+// zeroValuesTest checks the generated domain struct keeps a 0 or false the
+// client sends apart from a missing value
+const zeroValuesTest = `package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestZeroValues(t *testing.T) {
+	var sent Products
+	if err := json.Unmarshal([]byte(` + "`" + `{"price": 0, "featured": false, "stock": 0, "active": false}` + "`" + `), &sent); err != nil {
+		t.Fatal(err)
+	}
+	sent.ApplyDefaults()
+	if sent.Price == nil || *sent.Price != 0 || sent.Featured == nil || *sent.Featured || *sent.Stock != 0 || *sent.Active {
+		t.Errorf("sent zero values became %+v", sent)
+	}
+
+	var missing Products
+	missing.ApplyDefaults()
+	if missing.Price != nil || missing.Featured != nil || *missing.Stock != 5 || !*missing.Active || missing.Rating != 0 {
+		t.Errorf("missing values became %+v", missing)
+	}
+
+	// binding's required accepts any pointer that isn't nil
+	price, _ := reflect.TypeOf(Products{}).FieldByName("Price")
+	if tag := price.Tag.Get("binding"); tag != "required,min=0" {
+		t.Errorf("price binding = %q", tag)
+	}
+}
+`
+
+func TestRulesKeepZeroValuesApart(t *testing.T) {
+	path := writeBlueprint(t, zeroValuesBlueprint)
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	diags, err := service.Validate(context.Background(), path)
+	if err != nil || len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics %v %v", diags, err)
+	}
+	runGenerated(t, "Zero", generateGolden(t, service, path), []string{"internal/domain"}, map[string]string{
+		"internal/domain/zero_test.go": zeroValuesTest,
+	})
+}
+
+func TestValidatorChecksFieldTypes(t *testing.T) {
+	config := &domain.Config{
+		ProjectName: "Types",
+		Database:    domain.Database{Type: "mongodb"},
+		Models: []domain.Model{{Name: "items", Fields: map[string]string{
+			"kind":   "enum(a,b,a)",
+			"labels": "array<json>",
+			"price":  "decimal",
+			"tags":   "array",
+		}}},
+	}
+	var messages []string
+	for _, d := range validator.NewValidator().Validate(config) {
+		messages = append(messages, d.Message)
+	}
+	want := []string{
+		`duplicate enum value "a"`,
+		`unsupported array element type "json"`,
+		`array needs its element type`,
+	}
+	if len(messages) != len(want) {
+		t.Fatalf("expected %d diagnostics, got %q", len(want), messages)
+	}
+	for i, w := range want {
+		if !strings.Contains(messages[i], w) {
+			t.Errorf("diagnostic %d: got %q, want %q", i, messages[i], w)
+		}
+	}
+}
+
+func TestFieldTypeAliasesAreNormalized(t *testing.T) {
+	config := parseBlueprint(t, "# Aliases\n\n```json\n"+`{
+  "project_name": "Aliases",
+  "models": [{"name": "items", "fields": {"count": "int", "flags": "array<bool>", "seen": {"type": "timestamp"}}}]
+}`+"\n```\n")
+	fields := config.Models[0].Fields
+	if fields["count"] != "integer" || fields["flags"] != "array<boolean>" || fields["seen"] != "datetime" {
+		t.Fatalf("aliases not normalized: %v", fields)
+	}
+
+	config.Models[0].Fields["size"] = "bigint"
+	if err := domain.NormalizeFieldTypes(config); err == nil || !strings.Contains(err.Error(), `items.size: unknown field type "bigint"`) {
+		t.Fatalf("expected an unknown type error, got %v", err)
+	}
+}
+
+func TestValidatorAcceptsExamples(t *testing.T) {
+	files, err := filepath.Glob("../examples/*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// As the validate command does, after the modules added their models
+	osFS := infrastructure.NewOSFileSystem()
+	service := application.NewBlueprintService(osFS, infrastructure.NewGoTemplateEngine(), parser.NewMarkdownParser(osFS), validator.NewValidator(), generator.Generate)
+	for _, file := range append(files, "../blueprint.md") {
+		diags, err := service.Validate(context.Background(), file)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		for _, d := range diags {
+			t.Errorf("%s", d)
+		}
+	}
+}
+
+func TestParserReportsSyntaxErrorPosition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blueprint.md")
+	content := "# Title\n\n```json\n{\n  \"project_name\": \"x\",\n  \"models\": [,]\n}\n```\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := parser.NewMarkdownParser(infrastructure.NewOSFileSystem()).Parse(path)
+	if err == nil || !strings.Contains(err.Error(), path+":6:") {
+		t.Fatalf("expected error located at line 6, got %v", err)
+	}
+}
+
+func TestParserPlacesModelErrorsAtTheirKey(t *testing.T) {
+	dir := t.TempDir()
+	for _, c := range []struct {
+		name, content, want string
+	}{
+		{"fields.json", `{
+  "project_name": "Shop",
+  "models": [
+    {"name": "users", "fields": {"email": "string"}},
+    {
+      "name": "products",
+      "fields": {
+        "price": {"type": "float", "min": "zero"},
+        "title": 42
+      }
+    }
+  ]
+}
+`, `fields.json:8:36: models[1].fields.price.min: expected float64, got string`},
+		{"fields.yaml", `project_name: Shop
+models:
+  - name: users
+    fields:
+      email: string
+  - name: products
+    fields:
+      title: [string]
+`, `fields.yaml:8:7: models[1].fields.title: expected a type name or an object with "type"`},
+		{"protected.yaml", `project_name: Shop
+models:
+  - name: products
+    protected: "yes"
+`, `protected.yaml:4:5: models[0].protected: expected bool, got string`},
+	} {
+		path := filepath.Join(dir, c.name)
+		if err := os.WriteFile(path, []byte(c.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := parser.NewFileParser(infrastructure.NewOSFileSystem()).Parse(path)
+		if err == nil || !strings.HasSuffix(err.Error(), c.want) {
+			t.Errorf("%s: got error %v, want suffix %q", c.name, err, c.want)
+		}
+	}
+}
+
+func TestBlueprintFormatsReportTheSameProblems(t *testing.T) {
+	// Messages referring to another position differ only by it
+	positionRe := regexp.MustCompile(`at \S+:\d+:\d+`)
+	report := func(config *domain.Config) []string {
+		var out []string
+		for _, d := range validator.NewValidator().Validate(config) {
+			out = append(out, string(d.Severity)+" "+d.Path+": "+positionRe.ReplaceAllString(d.Message, "at <position>"))
+		}
+		return out
+	}
+	want := report(parseBlueprint(t, invalidBlueprint))
+
+	data := []byte(strings.TrimSuffix(strings.SplitN(invalidBlueprint, "```json\n", 2)[1], "```\n"))
+	yamlData, err := yaml.FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for name, content := range map[string][]byte{"blueprint.json": data, "blueprint.yaml": yamlData} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+		config, err := parser.NewFileParser(infrastructure.NewOSFileSystem()).Parse(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := report(config); strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("%s reports\n%s\nwant\n%s", name, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+
+		// Every problem points at the line declaring its key
+		lines := strings.Split(string(content), "\n")
+		for _, d := range validator.NewValidator().Validate(config) {
+			key := d.Path[strings.LastIndexAny(d.Path, ".]")+1:]
+			if key != "" && !strings.Contains(lines[d.Pos.Line-1][d.Pos.Column-1:], key) {
+				t.Errorf("%s: %s is not at the %q key", name, d, key)
+			}
+		}
+	}
+
+	path := filepath.Join(dir, "broken.yaml")
+	if err := os.WriteFile(path, []byte("project_name: x\nmodels:\n  - name: posts\n    protected: maybe\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.NewFileParser(infrastructure.NewOSFileSystem()).Parse(path); err == nil || !strings.Contains(err.Error(), path+":4:5:") {
+		t.Fatalf("expected error located at line 4, got %v", err)
+	}
+}
+
+func TestIncludedBlueprints(t *testing.T) {
+	fileParser := parser.NewFileParser(infrastructure.NewOSFileSystem())
+	split, err := fileParser.Parse("../examples/erp/blueprint.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range validator.NewValidator().Validate(split) {
+		t.Errorf("%s", d)
+	}
+	whole, err := fileParser.Parse("../examples/enterprise_erp.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := func(models []domain.Model) map[string]domain.Model {
+		out := make(map[string]domain.Model)
+		for _, m := range models {
+			out[m.Name] = m
+		}
+		return out
+	}
+	if !reflect.DeepEqual(byName(split.Models), byName(whole.Models)) {
+		t.Errorf("the split ERP blueprint declares other models than examples/enterprise_erp.md")
+	}
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	root := write("blueprint.yaml", "project_name: Shop\ninclude: [posts.json]\nmodels:\n  - name: users\n")
+	posts := write("posts.json", "{\"models\": [\n  {\"name\": \"posts\", \"relations\": {\"author\": \"belongsTo:users\"}},\n  {\"name\": \"Users\"}\n]}\n")
+
+	// Conflicts name both files, and diagnostics the index of the model in its file
+	want := posts + `:3:4: models[1].name: duplicate model "Users" (already declared at ` + root + `:4:5)`
+	if _, err := fileParser.Parse(root); err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+	write("posts.json", "{\"models\": [\n  {\"name\": \"posts\", \"relations\": {\"author\": \"belongsTo:users\"}},\n  {\"name\": \"tags\", \"fields\": {\"label\": \"strng\"}}\n]}\n")
+	config, err := fileParser.Parse(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diags := validator.NewValidator().Validate(config)
+	if len(diags) != 1 || diags[0].Pos.File != posts || diags[0].Pos.Line != 3 || diags[0].Path != "models[1].fields.label" {
+		t.Errorf("expected the unknown type reported at models[1] of posts.json, got %v", diags)
+	}
+
+	write("posts.json", `{"include": ["blueprint.yaml"], "models": []}`)
+	if _, err := fileParser.Parse(root); err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Errorf("expected an include cycle, got %v", err)
+	}
+	write("posts.json", `{"project_name": "Other", "models": []}`)
+	if _, err := fileParser.Parse(root); err == nil || !strings.Contains(err.Error(), `found "project_name"`) {
+		t.Errorf("expected included settings to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/application"
+	"github.com/eduardo/blueprint/internal/domain"
+	"github.com/eduardo/blueprint/internal/generator"
+	"github.com/eduardo/blueprint/internal/infrastructure"
+	"github.com/eduardo/blueprint/internal/parser"
+	"github.com/eduardo/blueprint/internal/validator"
+	"github.com/eduardo/blueprint/internal/verify"
+)
+
+func TestGeneratedExamplesTypeCheck(t *testing.T) {
+	blueprints, err := filepath.Glob("../examples/*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	osFS := infrastructure.NewOSFileSystem()
+	recorder := verify.NewRecorder(infrastructure.NewGoTemplateEngine())
+	service := application.NewBlueprintService(osFS, recorder, parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+
+	for _, blueprint := range blueprints {
+		files := generateGolden(t, service, blueprint)
+		project := make(map[string][]byte, len(files))
+		for path, content := range files {
+			project[path] = []byte(content)
+		}
+		problems, err := verify.Check(project, recorder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range problems {
+			t.Errorf("%s: %s", filepath.Base(blueprint), p)
+		}
+	}
+}
+
+func TestTypeErrorsPointAtTheirTemplate(t *testing.T) {
+	recorder := verify.NewRecorder(infrastructure.NewGoTemplateEngine())
+	domainFile, err := recorder.Render("domain", "package domain\n\nimport \"strings\"\n\ntype {{.Model.Name | title}} struct {\n\tTitle string\n}\n", struct{ Model domain.Model }{domain.Model{Name: "posts"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][]byte{
+		"go.mod":                   []byte("module Shop\n\ngo 1.23\n"),
+		"internal/domain/posts.go": domainFile,
+		"internal/handlers/posts/handler.go": []byte(`package posts
+
+import (
+	"Shop/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func Get(c *gin.Context, pool *pgxpool.Pool) {
+	post := domain.Posts{}
+	c.JSON(200, post.Titel)
+	pool.Ping()
+}
+`),
+	}
+	problems, err := verify.Check(files, recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	want := []string{
+		`internal/domain/posts.go:3:8: error: "strings" imported and not used (template domain, model posts)`,
+		`internal/handlers/posts/handler.go:11:19: error: post.Titel undefined`,
+		`internal/handlers/posts/handler.go:12:12: error: not enough arguments in call to pool.Ping`,
+	}
+	// The wording after the cause changes between Go releases
+	matches := len(got) == len(want)
+	for i := 0; matches && i < len(want); i++ {
+		matches = strings.HasPrefix(got[i], want[i])
+	}
+	if !matches {
+		t.Errorf("problems:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestTemplateVariantsTypeCheck(t *testing.T) {
+	// Every database with each auth provider, payment provider and pagination
+	// mode, checked against the stubs of the third-party packages
+	variants := []struct{ database, auth, payments, pagination string }{
+		{"postgresql", "jwt", "stripe", "cursor"},
+		{"postgresql", "firebase", "mercadopago", "offset"},
+		{"mongodb", "jwt", "mercadopago", "cursor"},
+		{"mongodb", "firebase", "stripe", "offset"},
+		{"firestore", "jwt", "stripe", "offset"},
+		{"firestore", "firebase", "mercadopago", "cursor"},
+	}
+	osFS := infrastructure.NewOSFileSystem()
+	recorder := verify.NewRecorder(infrastructure.NewGoTemplateEngine())
+	service := application.NewBlueprintService(osFS, recorder, parser.NewFileParser(osFS), validator.NewValidator(), generator.Generate)
+	for _, v := range variants {
+		name := strings.Join([]string{v.database, v.auth, v.payments, v.pagination}, "/")
+		blueprint := strings.Replace(queryBlueprint, `"database": {"type": "postgresql"},`, fmt.Sprintf(`"database": {"type": %q},
+  "auth": {"enabled": true, "provider": %q, "user_collection": "users"},
+  "pagination": {"mode": %q},
+  "modules": {"payments": {"provider": %q}},`, v.database, v.auth, v.pagination, v.payments), 1)
+		files := generateGolden(t, service, writeBlueprint(t, blueprint))
+
+		project := make(map[string][]byte, len(files))
+		for path, content := range files {
+			project[path] = []byte(content)
+		}
+		problems, err := verify.Check(project, recorder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range problems {
+			t.Errorf("%s: %s", name, p)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/eduardo/blueprint/internal/yaml"
+)
+
+func TestYAMLDecode(t *testing.T) {
+	doc := `%YAML 1.2
+---
+# a comment
+title: "Pet \"Store\""  # trailing comment
+plain: don't stop # here
+url: http://example.com/#frag
+folded_plain: one
+  two
+literal: |
+  line one
+    indented
+
+  after blank
+folded: >-
+  folded
+  text
+
+  paragraph
+version: '1.0 it''s'
+numbers: [1, 2.5, -3, 0x1F, 1e3, 1.0.0]
+scalars: {t: true, n: null, tilde: ~, empty: }
+list:
+- a
+- key: 1
+  other: [x, "y, z"]
+- - nested
+  - seq
+"200":
+  description: ok
+empty_map: {}
+...
+`
+	got, err := yaml.Decode([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"title":        `Pet "Store"`,
+		"plain":        "don't stop",
+		"url":          "http://example.com/#frag",
+		"folded_plain": "one two",
+		"literal":      "line one\n  indented\n\nafter blank\n",
+		"folded":       "folded text\nparagraph",
+		"version":      "1.0 it's",
+		"numbers":      []interface{}{1.0, 2.5, -3.0, 31.0, 1000.0, "1.0.0"},
+		"scalars":      map[string]interface{}{"t": true, "n": nil, "tilde": nil, "empty": nil},
+		"list": []interface{}{
+			"a",
+			map[string]interface{}{"key": 1.0, "other": []interface{}{"x", "y, z"}},
+			[]interface{}{"nested", "seq"},
+		},
+		"200":       map[string]interface{}{"description": "ok"},
+		"empty_map": map[string]interface{}{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestYAMLDecodeErrors(t *testing.T) {
+	tests := []struct {
+		doc, want string
+	}{
+		{"a: 1\na: 2\n", `line 2: duplicate key "a"`},
+		{"a:\n  b: 1\n   c: 2\n", "line 3: mapping values are not allowed in this context"},
+		{"a: *x\n", "unknown anchor 'x' referenced"},
+		{"a: [1, 2\n", "line 1: did not find expected ',' or ']'"},
+		{"a: 1\n---\nb: 2\n", "line 2: multiple documents are not supported"},
+		{"a:\n\tb: 1\n", "line 2: found character that cannot start any token"},
+		{"? [a]\n: 1\n", "line 1: keys must be scalars"},
+	}
+	for _, tt := range tests {
+		_, err := yaml.Decode([]byte(tt.doc))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Decode(%q) error = %v, want %q", tt.doc, err, tt.want)
+		}
+	}
+}
+
+func TestYAMLDecodeAnchors(t *testing.T) {
+	doc := `defaults: &defaults
+  type: string
+  required: true
+models:
+  - name: users
+    fields:
+      email: *defaults
+      name:
+        <<: *defaults
+        required: false
+  - &posts
+    name: posts
+copy: *posts
+`
+	got, positions, err := yaml.DecodePositions([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults := map[string]interface{}{"type": "string", "required": true}
+	want := map[string]interface{}{
+		"defaults": defaults,
+		"models": []interface{}{
+			map[string]interface{}{
+				"name": "users",
+				"fields": map[string]interface{}{
+					"email": defaults,
+					"name":  map[string]interface{}{"type": "string", "required": false},
+				},
+			},
+			map[string]interface{}{"name": "posts"},
+		},
+		"copy": map[string]interface{}{"name": "posts"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodePositions =\n%#v\nwant\n%#v", got, want)
+	}
+
+	for path, want := range map[string]yaml.Position{
+		"models[0]":                  {Line: 5, Column: 3},
+		"models[0].fields.email":     {Line: 7, Column: 7},
+		"models[0].fields.name.type": {Line: 2, Column: 3},
+		"models[1]":                  {Line: 11, Column: 3},
+		"models[1].name":             {Line: 12, Column: 5},
+		"copy.name":                  {Line: 12, Column: 5},
+	} {
+		if positions[path] != want {
+			t.Errorf("position of %s = %+v, want %+v", path, positions[path], want)
+		}
+	}
+}
+
+func TestYAMLFromJSON(t *testing.T) {
+	// Strings YAML would read as another type stay strings, and keys keep their order
+	doc := `{"z": "true", "a": ["1.0", "", "#x", "line\nbreak", null, 2, 2.5], "m": {"b": {}, "a": []}, "s": "plain"}`
+	data, err := yaml.FromJSON([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "z: ") || !strings.Contains(string(data), "m:\n  b: {}\n  a: []\n") {
+		t.Errorf("keys out of order:\n%s", data)
+	}
+	got, err := yaml.Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"z": "true",
+		"a": []interface{}{"1.0", "", "#x", "line\nbreak", nil, 2.0, 2.5},
+		"m": map[string]interface{}{"b": map[string]interface{}{}, "a": []interface{}{}},
+		"s": "plain",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("read back as %#v, want %#v\n%s", got, want, data)
+	}
+}